@@ -1,18 +1,22 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"voenix/backend/internal/ai"
 	"voenix/backend/internal/article"
 	articlePg "voenix/backend/internal/article/postgres"
+	"voenix/backend/internal/articleio"
+	articleioPg "voenix/backend/internal/articleio/postgres"
 	"voenix/backend/internal/auth"
 	authPg "voenix/backend/internal/auth/postgres"
 	"voenix/backend/internal/cart"
@@ -22,14 +26,25 @@ import (
 	"voenix/backend/internal/database"
 	"voenix/backend/internal/image"
 	imagePg "voenix/backend/internal/image/postgres"
+	"voenix/backend/internal/inventory"
+	inventoryPg "voenix/backend/internal/inventory/postgres"
 	"voenix/backend/internal/order"
 	orderPg "voenix/backend/internal/order/postgres"
+	"voenix/backend/internal/pricing"
+	pricingPg "voenix/backend/internal/pricing/postgres"
+	"voenix/backend/internal/promotion"
+	promotionPg "voenix/backend/internal/promotion/postgres"
 	"voenix/backend/internal/prompt"
 	promptPg "voenix/backend/internal/prompt/postgres"
+	"voenix/backend/internal/search"
+	searchPg "voenix/backend/internal/search/postgres"
+	"voenix/backend/internal/sitemap"
 	"voenix/backend/internal/supplier"
 	supplierPg "voenix/backend/internal/supplier/postgres"
 	"voenix/backend/internal/vat"
 	vatPg "voenix/backend/internal/vat/postgres"
+	"voenix/backend/internal/wishlist"
+	wishlistPg "voenix/backend/internal/wishlist/postgres"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -64,7 +79,8 @@ func main() {
 
 	cfg := cors.Config{
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Authorization", "Content-Type", "X-Requested-With"},
+		AllowHeaders:     []string{"Authorization", "Content-Type", "X-Requested-With", "If-Match"},
+		ExposeHeaders:    []string{"ETag"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
@@ -94,24 +110,63 @@ func main() {
 	// Repositories
 	authRepo := authPg.NewRepository(db)
 	articleRepo := articlePg.NewRepository(db)
+	articleioRepo := articleioPg.NewRepository(db)
 	cartRepo := cartPg.NewRepository(db)
 	countryRepo := countryPg.NewRepository(db)
 	imageRepo := imagePg.NewRepository(db)
 	orderRepo := orderPg.NewRepository(db)
+	pricingRepo := pricingPg.NewRepository(db)
+	inventoryRepo := inventoryPg.NewRepository(db)
 	promptRepo := promptPg.NewRepository(db)
+	promotionRepo := promotionPg.NewRepository(db)
+	searchRepo := searchPg.NewRepository(db)
 	supplierRepo := supplierPg.NewRepository(db)
 	vatRepo := vatPg.NewRepository(db)
+	wishlistRepo := wishlistPg.NewRepository(db)
 
 	// Services
 	authSvc := auth.NewService(authRepo)
 	articleSvc := article.NewService(articleRepo)
-	imageSvc := image.NewService(imageRepo)
+	imageSvc := image.NewService(imageRepo, articleSvc)
 	countrySvc := country.NewService(countryRepo)
 	supplierSvc := supplier.NewService(supplierRepo)
+	promotionSvc := promotion.NewService(promotionRepo)
 	orderSvc := order.NewService(orderRepo, articleSvc)
+	orderSvc.SetPromotions(promotionSvc)
 	vatSvc := vat.NewService(vatRepo)
 	promptSvc := prompt.NewService(promptRepo, ai.ProviderLLMIDs())
-	cartSvc := cart.NewService(cartRepo, articleSvc, promptSvc)
+	inventorySvc := inventory.NewService(inventoryRepo, articleSvc)
+	cartSvc := cart.NewService(cartRepo, articleSvc, promptSvc, inventorySvc)
+	guestCarts := cart.NewGuestCookies(os.Getenv("GUEST_CART_SECRET"))
+	authSvc.OnLogin(guestCarts.MergeOnLogin(cartSvc))
+	cartSvc.SetAbandonedCartNotifier(cart.LogNotifier{})
+	if v := os.Getenv("ABANDONED_CART_IDLE_HOURS"); v != "" {
+		if hours, err := strconv.Atoi(v); err == nil && hours > 0 {
+			cartSvc.SetAbandonedCartIdle(time.Duration(hours) * time.Hour)
+		} else {
+			log.Printf("warning: ABANDONED_CART_IDLE_HOURS must be a positive number of hours, got %q; using 24", v)
+		}
+	}
+	cartSvc.SetPromotions(promotionSvc)
+	orderSvc.SetPriceChecker(cartSvc)
+	wishlistSvc := wishlist.NewService(wishlistRepo, cartSvc, articleSvc, imageSvc)
+	pricingSvc := pricing.NewService(pricingRepo, articleSvc)
+	articleioSvc := articleio.NewService(articleioRepo, articleSvc)
+	sitemapSvc := sitemap.NewService(articleSvc, promptSvc)
+	searchSvc := search.NewService(searchRepo, articleSvc, promptSvc)
+	articleSvc.SetSearchIndex(searchSvc)
+	promptSvc.SetSearchIndex(searchSvc)
+
+	// Background jobs
+	go articleSvc.RunPriceScheduler(context.Background(), time.Minute)
+	go articleSvc.RunBoughtTogetherRefresher(context.Background(), time.Hour)
+	go cartSvc.RunGuestCartCleanup(context.Background(), time.Hour)
+	go cartSvc.RunAbandonedCartDetector(context.Background(), 15*time.Minute)
+	go func() {
+		if _, err := searchSvc.Rebuild(context.Background()); err != nil {
+			slog.Error("rebuilding search index failed", "error", err)
+		}
+	}()
 
 	// Routes
 	auth.RegisterRoutes(r, authSvc)
@@ -121,9 +176,17 @@ func main() {
 	image.RegisterRoutes(r, db, imageSvc)
 	ai.RegisterRoutes(r, db, imageSvc, promptSvc, articleSvc)
 	prompt.RegisterRoutes(r, db, promptSvc)
-	article.RegisterRoutes(r, auth.RequireRoles(db, "ADMIN"), articleSvc)
-	cart.RegisterRoutes(r, auth.RequireRoles(db, "ADMIN", "USER"), cartSvc)
+	article.RegisterRoutes(r, auth.RequireRoles(db, "ADMIN"), articleSvc, inventorySvc, imageSvc)
+	cart.RegisterRoutes(r, auth.RequireRoles(db, "ADMIN", "USER"), cartSvc, guestCarts)
+	cart.RegisterAdminRoutes(r, auth.RequireRoles(db, "ADMIN"), cartSvc)
 	order.RegisterRoutes(r, db, orderSvc)
+	pricing.RegisterRoutes(r, db, pricingSvc)
+	promotion.RegisterRoutes(r, auth.RequireRoles(db, "ADMIN"), promotionSvc)
+	inventory.RegisterRoutes(r, db, inventorySvc)
+	articleio.RegisterRoutes(r, db, articleioSvc)
+	sitemap.RegisterRoutes(r, sitemapSvc)
+	search.RegisterRoutes(r, auth.RequireRoles(db, "ADMIN"), searchSvc)
+	wishlist.RegisterRoutes(r, auth.RequireRoles(db, "ADMIN", "USER"), wishlistSvc)
 
 	addr := os.Getenv("ADDR")
 	if addr == "" {
@@ -111,7 +111,7 @@ func RegisterRoutes(r *gin.Engine, db *gorm.DB, imageService *imgsvc.Service, pr
 		size := strings.TrimSpace(c.PostForm("size"))
 
 		finalPrompt := strings.TrimSpace(strings.Join([]string{master, specific}, " "))
-		effectivePrompt := CombinePrompt(finalPrompt, nil)
+		effectivePrompt := CombinePrompt(finalPrompt, "", nil)
 
 		// TODO query param was removed
 		provStr := c.DefaultQuery("provider", c.PostForm("provider"))
@@ -271,7 +271,7 @@ func RegisterRoutes(r *gin.Engine, db *gorm.DB, imageService *imgsvc.Service, pr
 		}
 		ctx, cancel := context.WithTimeout(c.Request.Context(), 120*time.Second)
 		defer cancel()
-		effectivePrompt := CombinePrompt(req.Prompt, nil)
+		effectivePrompt := CombinePrompt(req.Prompt, "", nil)
 		images, err := gen.Edit(ctx, data, effectivePrompt, req.N)
 		if err != nil || len(images) == 0 {
 			var sb *SafetyBlockedError
@@ -360,7 +360,6 @@ func RegisterRoutes(r *gin.Engine, db *gorm.DB, imageService *imgsvc.Service, pr
 			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "Prompt content is empty", "detail": "The requested prompt has no text configured"})
 			return
 		}
-		combinedPrompt := CombinePrompt(promptText, promptRead.Slots)
 
 		// Optional crop params
 		cropX, _ := strconv.ParseFloat(strings.TrimSpace(c.PostForm("cropX")), 64)
@@ -379,6 +378,7 @@ func RegisterRoutes(r *gin.Engine, db *gorm.DB, imageService *imgsvc.Service, pr
 			c.JSON(http.StatusBadRequest, gin.H{"message": "Provider not implemented"})
 			return
 		}
+		combinedPrompt := CombinePrompt(promptText, llmValue, promptRead.Slots)
 
 		mugIDString := strings.TrimSpace(c.PostForm("mugId"))
 		if mugIDString == "" {
@@ -62,7 +62,7 @@ func TestAdminLLMsEndpoint(t *testing.T) {
 	authService := auth.NewService(authRepository)
 
 	imageRepository := imagepg.NewRepository(db)
-	imageService := img.NewService(imageRepository)
+	imageService := img.NewService(imageRepository, nil)
 
 	router := gin.New()
 	auth.RegisterRoutes(router, authService)
@@ -8,9 +8,10 @@ import (
 )
 
 // CombinePrompt builds a single prompt string from the base prompt and any slot variant prompts.
-// It trims whitespace, preserves a stable ordering based on slot type position, slot name, and ID,
-// and separates each section with a blank line for readability.
-func CombinePrompt(basePrompt string, slotVariants []prompt.PromptSlotVariantRead) string {
+// Only the variants written for llm (or, per slot type, the generic fallback) are used; see
+// prompt.SelectSlotVariantsForLLM. It trims whitespace, preserves a stable ordering based on
+// slot type position, slot name, and ID, and separates each section with a blank line for readability.
+func CombinePrompt(basePrompt string, llm string, slotVariants []prompt.PromptSlotVariantRead) string {
 	trimmedBasePrompt := strings.TrimSpace(basePrompt)
 	promptSections := make([]string, 0, 1+len(slotVariants))
 	if trimmedBasePrompt != "" {
@@ -21,8 +22,9 @@ func CombinePrompt(basePrompt string, slotVariants []prompt.PromptSlotVariantRea
 		return strings.Join(promptSections, "\n\n")
 	}
 
-	filteredSlotVariants := make([]prompt.PromptSlotVariantRead, 0, len(slotVariants))
-	for _, slotVariant := range slotVariants {
+	applicableSlotVariants := prompt.SelectSlotVariantsForLLM(slotVariants, llm)
+	filteredSlotVariants := make([]prompt.PromptSlotVariantRead, 0, len(applicableSlotVariants))
+	for _, slotVariant := range applicableSlotVariants {
 		if slotVariant.Prompt == nil {
 			continue
 		}
@@ -0,0 +1,47 @@
+package ai
+
+import (
+	"testing"
+
+	"voenix/backend/internal/prompt"
+)
+
+func slotVariant(id, slotTypeID, position int, llm, text string) prompt.PromptSlotVariantRead {
+	return prompt.PromptSlotVariantRead{
+		ID:               id,
+		PromptSlotTypeID: slotTypeID,
+		PromptSlotType:   &prompt.PromptSlotTypeRead{ID: slotTypeID, Position: position},
+		Name:             text,
+		Prompt:           &text,
+		LLM:              llm,
+	}
+}
+
+func TestCombinePromptUsesVariantForProviderLLM(t *testing.T) {
+	variants := []prompt.PromptSlotVariantRead{
+		slotVariant(1, 10, 1, "gemini-2.5-flash-image-preview", "style gemini"),
+		slotVariant(2, 10, 1, "gpt-image-1", "style gpt"),
+		slotVariant(3, 10, 1, prompt.SlotVariantLLMGeneric, "style generic"),
+		slotVariant(4, 20, 2, prompt.SlotVariantLLMGeneric, "background generic"),
+	}
+
+	got := CombinePrompt("base", "gpt-image-1", variants)
+	want := "base\n\nstyle gpt\n\nbackground generic"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCombinePromptFallsBackToGenericVariant(t *testing.T) {
+	variants := []prompt.PromptSlotVariantRead{
+		slotVariant(1, 10, 1, "gemini-2.5-flash-image-preview", "style gemini"),
+		slotVariant(2, 10, 1, "", "style legacy"),
+		slotVariant(3, 20, 2, "gpt-image-1", "background gpt"),
+	}
+
+	got := CombinePrompt("base", "flux", variants)
+	want := "base\n\nstyle legacy"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,113 @@
+package article
+
+import (
+	"context"
+	"time"
+)
+
+// Archiving replaces deletion for articles and variants: archived ones are
+// hidden from the catalog and removed from active carts, but stay readable so
+// orders referencing them keep resolving. Admins can restore them, or purge
+// them for good when they were never ordered.
+
+// ArchiveArticle archives an article. Archiving an archived article keeps its
+// original archive time.
+func (s *Service) ArchiveArticle(ctx context.Context, id int) (Article, error) {
+	art, err := s.repo.GetArticle(ctx, id)
+	if err != nil || art.ArchivedAt != nil {
+		return art, err
+	}
+	now := time.Now()
+	if err := s.repo.SetArticleArchived(ctx, id, &now); err != nil {
+		return Article{}, err
+	}
+	s.InvalidateCatalog()
+	s.reindexArticles(ctx, id)
+	return s.repo.GetArticle(ctx, id)
+}
+
+func (s *Service) RestoreArticle(ctx context.Context, id int) (Article, error) {
+	if err := s.repo.SetArticleArchived(ctx, id, nil); err != nil {
+		return Article{}, err
+	}
+	s.InvalidateCatalog()
+	s.reindexArticles(ctx, id)
+	return s.repo.GetArticle(ctx, id)
+}
+
+func (s *Service) ArchiveMugVariant(ctx context.Context, id int) (MugVariant, error) {
+	v, err := s.repo.GetMugVariant(ctx, id)
+	if err != nil || v.ArchivedAt != nil {
+		return v, err
+	}
+	now := time.Now()
+	if err := s.repo.SetMugVariantArchived(ctx, id, &now); err != nil {
+		return MugVariant{}, err
+	}
+	s.InvalidateCatalog()
+	return s.repo.GetMugVariant(ctx, id)
+}
+
+func (s *Service) RestoreMugVariant(ctx context.Context, id int) (MugVariant, error) {
+	if err := s.repo.SetMugVariantArchived(ctx, id, nil); err != nil {
+		return MugVariant{}, err
+	}
+	s.InvalidateCatalog()
+	return s.repo.GetMugVariant(ctx, id)
+}
+
+func (s *Service) ArchiveShirtVariant(ctx context.Context, id int) (ShirtVariant, error) {
+	v, err := s.repo.GetShirtVariant(ctx, id)
+	if err != nil || v.ArchivedAt != nil {
+		return v, err
+	}
+	now := time.Now()
+	if err := s.repo.SetShirtVariantArchived(ctx, id, &now); err != nil {
+		return ShirtVariant{}, err
+	}
+	return s.repo.GetShirtVariant(ctx, id)
+}
+
+func (s *Service) RestoreShirtVariant(ctx context.Context, id int) (ShirtVariant, error) {
+	if err := s.repo.SetShirtVariantArchived(ctx, id, nil); err != nil {
+		return ShirtVariant{}, err
+	}
+	return s.repo.GetShirtVariant(ctx, id)
+}
+
+func (s *Service) ArchiveArticleVariant(ctx context.Context, id int) (ArticleVariant, error) {
+	v, err := s.repo.GetArticleVariant(ctx, id)
+	if err != nil || v.ArchivedAt != nil {
+		return v, err
+	}
+	now := time.Now()
+	if err := s.repo.SetArticleVariantArchived(ctx, id, &now); err != nil {
+		return ArticleVariant{}, err
+	}
+	s.InvalidateCatalog()
+	return s.repo.GetArticleVariant(ctx, id)
+}
+
+func (s *Service) RestoreArticleVariant(ctx context.Context, id int) (ArticleVariant, error) {
+	if err := s.repo.SetArticleVariantArchived(ctx, id, nil); err != nil {
+		return ArticleVariant{}, err
+	}
+	s.InvalidateCatalog()
+	return s.repo.GetArticleVariant(ctx, id)
+}
+
+// ensurePurgeable allows purging a variant only once it is archived and when
+// no order references it.
+func (s *Service) ensurePurgeable(ctx context.Context, articleID, variantID int, archivedAt *time.Time) error {
+	if archivedAt == nil {
+		return ErrNotArchived
+	}
+	orders, err := s.repo.CountVariantOrders(ctx, articleID, variantID)
+	if err != nil {
+		return err
+	}
+	if orders > 0 {
+		return ErrVariantHasOrders
+	}
+	return nil
+}
@@ -0,0 +1,529 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	img "voenix/backend/internal/image"
+)
+
+// Value types of attributes in an article type's attribute schema.
+const (
+	AttributeTypeText    = "TEXT"
+	AttributeTypeNumber  = "NUMBER"
+	AttributeTypeBoolean = "BOOLEAN"
+	AttributeTypeEnum    = "ENUM"
+)
+
+var (
+	ErrArticleTypeNotFound   = errors.New("article type not found")
+	ErrArticleTypeExists     = errors.New("article type already exists")
+	ErrBuiltInArticleType    = errors.New("built-in article types cannot be changed")
+	ErrArticleTypeInUse      = errors.New("article type is in use")
+	ErrInvalidArticleType    = errors.New("invalid article type")
+	ErrInvalidAttributes     = errors.New("invalid attributes")
+	ErrInvalidVariantOptions = errors.New("invalid variant options")
+	ErrDuplicateVariant      = errors.New("a variant with these options already exists")
+	ErrInvalidPrintAreas     = errors.New("invalid print areas")
+	// ErrNotRegisteredType is returned for generic variant and print area
+	// operations on mugs and shirts, which keep their dedicated tables.
+	ErrNotRegisteredType = errors.New("operation is only available for registered article types")
+)
+
+var (
+	articleTypeCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	attributeKeyPattern    = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+)
+
+// AttributeDefinition describes one attribute articles of a type carry.
+type AttributeDefinition struct {
+	Key      string
+	Label    string
+	Type     string
+	Required bool
+	// Options lists the allowed values of ENUM attributes.
+	Options []string
+	// Unit is shown next to NUMBER values, e.g. "mm".
+	Unit string
+}
+
+// ArticleTypeDefinition is an entry of the article type registry. Built-in
+// types (MUG, SHIRT) have no schema or option axes; their details and
+// variants live in dedicated tables.
+type ArticleTypeDefinition struct {
+	Code            string
+	Name            string
+	Description     *string
+	BuiltIn         bool
+	AttributeSchema []AttributeDefinition
+	// OptionAxes name the dimensions variants differ in, e.g. color and size.
+	// Every variant sets a value for each axis.
+	OptionAxes []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ArticleVariant is a variant of an article of a registered type.
+type ArticleVariant struct {
+	ID        int
+	ArticleID int
+	// Options maps each option axis of the article's type to a value.
+	Options              map[string]string
+	ArticleVariantNumber *string
+	ExampleImageFilename *string
+	IsDefault            bool
+	Active               bool
+	ArchivedAt           *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// PrintArea is a named surface of an article that designs are printed on.
+type PrintArea struct {
+	ID        int
+	ArticleID int
+	Name      string
+	WidthMm   int
+	HeightMm  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Normalize trims the definition, upper-cases the code and checks that codes,
+// attribute keys and option axes are well formed and unique.
+func (d *ArticleTypeDefinition) Normalize() error {
+	d.Code = strings.ToUpper(strings.TrimSpace(d.Code))
+	d.Name = strings.TrimSpace(d.Name)
+	if !articleTypeCodePattern.MatchString(d.Code) {
+		return fmt.Errorf("%w: code must start with a letter and contain only A-Z, 0-9 and _", ErrInvalidArticleType)
+	}
+	if d.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidArticleType)
+	}
+	keys := make(map[string]bool, len(d.AttributeSchema)+len(d.OptionAxes))
+	for i := range d.AttributeSchema {
+		attr := &d.AttributeSchema[i]
+		attr.Key = strings.TrimSpace(attr.Key)
+		attr.Label = strings.TrimSpace(attr.Label)
+		attr.Type = strings.ToUpper(strings.TrimSpace(attr.Type))
+		if !attributeKeyPattern.MatchString(attr.Key) {
+			return fmt.Errorf("%w: attribute key %q must be camelCase", ErrInvalidArticleType, attr.Key)
+		}
+		if keys[attr.Key] {
+			return fmt.Errorf("%w: duplicate key %q", ErrInvalidArticleType, attr.Key)
+		}
+		keys[attr.Key] = true
+		if attr.Label == "" {
+			attr.Label = attr.Key
+		}
+		switch attr.Type {
+		case AttributeTypeText, AttributeTypeNumber, AttributeTypeBoolean:
+			attr.Options = nil
+		case AttributeTypeEnum:
+			options, err := trimmedUnique(attr.Options)
+			if err != nil || len(options) == 0 {
+				return fmt.Errorf("%w: enum attribute %q needs distinct options", ErrInvalidArticleType, attr.Key)
+			}
+			attr.Options = options
+		default:
+			return fmt.Errorf("%w: attribute %q has unknown type %q", ErrInvalidArticleType, attr.Key, attr.Type)
+		}
+	}
+	for i, axis := range d.OptionAxes {
+		axis = strings.TrimSpace(axis)
+		if !attributeKeyPattern.MatchString(axis) {
+			return fmt.Errorf("%w: option axis %q must be camelCase", ErrInvalidArticleType, axis)
+		}
+		if keys[axis] {
+			return fmt.Errorf("%w: duplicate key %q", ErrInvalidArticleType, axis)
+		}
+		keys[axis] = true
+		d.OptionAxes[i] = axis
+	}
+	return nil
+}
+
+// NormalizeAttributes checks values against the attribute schema and returns
+// them with strings trimmed and empty values dropped. Unknown keys, missing
+// required attributes and values of the wrong type are rejected.
+func (d *ArticleTypeDefinition) NormalizeAttributes(values map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(d.AttributeSchema))
+	known := make(map[string]bool, len(d.AttributeSchema))
+	for _, attr := range d.AttributeSchema {
+		known[attr.Key] = true
+		v, ok := values[attr.Key]
+		if s, isString := v.(string); isString {
+			v = strings.TrimSpace(s)
+			ok = ok && v != ""
+		}
+		if !ok || v == nil {
+			if attr.Required {
+				return nil, fmt.Errorf("%w: %s is required", ErrInvalidAttributes, attr.Key)
+			}
+			continue
+		}
+		if err := checkAttributeValue(attr, v); err != nil {
+			return nil, err
+		}
+		out[attr.Key] = v
+	}
+	for key := range values {
+		if !known[key] {
+			return nil, fmt.Errorf("%w: unknown attribute %s", ErrInvalidAttributes, key)
+		}
+	}
+	return out, nil
+}
+
+func checkAttributeValue(attr AttributeDefinition, v any) error {
+	switch attr.Type {
+	case AttributeTypeText:
+		if _, ok := v.(string); ok {
+			return nil
+		}
+	case AttributeTypeNumber:
+		switch v.(type) {
+		case float64, int:
+			return nil
+		}
+	case AttributeTypeBoolean:
+		if _, ok := v.(bool); ok {
+			return nil
+		}
+	case AttributeTypeEnum:
+		if s, ok := v.(string); ok {
+			for _, option := range attr.Options {
+				if option == s {
+					return nil
+				}
+			}
+			return fmt.Errorf("%w: %s must be one of %s", ErrInvalidAttributes, attr.Key, strings.Join(attr.Options, ", "))
+		}
+	}
+	return fmt.Errorf("%w: %s must be of type %s", ErrInvalidAttributes, attr.Key, attr.Type)
+}
+
+// NormalizeOptions checks that options set a non-empty value for every option
+// axis and nothing else, and returns them trimmed.
+func (d *ArticleTypeDefinition) NormalizeOptions(options map[string]string) (map[string]string, error) {
+	out := make(map[string]string, len(d.OptionAxes))
+	for _, axis := range d.OptionAxes {
+		value := strings.TrimSpace(options[axis])
+		if value == "" {
+			return nil, fmt.Errorf("%w: %s is required", ErrInvalidVariantOptions, axis)
+		}
+		out[axis] = value
+	}
+	if len(options) != len(out) {
+		return nil, fmt.Errorf("%w: only %s may be set", ErrInvalidVariantOptions, strings.Join(d.OptionAxes, ", "))
+	}
+	return out, nil
+}
+
+// VariantOptionsKey is the canonical form of a variant's options: axes in
+// alphabetical order with lower-cased values, e.g. "color=black;size=xl".
+// Variants of an article must have distinct keys.
+func VariantOptionsKey(options map[string]string) string {
+	axes := make([]string, 0, len(options))
+	for axis := range options {
+		axes = append(axes, axis)
+	}
+	sort.Strings(axes)
+	parts := make([]string, 0, len(axes))
+	for _, axis := range axes {
+		parts = append(parts, axis+"="+strings.ToLower(options[axis]))
+	}
+	return strings.Join(parts, ";")
+}
+
+// DisplayName joins the option values in axis order, e.g. "Black / A3".
+func (v *ArticleVariant) DisplayName(axes []string) string {
+	parts := make([]string, 0, len(axes))
+	for _, axis := range axes {
+		if value := v.Options[axis]; value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// NormalizePrintAreas trims names and checks that they are distinct, ignoring
+// case, and that every area has a positive size.
+func NormalizePrintAreas(areas []PrintArea) ([]PrintArea, error) {
+	out := make([]PrintArea, 0, len(areas))
+	seen := make(map[string]bool, len(areas))
+	for _, a := range areas {
+		a.Name = strings.TrimSpace(a.Name)
+		key := strings.ToLower(a.Name)
+		if a.Name == "" || seen[key] {
+			return nil, fmt.Errorf("%w: names must be present and distinct", ErrInvalidPrintAreas)
+		}
+		if a.WidthMm <= 0 || a.HeightMm <= 0 {
+			return nil, fmt.Errorf("%w: %s needs a positive width and height", ErrInvalidPrintAreas, a.Name)
+		}
+		seen[key] = true
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func trimmedUnique(values []string) ([]string, error) {
+	out := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			return nil, errors.New("values must be present and distinct")
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// --- Service ---
+
+func (s *Service) ListArticleTypes(ctx context.Context) ([]ArticleTypeDefinition, error) {
+	return s.repo.ListArticleTypes(ctx)
+}
+
+// GetArticleType looks up a type by code, ignoring case.
+func (s *Service) GetArticleType(ctx context.Context, code string) (ArticleTypeDefinition, error) {
+	def, err := s.repo.GetArticleType(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if errorsIsNotFound(err) {
+		return ArticleTypeDefinition{}, fmt.Errorf("%w: %s", ErrArticleTypeNotFound, code)
+	}
+	return def, err
+}
+
+func (s *Service) CreateArticleType(ctx context.Context, def *ArticleTypeDefinition) (ArticleTypeDefinition, error) {
+	if err := def.Normalize(); err != nil {
+		return ArticleTypeDefinition{}, err
+	}
+	def.BuiltIn = false
+	if _, err := s.repo.GetArticleType(ctx, def.Code); err == nil {
+		return ArticleTypeDefinition{}, fmt.Errorf("%w: %s", ErrArticleTypeExists, def.Code)
+	} else if !errorsIsNotFound(err) {
+		return ArticleTypeDefinition{}, err
+	}
+	if err := s.repo.CreateArticleType(ctx, def); err != nil {
+		return ArticleTypeDefinition{}, err
+	}
+	return *def, nil
+}
+
+// UpdateArticleType replaces name, description, attribute schema and option
+// axes of a registered type. Option axes are fixed once articles use the
+// type, since their variants are keyed by them; attribute values of existing
+// articles are checked against a changed schema when they are next saved.
+func (s *Service) UpdateArticleType(ctx context.Context, def *ArticleTypeDefinition) (ArticleTypeDefinition, error) {
+	defer s.InvalidateCatalog()
+	existing, err := s.GetArticleType(ctx, def.Code)
+	if err != nil {
+		return ArticleTypeDefinition{}, err
+	}
+	if existing.BuiltIn {
+		return ArticleTypeDefinition{}, ErrBuiltInArticleType
+	}
+	if err := def.Normalize(); err != nil {
+		return ArticleTypeDefinition{}, err
+	}
+	if !slices.Equal(existing.OptionAxes, def.OptionAxes) {
+		count, err := s.repo.CountArticlesByType(ctx, def.Code)
+		if err != nil {
+			return ArticleTypeDefinition{}, err
+		}
+		if count > 0 {
+			return ArticleTypeDefinition{}, fmt.Errorf("%w: option axes cannot change while %d articles use it", ErrArticleTypeInUse, count)
+		}
+	}
+	def.BuiltIn = false
+	def.CreatedAt = existing.CreatedAt
+	if err := s.repo.UpdateArticleType(ctx, def); err != nil {
+		return ArticleTypeDefinition{}, err
+	}
+	return *def, nil
+}
+
+func (s *Service) DeleteArticleType(ctx context.Context, code string) error {
+	def, err := s.GetArticleType(ctx, code)
+	if err != nil {
+		return err
+	}
+	if def.BuiltIn {
+		return ErrBuiltInArticleType
+	}
+	count, err := s.repo.CountArticlesByType(ctx, def.Code)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d articles use it", ErrArticleTypeInUse, count)
+	}
+	return s.repo.DeleteArticleType(ctx, def.Code)
+}
+
+// prepareArticleType normalizes the article's type code and checks its
+// attributes against the type's schema. Mugs and shirts carry no attributes.
+func (s *Service) prepareArticleType(ctx context.Context, art *Article) error {
+	def, err := s.GetArticleType(ctx, art.ArticleType)
+	if err != nil {
+		return err
+	}
+	art.ArticleType = def.Code
+	if def.BuiltIn {
+		art.Attributes = map[string]any{}
+		return nil
+	}
+	attributes, err := def.NormalizeAttributes(art.Attributes)
+	if err != nil {
+		return err
+	}
+	art.Attributes = attributes
+	return nil
+}
+
+// registeredArticle loads an article of a registered type together with the
+// type. Mugs and shirts fail with ErrNotRegisteredType.
+func (s *Service) registeredArticle(ctx context.Context, articleID int) (Article, ArticleTypeDefinition, error) {
+	art, err := s.repo.GetArticle(ctx, articleID)
+	if err != nil {
+		return Article{}, ArticleTypeDefinition{}, err
+	}
+	def, err := s.GetArticleType(ctx, art.ArticleType)
+	if err != nil {
+		return Article{}, ArticleTypeDefinition{}, err
+	}
+	if def.BuiltIn {
+		return Article{}, ArticleTypeDefinition{}, ErrNotRegisteredType
+	}
+	return art, def, nil
+}
+
+func (s *Service) ListArticleVariants(ctx context.Context, articleID int, onlyActive bool) ([]ArticleVariant, error) {
+	return s.repo.ListArticleVariants(ctx, articleID, onlyActive)
+}
+
+func (s *Service) GetArticleVariant(ctx context.Context, id int) (ArticleVariant, error) {
+	return s.repo.GetArticleVariant(ctx, id)
+}
+
+// CreateArticleVariant adds a variant to an article of a registered type. Its
+// options must cover exactly the type's option axes and differ from those of
+// the article's other variants.
+func (s *Service) CreateArticleVariant(ctx context.Context, variant *ArticleVariant) (ArticleVariant, error) {
+	defer s.InvalidateCatalog()
+	if err := s.checkArticleVariant(ctx, variant); err != nil {
+		return ArticleVariant{}, err
+	}
+	if err := s.repo.CreateArticleVariant(ctx, variant); err != nil {
+		return ArticleVariant{}, err
+	}
+	return *variant, nil
+}
+
+func (s *Service) UpdateArticleVariant(ctx context.Context, variant *ArticleVariant) (ArticleVariant, error) {
+	defer s.InvalidateCatalog()
+	if err := s.checkArticleVariant(ctx, variant); err != nil {
+		return ArticleVariant{}, err
+	}
+	if err := s.repo.UpdateArticleVariant(ctx, variant); err != nil {
+		return ArticleVariant{}, err
+	}
+	return *variant, nil
+}
+
+func (s *Service) checkArticleVariant(ctx context.Context, variant *ArticleVariant) error {
+	_, def, err := s.registeredArticle(ctx, variant.ArticleID)
+	if err != nil {
+		return err
+	}
+	options, err := def.NormalizeOptions(variant.Options)
+	if err != nil {
+		return err
+	}
+	variant.Options = options
+	siblings, err := s.repo.ListArticleVariants(ctx, variant.ArticleID, false)
+	if err != nil {
+		return err
+	}
+	key := VariantOptionsKey(options)
+	for i := range siblings {
+		if siblings[i].ID != variant.ID && VariantOptionsKey(siblings[i].Options) == key {
+			return ErrDuplicateVariant
+		}
+	}
+	return nil
+}
+
+// PurgeArticleVariant permanently deletes an archived variant that was never
+// ordered.
+func (s *Service) PurgeArticleVariant(ctx context.Context, id int) error {
+	defer s.InvalidateCatalog()
+	variant, err := s.repo.GetArticleVariant(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.ensurePurgeable(ctx, variant.ArticleID, id, variant.ArchivedAt); err != nil {
+		return err
+	}
+	galleryFiles, err := s.variantGalleryFiles(ctx, variant.ArticleID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteArticleVariant(ctx, id); err != nil {
+		return err
+	}
+	removeGalleryFiles(galleryFiles)
+	if variant.ExampleImageFilename != nil && strings.TrimSpace(*variant.ExampleImageFilename) != "" {
+		if storageLocations, err := img.NewStorageLocations(); err == nil {
+			_ = os.Remove(filepath.Join(storageLocations.ArticleVariantExample(), filepath.Base(*variant.ExampleImageFilename)))
+		}
+	}
+	return nil
+}
+
+func (s *Service) ListPrintAreas(ctx context.Context, articleID int) ([]PrintArea, error) {
+	return s.repo.ListPrintAreas(ctx, articleID)
+}
+
+// SetPrintAreas replaces the print areas of an article of a registered type.
+func (s *Service) SetPrintAreas(ctx context.Context, articleID int, areas []PrintArea) ([]PrintArea, error) {
+	defer s.InvalidateCatalog()
+	if _, _, err := s.registeredArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+	normalized, err := NormalizePrintAreas(areas)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplacePrintAreas(ctx, articleID, normalized); err != nil {
+		return nil, err
+	}
+	return s.repo.ListPrintAreas(ctx, articleID)
+}
+
+// TypeCatalog returns the registered type with its active articles for the
+// public listing.
+func (s *Service) TypeCatalog(ctx context.Context, code string) (ArticleTypeDefinition, []TypeCatalogEntry, error) {
+	def, err := s.GetArticleType(ctx, code)
+	if err != nil {
+		return ArticleTypeDefinition{}, nil, err
+	}
+	if def.BuiltIn {
+		return def, nil, ErrNotRegisteredType
+	}
+	entries, err := s.repo.ListTypeCatalog(ctx, def.Code)
+	if err != nil {
+		return ArticleTypeDefinition{}, nil, err
+	}
+	return def, entries, nil
+}
@@ -0,0 +1,55 @@
+package article
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArticleTypeNormalizeRejectsMalformedDefinitions(t *testing.T) {
+	cases := map[string]ArticleTypeDefinition{
+		"code":          {Code: "phone case", Name: "Phone case"},
+		"name":          {Code: "CASE", Name: " "},
+		"attribute key": {Code: "CASE", Name: "Case", AttributeSchema: []AttributeDefinition{{Key: "Model", Type: AttributeTypeText}}},
+		"type":          {Code: "CASE", Name: "Case", AttributeSchema: []AttributeDefinition{{Key: "model", Type: "DATE"}}},
+		"enum options":  {Code: "CASE", Name: "Case", AttributeSchema: []AttributeDefinition{{Key: "finish", Type: AttributeTypeEnum}}},
+		"axis clash":    {Code: "CASE", Name: "Case", AttributeSchema: []AttributeDefinition{{Key: "color", Type: AttributeTypeText}}, OptionAxes: []string{"color"}},
+	}
+	for name, def := range cases {
+		if err := def.Normalize(); !errors.Is(err, ErrInvalidArticleType) {
+			t.Errorf("%s: err = %v, want ErrInvalidArticleType", name, err)
+		}
+	}
+}
+
+func TestNormalizeAttributesChecksSchema(t *testing.T) {
+	def := ArticleTypeDefinition{AttributeSchema: []AttributeDefinition{
+		{Key: "diameterMm", Type: AttributeTypeNumber, Required: true},
+		{Key: "cork", Type: AttributeTypeBoolean},
+		{Key: "note", Type: AttributeTypeText},
+	}}
+	got, err := def.NormalizeAttributes(map[string]any{"diameterMm": 95.0, "cork": true, "note": "  "})
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if len(got) != 2 || got["diameterMm"] != 95.0 || got["cork"] != true {
+		t.Fatalf("unexpected attributes: %v", got)
+	}
+	for _, values := range []map[string]any{
+		{},
+		{"diameterMm": "95"},
+		{"diameterMm": 95.0, "cork": "yes"},
+		{"diameterMm": 95.0, "colour": "red"},
+	} {
+		if _, err := def.NormalizeAttributes(values); !errors.Is(err, ErrInvalidAttributes) {
+			t.Errorf("NormalizeAttributes(%v) err = %v, want ErrInvalidAttributes", values, err)
+		}
+	}
+}
+
+func TestVariantOptionsKeyIgnoresOrderAndCase(t *testing.T) {
+	a := VariantOptionsKey(map[string]string{"size": "XL", "color": "Black"})
+	b := VariantOptionsKey(map[string]string{"color": "black", "size": "xl"})
+	if a != b || a != "color=black;size=xl" {
+		t.Fatalf("keys = %q, %q", a, b)
+	}
+}
@@ -0,0 +1,58 @@
+package article
+
+import "context"
+
+// VariantAvailability describes whether a mug or shirt variant can be sold.
+// Variants without stock tracking are always in stock and report no quantity.
+type VariantAvailability struct {
+	Tracked   bool
+	Available int
+	InStock   bool
+	LowStock  bool
+}
+
+// AvailabilityLookup resolves the availability of variants of one type
+// (ArticleTypeMug or ArticleTypeShirt), keyed by variant ID. Variants missing
+// from the result are untracked.
+type AvailabilityLookup interface {
+	VariantAvailability(ctx context.Context, variantType string, variantIDs []int) (map[int]VariantAvailability, error)
+}
+
+// VariantAvailabilityResponse is the public representation of a variant's
+// availability. AvailableQuantity is omitted for untracked variants.
+type VariantAvailabilityResponse struct {
+	InStock           bool `json:"inStock"`
+	AvailableQuantity *int `json:"availableQuantity,omitempty"`
+	LowStock          bool `json:"lowStock"`
+}
+
+// NewVariantAvailabilityResponse converts availability into its response form.
+func NewVariantAvailabilityResponse(a VariantAvailability) VariantAvailabilityResponse {
+	resp := VariantAvailabilityResponse{InStock: a.InStock, LowStock: a.LowStock}
+	if a.Tracked {
+		available := a.Available
+		resp.AvailableQuantity = &available
+	}
+	return resp
+}
+
+// LookupAvailability returns the availability of every given variant, treating
+// a nil lookup and missing entries as untracked.
+func LookupAvailability(ctx context.Context, lookup AvailabilityLookup, variantType string, variantIDs []int) (map[int]VariantAvailabilityResponse, error) {
+	found := map[int]VariantAvailability{}
+	if lookup != nil && len(variantIDs) > 0 {
+		var err error
+		if found, err = lookup.VariantAvailability(ctx, variantType, variantIDs); err != nil {
+			return nil, err
+		}
+	}
+	out := make(map[int]VariantAvailabilityResponse, len(variantIDs))
+	for _, id := range variantIDs {
+		a, ok := found[id]
+		if !ok {
+			a = VariantAvailability{InStock: true}
+		}
+		out[id] = NewVariantAvailabilityResponse(a)
+	}
+	return out, nil
+}
@@ -0,0 +1,78 @@
+package article
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// catalogCacheTTL bounds how long a cached catalog is served. Writes through
+// the Service invalidate it immediately; the TTL covers writes made by other
+// instances.
+const catalogCacheTTL = 5 * time.Minute
+
+// MugCatalogEntry bundles what the public mug listing shows for one article:
+// its mug details, active variants, gallery images of the article and its
+// variants, and cost calculation (nil without one).
+type MugCatalogEntry struct {
+	Article  Article
+	Details  MugDetails
+	Variants []MugVariant
+	Gallery  []GalleryImage
+	Price    *Price
+}
+
+// TypeCatalogEntry is the public listing of one article of a registered
+// type: its active variants, print areas, gallery images and cost
+// calculation (nil without one).
+type TypeCatalogEntry struct {
+	Article    Article
+	Variants   []ArticleVariant
+	PrintAreas []PrintArea
+	Gallery    []GalleryImage
+	Price      *Price
+}
+
+// catalogCache holds the last loaded mug catalog. generation is bumped on
+// every invalidation so a load that raced with a write is not stored.
+type catalogCache struct {
+	mu         sync.Mutex
+	mugs       []MugCatalogEntry
+	loadedAt   time.Time
+	generation uint64
+}
+
+// MugCatalog returns all active mugs with details for the public listing.
+// The result is shared between callers and must not be modified.
+func (s *Service) MugCatalog(ctx context.Context) ([]MugCatalogEntry, error) {
+	s.catalog.mu.Lock()
+	if s.catalog.mugs != nil && time.Since(s.catalog.loadedAt) < catalogCacheTTL {
+		mugs := s.catalog.mugs
+		s.catalog.mu.Unlock()
+		return mugs, nil
+	}
+	generation := s.catalog.generation
+	s.catalog.mu.Unlock()
+
+	mugs, err := s.repo.ListMugCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.catalog.mu.Lock()
+	if s.catalog.generation == generation {
+		s.catalog.mugs = mugs
+		s.catalog.loadedAt = time.Now()
+	}
+	s.catalog.mu.Unlock()
+	return mugs, nil
+}
+
+// InvalidateCatalog drops the cached catalog. Call it after writes to
+// articles, mug details, mug variants, galleries or prices made outside this Service.
+func (s *Service) InvalidateCatalog() {
+	s.catalog.mu.Lock()
+	s.catalog.mugs = nil
+	s.catalog.generation++
+	s.catalog.mu.Unlock()
+}
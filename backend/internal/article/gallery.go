@@ -0,0 +1,234 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	img "voenix/backend/internal/image"
+)
+
+// galleryImageType is the admin image type gallery uploads are stored under.
+const galleryImageType = "ARTICLE_GALLERY"
+
+// maxGalleryAltTextLength matches the alt_text column.
+const maxGalleryAltTextLength = 500
+
+var (
+	ErrInvalidGalleryVariant = errors.New("variant does not belong to the article")
+	ErrInvalidGalleryOrder   = errors.New("image ids must list every image of the gallery exactly once")
+	ErrGalleryAltTextTooLong = errors.New("alt text must be at most 500 characters")
+)
+
+// GalleryImage is a photo in the gallery of an article or, when VariantID is
+// set, of one of its variants. The variant kind follows the article type:
+// mug variants for mugs, shirt variants for shirts and generic variants for
+// registered types. Each gallery has at most one primary image.
+type GalleryImage struct {
+	ID        int
+	ArticleID int
+	VariantID *int
+	Filename  string
+	AltText   string
+	Position  int
+	IsPrimary bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// InGallery reports whether the image belongs to the gallery of the given
+// variant, or to the article's own gallery when variantID is nil.
+func (g *GalleryImage) InGallery(variantID *int) bool {
+	if g.VariantID == nil || variantID == nil {
+		return g.VariantID == nil && variantID == nil
+	}
+	return *g.VariantID == *variantID
+}
+
+// GalleryOf returns the images of one gallery, keeping their order.
+func GalleryOf(images []GalleryImage, variantID *int) []GalleryImage {
+	out := []GalleryImage{}
+	for i := range images {
+		if images[i].InGallery(variantID) {
+			out = append(out, images[i])
+		}
+	}
+	return out
+}
+
+// ImageUploader stores admin image uploads and returns the stored filename.
+// image.Service implements it.
+type ImageUploader interface {
+	UploadAdminImage(ctx context.Context, fileReader io.Reader, imageType string, cropArea *img.CropArea) (string, string, error)
+}
+
+// ListGallery returns all gallery images of an article and its variants,
+// ordered by position.
+func (s *Service) ListGallery(ctx context.Context, articleID int) ([]GalleryImage, error) {
+	if _, err := s.repo.GetArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListGalleryImages(ctx, articleID)
+}
+
+// AddGalleryImage uploads the file and appends it to the gallery of the
+// article or of image.VariantID. The first image of a gallery becomes its
+// primary image. The stored file is removed again when saving fails.
+func (s *Service) AddGalleryImage(ctx context.Context, uploader ImageUploader, image *GalleryImage, file io.Reader, crop *img.CropArea) (GalleryImage, error) {
+	image.AltText = strings.TrimSpace(image.AltText)
+	if len(image.AltText) > maxGalleryAltTextLength {
+		return GalleryImage{}, ErrGalleryAltTextTooLong
+	}
+	art, err := s.repo.GetArticle(ctx, image.ArticleID)
+	if err != nil {
+		return GalleryImage{}, err
+	}
+	if err := s.validateGalleryVariant(ctx, &art, image.VariantID); err != nil {
+		return GalleryImage{}, err
+	}
+	existing, err := s.repo.ListGalleryImages(ctx, art.ID)
+	if err != nil {
+		return GalleryImage{}, err
+	}
+	if len(GalleryOf(existing, image.VariantID)) == 0 {
+		image.IsPrimary = true
+	}
+	filename, _, err := uploader.UploadAdminImage(ctx, file, galleryImageType, crop)
+	if err != nil {
+		return GalleryImage{}, err
+	}
+	image.Filename = filename
+	if err := s.repo.CreateGalleryImage(ctx, image); err != nil {
+		removeGalleryFiles([]string{filename})
+		return GalleryImage{}, err
+	}
+	s.InvalidateCatalog()
+	return s.repo.GetGalleryImage(ctx, image.ID)
+}
+
+// UpdateGalleryImage changes the alt text of an image and, when isPrimary is
+// set, makes it the primary image of its gallery.
+func (s *Service) UpdateGalleryImage(ctx context.Context, id int, altText string, isPrimary bool) (GalleryImage, error) {
+	altText = strings.TrimSpace(altText)
+	if len(altText) > maxGalleryAltTextLength {
+		return GalleryImage{}, ErrGalleryAltTextTooLong
+	}
+	image, err := s.repo.GetGalleryImage(ctx, id)
+	if err != nil {
+		return GalleryImage{}, err
+	}
+	image.AltText = altText
+	// A gallery always keeps a primary image; it moves by promoting another.
+	image.IsPrimary = image.IsPrimary || isPrimary
+	if err := s.repo.UpdateGalleryImage(ctx, &image); err != nil {
+		return GalleryImage{}, err
+	}
+	s.InvalidateCatalog()
+	return s.repo.GetGalleryImage(ctx, id)
+}
+
+// DeleteGalleryImage removes the image and its file. When it was the primary
+// image the next one of its gallery takes over.
+func (s *Service) DeleteGalleryImage(ctx context.Context, id int) error {
+	image, err := s.repo.GetGalleryImage(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteGalleryImage(ctx, id); err != nil {
+		return err
+	}
+	s.InvalidateCatalog()
+	removeGalleryFiles([]string{image.Filename})
+	return nil
+}
+
+// ReorderGallery sets the order of the gallery of the article or of one of
+// its variants. imageIDs must list every image of that gallery once.
+func (s *Service) ReorderGallery(ctx context.Context, articleID int, variantID *int, imageIDs []int) ([]GalleryImage, error) {
+	images, err := s.ListGallery(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	gallery := GalleryOf(images, variantID)
+	if len(gallery) != len(imageIDs) {
+		return nil, ErrInvalidGalleryOrder
+	}
+	seen := make(map[int]bool, len(imageIDs))
+	for _, id := range imageIDs {
+		if seen[id] || !slices.ContainsFunc(gallery, func(g GalleryImage) bool { return g.ID == id }) {
+			return nil, ErrInvalidGalleryOrder
+		}
+		seen[id] = true
+	}
+	if err := s.repo.ReorderGallery(ctx, imageIDs); err != nil {
+		return nil, err
+	}
+	s.InvalidateCatalog()
+	images, err = s.repo.ListGalleryImages(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	return GalleryOf(images, variantID), nil
+}
+
+func (s *Service) validateGalleryVariant(ctx context.Context, art *Article, variantID *int) error {
+	if variantID == nil {
+		return nil
+	}
+	var ownerID int
+	var err error
+	switch art.ArticleType {
+	case ArticleTypeMug:
+		var v MugVariant
+		v, err = s.repo.GetMugVariant(ctx, *variantID)
+		ownerID = v.ArticleID
+	case ArticleTypeShirt:
+		var v ShirtVariant
+		v, err = s.repo.GetShirtVariant(ctx, *variantID)
+		ownerID = v.ArticleID
+	default:
+		var v ArticleVariant
+		v, err = s.repo.GetArticleVariant(ctx, *variantID)
+		ownerID = v.ArticleID
+	}
+	if errorsIsNotFound(err) || (err == nil && ownerID != art.ID) {
+		return ErrInvalidGalleryVariant
+	}
+	return err
+}
+
+// variantGalleryFiles returns the files of a variant's gallery so they can be
+// removed once the variant is deleted.
+func (s *Service) variantGalleryFiles(ctx context.Context, articleID, variantID int) ([]string, error) {
+	images, err := s.repo.ListGalleryImages(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, g := range GalleryOf(images, &variantID) {
+		files = append(files, g.Filename)
+	}
+	return files, nil
+}
+
+// removeGalleryFiles deletes gallery files from disk, best-effort.
+func removeGalleryFiles(filenames []string) {
+	if len(filenames) == 0 {
+		return
+	}
+	storageLocations, err := img.NewStorageLocations()
+	if err != nil {
+		return
+	}
+	for _, name := range filenames {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		_ = os.Remove(filepath.Join(storageLocations.ArticleGallery(), filepath.Base(name)))
+	}
+}
@@ -2,12 +2,22 @@ package article
 
 import "github.com/gin-gonic/gin"
 
-// RegisterRoutes mounts admin + public article routes.
-func RegisterRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service) {
+// RegisterRoutes mounts admin + public article routes. stock may be nil when
+// variant stock is not tracked; uploader stores gallery uploads.
+func RegisterRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service, stock AvailabilityLookup, uploader ImageUploader) {
 	registerAdminCategoryRoutes(r, adminMiddleware, svc)
 	registerAdminSubCategoryRoutes(r, adminMiddleware, svc)
 	registerAdminArticleRoutes(r, adminMiddleware, svc)
 	registerAdminMugVariantRoutes(r, adminMiddleware, svc)
 	registerAdminShirtVariantRoutes(r, adminMiddleware, svc)
-	registerPublicMugRoutes(r, svc)
+	registerAdminArticleTypeRoutes(r, adminMiddleware, svc)
+	registerAdminArticleVariantRoutes(r, adminMiddleware, svc)
+	registerAdminPriceTierRoutes(r, adminMiddleware, svc)
+	registerAdminPriceHistoryRoutes(r, adminMiddleware, svc)
+	registerAdminGalleryRoutes(r, adminMiddleware, svc, uploader)
+	registerAdminRelationRoutes(r, adminMiddleware, svc)
+	registerPublicMugRoutes(r, svc, stock)
+	registerPublicShirtRoutes(r, svc, stock)
+	registerPublicCatalogRoutes(r, svc)
+	registerPublicArticleRoutes(r, svc, stock)
 }
@@ -0,0 +1,188 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type attributeDefinitionPayload struct {
+	Key      string   `json:"key"`
+	Label    string   `json:"label"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options"`
+	Unit     string   `json:"unit"`
+}
+
+type articleTypeRequest struct {
+	Code            string                       `json:"code"`
+	Name            string                       `json:"name"`
+	Description     *string                      `json:"description"`
+	AttributeSchema []attributeDefinitionPayload `json:"attributeSchema"`
+	OptionAxes      []string                     `json:"optionAxes"`
+}
+
+type articleTypeResponse struct {
+	Code            string                       `json:"code"`
+	Name            string                       `json:"name"`
+	Description     *string                      `json:"description"`
+	BuiltIn         bool                         `json:"builtIn"`
+	AttributeSchema []attributeDefinitionPayload `json:"attributeSchema"`
+	OptionAxes      []string                     `json:"optionAxes"`
+	// CatalogURL is the public listing of the type's articles.
+	CatalogURL string     `json:"catalogUrl"`
+	CreatedAt  *time.Time `json:"createdAt"`
+	UpdatedAt  *time.Time `json:"updatedAt"`
+}
+
+// registerAdminArticleTypeRoutes mounts the article type registry. MUG and
+// SHIRT are listed but cannot be changed.
+func registerAdminArticleTypeRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service) {
+	grp := r.Group("/api/admin/articles/types")
+	grp.Use(adminMiddleware)
+
+	grp.GET("", func(c *gin.Context) {
+		defs, err := svc.ListArticleTypes(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch article types"})
+			return
+		}
+		out := make([]articleTypeResponse, 0, len(defs))
+		for i := range defs {
+			out = append(out, toArticleTypeResponse(&defs[i]))
+		}
+		c.JSON(http.StatusOK, out)
+	})
+
+	grp.GET("/:code", func(c *gin.Context) {
+		def, err := svc.GetArticleType(c.Request.Context(), c.Param("code"))
+		if err != nil {
+			writeArticleTypeError(c, err, "Failed to fetch article type")
+			return
+		}
+		c.JSON(http.StatusOK, toArticleTypeResponse(&def))
+	})
+
+	grp.POST("", func(c *gin.Context) {
+		var payload articleTypeRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		def := mapArticleTypeRequest(&payload)
+		created, err := svc.CreateArticleType(c.Request.Context(), &def)
+		if err != nil {
+			writeArticleTypeError(c, err, "Failed to create article type")
+			return
+		}
+		c.JSON(http.StatusCreated, toArticleTypeResponse(&created))
+	})
+
+	grp.PUT("/:code", func(c *gin.Context) {
+		var payload articleTypeRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		payload.Code = c.Param("code")
+		def := mapArticleTypeRequest(&payload)
+		updated, err := svc.UpdateArticleType(c.Request.Context(), &def)
+		if err != nil {
+			writeArticleTypeError(c, err, "Failed to update article type")
+			return
+		}
+		c.JSON(http.StatusOK, toArticleTypeResponse(&updated))
+	})
+
+	grp.DELETE("/:code", func(c *gin.Context) {
+		if err := svc.DeleteArticleType(c.Request.Context(), c.Param("code")); err != nil {
+			writeArticleTypeError(c, err, "Failed to delete article type")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+}
+
+// writeArticleTypeError maps registry errors to responses; anything else is
+// reported with fallback.
+func writeArticleTypeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrArticleTypeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": "Article type not found"})
+	case errors.Is(err, ErrArticleTypeExists), errors.Is(err, ErrArticleTypeInUse):
+		c.JSON(http.StatusConflict, gin.H{"detail": err.Error()})
+	case errors.Is(err, ErrBuiltInArticleType), errors.Is(err, ErrInvalidArticleType):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
+
+func mapArticleTypeRequest(req *articleTypeRequest) ArticleTypeDefinition {
+	def := ArticleTypeDefinition{
+		Code:            req.Code,
+		Name:            req.Name,
+		Description:     req.Description,
+		AttributeSchema: make([]AttributeDefinition, 0, len(req.AttributeSchema)),
+		OptionAxes:      append([]string{}, req.OptionAxes...),
+	}
+	for _, a := range req.AttributeSchema {
+		def.AttributeSchema = append(def.AttributeSchema, AttributeDefinition{
+			Key:      a.Key,
+			Label:    a.Label,
+			Type:     a.Type,
+			Required: a.Required,
+			Options:  a.Options,
+			Unit:     a.Unit,
+		})
+	}
+	return def
+}
+
+func toArticleTypeResponse(def *ArticleTypeDefinition) articleTypeResponse {
+	out := articleTypeResponse{
+		Code:            def.Code,
+		Name:            def.Name,
+		Description:     def.Description,
+		BuiltIn:         def.BuiltIn,
+		AttributeSchema: make([]attributeDefinitionPayload, 0, len(def.AttributeSchema)),
+		OptionAxes:      def.OptionAxes,
+		CatalogURL:      catalogURL(def),
+		CreatedAt:       timePtr(def.CreatedAt),
+		UpdatedAt:       timePtr(def.UpdatedAt),
+	}
+	if out.OptionAxes == nil {
+		out.OptionAxes = []string{}
+	}
+	for _, a := range def.AttributeSchema {
+		options := a.Options
+		if options == nil {
+			options = []string{}
+		}
+		out.AttributeSchema = append(out.AttributeSchema, attributeDefinitionPayload{
+			Key:      a.Key,
+			Label:    a.Label,
+			Type:     a.Type,
+			Required: a.Required,
+			Options:  options,
+			Unit:     a.Unit,
+		})
+	}
+	return out
+}
+
+// catalogURL returns where the public listing of a type lives: mugs and shirts
+// keep their dedicated endpoints.
+func catalogURL(def *ArticleTypeDefinition) string {
+	switch def.Code {
+	case ArticleTypeMug:
+		return "/api/mugs"
+	case ArticleTypeShirt:
+		return "/api/shirts"
+	default:
+		return "/api/catalog/" + def.Code
+	}
+}
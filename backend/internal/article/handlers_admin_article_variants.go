@@ -0,0 +1,221 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type articleVariantRequest struct {
+	Options              map[string]string `json:"options"`
+	ArticleVariantNumber *string           `json:"articleVariantNumber"`
+	ExampleImageFilename *string           `json:"exampleImageFilename"`
+	IsDefault            *bool             `json:"isDefault"`
+	Active               *bool             `json:"active"`
+}
+
+type printAreaPayload struct {
+	Name     string `json:"name"`
+	WidthMm  int    `json:"widthMm"`
+	HeightMm int    `json:"heightMm"`
+}
+
+type printAreasRequest struct {
+	PrintAreas []printAreaPayload `json:"printAreas"`
+}
+
+type articleVariantResponse struct {
+	ID                   int               `json:"id"`
+	ArticleID            int               `json:"articleId"`
+	Options              map[string]string `json:"options"`
+	ArticleVariantNumber *string           `json:"articleVariantNumber"`
+	ExampleImageURL      *string           `json:"exampleImageUrl"`
+	ExampleImageFilename *string           `json:"exampleImageFilename"`
+	IsDefault            bool              `json:"isDefault"`
+	Active               bool              `json:"active"`
+	ArchivedAt           *time.Time        `json:"archivedAt"`
+	CreatedAt            *time.Time        `json:"createdAt"`
+	UpdatedAt            *time.Time        `json:"updatedAt"`
+}
+
+type printAreaResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	WidthMm  int    `json:"widthMm"`
+	HeightMm int    `json:"heightMm"`
+}
+
+// registerAdminArticleVariantRoutes mounts variant and print area management
+// for articles of registered types. Mugs and shirts use their own routes.
+func registerAdminArticleVariantRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service) {
+	grp := r.Group("/api/admin/articles")
+	grp.Use(adminMiddleware)
+
+	grp.POST("/:id/variants", func(c *gin.Context) {
+		aid, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+			return
+		}
+		var payload articleVariantRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		variant := ArticleVariant{ArticleID: aid, Active: true}
+		applyArticleVariantRequest(&variant, &payload)
+		created, err := svc.CreateArticleVariant(c.Request.Context(), &variant)
+		if err != nil {
+			writeArticleVariantError(c, err, "Article not found", "Failed to create variant")
+			return
+		}
+		c.JSON(http.StatusCreated, toArticleVariantResponse(&created))
+	})
+
+	grp.PUT("/variants/:variantId", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("variantId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+			return
+		}
+		existing, err := svc.GetArticleVariant(c.Request.Context(), id)
+		if err != nil {
+			writeArticleVariantError(c, err, "Variant not found", "Failed to fetch variant")
+			return
+		}
+		var payload articleVariantRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		applyArticleVariantRequest(&existing, &payload)
+		updated, err := svc.UpdateArticleVariant(c.Request.Context(), &existing)
+		if err != nil {
+			writeArticleVariantError(c, err, "Variant not found", "Failed to update variant")
+			return
+		}
+		c.JSON(http.StatusOK, toArticleVariantResponse(&updated))
+	})
+
+	// DELETE archives the variant; purge removes it when it was never ordered.
+	grp.DELETE("/variants/:variantId", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("variantId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+			return
+		}
+		if _, err := svc.ArchiveArticleVariant(c.Request.Context(), id); err != nil {
+			if errorsIsNotFound(err) {
+				c.Status(http.StatusNoContent)
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to archive variant"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	grp.POST("/variants/:variantId/restore", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("variantId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+			return
+		}
+		restored, err := svc.RestoreArticleVariant(c.Request.Context(), id)
+		if err != nil {
+			writeArchiveError(c, err, "Variant not found", "Failed to restore variant")
+			return
+		}
+		c.JSON(http.StatusOK, toArticleVariantResponse(&restored))
+	})
+
+	grp.DELETE("/variants/:variantId/purge", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("variantId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+			return
+		}
+		if err := svc.PurgeArticleVariant(c.Request.Context(), id); err != nil {
+			writeArchiveError(c, err, "Variant not found", "Failed to purge variant")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	// PUT replaces all print areas of the article.
+	grp.PUT("/:id/print-areas", func(c *gin.Context) {
+		aid, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+			return
+		}
+		var payload printAreasRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		areas := make([]PrintArea, 0, len(payload.PrintAreas))
+		for _, a := range payload.PrintAreas {
+			areas = append(areas, PrintArea{Name: a.Name, WidthMm: a.WidthMm, HeightMm: a.HeightMm})
+		}
+		saved, err := svc.SetPrintAreas(c.Request.Context(), aid, areas)
+		if err != nil {
+			writeArticleVariantError(c, err, "Article not found", "Failed to save print areas")
+			return
+		}
+		c.JSON(http.StatusOK, toPrintAreaResponses(saved))
+	})
+}
+
+func applyArticleVariantRequest(v *ArticleVariant, req *articleVariantRequest) {
+	v.Options = req.Options
+	v.ArticleVariantNumber = req.ArticleVariantNumber
+	v.ExampleImageFilename = req.ExampleImageFilename
+	if req.IsDefault != nil {
+		v.IsDefault = *req.IsDefault
+	}
+	if req.Active != nil {
+		v.Active = *req.Active
+	}
+}
+
+func writeArticleVariantError(c *gin.Context, err error, notFound, fallback string) {
+	switch {
+	case errorsIsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"detail": notFound})
+	case errors.Is(err, ErrDuplicateVariant):
+		c.JSON(http.StatusConflict, gin.H{"detail": err.Error()})
+	case errors.Is(err, ErrNotRegisteredType), errors.Is(err, ErrArticleTypeNotFound),
+		errors.Is(err, ErrInvalidVariantOptions), errors.Is(err, ErrInvalidPrintAreas):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
+
+func toArticleVariantResponse(v *ArticleVariant) articleVariantResponse {
+	return articleVariantResponse{
+		ID:                   v.ID,
+		ArticleID:            v.ArticleID,
+		Options:              v.Options,
+		ArticleVariantNumber: v.ArticleVariantNumber,
+		ExampleImageURL:      strPtrOrNil(publicArticleVariantExampleURL(v.ExampleImageFilename)),
+		ExampleImageFilename: v.ExampleImageFilename,
+		IsDefault:            v.IsDefault,
+		Active:               v.Active,
+		ArchivedAt:           v.ArchivedAt,
+		CreatedAt:            timePtr(v.CreatedAt),
+		UpdatedAt:            timePtr(v.UpdatedAt),
+	}
+}
+
+func toPrintAreaResponses(areas []PrintArea) []printAreaResponse {
+	out := make([]printAreaResponse, 0, len(areas))
+	for _, a := range areas {
+		out = append(out, printAreaResponse{ID: a.ID, Name: a.Name, WidthMm: a.WidthMm, HeightMm: a.HeightMm})
+	}
+	return out
+}
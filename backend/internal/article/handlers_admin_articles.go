@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"voenix/backend/internal/utility"
 )
 
 // Requests
@@ -39,10 +41,13 @@ type createMugDetailsRequest struct {
 }
 
 type createShirtDetailsRequest struct {
-	Material         string   `json:"material"`
-	CareInstructions *string  `json:"careInstructions"`
-	FitType          string   `json:"fitType"`
-	AvailableSizes   []string `json:"availableSizes"`
+	Material          string   `json:"material"`
+	CareInstructions  *string  `json:"careInstructions"`
+	FitType           string   `json:"fitType"`
+	AvailableSizes    []string `json:"availableSizes"`
+	PrintAreaWidthMm  int      `json:"printAreaWidthMm"`
+	PrintAreaHeightMm int      `json:"printAreaHeightMm"`
+	PrintAreaPosition string   `json:"printAreaPosition"`
 }
 
 type costCalculationRequest struct {
@@ -79,18 +84,23 @@ type costCalculationRequest struct {
 
 // Responses
 type articleMugVariantResponse struct {
-	ID                   int        `json:"id"`
-	ArticleID            int        `json:"articleId"`
-	InsideColorCode      string     `json:"insideColorCode"`
-	OutsideColorCode     string     `json:"outsideColorCode"`
-	Name                 string     `json:"name"`
-	ExampleImageURL      *string    `json:"exampleImageUrl"`
-	ArticleVariantNumber *string    `json:"articleVariantNumber"`
-	IsDefault            bool       `json:"isDefault"`
-	Active               bool       `json:"active"`
-	ExampleImageFilename *string    `json:"exampleImageFilename"`
-	CreatedAt            *time.Time `json:"createdAt"`
-	UpdatedAt            *time.Time `json:"updatedAt"`
+	ID                   int     `json:"id"`
+	ArticleID            int     `json:"articleId"`
+	InsideColorCode      string  `json:"insideColorCode"`
+	OutsideColorCode     string  `json:"outsideColorCode"`
+	Name                 string  `json:"name"`
+	ExampleImageURL      *string `json:"exampleImageUrl"`
+	ArticleVariantNumber *string `json:"articleVariantNumber"`
+	IsDefault            bool    `json:"isDefault"`
+	Active               bool    `json:"active"`
+	ExampleImageFilename *string `json:"exampleImageFilename"`
+	// MockupPrintArea lists the print area corners clockwise from the
+	// top-left in fractions of the photo size; null without a mockup.
+	MockupPrintArea []mockupPoint `json:"mockupPrintArea"`
+	MockupCurvature float64       `json:"mockupCurvature"`
+	ArchivedAt      *time.Time    `json:"archivedAt"`
+	CreatedAt       *time.Time    `json:"createdAt"`
+	UpdatedAt       *time.Time    `json:"updatedAt"`
 }
 
 type articleShirtVariantResponse struct {
@@ -99,6 +109,7 @@ type articleShirtVariantResponse struct {
 	Color           string     `json:"color"`
 	Size            string     `json:"size"`
 	ExampleImageURL *string    `json:"exampleImageUrl"`
+	ArchivedAt      *time.Time `json:"archivedAt"`
 	CreatedAt       *time.Time `json:"createdAt"`
 	UpdatedAt       *time.Time `json:"updatedAt"`
 }
@@ -119,73 +130,84 @@ type articleMugDetailsResponse struct {
 }
 
 type articleShirtDetailsResponse struct {
-	ArticleID        int        `json:"articleId"`
-	Material         string     `json:"material"`
-	CareInstructions *string    `json:"careInstructions"`
-	FitType          string     `json:"fitType"`
-	AvailableSizes   []string   `json:"availableSizes"`
-	CreatedAt        *time.Time `json:"createdAt"`
-	UpdatedAt        *time.Time `json:"updatedAt"`
+	ArticleID         int        `json:"articleId"`
+	Material          string     `json:"material"`
+	CareInstructions  *string    `json:"careInstructions"`
+	FitType           string     `json:"fitType"`
+	AvailableSizes    []string   `json:"availableSizes"`
+	PrintAreaWidthMm  int        `json:"printAreaWidthMm"`
+	PrintAreaHeightMm int        `json:"printAreaHeightMm"`
+	PrintAreaPosition string     `json:"printAreaPosition"`
+	CreatedAt         *time.Time `json:"createdAt"`
+	UpdatedAt         *time.Time `json:"updatedAt"`
 }
 
 type costCalculationResponse struct {
-	ID                       int        `json:"id"`
-	ArticleID                int        `json:"articleId"`
-	PurchasePriceNet         int        `json:"purchasePriceNet"`
-	PurchasePriceTax         int        `json:"purchasePriceTax"`
-	PurchasePriceGross       int        `json:"purchasePriceGross"`
-	PurchaseCostNet          int        `json:"purchaseCostNet"`
-	PurchaseCostTax          int        `json:"purchaseCostTax"`
-	PurchaseCostGross        int        `json:"purchaseCostGross"`
-	PurchaseCostPercent      float64    `json:"purchaseCostPercent"`
-	PurchaseTotalNet         int        `json:"purchaseTotalNet"`
-	PurchaseTotalTax         int        `json:"purchaseTotalTax"`
-	PurchaseTotalGross       int        `json:"purchaseTotalGross"`
-	PurchasePriceUnit        string     `json:"purchasePriceUnit"`
-	PurchaseVatRateID        *int       `json:"purchaseVatRateId"`
-	PurchaseVatRatePercent   float64    `json:"purchaseVatRatePercent"`
-	PurchaseCalculationMode  string     `json:"purchaseCalculationMode"`
-	SalesVatRateID           *int       `json:"salesVatRateId"`
-	SalesVatRatePercent      float64    `json:"salesVatRatePercent"`
-	SalesMarginNet           int        `json:"salesMarginNet"`
-	SalesMarginTax           int        `json:"salesMarginTax"`
-	SalesMarginGross         int        `json:"salesMarginGross"`
-	SalesMarginPercent       float64    `json:"salesMarginPercent"`
-	SalesTotalNet            int        `json:"salesTotalNet"`
-	SalesTotalTax            int        `json:"salesTotalTax"`
-	SalesTotalGross          int        `json:"salesTotalGross"`
-	SalesPriceUnit           string     `json:"salesPriceUnit"`
-	SalesCalculationMode     string     `json:"salesCalculationMode"`
-	PurchasePriceCorresponds string     `json:"purchasePriceCorresponds"`
-	SalesPriceCorresponds    string     `json:"salesPriceCorresponds"`
-	PurchaseActiveRow        string     `json:"purchaseActiveRow"`
-	SalesActiveRow           string     `json:"salesActiveRow"`
-	CreatedAt                *time.Time `json:"createdAt"`
-	UpdatedAt                *time.Time `json:"updatedAt"`
+	ID                       int                 `json:"id"`
+	ArticleID                int                 `json:"articleId"`
+	PurchasePriceNet         int                 `json:"purchasePriceNet"`
+	PurchasePriceTax         int                 `json:"purchasePriceTax"`
+	PurchasePriceGross       int                 `json:"purchasePriceGross"`
+	PurchaseCostNet          int                 `json:"purchaseCostNet"`
+	PurchaseCostTax          int                 `json:"purchaseCostTax"`
+	PurchaseCostGross        int                 `json:"purchaseCostGross"`
+	PurchaseCostPercent      float64             `json:"purchaseCostPercent"`
+	PurchaseTotalNet         int                 `json:"purchaseTotalNet"`
+	PurchaseTotalTax         int                 `json:"purchaseTotalTax"`
+	PurchaseTotalGross       int                 `json:"purchaseTotalGross"`
+	PurchasePriceUnit        string              `json:"purchasePriceUnit"`
+	PurchaseVatRateID        *int                `json:"purchaseVatRateId"`
+	PurchaseVatRatePercent   float64             `json:"purchaseVatRatePercent"`
+	PurchaseCalculationMode  string              `json:"purchaseCalculationMode"`
+	SalesVatRateID           *int                `json:"salesVatRateId"`
+	SalesVatRatePercent      float64             `json:"salesVatRatePercent"`
+	SalesMarginNet           int                 `json:"salesMarginNet"`
+	SalesMarginTax           int                 `json:"salesMarginTax"`
+	SalesMarginGross         int                 `json:"salesMarginGross"`
+	SalesMarginPercent       float64             `json:"salesMarginPercent"`
+	SalesTotalNet            int                 `json:"salesTotalNet"`
+	SalesTotalTax            int                 `json:"salesTotalTax"`
+	SalesTotalGross          int                 `json:"salesTotalGross"`
+	SalesPriceUnit           string              `json:"salesPriceUnit"`
+	SalesCalculationMode     string              `json:"salesCalculationMode"`
+	PurchasePriceCorresponds string              `json:"purchasePriceCorresponds"`
+	SalesPriceCorresponds    string              `json:"salesPriceCorresponds"`
+	PurchaseActiveRow        string              `json:"purchaseActiveRow"`
+	SalesActiveRow           string              `json:"salesActiveRow"`
+	Tiers                    []priceTierResponse `json:"tiers"`
+	CreatedAt                *time.Time          `json:"createdAt"`
+	UpdatedAt                *time.Time          `json:"updatedAt"`
 }
 
 type ArticleResponse struct {
-	ID                    int                           `json:"id"`
-	Name                  string                        `json:"name"`
-	DescriptionShort      string                        `json:"descriptionShort"`
-	DescriptionLong       string                        `json:"descriptionLong"`
-	Active                bool                          `json:"active"`
-	ArticleType           string                        `json:"articleType"`
-	CategoryID            int                           `json:"categoryId"`
-	CategoryName          string                        `json:"categoryName"`
-	SubcategoryID         *int                          `json:"subcategoryId"`
-	SubcategoryName       *string                       `json:"subcategoryName"`
-	SupplierID            *int                          `json:"supplierId"`
-	SupplierName          *string                       `json:"supplierName"`
-	SupplierArticleName   *string                       `json:"supplierArticleName"`
-	SupplierArticleNumber *string                       `json:"supplierArticleNumber"`
-	MugVariants           []articleMugVariantResponse   `json:"mugVariants"`
-	ShirtVariants         []articleShirtVariantResponse `json:"shirtVariants"`
-	MugDetails            *articleMugDetailsResponse    `json:"mugDetails"`
-	ShirtDetails          *articleShirtDetailsResponse  `json:"shirtDetails"`
-	CostCalculation       *costCalculationResponse      `json:"costCalculation"`
-	CreatedAt             *time.Time                    `json:"createdAt"`
-	UpdatedAt             *time.Time                    `json:"updatedAt"`
+	ID                    int     `json:"id"`
+	Name                  string  `json:"name"`
+	Slug                  string  `json:"slug"`
+	DescriptionShort      string  `json:"descriptionShort"`
+	DescriptionLong       string  `json:"descriptionLong"`
+	Active                bool    `json:"active"`
+	ArticleType           string  `json:"articleType"`
+	CategoryID            int     `json:"categoryId"`
+	CategoryName          string  `json:"categoryName"`
+	SubcategoryID         *int    `json:"subcategoryId"`
+	SubcategoryName       *string `json:"subcategoryName"`
+	SupplierID            *int    `json:"supplierId"`
+	SupplierName          *string `json:"supplierName"`
+	SupplierArticleName   *string `json:"supplierArticleName"`
+	SupplierArticleNumber *string `json:"supplierArticleNumber"`
+	// Attributes, Variants and PrintAreas belong to articles of registered
+	// types; they are empty for mugs and shirts.
+	Attributes      map[string]any                `json:"attributes"`
+	MugVariants     []articleMugVariantResponse   `json:"mugVariants"`
+	ShirtVariants   []articleShirtVariantResponse `json:"shirtVariants"`
+	Variants        []articleVariantResponse      `json:"variants"`
+	PrintAreas      []printAreaResponse           `json:"printAreas"`
+	MugDetails      *articleMugDetailsResponse    `json:"mugDetails"`
+	ShirtDetails    *articleShirtDetailsResponse  `json:"shirtDetails"`
+	CostCalculation *costCalculationResponse      `json:"costCalculation"`
+	ArchivedAt      *time.Time                    `json:"archivedAt"`
+	CreatedAt       *time.Time                    `json:"createdAt"`
+	UpdatedAt       *time.Time                    `json:"updatedAt"`
 }
 
 type paginatedResponse[T any] struct {
@@ -198,6 +220,7 @@ type paginatedResponse[T any] struct {
 
 type createArticleRequest struct {
 	Name                  string                      `json:"name"`
+	Slug                  *string                     `json:"slug"`
 	DescriptionShort      string                      `json:"descriptionShort"`
 	DescriptionLong       string                      `json:"descriptionLong"`
 	Active                bool                        `json:"active"`
@@ -207,6 +230,7 @@ type createArticleRequest struct {
 	SupplierID            *int                        `json:"supplierId"`
 	SupplierArticleName   *string                     `json:"supplierArticleName"`
 	SupplierArticleNumber *string                     `json:"supplierArticleNumber"`
+	Attributes            map[string]any              `json:"attributes"`
 	MugVariants           []createMugVariantRequest   `json:"mugVariants"`
 	ShirtVariants         []createShirtVariantRequest `json:"shirtVariants"`
 	MugDetails            *createMugDetailsRequest    `json:"mugDetails"`
@@ -216,6 +240,7 @@ type createArticleRequest struct {
 
 type updateArticleRequest struct {
 	Name                  string                     `json:"name"`
+	Slug                  *string                    `json:"slug"`
 	DescriptionShort      string                     `json:"descriptionShort"`
 	DescriptionLong       string                     `json:"descriptionLong"`
 	Active                bool                       `json:"active"`
@@ -224,6 +249,7 @@ type updateArticleRequest struct {
 	SupplierID            *int                       `json:"supplierId"`
 	SupplierArticleName   *string                    `json:"supplierArticleName"`
 	SupplierArticleNumber *string                    `json:"supplierArticleNumber"`
+	Attributes            map[string]any             `json:"attributes"`
 	MugDetails            *createMugDetailsRequest   `json:"mugDetails"`
 	ShirtDetails          *createShirtDetailsRequest `json:"shirtDetails"`
 	CostCalculation       *costCalculationRequest    `json:"costCalculation"`
@@ -266,6 +292,9 @@ func registerAdminArticleRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 				opts.Active = &val
 			}
 		}
+		if arch := strings.TrimSpace(c.Query("archived")); arch == "true" || arch == "1" {
+			opts.Archived = true
+		}
 		opts.Search = strings.TrimSpace(c.Query("search"))
 		items, total, err := svc.ListArticles(c.Request.Context(), opts)
 		if err != nil {
@@ -284,6 +313,9 @@ func registerAdminArticleRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 				sv := item.ShirtVariants[j]
 				resp.ShirtVariants = append(resp.ShirtVariants, toArticleShirtVariantResponse(&sv))
 			}
+			for j := range item.Variants {
+				resp.Variants = append(resp.Variants, toArticleVariantResponse(&item.Variants[j]))
+			}
 			out = append(out, resp)
 		}
 		resp := paginatedResponse[ArticleResponse]{
@@ -321,6 +353,10 @@ func registerAdminArticleRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 			sv := detail.ShirtVariants[i]
 			resp.ShirtVariants = append(resp.ShirtVariants, toArticleShirtVariantResponse(&sv))
 		}
+		for i := range detail.Variants {
+			resp.Variants = append(resp.Variants, toArticleVariantResponse(&detail.Variants[i]))
+		}
+		resp.PrintAreas = toPrintAreaResponses(detail.PrintAreas)
 		resp.CostCalculation = toCostCalculationResponse(detail.CostCalculation)
 		c.JSON(http.StatusOK, resp)
 	})
@@ -377,6 +413,7 @@ func registerAdminArticleRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 		}
 		articleDomain := Article{
 			Name:                  payload.Name,
+			Slug:                  strings.TrimSpace(utility.DerefPointer(payload.Slug, "")),
 			DescriptionShort:      payload.DescriptionShort,
 			DescriptionLong:       payload.DescriptionLong,
 			Active:                payload.Active,
@@ -386,6 +423,7 @@ func registerAdminArticleRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 			SupplierID:            payload.SupplierID,
 			SupplierArticleName:   payload.SupplierArticleName,
 			SupplierArticleNumber: payload.SupplierArticleNumber,
+			Attributes:            payload.Attributes,
 		}
 		mugDetails := mapCreateMugDetails(payload.MugDetails)
 		shirtDetails := mapCreateShirtDetails(payload.ShirtDetails)
@@ -395,8 +433,11 @@ func registerAdminArticleRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 		detail, err := svc.CreateArticle(c.Request.Context(), &articleDomain, mugDetails, shirtDetails, cost, mugVariants, shirtVariants)
 		if err != nil {
 			switch {
-			case errors.Is(err, ErrCategoryNotFound), errors.Is(err, ErrSubcategoryNotFound), errors.Is(err, ErrSupplierNotFound), errors.Is(err, ErrVatNotFound):
+			case errors.Is(err, ErrCategoryNotFound), errors.Is(err, ErrSubcategoryNotFound), errors.Is(err, ErrSupplierNotFound), errors.Is(err, ErrVatNotFound),
+				errors.Is(err, ErrArticleTypeNotFound), errors.Is(err, ErrInvalidAttributes), errors.Is(err, utility.ErrInvalidSlug):
 				c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+			case errors.Is(err, utility.ErrSlugTaken):
+				c.JSON(http.StatusConflict, gin.H{"detail": err.Error()})
 			default:
 				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to create article"})
 			}
@@ -458,6 +499,9 @@ func registerAdminArticleRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 			}
 		}
 		existing.Name = payload.Name
+		if payload.Slug != nil {
+			existing.Slug = strings.TrimSpace(*payload.Slug)
+		}
 		existing.DescriptionShort = payload.DescriptionShort
 		existing.DescriptionLong = payload.DescriptionLong
 		existing.Active = payload.Active
@@ -466,14 +510,18 @@ func registerAdminArticleRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 		existing.SupplierID = payload.SupplierID
 		existing.SupplierArticleName = payload.SupplierArticleName
 		existing.SupplierArticleNumber = payload.SupplierArticleNumber
+		existing.Attributes = payload.Attributes
 		mugDetails := mapCreateMugDetails(payload.MugDetails)
 		shirtDetails := mapCreateShirtDetails(payload.ShirtDetails)
 		cost := mapCostCalculation(payload.CostCalculation)
 		detail, err := svc.UpdateArticle(c.Request.Context(), &existing, mugDetails, shirtDetails, cost)
 		if err != nil {
 			switch {
-			case errors.Is(err, ErrCategoryNotFound), errors.Is(err, ErrSubcategoryNotFound), errors.Is(err, ErrSupplierNotFound), errors.Is(err, ErrVatNotFound):
+			case errors.Is(err, ErrCategoryNotFound), errors.Is(err, ErrSubcategoryNotFound), errors.Is(err, ErrSupplierNotFound), errors.Is(err, ErrVatNotFound),
+				errors.Is(err, ErrArticleTypeNotFound), errors.Is(err, ErrInvalidAttributes), errors.Is(err, utility.ErrInvalidSlug):
 				c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+			case errors.Is(err, utility.ErrSlugTaken):
+				c.JSON(http.StatusConflict, gin.H{"detail": err.Error()})
 			default:
 				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to update article"})
 			}
@@ -482,27 +530,47 @@ func registerAdminArticleRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 		c.Redirect(http.StatusSeeOther, "/api/admin/articles/"+strconv.Itoa(detail.Article.ID))
 	})
 
+	// DELETE archives: the article leaves the catalog and carts but stays
+	// available to orders. Purge removes it for good.
 	grp.DELETE("/:id", func(c *gin.Context) {
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
 			return
 		}
-		if _, err := svc.GetArticle(c.Request.Context(), id); err != nil {
+		if _, err := svc.ArchiveArticle(c.Request.Context(), id); err != nil {
 			if errorsIsNotFound(err) {
 				c.Status(http.StatusNoContent)
 				return
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch article"})
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to archive article"})
 			return
 		}
-		if err := svc.DeleteArticle(c.Request.Context(), id); err != nil {
-			switch {
-			case errors.Is(err, ErrArticleHasOrders):
-				c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
-			default:
-				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to delete article"})
-			}
+		c.Status(http.StatusNoContent)
+	})
+
+	grp.POST("/:id/restore", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+			return
+		}
+		if _, err := svc.RestoreArticle(c.Request.Context(), id); err != nil {
+			writeArchiveError(c, err, "Article not found", "Failed to restore article")
+			return
+		}
+		c.Redirect(http.StatusSeeOther, "/api/admin/articles/"+strconv.Itoa(id))
+	})
+
+	// Purge deletes an archived article that was never ordered.
+	grp.DELETE("/:id/purge", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+			return
+		}
+		if err := svc.PurgeArticle(c.Request.Context(), id); err != nil {
+			writeArchiveError(c, err, "Article not found", "Failed to purge article")
 			return
 		}
 		c.Status(http.StatusNoContent)
@@ -566,11 +634,18 @@ func mapCreateShirtDetails(req *createShirtDetailsRequest) *ShirtDetails {
 	if req == nil {
 		return nil
 	}
+	position := strings.ToUpper(strings.TrimSpace(req.PrintAreaPosition))
+	if position != ShirtPrintAreaBack {
+		position = ShirtPrintAreaFront
+	}
 	return &ShirtDetails{
-		Material:         req.Material,
-		CareInstructions: req.CareInstructions,
-		FitType:          req.FitType,
-		AvailableSizes:   strings.Join(req.AvailableSizes, ","),
+		Material:          req.Material,
+		CareInstructions:  req.CareInstructions,
+		FitType:           req.FitType,
+		AvailableSizes:    strings.Join(req.AvailableSizes, ","),
+		PrintAreaWidthMm:  req.PrintAreaWidthMm,
+		PrintAreaHeightMm: req.PrintAreaHeightMm,
+		PrintAreaPosition: position,
 	}
 }
 
@@ -11,11 +11,13 @@ import (
 
 type articleCategoryCreate struct {
 	Name        string  `json:"name"`
+	Slug        *string `json:"slug"`
 	Description *string `json:"description"`
 }
 
 type articleCategoryUpdate struct {
 	Name        *string `json:"name"`
+	Slug        *string `json:"slug"`
 	Description *string `json:"description"`
 }
 
@@ -33,6 +35,7 @@ func registerAdminCategoryRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 		type Row struct {
 			ID            int        `json:"id"`
 			Name          string     `json:"name"`
+			Slug          string     `json:"slug"`
 			Description   *string    `json:"description"`
 			CreatedAt     *time.Time `json:"createdAt"`
 			UpdatedAt     *time.Time `json:"updatedAt"`
@@ -44,6 +47,7 @@ func registerAdminCategoryRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 			out = append(out, Row{
 				ID:            cat.ID,
 				Name:          cat.Name,
+				Slug:          cat.Slug,
 				Description:   cat.Description,
 				CreatedAt:     timePtr(cat.CreatedAt),
 				UpdatedAt:     timePtr(cat.UpdatedAt),
@@ -71,6 +75,7 @@ func registerAdminCategoryRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 		c.JSON(http.StatusOK, gin.H{
 			"id":          row.ID,
 			"name":        row.Name,
+			"slug":        row.Slug,
 			"description": row.Description,
 			"createdAt":   timePtr(row.CreatedAt),
 			"updatedAt":   timePtr(row.UpdatedAt),
@@ -83,14 +88,18 @@ func registerAdminCategoryRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
 			return
 		}
-		row, err := svc.CreateCategory(c.Request.Context(), strings.TrimSpace(payload.Name), payload.Description)
+		row, err := svc.CreateCategory(c.Request.Context(), strings.TrimSpace(payload.Name), payload.Slug, payload.Description)
 		if err != nil {
+			if writeSlugError(c, err) {
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to create category"})
 			return
 		}
 		c.JSON(http.StatusCreated, gin.H{
 			"id":          row.ID,
 			"name":        row.Name,
+			"slug":        row.Slug,
 			"description": row.Description,
 			"createdAt":   timePtr(row.CreatedAt),
 			"updatedAt":   timePtr(row.UpdatedAt),
@@ -113,18 +122,22 @@ func registerAdminCategoryRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc,
 			t := strings.TrimSpace(*payload.Name)
 			namePtr = &t
 		}
-		row, err := svc.UpdateCategory(c.Request.Context(), id, namePtr, payload.Description)
+		row, err := svc.UpdateCategory(c.Request.Context(), id, namePtr, payload.Slug, payload.Description)
 		if err != nil {
 			if errorsIsNotFound(err) {
 				c.JSON(http.StatusNotFound, gin.H{"detail": "ArticleCategory not found"})
 				return
 			}
+			if writeSlugError(c, err) {
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to update category"})
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{
 			"id":          row.ID,
 			"name":        row.Name,
+			"slug":        row.Slug,
 			"description": row.Description,
 			"createdAt":   timePtr(row.CreatedAt),
 			"updatedAt":   timePtr(row.UpdatedAt),
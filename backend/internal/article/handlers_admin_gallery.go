@@ -0,0 +1,209 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	img "voenix/backend/internal/image"
+)
+
+type galleryImageUpdateRequest struct {
+	AltText   string `json:"altText"`
+	IsPrimary bool   `json:"isPrimary"`
+}
+
+type galleryOrderRequest struct {
+	VariantID *int  `json:"variantId"`
+	ImageIDs  []int `json:"imageIds"`
+}
+
+type galleryImageResponse struct {
+	ID        int        `json:"id"`
+	ArticleID int        `json:"articleId"`
+	VariantID *int       `json:"variantId"`
+	Filename  string     `json:"filename"`
+	URL       string     `json:"url"`
+	AltText   string     `json:"altText"`
+	Position  int        `json:"position"`
+	IsPrimary bool       `json:"isPrimary"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+// registerAdminGalleryRoutes mounts gallery management for articles and their
+// variants. Uploads are stored through uploader.
+func registerAdminGalleryRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service, uploader ImageUploader) {
+	grp := r.Group("/api/admin/articles")
+	grp.Use(adminMiddleware)
+
+	grp.GET("/:id/gallery", func(c *gin.Context) {
+		aid, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+			return
+		}
+		images, err := svc.ListGallery(c.Request.Context(), aid)
+		if err != nil {
+			writeGalleryError(c, err, "Article not found", "Failed to fetch gallery")
+			return
+		}
+		c.JSON(http.StatusOK, toGalleryImageResponses(images))
+	})
+
+	// Upload a gallery image: expects form field "image", optional "altText",
+	// "variantId", "isPrimary" and crop fields.
+	grp.POST("/:id/gallery", func(c *gin.Context) {
+		aid, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+			return
+		}
+		fileHeader, err := c.FormFile("image")
+		if err != nil || fileHeader == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Missing image"})
+			return
+		}
+		image := GalleryImage{ArticleID: aid, AltText: c.PostForm("altText")}
+		if raw := strings.TrimSpace(c.PostForm("variantId")); raw != "" {
+			vid, err := strconv.Atoi(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+				return
+			}
+			image.VariantID = &vid
+		}
+		if raw := strings.TrimSpace(c.PostForm("isPrimary")); raw != "" {
+			primary, err := strconv.ParseBool(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid isPrimary"})
+				return
+			}
+			image.IsPrimary = primary
+		}
+		crop, err := parseCropForm(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid crop area"})
+			return
+		}
+		f, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Failed to read upload"})
+			return
+		}
+		defer func() { _ = f.Close() }()
+		created, err := svc.AddGalleryImage(c.Request.Context(), uploader, &image, f, crop)
+		if err != nil {
+			writeGalleryError(c, err, "Article not found", "Failed to add gallery image")
+			return
+		}
+		c.JSON(http.StatusCreated, toGalleryImageResponse(&created))
+	})
+
+	grp.PUT("/:id/gallery/order", func(c *gin.Context) {
+		aid, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+			return
+		}
+		var payload galleryOrderRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		images, err := svc.ReorderGallery(c.Request.Context(), aid, payload.VariantID, payload.ImageIDs)
+		if err != nil {
+			writeGalleryError(c, err, "Article not found", "Failed to reorder gallery")
+			return
+		}
+		c.JSON(http.StatusOK, toGalleryImageResponses(images))
+	})
+
+	grp.PUT("/gallery/:imageId", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("imageId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid image id"})
+			return
+		}
+		var payload galleryImageUpdateRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		updated, err := svc.UpdateGalleryImage(c.Request.Context(), id, payload.AltText, payload.IsPrimary)
+		if err != nil {
+			writeGalleryError(c, err, "Gallery image not found", "Failed to update gallery image")
+			return
+		}
+		c.JSON(http.StatusOK, toGalleryImageResponse(&updated))
+	})
+
+	grp.DELETE("/gallery/:imageId", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("imageId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid image id"})
+			return
+		}
+		if err := svc.DeleteGalleryImage(c.Request.Context(), id); err != nil {
+			writeGalleryError(c, err, "Gallery image not found", "Failed to delete gallery image")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+}
+
+// parseCropForm reads the optional cropX, cropY, cropWidth and cropHeight
+// form fields. All four must be given for a crop to apply.
+func parseCropForm(c *gin.Context) (*img.CropArea, error) {
+	fields := []string{c.PostForm("cropX"), c.PostForm("cropY"), c.PostForm("cropWidth"), c.PostForm("cropHeight")}
+	values := make([]float64, 0, len(fields))
+	for _, f := range fields {
+		if f == "" {
+			return nil, nil
+		}
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return &img.CropArea{X: values[0], Y: values[1], Width: values[2], Height: values[3]}, nil
+}
+
+func writeGalleryError(c *gin.Context, err error, notFound, fallback string) {
+	switch {
+	case errorsIsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"detail": notFound})
+	case errors.Is(err, ErrInvalidGalleryVariant), errors.Is(err, ErrInvalidGalleryOrder), errors.Is(err, ErrGalleryAltTextTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
+
+func toGalleryImageResponse(g *GalleryImage) galleryImageResponse {
+	return galleryImageResponse{
+		ID:        g.ID,
+		ArticleID: g.ArticleID,
+		VariantID: g.VariantID,
+		Filename:  g.Filename,
+		URL:       publicArticleGalleryURL(g.Filename),
+		AltText:   g.AltText,
+		Position:  g.Position,
+		IsPrimary: g.IsPrimary,
+		CreatedAt: timePtr(g.CreatedAt),
+		UpdatedAt: timePtr(g.UpdatedAt),
+	}
+}
+
+func toGalleryImageResponses(images []GalleryImage) []galleryImageResponse {
+	out := make([]galleryImageResponse, 0, len(images))
+	for i := range images {
+		out = append(out, toGalleryImageResponse(&images[i]))
+	}
+	return out
+}
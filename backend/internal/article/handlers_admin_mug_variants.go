@@ -1,6 +1,7 @@
 package article
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"os"
@@ -22,6 +23,19 @@ type mugVariantCreate struct {
 	Active               *bool   `json:"active"`
 }
 
+type mockupPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// mugVariantMockupRequest sets the print area corners, clockwise from the
+// top-left in fractions of the example photo, and the curvature (0..1). An
+// empty printArea removes the mockup.
+type mugVariantMockupRequest struct {
+	PrintArea []mockupPoint `json:"printArea"`
+	Curvature float64       `json:"curvature"`
+}
+
 type mugVariantCopyRequest struct {
 	VariantIDs []int `json:"variantIds"`
 }
@@ -133,18 +147,46 @@ func registerAdminMugVariantRoutes(r *gin.Engine, adminMiddleware gin.HandlerFun
 		c.JSON(http.StatusOK, toArticleMugVariantResponse(&updated))
 	})
 
+	// DELETE archives the variant; purge removes it when it was never ordered.
 	grp.DELETE("/variants/:variantId", func(c *gin.Context) {
 		id, err := strconv.Atoi(c.Param("variantId"))
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
 			return
 		}
-		if err := svc.DeleteMugVariant(c.Request.Context(), id); err != nil {
+		if _, err := svc.ArchiveMugVariant(c.Request.Context(), id); err != nil {
 			if errorsIsNotFound(err) {
 				c.JSON(http.StatusNotFound, gin.H{"detail": "Variant not found"})
 				return
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to delete variant"})
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to archive variant"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	grp.POST("/variants/:variantId/restore", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("variantId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+			return
+		}
+		restored, err := svc.RestoreMugVariant(c.Request.Context(), id)
+		if err != nil {
+			writeArchiveError(c, err, "Variant not found", "Failed to restore variant")
+			return
+		}
+		c.JSON(http.StatusOK, toArticleMugVariantResponse(&restored))
+	})
+
+	grp.DELETE("/variants/:variantId/purge", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("variantId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+			return
+		}
+		if err := svc.PurgeMugVariant(c.Request.Context(), id); err != nil {
+			writeArchiveError(c, err, "Variant not found", "Failed to purge variant")
 			return
 		}
 		c.Status(http.StatusNoContent)
@@ -259,6 +301,44 @@ func registerAdminMugVariantRoutes(r *gin.Engine, adminMiddleware gin.HandlerFun
 		c.JSON(http.StatusOK, toArticleMugVariantResponse(&updated))
 	})
 
+	grp.PUT("/variants/:variantId/mockup", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("variantId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+			return
+		}
+		var payload mugVariantMockupRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		var printArea *img.MockupQuad
+		if len(payload.PrintArea) > 0 {
+			var q img.MockupQuad
+			if len(payload.PrintArea) != len(q) {
+				c.JSON(http.StatusBadRequest, gin.H{"detail": "printArea needs exactly 4 points"})
+				return
+			}
+			for i, p := range payload.PrintArea {
+				q[i] = img.MockupPoint{X: p.X, Y: p.Y}
+			}
+			printArea = &q
+		}
+		updated, err := svc.SetMugVariantMockup(c.Request.Context(), id, printArea, payload.Curvature)
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrInvalidMockup):
+				c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+			case errorsIsNotFound(err):
+				c.JSON(http.StatusNotFound, gin.H{"detail": "Variant not found"})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to update variant"})
+			}
+			return
+		}
+		c.JSON(http.StatusOK, toArticleMugVariantResponse(&updated))
+	})
+
 	// Variants catalog (summary of all mugs with their variants)
 	grp.GET("/variants-catalog", func(c *gin.Context) {
 		exclude := c.Query("excludeMugId")
@@ -343,6 +423,8 @@ func registerAdminMugVariantRoutes(r *gin.Engine, adminMiddleware gin.HandlerFun
 				ExampleImageFilename: variant.ExampleImageFilename,
 				IsDefault:            variant.IsDefault,
 				Active:               variant.Active,
+				MockupPrintArea:      variant.MockupPrintArea,
+				MockupCurvature:      variant.MockupCurvature,
 			}
 			copyCreated, err := svc.CreateMugVariant(c.Request.Context(), &copyVariant)
 			if err == nil {
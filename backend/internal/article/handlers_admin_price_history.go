@@ -0,0 +1,163 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type schedulePriceChangeRequest struct {
+	EffectiveFrom   time.Time               `json:"effectiveFrom"`
+	CostCalculation *costCalculationRequest `json:"costCalculation"`
+}
+
+type priceHistoryEntryResponse struct {
+	ID              int                      `json:"id"`
+	EffectiveFrom   time.Time                `json:"effectiveFrom"`
+	AppliedAt       *time.Time               `json:"appliedAt"`
+	Status          string                   `json:"status"`
+	CostCalculation *costCalculationResponse `json:"costCalculation"`
+	CreatedAt       time.Time                `json:"createdAt"`
+}
+
+type priceTimelineResponse struct {
+	PriceID int                         `json:"priceId"`
+	Current *costCalculationResponse    `json:"current"`
+	Entries []priceHistoryEntryResponse `json:"entries"`
+	// PriceAt is the price in effect at the ?at= moment, if one was asked for.
+	PriceAt *costCalculationResponse `json:"priceAt,omitempty"`
+}
+
+// registerAdminPriceHistoryRoutes mounts the price timeline and scheduling of
+// price changes for article prices and, by price ID, for prompt prices.
+func registerAdminPriceHistoryRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service) {
+	articles := r.Group("/api/admin/articles")
+	articles.Use(adminMiddleware)
+
+	// GET /api/admin/articles/:id/price-history?at=2025-03-15T00:00:00Z
+	articles.GET("/:id/price-history", func(c *gin.Context) {
+		if price, ok := adminArticlePrice(c, svc); ok {
+			writePriceTimeline(c, svc, price.ID)
+		}
+	})
+	articles.POST("/:id/price-history", func(c *gin.Context) {
+		if price, ok := adminArticlePrice(c, svc); ok {
+			schedulePriceChange(c, svc, price.ID)
+		}
+	})
+	articles.DELETE("/:id/price-history/:entryId", func(c *gin.Context) {
+		if price, ok := adminArticlePrice(c, svc); ok {
+			cancelPriceChange(c, svc, price.ID)
+		}
+	})
+
+	prices := r.Group("/api/admin/prices")
+	prices.Use(adminMiddleware)
+
+	prices.GET("/:priceId/history", func(c *gin.Context) {
+		if price, ok := adminPriceByID(c, svc); ok {
+			writePriceTimeline(c, svc, price.ID)
+		}
+	})
+	prices.POST("/:priceId/history", func(c *gin.Context) {
+		if price, ok := adminPriceByID(c, svc); ok {
+			schedulePriceChange(c, svc, price.ID)
+		}
+	})
+	prices.DELETE("/:priceId/history/:entryId", func(c *gin.Context) {
+		if price, ok := adminPriceByID(c, svc); ok {
+			cancelPriceChange(c, svc, price.ID)
+		}
+	})
+}
+
+func writePriceTimeline(c *gin.Context, svc *Service, priceID int) {
+	var at *time.Time
+	if raw := c.Query("at"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid at: use RFC 3339"})
+			return
+		}
+		at = &t
+	}
+	timeline, err := svc.PriceTimeline(c.Request.Context(), priceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch price history"})
+		return
+	}
+	out := toPriceTimelineResponse(timeline, time.Now())
+	if at != nil {
+		price, err := svc.GetCostCalculationByIDAt(c.Request.Context(), priceID, *at)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch price history"})
+			return
+		}
+		out.PriceAt = toCostCalculationResponse(price)
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func schedulePriceChange(c *gin.Context, svc *Service, priceID int) {
+	var payload schedulePriceChangeRequest
+	if err := c.ShouldBindJSON(&payload); err != nil || payload.CostCalculation == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+		return
+	}
+	if _, err := svc.SchedulePriceChange(c.Request.Context(), priceID, mapCostCalculation(payload.CostCalculation), payload.EffectiveFrom); err != nil {
+		switch {
+		case errors.Is(err, ErrPriceChangeNotInFuture):
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "effectiveFrom must be in the future"})
+		case errors.Is(err, ErrVatNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to schedule price change"})
+		}
+		return
+	}
+	writePriceTimeline(c, svc, priceID)
+}
+
+func cancelPriceChange(c *gin.Context, svc *Service, priceID int) {
+	entryID, err := strconv.Atoi(c.Param("entryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid entry id"})
+		return
+	}
+	if err := svc.CancelScheduledPriceChange(c.Request.Context(), priceID, entryID); err != nil {
+		if errors.Is(err, ErrScheduledPriceChangeNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"detail": "Scheduled price change not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to cancel price change"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func toPriceTimelineResponse(t *PriceTimeline, now time.Time) priceTimelineResponse {
+	out := priceTimelineResponse{
+		PriceID: t.Current.ID,
+		Current: toCostCalculationResponse(t.Current),
+		Entries: make([]priceHistoryEntryResponse, 0, len(t.Entries)),
+	}
+	for i := range t.Entries {
+		e := &t.Entries[i]
+		calc := toCostCalculationResponse(t.Current.WithCalculation(&e.Calculation))
+		// Tiers and timestamps belong to the price, not to a version of it.
+		calc.Tiers = []priceTierResponse{}
+		calc.CreatedAt, calc.UpdatedAt = nil, nil
+		out.Entries = append(out.Entries, priceHistoryEntryResponse{
+			ID:              e.ID,
+			EffectiveFrom:   e.EffectiveFrom,
+			AppliedAt:       e.AppliedAt,
+			Status:          t.Status(i, now),
+			CostCalculation: calc,
+			CreatedAt:       e.CreatedAt,
+		})
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type priceTierRequest struct {
+	MinQuantity     int `json:"minQuantity"`
+	SalesTotalGross int `json:"salesTotalGross"`
+}
+
+type priceTiersRequest struct {
+	Tiers []priceTierRequest `json:"tiers"`
+}
+
+type priceTierResponse struct {
+	ID              int `json:"id"`
+	MinQuantity     int `json:"minQuantity"`
+	SalesTotalGross int `json:"salesTotalGross"`
+}
+
+type priceTiersResponse struct {
+	PriceID   int                 `json:"priceId"`
+	BasePrice int                 `json:"basePrice"`
+	Tiers     []priceTierResponse `json:"tiers"`
+}
+
+// registerAdminPriceTierRoutes mounts tier management for article prices and,
+// by price ID, for prompt prices.
+func registerAdminPriceTierRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service) {
+	articles := r.Group("/api/admin/articles")
+	articles.Use(adminMiddleware)
+
+	articles.GET("/:id/price-tiers", func(c *gin.Context) {
+		if price, ok := adminArticlePrice(c, svc); ok {
+			c.JSON(http.StatusOK, toPriceTiersResponse(price))
+		}
+	})
+	articles.PUT("/:id/price-tiers", func(c *gin.Context) {
+		if price, ok := adminArticlePrice(c, svc); ok {
+			replacePriceTiers(c, svc, price)
+		}
+	})
+
+	prices := r.Group("/api/admin/prices")
+	prices.Use(adminMiddleware)
+
+	prices.GET("/:priceId/tiers", func(c *gin.Context) {
+		if price, ok := adminPriceByID(c, svc); ok {
+			c.JSON(http.StatusOK, toPriceTiersResponse(price))
+		}
+	})
+	prices.PUT("/:priceId/tiers", func(c *gin.Context) {
+		if price, ok := adminPriceByID(c, svc); ok {
+			replacePriceTiers(c, svc, price)
+		}
+	})
+}
+
+// adminArticlePrice loads the price of the article in the :id parameter and
+// writes the error response when there is none.
+func adminArticlePrice(c *gin.Context, svc *Service) (*Price, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+		return nil, false
+	}
+	price, err := svc.GetCostCalculation(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch price"})
+		return nil, false
+	}
+	if price == nil {
+		c.JSON(http.StatusNotFound, gin.H{"detail": "Article has no price"})
+		return nil, false
+	}
+	return price, true
+}
+
+// adminPriceByID loads the price in the :priceId parameter and writes the
+// error response when it does not exist.
+func adminPriceByID(c *gin.Context, svc *Service) (*Price, bool) {
+	id, err := strconv.Atoi(c.Param("priceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid price id"})
+		return nil, false
+	}
+	price, err := svc.GetCostCalculationByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch price"})
+		return nil, false
+	}
+	if price == nil {
+		c.JSON(http.StatusNotFound, gin.H{"detail": "Price not found"})
+		return nil, false
+	}
+	return price, true
+}
+
+func replacePriceTiers(c *gin.Context, svc *Service, price *Price) {
+	var payload priceTiersRequest
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+		return
+	}
+	tiers := make([]PriceTier, 0, len(payload.Tiers))
+	for _, t := range payload.Tiers {
+		tiers = append(tiers, PriceTier{MinQuantity: t.MinQuantity, SalesTotalGross: t.SalesTotalGross})
+	}
+	saved, err := svc.SetPriceTiers(c.Request.Context(), price.ID, tiers)
+	if err != nil {
+		if errors.Is(err, ErrInvalidPriceTiers) {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Tiers need distinct minimum quantities of at least 2 and prices that do not rise with quantity"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to save price tiers"})
+		return
+	}
+	price.Tiers = saved
+	c.JSON(http.StatusOK, toPriceTiersResponse(price))
+}
+
+func toPriceTiersResponse(p *Price) priceTiersResponse {
+	return priceTiersResponse{PriceID: p.ID, BasePrice: p.SalesTotalGross, Tiers: toPriceTierResponses(p.Tiers)}
+}
+
+func toPriceTierResponses(tiers []PriceTier) []priceTierResponse {
+	out := make([]priceTierResponse, 0, len(tiers))
+	for _, t := range tiers {
+		out = append(out, priceTierResponse{ID: t.ID, MinQuantity: t.MinQuantity, SalesTotalGross: t.SalesTotalGross})
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type articleRelationRequest struct {
+	RelatedArticleID int  `json:"relatedArticleId"`
+	RelatedVariantID *int `json:"relatedVariantId"`
+}
+
+type articleRelationsRequest struct {
+	Relations []articleRelationRequest `json:"relations"`
+}
+
+type articleRelationResponse struct {
+	ID               int        `json:"id"`
+	RelatedArticleID int        `json:"relatedArticleId"`
+	RelatedVariantID *int       `json:"relatedVariantId"`
+	Position         int        `json:"position"`
+	CreatedAt        *time.Time `json:"createdAt"`
+}
+
+type boughtTogetherResponse struct {
+	RelatedArticleID int        `json:"relatedArticleId"`
+	OrderCount       int        `json:"orderCount"`
+	UpdatedAt        *time.Time `json:"updatedAt"`
+}
+
+// registerAdminRelationRoutes mounts curated article relations and the
+// bought-together pairs computed from orders.
+func registerAdminRelationRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service) {
+	grp := r.Group("/api/admin/articles")
+	grp.Use(adminMiddleware)
+
+	grp.GET("/:id/relations", func(c *gin.Context) {
+		aid, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+			return
+		}
+		relations, err := svc.ListRelations(c.Request.Context(), aid)
+		if err != nil {
+			writeRelationError(c, err, "Failed to fetch relations")
+			return
+		}
+		c.JSON(http.StatusOK, toArticleRelationResponses(relations))
+	})
+
+	// Replace the curated relations; their order is the display order.
+	grp.PUT("/:id/relations", func(c *gin.Context) {
+		aid, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+			return
+		}
+		var payload articleRelationsRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		relations := make([]ArticleRelation, 0, len(payload.Relations))
+		for _, rel := range payload.Relations {
+			relations = append(relations, ArticleRelation{RelatedArticleID: rel.RelatedArticleID, RelatedVariantID: rel.RelatedVariantID})
+		}
+		saved, err := svc.SetRelations(c.Request.Context(), aid, relations)
+		if err != nil {
+			writeRelationError(c, err, "Failed to save relations")
+			return
+		}
+		c.JSON(http.StatusOK, toArticleRelationResponses(saved))
+	})
+
+	grp.GET("/:id/bought-together", func(c *gin.Context) {
+		aid, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid article id"})
+			return
+		}
+		pairs, err := svc.ListBoughtTogether(c.Request.Context(), aid)
+		if err != nil {
+			writeRelationError(c, err, "Failed to fetch bought-together articles")
+			return
+		}
+		out := make([]boughtTogetherResponse, 0, len(pairs))
+		for _, p := range pairs {
+			out = append(out, boughtTogetherResponse{RelatedArticleID: p.RelatedArticleID, OrderCount: p.OrderCount, UpdatedAt: timePtr(p.UpdatedAt)})
+		}
+		c.JSON(http.StatusOK, out)
+	})
+
+	// Recompute the bought-together pairs now instead of waiting for the
+	// background refresh.
+	grp.POST("/bought-together/refresh", func(c *gin.Context) {
+		pairs, err := svc.RefreshBoughtTogether(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to refresh bought-together articles"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"pairs": pairs})
+	})
+}
+
+func writeRelationError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errorsIsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"detail": "Article not found"})
+	case errors.Is(err, ErrInvalidRelation):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
+
+func toArticleRelationResponses(relations []ArticleRelation) []articleRelationResponse {
+	out := make([]articleRelationResponse, 0, len(relations))
+	for _, rel := range relations {
+		out = append(out, articleRelationResponse{
+			ID:               rel.ID,
+			RelatedArticleID: rel.RelatedArticleID,
+			RelatedVariantID: rel.RelatedVariantID,
+			Position:         rel.Position,
+			CreatedAt:        timePtr(rel.CreatedAt),
+		})
+	}
+	return out
+}
@@ -82,14 +82,46 @@ func registerAdminShirtVariantRoutes(r *gin.Engine, adminMiddleware gin.HandlerF
 		c.JSON(http.StatusOK, toArticleShirtVariantResponse(&updated))
 	})
 
+	// DELETE archives the variant; purge removes it when it was never ordered.
 	grp.DELETE("/variants/:variantId", func(c *gin.Context) {
 		id, err := strconv.Atoi(c.Param("variantId"))
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
 			return
 		}
-		if err := svc.DeleteShirtVariant(c.Request.Context(), id); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to delete variant"})
+		if _, err := svc.ArchiveShirtVariant(c.Request.Context(), id); err != nil {
+			if errorsIsNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"detail": "Variant not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to archive variant"})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	grp.POST("/variants/:variantId/restore", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("variantId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+			return
+		}
+		restored, err := svc.RestoreShirtVariant(c.Request.Context(), id)
+		if err != nil {
+			writeArchiveError(c, err, "Variant not found", "Failed to restore variant")
+			return
+		}
+		c.JSON(http.StatusOK, toArticleShirtVariantResponse(&restored))
+	})
+
+	grp.DELETE("/variants/:variantId/purge", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("variantId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+			return
+		}
+		if err := svc.PurgeShirtVariant(c.Request.Context(), id); err != nil {
+			writeArchiveError(c, err, "Variant not found", "Failed to purge variant")
 			return
 		}
 		c.Status(http.StatusNoContent)
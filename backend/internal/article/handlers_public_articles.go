@@ -0,0 +1,249 @@
+package article
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Responses for public article pages addressed by slug
+type publicCategoryRefResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type publicPriceTierResponse struct {
+	MinQuantity int     `json:"minQuantity"`
+	Price       float64 `json:"price"`
+}
+
+type publicArticleDetailResponse struct {
+	ID               int                            `json:"id"`
+	Slug             string                         `json:"slug"`
+	Name             string                         `json:"name"`
+	ArticleType      string                         `json:"articleType"`
+	DescriptionShort string                         `json:"descriptionShort"`
+	DescriptionLong  string                         `json:"descriptionLong"`
+	Category         publicCategoryRefResponse      `json:"category"`
+	SubcategoryName  *string                        `json:"subcategoryName"`
+	Price            float64                        `json:"price"`
+	PriceTiers       []publicPriceTierResponse      `json:"priceTiers"`
+	Image            *string                        `json:"image"`
+	Attributes       map[string]any                 `json:"attributes"`
+	MugDetails       *articleMugDetailsResponse     `json:"mugDetails"`
+	ShirtDetails     *articleShirtDetailsResponse   `json:"shirtDetails"`
+	MugVariants      []publicMugVariantResponse     `json:"mugVariants"`
+	ShirtVariants    []publicShirtVariantResponse   `json:"shirtVariants"`
+	Variants         []publicArticleVariantResponse `json:"variants"`
+	PrintAreas       []printAreaResponse            `json:"printAreas"`
+	Gallery          []publicGalleryImageResponse   `json:"gallery"`
+}
+
+type publicArticleSummaryResponse struct {
+	ID               int    `json:"id"`
+	Slug             string `json:"slug"`
+	Name             string `json:"name"`
+	ArticleType      string `json:"articleType"`
+	DescriptionShort string `json:"descriptionShort"`
+}
+
+type publicArticleCategoryResponse struct {
+	ID          int                            `json:"id"`
+	Name        string                         `json:"name"`
+	Slug        string                         `json:"slug"`
+	Description *string                        `json:"description"`
+	Articles    []publicArticleSummaryResponse `json:"articles"`
+}
+
+// registerPublicArticleRoutes mounts article and category pages by slug.
+// Former slugs answer with a permanent redirect to the current one.
+func registerPublicArticleRoutes(r *gin.Engine, svc *Service, stock AvailabilityLookup) {
+	grp := r.Group("/api/articles")
+
+	grp.GET("/categories/:slug", func(c *gin.Context) {
+		slug := c.Param("slug")
+		cat, articles, err := svc.PublicCategory(c.Request.Context(), slug)
+		if err != nil {
+			if errorsIsNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"detail": "Category not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch category"})
+			return
+		}
+		if cat.Slug != slug {
+			c.Redirect(http.StatusMovedPermanently, "/api/articles/categories/"+url.PathEscape(cat.Slug))
+			return
+		}
+		out := publicArticleCategoryResponse{
+			ID:          cat.ID,
+			Name:        cat.Name,
+			Slug:        cat.Slug,
+			Description: cat.Description,
+			Articles:    make([]publicArticleSummaryResponse, 0, len(articles)),
+		}
+		for i := range articles {
+			a := &articles[i]
+			out.Articles = append(out.Articles, publicArticleSummaryResponse{
+				ID:               a.ID,
+				Slug:             a.Slug,
+				Name:             a.Name,
+				ArticleType:      a.ArticleType,
+				DescriptionShort: a.DescriptionShort,
+			})
+		}
+		c.JSON(http.StatusOK, out)
+	})
+
+	grp.GET("/:slug", func(c *gin.Context) {
+		slug := c.Param("slug")
+		ctx := c.Request.Context()
+		detail, err := svc.PublicArticle(ctx, slug)
+		if err != nil {
+			if errorsIsNotFound(err) {
+				c.JSON(http.StatusNotFound, gin.H{"detail": "Article not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch article"})
+			return
+		}
+		a := detail.Article
+		if a.Slug != slug {
+			c.Redirect(http.StatusMovedPermanently, "/api/articles/"+url.PathEscape(a.Slug))
+			return
+		}
+		cat, err := svc.GetCategory(ctx, a.CategoryID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch category"})
+			return
+		}
+		gallery, err := svc.ListGallery(ctx, a.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch gallery"})
+			return
+		}
+		out := publicArticleDetailResponse{
+			ID:               a.ID,
+			Slug:             a.Slug,
+			Name:             a.Name,
+			ArticleType:      a.ArticleType,
+			DescriptionShort: a.DescriptionShort,
+			DescriptionLong:  a.DescriptionLong,
+			Category:         publicCategoryRefResponse{ID: cat.ID, Name: cat.Name, Slug: cat.Slug},
+			SubcategoryName:  detail.SubcategoryName,
+			PriceTiers:       []publicPriceTierResponse{},
+			Attributes:       a.Attributes,
+			MugDetails:       toArticleMugDetailsResponse(detail.MugDetails),
+			ShirtDetails:     toArticleShirtDetailsResponse(detail.ShirtDetails),
+			MugVariants:      []publicMugVariantResponse{},
+			ShirtVariants:    []publicShirtVariantResponse{},
+			Variants:         []publicArticleVariantResponse{},
+			PrintAreas:       toPrintAreaResponses(detail.PrintAreas),
+			Gallery:          toPublicGalleryResponses(gallery, nil),
+		}
+		if calc := detail.CostCalculation; calc != nil {
+			out.Price = float64(calc.SalesTotalGross) / 100.0
+			for _, t := range calc.Tiers {
+				out.PriceTiers = append(out.PriceTiers, publicPriceTierResponse{MinQuantity: t.MinQuantity, Price: float64(t.SalesTotalGross) / 100.0})
+			}
+		}
+
+		switch a.ArticleType {
+		case ArticleTypeMug:
+			ids := make([]int, 0, len(detail.MugVariants))
+			for i := range detail.MugVariants {
+				ids = append(ids, detail.MugVariants[i].ID)
+			}
+			availability, err := LookupAvailability(ctx, stock, ArticleTypeMug, ids)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch stock"})
+				return
+			}
+			for i := range detail.MugVariants {
+				v := &detail.MugVariants[i]
+				if !v.Active || v.ArchivedAt != nil {
+					continue
+				}
+				imageURL := strPtrOrNil(publicMugVariantExampleURL(v.ExampleImageFilename))
+				if imageURL != nil && (out.Image == nil || v.IsDefault) {
+					out.Image = imageURL
+				}
+				out.MugVariants = append(out.MugVariants, publicMugVariantResponse{
+					ID:                   v.ID,
+					MugID:                a.ID,
+					ColorCode:            v.OutsideColorCode,
+					Name:                 v.Name,
+					ExampleImageURL:      imageURL,
+					ArticleVariantNumber: v.ArticleVariantNumber,
+					IsDefault:            v.IsDefault,
+					Active:               v.Active,
+					ExampleImageFilename: v.ExampleImageFilename,
+					Availability:         availability[v.ID],
+					Gallery:              toPublicGalleryResponses(gallery, &v.ID),
+					CreatedAt:            timePtr(v.CreatedAt),
+					UpdatedAt:            timePtr(v.UpdatedAt),
+				})
+			}
+		case ArticleTypeShirt:
+			ids := make([]int, 0, len(detail.ShirtVariants))
+			for i := range detail.ShirtVariants {
+				ids = append(ids, detail.ShirtVariants[i].ID)
+			}
+			availability, err := LookupAvailability(ctx, stock, ArticleTypeShirt, ids)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch stock"})
+				return
+			}
+			for i := range detail.ShirtVariants {
+				v := &detail.ShirtVariants[i]
+				if v.ArchivedAt != nil {
+					continue
+				}
+				imageURL := strPtrOrNil(publicShirtVariantExampleURL(v.ExampleImageFilename))
+				if out.Image == nil {
+					out.Image = imageURL
+				}
+				out.ShirtVariants = append(out.ShirtVariants, publicShirtVariantResponse{
+					ID:                   v.ID,
+					ShirtID:              a.ID,
+					Color:                v.Color,
+					Size:                 v.Size,
+					ExampleImageURL:      imageURL,
+					ExampleImageFilename: v.ExampleImageFilename,
+					Availability:         availability[v.ID],
+					Gallery:              toPublicGalleryResponses(gallery, &v.ID),
+					CreatedAt:            timePtr(v.CreatedAt),
+					UpdatedAt:            timePtr(v.UpdatedAt),
+				})
+			}
+		default:
+			def, err := svc.GetArticleType(ctx, a.ArticleType)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch article type"})
+				return
+			}
+			for i := range detail.Variants {
+				v := &detail.Variants[i]
+				if !v.Active || v.ArchivedAt != nil {
+					continue
+				}
+				imageURL := strPtrOrNil(publicArticleVariantExampleURL(v.ExampleImageFilename))
+				if imageURL != nil && (out.Image == nil || v.IsDefault) {
+					out.Image = imageURL
+				}
+				out.Variants = append(out.Variants, publicArticleVariantResponse{
+					ID:              v.ID,
+					ArticleID:       a.ID,
+					Name:            v.DisplayName(def.OptionAxes),
+					Options:         v.Options,
+					ExampleImageURL: imageURL,
+					IsDefault:       v.IsDefault,
+					Gallery:         toPublicGalleryResponses(gallery, &v.ID),
+				})
+			}
+		}
+		c.JSON(http.StatusOK, out)
+	})
+}
@@ -0,0 +1,145 @@
+package article
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Responses for the public catalog of registered article types
+type publicOptionAxisResponse struct {
+	Axis   string   `json:"axis"`
+	Values []string `json:"values"`
+}
+
+type publicGalleryImageResponse struct {
+	ID        int    `json:"id"`
+	URL       string `json:"url"`
+	AltText   string `json:"altText"`
+	Position  int    `json:"position"`
+	IsPrimary bool   `json:"isPrimary"`
+}
+
+type publicArticleVariantResponse struct {
+	ID              int                          `json:"id"`
+	ArticleID       int                          `json:"articleId"`
+	Name            string                       `json:"name"`
+	Options         map[string]string            `json:"options"`
+	ExampleImageURL *string                      `json:"exampleImageUrl"`
+	IsDefault       bool                         `json:"isDefault"`
+	Gallery         []publicGalleryImageResponse `json:"gallery"`
+}
+
+type publicCatalogArticleResponse struct {
+	ID               int                            `json:"id"`
+	Name             string                         `json:"name"`
+	ArticleType      string                         `json:"articleType"`
+	Price            float64                        `json:"price"`
+	Image            *string                        `json:"image"`
+	DescriptionShort *string                        `json:"descriptionShort"`
+	DescriptionLong  *string                        `json:"descriptionLong"`
+	Attributes       map[string]any                 `json:"attributes"`
+	PrintAreas       []printAreaResponse            `json:"printAreas"`
+	Options          []publicOptionAxisResponse     `json:"options"`
+	Variants         []publicArticleVariantResponse `json:"variants"`
+	Gallery          []publicGalleryImageResponse   `json:"gallery"`
+}
+
+type publicCatalogResponse struct {
+	Type     articleTypeResponse            `json:"type"`
+	Articles []publicCatalogArticleResponse `json:"articles"`
+}
+
+// registerPublicCatalogRoutes mounts the listing of article types and of the
+// articles of registered types. Mugs and shirts are redirected to their
+// dedicated endpoints.
+func registerPublicCatalogRoutes(r *gin.Engine, svc *Service) {
+	grp := r.Group("/api/catalog")
+
+	grp.GET("/types", func(c *gin.Context) {
+		defs, err := svc.ListArticleTypes(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch article types"})
+			return
+		}
+		out := make([]articleTypeResponse, 0, len(defs))
+		for i := range defs {
+			out = append(out, toArticleTypeResponse(&defs[i]))
+		}
+		c.JSON(http.StatusOK, out)
+	})
+
+	grp.GET("/:type", func(c *gin.Context) {
+		def, entries, err := svc.TypeCatalog(c.Request.Context(), c.Param("type"))
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrArticleTypeNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"detail": "Article type not found"})
+			case errors.Is(err, ErrNotRegisteredType):
+				c.Redirect(http.StatusTemporaryRedirect, catalogURL(&def))
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch catalog"})
+			}
+			return
+		}
+		out := publicCatalogResponse{
+			Type:     toArticleTypeResponse(&def),
+			Articles: make([]publicCatalogArticleResponse, 0, len(entries)),
+		}
+		for i := range entries {
+			out.Articles = append(out.Articles, toPublicCatalogArticleResponse(&def, &entries[i]))
+		}
+		c.JSON(http.StatusOK, out)
+	})
+}
+
+func toPublicCatalogArticleResponse(def *ArticleTypeDefinition, e *TypeCatalogEntry) publicCatalogArticleResponse {
+	a := e.Article
+	price := 0.0
+	if e.Price != nil && e.Price.SalesTotalGross != 0 {
+		price = float64(e.Price.SalesTotalGross) / 100.0
+	}
+	out := publicCatalogArticleResponse{
+		ID:               a.ID,
+		Name:             a.Name,
+		ArticleType:      a.ArticleType,
+		Price:            price,
+		DescriptionShort: &a.DescriptionShort,
+		DescriptionLong:  &a.DescriptionLong,
+		Attributes:       a.Attributes,
+		PrintAreas:       toPrintAreaResponses(e.PrintAreas),
+		Options:          make([]publicOptionAxisResponse, 0, len(def.OptionAxes)),
+		Variants:         make([]publicArticleVariantResponse, 0, len(e.Variants)),
+		Gallery:          toPublicGalleryResponses(e.Gallery, nil),
+	}
+	// Option values are listed in the order variants were added.
+	for _, axis := range def.OptionAxes {
+		values := []string{}
+		seen := map[string]bool{}
+		for i := range e.Variants {
+			if v := e.Variants[i].Options[axis]; v != "" && !seen[v] {
+				seen[v] = true
+				values = append(values, v)
+			}
+		}
+		out.Options = append(out.Options, publicOptionAxisResponse{Axis: axis, Values: values})
+	}
+	for i := range e.Variants {
+		v := &e.Variants[i]
+		url := strPtrOrNil(publicArticleVariantExampleURL(v.ExampleImageFilename))
+		if url != nil && (out.Image == nil || v.IsDefault) {
+			out.Image = url
+		}
+		out.Variants = append(out.Variants, publicArticleVariantResponse{
+			ID:              v.ID,
+			ArticleID:       a.ID,
+			Name:            v.DisplayName(def.OptionAxes),
+			Options:         v.Options,
+			ExampleImageURL: url,
+			IsDefault:       v.IsDefault,
+			Gallery:         toPublicGalleryResponses(e.Gallery, &v.ID),
+		})
+	}
+	return out
+}
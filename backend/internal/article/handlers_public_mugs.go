@@ -9,71 +9,69 @@ import (
 
 // Responses for public mug endpoints
 type publicMugVariantResponse struct {
-	ID                   int        `json:"id"`
-	MugID                int        `json:"mugId"`
-	ColorCode            string     `json:"colorCode"`
-	Name                 string     `json:"name"`
-	ExampleImageURL      *string    `json:"exampleImageUrl"`
-	ArticleVariantNumber *string    `json:"articleVariantNumber"`
-	IsDefault            bool       `json:"isDefault"`
-	Active               bool       `json:"active"`
-	ExampleImageFilename *string    `json:"exampleImageFilename"`
-	CreatedAt            *time.Time `json:"createdAt"`
-	UpdatedAt            *time.Time `json:"updatedAt"`
+	ID                   int                          `json:"id"`
+	MugID                int                          `json:"mugId"`
+	ColorCode            string                       `json:"colorCode"`
+	Name                 string                       `json:"name"`
+	ExampleImageURL      *string                      `json:"exampleImageUrl"`
+	ArticleVariantNumber *string                      `json:"articleVariantNumber"`
+	IsDefault            bool                         `json:"isDefault"`
+	Active               bool                         `json:"active"`
+	ExampleImageFilename *string                      `json:"exampleImageFilename"`
+	Availability         VariantAvailabilityResponse  `json:"availability"`
+	Gallery              []publicGalleryImageResponse `json:"gallery"`
+	CreatedAt            *time.Time                   `json:"createdAt"`
+	UpdatedAt            *time.Time                   `json:"updatedAt"`
 }
 
 type publicMugResponse struct {
-	ID                    int                        `json:"id"`
-	Name                  string                     `json:"name"`
-	Price                 float64                    `json:"price"`
-	Image                 *string                    `json:"image"`
-	FillingQuantity       *string                    `json:"fillingQuantity"`
-	DescriptionShort      *string                    `json:"descriptionShort"`
-	DescriptionLong       *string                    `json:"descriptionLong"`
-	HeightMm              int                        `json:"heightMm"`
-	DiameterMm            int                        `json:"diameterMm"`
-	PrintTemplateWidthMm  int                        `json:"printTemplateWidthMm"`
-	PrintTemplateHeightMm int                        `json:"printTemplateHeightMm"`
-	DishwasherSafe        bool                       `json:"dishwasherSafe"`
-	Variants              []publicMugVariantResponse `json:"variants"`
+	ID                    int                          `json:"id"`
+	Name                  string                       `json:"name"`
+	Price                 float64                      `json:"price"`
+	Image                 *string                      `json:"image"`
+	FillingQuantity       *string                      `json:"fillingQuantity"`
+	DescriptionShort      *string                      `json:"descriptionShort"`
+	DescriptionLong       *string                      `json:"descriptionLong"`
+	HeightMm              int                          `json:"heightMm"`
+	DiameterMm            int                          `json:"diameterMm"`
+	PrintTemplateWidthMm  int                          `json:"printTemplateWidthMm"`
+	PrintTemplateHeightMm int                          `json:"printTemplateHeightMm"`
+	DishwasherSafe        bool                         `json:"dishwasherSafe"`
+	Variants              []publicMugVariantResponse   `json:"variants"`
+	Gallery               []publicGalleryImageResponse `json:"gallery"`
 }
 
-func registerPublicMugRoutes(r *gin.Engine, svc *Service) {
+func registerPublicMugRoutes(r *gin.Engine, svc *Service, stock AvailabilityLookup) {
 	grp := r.Group("/api/mugs")
 
 	grp.GET("", func(c *gin.Context) {
-		mugs, err := svc.ListMugArticles(c.Request.Context(), true, nil)
+		mugs, err := svc.MugCatalog(c.Request.Context())
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch mugs"})
 			return
 		}
-		out := make([]publicMugResponse, 0, len(mugs))
+		variantIDs := make([]int, 0)
 		for i := range mugs {
-			a := mugs[i]
-			md, err := svc.GetMugDetails(c.Request.Context(), a.ID)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch mug details"})
-				return
-			}
-			if md == nil {
-				continue
-			}
-			vs, err := svc.ListMugVariants(c.Request.Context(), a.ID, true)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch variants"})
-				return
+			for j := range mugs[i].Variants {
+				variantIDs = append(variantIDs, mugs[i].Variants[j].ID)
 			}
+		}
+		availability, err := LookupAvailability(c.Request.Context(), stock, ArticleTypeMug, variantIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch stock"})
+			return
+		}
+		out := make([]publicMugResponse, 0, len(mugs))
+		for i := range mugs {
+			a := mugs[i].Article
+			md := mugs[i].Details
+			vs := mugs[i].Variants
 			def := ""
 			if dv := defaultMugVariant(vs); dv != nil {
 				def = publicMugVariantExampleURL(dv.ExampleImageFilename)
 			}
-			calc, err := svc.GetCostCalculation(c.Request.Context(), a.ID)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch pricing"})
-				return
-			}
 			price := 0.0
-			if calc != nil && calc.SalesTotalGross != 0 {
+			if calc := mugs[i].Price; calc != nil && calc.SalesTotalGross != 0 {
 				price = float64(calc.SalesTotalGross) / 100.0
 			}
 			variants := make([]publicMugVariantResponse, 0, len(vs))
@@ -89,6 +87,8 @@ func registerPublicMugRoutes(r *gin.Engine, svc *Service) {
 					IsDefault:            v.IsDefault,
 					Active:               v.Active,
 					ExampleImageFilename: v.ExampleImageFilename,
+					Availability:         availability[v.ID],
+					Gallery:              toPublicGalleryResponses(mugs[i].Gallery, &v.ID),
 					CreatedAt:            timePtr(v.CreatedAt),
 					UpdatedAt:            timePtr(v.UpdatedAt),
 				})
@@ -107,6 +107,7 @@ func registerPublicMugRoutes(r *gin.Engine, svc *Service) {
 				PrintTemplateHeightMm: md.PrintTemplateHeightMm,
 				DishwasherSafe:        md.DishwasherSafe,
 				Variants:              variants,
+				Gallery:               toPublicGalleryResponses(mugs[i].Gallery, nil),
 			})
 		}
 		c.JSON(http.StatusOK, out)
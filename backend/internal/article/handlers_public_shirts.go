@@ -0,0 +1,152 @@
+package article
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Responses for public shirt endpoints
+type publicShirtVariantResponse struct {
+	ID                   int                          `json:"id"`
+	ShirtID              int                          `json:"shirtId"`
+	Color                string                       `json:"color"`
+	Size                 string                       `json:"size"`
+	ExampleImageURL      *string                      `json:"exampleImageUrl"`
+	ExampleImageFilename *string                      `json:"exampleImageFilename"`
+	Availability         VariantAvailabilityResponse  `json:"availability"`
+	Gallery              []publicGalleryImageResponse `json:"gallery"`
+	CreatedAt            *time.Time                   `json:"createdAt"`
+	UpdatedAt            *time.Time                   `json:"updatedAt"`
+}
+
+type publicShirtColorResponse struct {
+	Color           string                       `json:"color"`
+	ExampleImageURL *string                      `json:"exampleImageUrl"`
+	Sizes           []string                     `json:"sizes"`
+	Variants        []publicShirtVariantResponse `json:"variants"`
+}
+
+type publicShirtResponse struct {
+	ID                int                          `json:"id"`
+	Name              string                       `json:"name"`
+	Price             float64                      `json:"price"`
+	Image             *string                      `json:"image"`
+	DescriptionShort  *string                      `json:"descriptionShort"`
+	DescriptionLong   *string                      `json:"descriptionLong"`
+	Material          string                       `json:"material"`
+	CareInstructions  *string                      `json:"careInstructions"`
+	FitType           string                       `json:"fitType"`
+	AvailableSizes    []string                     `json:"availableSizes"`
+	PrintAreaWidthMm  int                          `json:"printAreaWidthMm"`
+	PrintAreaHeightMm int                          `json:"printAreaHeightMm"`
+	PrintAreaPosition string                       `json:"printAreaPosition"`
+	Colors            []publicShirtColorResponse   `json:"colors"`
+	Gallery           []publicGalleryImageResponse `json:"gallery"`
+}
+
+func registerPublicShirtRoutes(r *gin.Engine, svc *Service, stock AvailabilityLookup) {
+	grp := r.Group("/api/shirts")
+
+	grp.GET("", func(c *gin.Context) {
+		shirts, err := svc.ListShirtArticles(c.Request.Context(), true, nil)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch shirts"})
+			return
+		}
+		out := make([]publicShirtResponse, 0, len(shirts))
+		for i := range shirts {
+			a := shirts[i]
+			sd, err := svc.GetShirtDetails(c.Request.Context(), a.ID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch shirt details"})
+				return
+			}
+			if sd == nil {
+				continue
+			}
+			vs, err := svc.ListShirtVariants(c.Request.Context(), a.ID, true)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch variants"})
+				return
+			}
+			gallery, err := svc.ListGallery(c.Request.Context(), a.ID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch gallery"})
+				return
+			}
+			calc, err := svc.GetCostCalculation(c.Request.Context(), a.ID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch pricing"})
+				return
+			}
+			price := 0.0
+			if calc != nil && calc.SalesTotalGross != 0 {
+				price = float64(calc.SalesTotalGross) / 100.0
+			}
+			variantIDs := make([]int, 0, len(vs))
+			for j := range vs {
+				variantIDs = append(variantIDs, vs[j].ID)
+			}
+			availability, err := LookupAvailability(c.Request.Context(), stock, ArticleTypeShirt, variantIDs)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch stock"})
+				return
+			}
+			sizes := sd.Sizes()
+			groups := groupShirtVariantsByColor(vs, sizes)
+			colors := make([]publicShirtColorResponse, 0, len(groups))
+			def := ""
+			for _, g := range groups {
+				color := publicShirtColorResponse{
+					Color:    g[0].Color,
+					Sizes:    make([]string, 0, len(g)),
+					Variants: make([]publicShirtVariantResponse, 0, len(g)),
+				}
+				for j := range g {
+					v := g[j]
+					url := publicShirtVariantExampleURL(v.ExampleImageFilename)
+					if color.ExampleImageURL == nil {
+						color.ExampleImageURL = strPtrOrNil(url)
+					}
+					color.Sizes = append(color.Sizes, v.Size)
+					color.Variants = append(color.Variants, publicShirtVariantResponse{
+						ID:                   v.ID,
+						ShirtID:              a.ID,
+						Color:                v.Color,
+						Size:                 v.Size,
+						ExampleImageURL:      strPtrOrNil(url),
+						ExampleImageFilename: v.ExampleImageFilename,
+						Availability:         availability[v.ID],
+						Gallery:              toPublicGalleryResponses(gallery, &v.ID),
+						CreatedAt:            timePtr(v.CreatedAt),
+						UpdatedAt:            timePtr(v.UpdatedAt),
+					})
+				}
+				if def == "" && color.ExampleImageURL != nil {
+					def = *color.ExampleImageURL
+				}
+				colors = append(colors, color)
+			}
+			out = append(out, publicShirtResponse{
+				ID:                a.ID,
+				Name:              a.Name,
+				Price:             price,
+				Image:             strPtrOrNil(def),
+				DescriptionShort:  &a.DescriptionShort,
+				DescriptionLong:   &a.DescriptionLong,
+				Material:          sd.Material,
+				CareInstructions:  sd.CareInstructions,
+				FitType:           sd.FitType,
+				AvailableSizes:    sizes,
+				PrintAreaWidthMm:  sd.PrintAreaWidthMm,
+				PrintAreaHeightMm: sd.PrintAreaHeightMm,
+				PrintAreaPosition: sd.PrintAreaPosition,
+				Colors:            colors,
+				Gallery:           toPublicGalleryResponses(gallery, nil),
+			})
+		}
+		c.JSON(http.StatusOK, out)
+	})
+}
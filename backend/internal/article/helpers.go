@@ -2,10 +2,40 @@ package article
 
 import (
 	"errors"
+	"net/http"
 
+	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+
+	"voenix/backend/internal/utility"
 )
 
 func errorsIsNotFound(err error) bool { return errors.Is(err, gorm.ErrRecordNotFound) }
 
+// writeSlugError answers slug validation errors and reports whether err was
+// one.
+func writeSlugError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, utility.ErrInvalidSlug):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+	case errors.Is(err, utility.ErrSlugTaken):
+		c.JSON(http.StatusConflict, gin.H{"detail": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+// writeArchiveError answers errors of archive, restore and purge operations.
+func writeArchiveError(c *gin.Context, err error, notFound, fallback string) {
+	switch {
+	case errorsIsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"detail": notFound})
+	case errors.Is(err, ErrNotArchived), errors.Is(err, ErrArticleHasOrders), errors.Is(err, ErrVariantHasOrders):
+		c.JSON(http.StatusConflict, gin.H{"detail": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
+
 // timePtr and strPtrOrNil are defined in dtos.go
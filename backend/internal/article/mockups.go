@@ -0,0 +1,65 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	img "voenix/backend/internal/image"
+)
+
+// ErrInvalidMockup is returned for print areas the compositor cannot use or
+// curvatures outside 0..1.
+var ErrInvalidMockup = errors.New("invalid mockup settings")
+
+// SetMugVariantMockup stores where designs go on the variant's example photo.
+// A nil printArea removes the mockup.
+func (s *Service) SetMugVariantMockup(ctx context.Context, variantID int, printArea *img.MockupQuad, curvature float64) (MugVariant, error) {
+	if printArea != nil {
+		if err := printArea.Validate(); err != nil {
+			return MugVariant{}, fmt.Errorf("%w: %v", ErrInvalidMockup, err)
+		}
+	}
+	if curvature < 0 || curvature > 1 {
+		return MugVariant{}, fmt.Errorf("%w: curvature must be between 0 and 1", ErrInvalidMockup)
+	}
+	variant, err := s.repo.GetMugVariant(ctx, variantID)
+	if err != nil {
+		return MugVariant{}, err
+	}
+	variant.MockupPrintArea = printArea
+	variant.MockupCurvature = curvature
+	return s.UpdateMugVariant(ctx, &variant)
+}
+
+// MugVariantMockupTemplate implements image.MockupTemplateSource. Variants
+// without an example photo or print area have no template.
+func (s *Service) MugVariantMockupTemplate(ctx context.Context, variantID int) (*img.MockupTemplate, error) {
+	variant, err := s.repo.GetMugVariant(ctx, variantID)
+	if err != nil {
+		return nil, err
+	}
+	if variant.MockupPrintArea == nil || variant.ExampleImageFilename == nil || strings.TrimSpace(*variant.ExampleImageFilename) == "" {
+		return nil, nil
+	}
+	loc, err := img.NewStorageLocations()
+	if err != nil {
+		return nil, err
+	}
+	tpl := &img.MockupTemplate{
+		PhotoPath: filepath.Join(loc.MugVariantExample(), filepath.Base(*variant.ExampleImageFilename)),
+		PrintArea: *variant.MockupPrintArea,
+		Curvature: variant.MockupCurvature,
+	}
+	details, err := s.repo.GetMugDetails(ctx, variant.ArticleID)
+	if err != nil {
+		return nil, err
+	}
+	if details != nil {
+		tpl.PrintWidthMm = details.PrintTemplateWidthMm
+		tpl.PrintHeightMm = details.PrintTemplateHeightMm
+	}
+	return tpl, nil
+}
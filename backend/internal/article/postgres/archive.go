@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// --- Archiving ---
+
+func (r *Repository) SetArticleArchived(ctx context.Context, id int, archivedAt *time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&articleRow{}).Where("id = ?", id).Update("archived_at", archivedAt)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if archivedAt == nil {
+			return nil
+		}
+		return tx.Exec("DELETE FROM cart_items WHERE article_id = ? AND cart_id IN (SELECT id FROM carts WHERE status = 'active')", id).Error
+	})
+}
+
+func (r *Repository) SetMugVariantArchived(ctx context.Context, id int, archivedAt *time.Time) error {
+	return setVariantArchived(r.db.WithContext(ctx), &mugVariantRow{}, "article_mug_variants", id, archivedAt, true)
+}
+
+func (r *Repository) SetShirtVariantArchived(ctx context.Context, id int, archivedAt *time.Time) error {
+	return setVariantArchived(r.db.WithContext(ctx), &shirtVariantRow{}, "article_shirt_variants", id, archivedAt, false)
+}
+
+func (r *Repository) SetArticleVariantArchived(ctx context.Context, id int, archivedAt *time.Time) error {
+	return setVariantArchived(r.db.WithContext(ctx), &articleVariantRow{}, "article_variants", id, archivedAt, true)
+}
+
+func (r *Repository) CountVariantOrders(ctx context.Context, articleID, variantID int) (int, error) {
+	var cnt int64
+	if err := r.db.WithContext(ctx).
+		Table("order_items").
+		Where("article_id = ? AND variant_id = ?", articleID, variantID).
+		Count(&cnt).Error; err != nil {
+		return 0, err
+	}
+	return int(cnt), nil
+}
+
+// setVariantArchived archives or restores a variant stored in variantTable.
+// Cart items are matched on the variant's article too, since variants of
+// different kinds share ids.
+func setVariantArchived(db *gorm.DB, model any, variantTable string, id int, archivedAt *time.Time, hasDefault bool) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]any{"archived_at": archivedAt}
+		if archivedAt != nil && hasDefault {
+			updates["is_default"] = false
+		}
+		res := tx.Model(model).Where("id = ?", id).Updates(updates)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if archivedAt == nil {
+			return nil
+		}
+		return tx.Exec("DELETE FROM cart_items WHERE variant_id = ? AND article_id IN (SELECT article_id FROM "+variantTable+" WHERE id = ?) AND cart_id IN (SELECT id FROM carts WHERE status = 'active')", id, id).Error
+	})
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+)
+
+func TestArchiveRestoreAndPurge(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&articleCategoryRow{}, &articleSubCategoryRow{}, &articleRow{}, &articleTypeRow{},
+		&articleVariantRow{}, &printAreaRow{}, &mugVariantRow{}, &shirtVariantRow{}, &mugDetailsRow{}, &shirtDetailsRow{},
+		&priceRow{}, &priceHistoryRow{}, &priceTierRow{}, &articleSlugRedirectRow{}, &galleryImageRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	for _, stmt := range []string{
+		"CREATE TABLE order_items (id integer primary key, article_id integer, variant_id integer)",
+		"CREATE TABLE carts (id integer primary key, status text)",
+		"CREATE TABLE cart_items (id integer primary key, cart_id integer, article_id integer, variant_id integer)",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+	}
+	if err := db.Create(&articleTypeRow{Code: article.ArticleTypeMug, Name: "Mug", BuiltIn: true}).Error; err != nil {
+		t.Fatalf("seed built-in type: %v", err)
+	}
+	if err := db.Create(&articleCategoryRow{ID: 1, Name: "Mugs", Slug: "mugs"}).Error; err != nil {
+		t.Fatalf("seed category: %v", err)
+	}
+	ctx := context.Background()
+	repo := NewRepository(db)
+	svc := article.NewService(repo)
+
+	newMug := func(name string) article.Article {
+		a := article.Article{Name: name, ArticleType: article.ArticleTypeMug, CategoryID: 1, Active: true}
+		if _, err := svc.CreateArticle(ctx, &a, &article.MugDetails{HeightMm: 95}, nil, nil, nil, nil); err != nil {
+			t.Fatalf("create article: %v", err)
+		}
+		return a
+	}
+	ordered := newMug("Ordered mug")
+	white, err := svc.CreateMugVariant(ctx, &article.MugVariant{ArticleID: ordered.ID, Name: "White", IsDefault: true, Active: true})
+	if err != nil {
+		t.Fatalf("create variant: %v", err)
+	}
+	black, err := svc.CreateMugVariant(ctx, &article.MugVariant{ArticleID: ordered.ID, Name: "Black", Active: true})
+	if err != nil {
+		t.Fatalf("create variant: %v", err)
+	}
+	for _, stmt := range []string{
+		"INSERT INTO carts (id, status) VALUES (1, 'active'), (2, 'converted')",
+		"INSERT INTO order_items (article_id, variant_id) VALUES (?, ?)",
+	} {
+		if err := db.Exec(stmt, ordered.ID, white.ID).Error; err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	if err := db.Exec("INSERT INTO cart_items (cart_id, article_id, variant_id) VALUES (1, ?, ?), (1, ?, ?), (2, ?, ?)",
+		ordered.ID, black.ID, ordered.ID, white.ID, ordered.ID, black.ID).Error; err != nil {
+		t.Fatalf("seed cart items: %v", err)
+	}
+
+	if err := svc.PurgeMugVariant(ctx, black.ID); !errors.Is(err, article.ErrNotArchived) {
+		t.Fatalf("purging a current variant: err = %v", err)
+	}
+	archived, err := svc.ArchiveMugVariant(ctx, white.ID)
+	if err != nil || archived.ArchivedAt == nil || archived.IsDefault {
+		t.Fatalf("archive variant: %+v, %v", archived, err)
+	}
+	if err := svc.PurgeMugVariant(ctx, white.ID); !errors.Is(err, article.ErrVariantHasOrders) {
+		t.Fatalf("purging an ordered variant: err = %v", err)
+	}
+	visible, err := svc.ListMugVariants(ctx, ordered.ID, true)
+	if err != nil || len(visible) != 1 || visible[0].ID != black.ID {
+		t.Fatalf("archived variant should be hidden: %+v, %v", visible, err)
+	}
+	var activeItems int64
+	db.Table("cart_items").Where("cart_id = 1").Count(&activeItems)
+	if activeItems != 1 {
+		t.Fatalf("active cart items = %d, want 1", activeItems)
+	}
+	// Saving a variant must not touch its archive state.
+	archived.Name = "Snow"
+	if _, err := svc.UpdateMugVariant(ctx, &archived); err != nil {
+		t.Fatalf("update archived variant: %v", err)
+	}
+	if v, err := svc.GetMugVariant(ctx, white.ID); err != nil || v.ArchivedAt == nil {
+		t.Fatalf("variant should stay archived: %+v, %v", v, err)
+	}
+
+	if _, err := svc.ArchiveArticle(ctx, ordered.ID); err != nil {
+		t.Fatalf("archive article: %v", err)
+	}
+	entries, err := svc.MugCatalog(ctx)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("archived article should leave the catalog: %+v, %v", entries, err)
+	}
+	if _, err := svc.PublicArticle(ctx, ordered.Slug); !errorsIsNotFound(err) {
+		t.Fatalf("archived article page: err = %v", err)
+	}
+	if summary, err := svc.GetArticleSummary(ctx, ordered.ID); err != nil || summary.ArchivedAt == nil {
+		t.Fatalf("archived article should resolve for orders: %+v, %v", summary, err)
+	}
+	items, total, err := svc.ListArticles(ctx, article.ArticleListOptions{Archived: true})
+	if err != nil || total != 1 || items[0].Article.ID != ordered.ID {
+		t.Fatalf("archived listing: %+v, %d, %v", items, total, err)
+	}
+	db.Table("cart_items").Count(&activeItems)
+	if activeItems != 1 {
+		t.Fatalf("only the converted cart should keep its item, got %d", activeItems)
+	}
+	if err := svc.PurgeArticle(ctx, ordered.ID); !errors.Is(err, article.ErrArticleHasOrders) {
+		t.Fatalf("purging an ordered article: err = %v", err)
+	}
+
+	restored, err := svc.RestoreArticle(ctx, ordered.ID)
+	if err != nil || restored.ArchivedAt != nil {
+		t.Fatalf("restore article: %+v, %v", restored, err)
+	}
+	if entries, err := svc.MugCatalog(ctx); err != nil || len(entries) != 1 || len(entries[0].Variants) != 1 {
+		t.Fatalf("restored article should be listed with its current variant: %+v, %v", entries, err)
+	}
+
+	unused := newMug("Unused mug")
+	if _, err := svc.ArchiveArticle(ctx, unused.ID); err != nil {
+		t.Fatalf("archive unused article: %v", err)
+	}
+	if err := svc.PurgeArticle(ctx, unused.ID); err != nil {
+		t.Fatalf("purge unused article: %v", err)
+	}
+	if _, err := svc.GetArticle(ctx, unused.ID); !errorsIsNotFound(err) {
+		t.Fatalf("purged article should be gone: err = %v", err)
+	}
+}
+
+func errorsIsNotFound(err error) bool { return errors.Is(err, gorm.ErrRecordNotFound) }
@@ -0,0 +1,394 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+)
+
+// --- Article types ---
+
+func (r *Repository) ListArticleTypes(ctx context.Context) ([]article.ArticleTypeDefinition, error) {
+	var rows []articleTypeRow
+	if err := r.db.WithContext(ctx).Order("built_in desc, name asc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]article.ArticleTypeDefinition, 0, len(rows))
+	for i := range rows {
+		out = append(out, toArticleType(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) GetArticleType(ctx context.Context, code string) (article.ArticleTypeDefinition, error) {
+	var row articleTypeRow
+	if err := r.db.WithContext(ctx).First(&row, "code = ?", code).Error; err != nil {
+		return article.ArticleTypeDefinition{}, err
+	}
+	return toArticleType(&row), nil
+}
+
+func (r *Repository) CreateArticleType(ctx context.Context, def *article.ArticleTypeDefinition) error {
+	row := fromArticleType(def)
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return err
+	}
+	def.CreatedAt = row.CreatedAt
+	def.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *Repository) UpdateArticleType(ctx context.Context, def *article.ArticleTypeDefinition) error {
+	row := fromArticleType(def)
+	if err := r.db.WithContext(ctx).Save(row).Error; err != nil {
+		return err
+	}
+	def.CreatedAt = row.CreatedAt
+	def.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *Repository) DeleteArticleType(ctx context.Context, code string) error {
+	return r.db.WithContext(ctx).Delete(&articleTypeRow{}, "code = ?", code).Error
+}
+
+func (r *Repository) CountArticlesByType(ctx context.Context, code string) (int, error) {
+	var cnt int64
+	if err := r.db.WithContext(ctx).Model(&articleRow{}).Where("article_type = ?", code).Count(&cnt).Error; err != nil {
+		return 0, err
+	}
+	return int(cnt), nil
+}
+
+// --- Variants of registered types ---
+
+func (r *Repository) ListArticleVariants(ctx context.Context, articleID int, onlyActive bool) ([]article.ArticleVariant, error) {
+	tx := r.db.WithContext(ctx).Where("article_id = ?", articleID)
+	if onlyActive {
+		tx = tx.Where("active = ? AND archived_at IS NULL", true)
+	}
+	var rows []articleVariantRow
+	if err := tx.Order("id asc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]article.ArticleVariant, 0, len(rows))
+	for i := range rows {
+		out = append(out, toArticleVariant(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) GetArticleVariant(ctx context.Context, id int) (article.ArticleVariant, error) {
+	var row articleVariantRow
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+		return article.ArticleVariant{}, err
+	}
+	return toArticleVariant(&row), nil
+}
+
+func (r *Repository) CreateArticleVariant(ctx context.Context, variant *article.ArticleVariant) error {
+	row := fromArticleVariant(variant)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := clearDefaultVariant(tx, row); err != nil {
+			return err
+		}
+		if err := tx.Create(row).Error; err != nil {
+			return err
+		}
+		// gorm skips zero values that have a column default on create.
+		if !row.Active {
+			return tx.Model(row).Update("active", false).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	variant.ID = row.ID
+	variant.CreatedAt = row.CreatedAt
+	variant.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *Repository) UpdateArticleVariant(ctx context.Context, variant *article.ArticleVariant) error {
+	row := fromArticleVariant(variant)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := clearDefaultVariant(tx, row); err != nil {
+			return err
+		}
+		return tx.Omit("archived_at").Save(row).Error
+	})
+	if err != nil {
+		return err
+	}
+	variant.CreatedAt = row.CreatedAt
+	variant.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+// clearDefaultVariant drops the default flag from the article's other
+// variants when row becomes the default.
+func clearDefaultVariant(tx *gorm.DB, row *articleVariantRow) error {
+	if !row.IsDefault {
+		return nil
+	}
+	return tx.Model(&articleVariantRow{}).
+		Where("article_id = ? AND id <> ? AND is_default = ?", row.ArticleID, row.ID, true).
+		Update("is_default", false).Error
+}
+
+func (r *Repository) DeleteArticleVariant(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deleteVariantGallery(tx, "article_variants", id); err != nil {
+			return err
+		}
+		return tx.Delete(&articleVariantRow{}, id).Error
+	})
+}
+
+// --- Print areas ---
+
+func (r *Repository) ListPrintAreas(ctx context.Context, articleID int) ([]article.PrintArea, error) {
+	var rows []printAreaRow
+	if err := r.db.WithContext(ctx).Where("article_id = ?", articleID).Order("id asc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]article.PrintArea, 0, len(rows))
+	for i := range rows {
+		out = append(out, toPrintArea(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) ReplacePrintAreas(ctx context.Context, articleID int, areas []article.PrintArea) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", articleID).Delete(&printAreaRow{}).Error; err != nil {
+			return err
+		}
+		if len(areas) == 0 {
+			return nil
+		}
+		rows := make([]printAreaRow, 0, len(areas))
+		for _, a := range areas {
+			rows = append(rows, printAreaRow{ArticleID: articleID, Name: a.Name, WidthMm: a.WidthMm, HeightMm: a.HeightMm})
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+// --- Catalog ---
+
+func (r *Repository) ListTypeCatalog(ctx context.Context, articleType string) ([]article.TypeCatalogEntry, error) {
+	var articles []articleRow
+	if err := r.db.WithContext(ctx).
+		Where("article_type = ? AND active = ? AND archived_at IS NULL", articleType, true).
+		Order("id desc").
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	if len(articles) == 0 {
+		return []article.TypeCatalogEntry{}, nil
+	}
+	ids := make([]int, 0, len(articles))
+	for i := range articles {
+		ids = append(ids, articles[i].ID)
+	}
+
+	var variants []articleVariantRow
+	if err := r.db.WithContext(ctx).
+		Where("article_id IN ? AND active = ? AND archived_at IS NULL", ids, true).
+		Order("id asc").
+		Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	var areas []printAreaRow
+	if err := r.db.WithContext(ctx).Where("article_id IN ?", ids).Order("id asc").Find(&areas).Error; err != nil {
+		return nil, err
+	}
+	var gallery []galleryImageRow
+	if err := r.db.WithContext(ctx).Where("article_id IN ?", ids).Order("position asc, id asc").Find(&gallery).Error; err != nil {
+		return nil, err
+	}
+	var prices []priceRow
+	if err := r.db.WithContext(ctx).Where("article_id IN ?", ids).Find(&prices).Error; err != nil {
+		return nil, err
+	}
+
+	galleryByArticle := groupGalleryImages(gallery)
+	variantsByArticle := make(map[int][]article.ArticleVariant)
+	for i := range variants {
+		variantsByArticle[variants[i].ArticleID] = append(variantsByArticle[variants[i].ArticleID], toArticleVariant(&variants[i]))
+	}
+	areasByArticle := make(map[int][]article.PrintArea)
+	for i := range areas {
+		areasByArticle[areas[i].ArticleID] = append(areasByArticle[areas[i].ArticleID], toPrintArea(&areas[i]))
+	}
+	pricesByArticle := make(map[int]*article.Price, len(prices))
+	for i := range prices {
+		if prices[i].ArticleID == nil {
+			continue
+		}
+		price := toCostCalculation(&prices[i])
+		pricesByArticle[*prices[i].ArticleID] = &price
+	}
+
+	out := make([]article.TypeCatalogEntry, 0, len(articles))
+	for i := range articles {
+		a := articles[i]
+		entry := article.TypeCatalogEntry{
+			Article:    toArticle(&a),
+			Variants:   variantsByArticle[a.ID],
+			PrintAreas: areasByArticle[a.ID],
+			Gallery:    galleryByArticle[a.ID],
+			Price:      pricesByArticle[a.ID],
+		}
+		if entry.Variants == nil {
+			entry.Variants = []article.ArticleVariant{}
+		}
+		if entry.PrintAreas == nil {
+			entry.PrintAreas = []article.PrintArea{}
+		}
+		if entry.Gallery == nil {
+			entry.Gallery = []article.GalleryImage{}
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+// --- Conversion helpers ---
+
+func toArticleType(row *articleTypeRow) article.ArticleTypeDefinition {
+	var schema []attributeDefinitionJSON
+	// Rows are written by fromArticleType; unreadable JSON leaves the schema
+	// empty rather than failing every read.
+	_ = json.Unmarshal([]byte(row.AttributeSchema), &schema)
+	axes := []string{}
+	_ = json.Unmarshal([]byte(row.OptionAxes), &axes)
+	def := article.ArticleTypeDefinition{
+		Code:            row.Code,
+		Name:            row.Name,
+		Description:     row.Description,
+		BuiltIn:         row.BuiltIn,
+		AttributeSchema: make([]article.AttributeDefinition, 0, len(schema)),
+		OptionAxes:      axes,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	}
+	for _, a := range schema {
+		def.AttributeSchema = append(def.AttributeSchema, article.AttributeDefinition{
+			Key:      a.Key,
+			Label:    a.Label,
+			Type:     a.Type,
+			Required: a.Required,
+			Options:  a.Options,
+			Unit:     a.Unit,
+		})
+	}
+	return def
+}
+
+func fromArticleType(def *article.ArticleTypeDefinition) *articleTypeRow {
+	schema := make([]attributeDefinitionJSON, 0, len(def.AttributeSchema))
+	for _, a := range def.AttributeSchema {
+		schema = append(schema, attributeDefinitionJSON{
+			Key:      a.Key,
+			Label:    a.Label,
+			Type:     a.Type,
+			Required: a.Required,
+			Options:  a.Options,
+			Unit:     a.Unit,
+		})
+	}
+	axes := def.OptionAxes
+	if axes == nil {
+		axes = []string{}
+	}
+	return &articleTypeRow{
+		Code:            def.Code,
+		Name:            def.Name,
+		Description:     def.Description,
+		BuiltIn:         def.BuiltIn,
+		AttributeSchema: mustJSON(schema),
+		OptionAxes:      mustJSON(axes),
+		CreatedAt:       def.CreatedAt,
+		UpdatedAt:       def.UpdatedAt,
+	}
+}
+
+func toArticleVariant(row *articleVariantRow) article.ArticleVariant {
+	options := map[string]string{}
+	_ = json.Unmarshal([]byte(row.Options), &options)
+	return article.ArticleVariant{
+		ID:                   row.ID,
+		ArticleID:            row.ArticleID,
+		Options:              options,
+		ArticleVariantNumber: row.ArticleVariantNumber,
+		ExampleImageFilename: row.ExampleImageFilename,
+		IsDefault:            row.IsDefault,
+		Active:               row.Active,
+		ArchivedAt:           row.ArchivedAt,
+		CreatedAt:            row.CreatedAt,
+		UpdatedAt:            row.UpdatedAt,
+	}
+}
+
+func fromArticleVariant(v *article.ArticleVariant) *articleVariantRow {
+	options := v.Options
+	if options == nil {
+		options = map[string]string{}
+	}
+	return &articleVariantRow{
+		ID:                   v.ID,
+		ArticleID:            v.ArticleID,
+		Options:              mustJSON(options),
+		OptionsKey:           article.VariantOptionsKey(options),
+		ArticleVariantNumber: v.ArticleVariantNumber,
+		ExampleImageFilename: v.ExampleImageFilename,
+		IsDefault:            v.IsDefault,
+		Active:               v.Active,
+		ArchivedAt:           v.ArchivedAt,
+		CreatedAt:            v.CreatedAt,
+		UpdatedAt:            v.UpdatedAt,
+	}
+}
+
+func toPrintArea(row *printAreaRow) article.PrintArea {
+	return article.PrintArea{
+		ID:        row.ID,
+		ArticleID: row.ArticleID,
+		Name:      row.Name,
+		WidthMm:   row.WidthMm,
+		HeightMm:  row.HeightMm,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+func decodeAttributes(raw string) map[string]any {
+	attributes := map[string]any{}
+	if raw != "" {
+		_ = json.Unmarshal([]byte(raw), &attributes)
+	}
+	return attributes
+}
+
+func encodeAttributes(attributes map[string]any) string {
+	if len(attributes) == 0 {
+		return "{}"
+	}
+	return mustJSON(attributes)
+}
+
+// mustJSON marshals values that always encode: strings, slices and maps of
+// JSON-decoded values.
+func mustJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+)
+
+func TestRegisteredArticleTypeLifecycle(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&articleCategoryRow{}, &articleSubCategoryRow{}, &articleRow{}, &articleTypeRow{},
+		&articleVariantRow{}, &printAreaRow{}, &mugVariantRow{}, &shirtVariantRow{}, &priceRow{}, &priceHistoryRow{}, &priceTierRow{}, &articleSlugRedirectRow{}, &galleryImageRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	if err := db.Create(&articleTypeRow{Code: article.ArticleTypeMug, Name: "Mug", BuiltIn: true}).Error; err != nil {
+		t.Fatalf("seed built-in type: %v", err)
+	}
+	if err := db.Create(&articleCategoryRow{ID: 1, Name: "Wall art"}).Error; err != nil {
+		t.Fatalf("seed category: %v", err)
+	}
+	ctx := context.Background()
+	svc := article.NewService(NewRepository(db))
+
+	if _, err := svc.UpdateArticleType(ctx, &article.ArticleTypeDefinition{Code: "mug", Name: "Cup"}); !errors.Is(err, article.ErrBuiltInArticleType) {
+		t.Fatalf("updating a built-in type: err = %v", err)
+	}
+	poster, err := svc.CreateArticleType(ctx, &article.ArticleTypeDefinition{
+		Code: "poster",
+		Name: "Poster",
+		AttributeSchema: []article.AttributeDefinition{
+			{Key: "paper", Type: "enum", Required: true, Options: []string{"Matte", "Glossy"}},
+			{Key: "weightGsm", Type: article.AttributeTypeNumber, Unit: "g/m²"},
+		},
+		OptionAxes: []string{"size", "frame"},
+	})
+	if err != nil {
+		t.Fatalf("create type: %v", err)
+	}
+	if poster.Code != "POSTER" {
+		t.Fatalf("code = %q, want POSTER", poster.Code)
+	}
+
+	art := article.Article{Name: "City print", ArticleType: "poster", CategoryID: 1, Active: true,
+		Attributes: map[string]any{"paper": "Velvet"}}
+	if _, err := svc.CreateArticle(ctx, &art, nil, nil, nil, nil, nil); !errors.Is(err, article.ErrInvalidAttributes) {
+		t.Fatalf("unknown enum value: err = %v", err)
+	}
+	art.Attributes = map[string]any{"paper": " Matte ", "weightGsm": 200.0}
+	detail, err := svc.CreateArticle(ctx, &art, nil, nil, &article.Price{SalesTotalGross: 2500}, nil, nil)
+	if err != nil {
+		t.Fatalf("create article: %v", err)
+	}
+	if detail.Article.ArticleType != "POSTER" || detail.Article.Attributes["paper"] != "Matte" {
+		t.Fatalf("unexpected article: %+v", detail.Article)
+	}
+
+	if _, err := svc.CreateArticleVariant(ctx, &article.ArticleVariant{ArticleID: art.ID, Options: map[string]string{"size": "A3"}}); !errors.Is(err, article.ErrInvalidVariantOptions) {
+		t.Fatalf("missing axis: err = %v", err)
+	}
+	a3, err := svc.CreateArticleVariant(ctx, &article.ArticleVariant{ArticleID: art.ID, Options: map[string]string{"size": "A3", "frame": "None"}, IsDefault: true, Active: true})
+	if err != nil {
+		t.Fatalf("create variant: %v", err)
+	}
+	if _, err := svc.CreateArticleVariant(ctx, &article.ArticleVariant{ArticleID: art.ID, Options: map[string]string{"size": "a3", "frame": "none"}, Active: true}); !errors.Is(err, article.ErrDuplicateVariant) {
+		t.Fatalf("duplicate options: err = %v", err)
+	}
+	a2, err := svc.CreateArticleVariant(ctx, &article.ArticleVariant{ArticleID: art.ID, Options: map[string]string{"size": "A2", "frame": "Oak"}, IsDefault: true, Active: true})
+	if err != nil {
+		t.Fatalf("create second variant: %v", err)
+	}
+	if reloaded, err := svc.GetArticleVariant(ctx, a3.ID); err != nil || reloaded.IsDefault {
+		t.Fatalf("first variant should no longer be the default: %+v, %v", reloaded, err)
+	}
+	if _, err := svc.SetPrintAreas(ctx, art.ID, []article.PrintArea{{Name: "Front", WidthMm: 297, HeightMm: 420}, {Name: "front", WidthMm: 1, HeightMm: 1}}); !errors.Is(err, article.ErrInvalidPrintAreas) {
+		t.Fatalf("duplicate print area names: err = %v", err)
+	}
+	if _, err := svc.SetPrintAreas(ctx, art.ID, []article.PrintArea{{Name: "Front", WidthMm: 297, HeightMm: 420}}); err != nil {
+		t.Fatalf("set print areas: %v", err)
+	}
+
+	def, entries, err := svc.TypeCatalog(ctx, "Poster")
+	if err != nil {
+		t.Fatalf("catalog: %v", err)
+	}
+	if len(def.OptionAxes) != 2 || len(entries) != 1 {
+		t.Fatalf("unexpected catalog: %+v %+v", def, entries)
+	}
+	entry := entries[0]
+	if len(entry.Variants) != 2 || entry.Variants[1].ID != a2.ID || !entry.Variants[1].IsDefault {
+		t.Fatalf("unexpected variants: %+v", entry.Variants)
+	}
+	if got := entry.Variants[1].DisplayName(def.OptionAxes); got != "A2 / Oak" {
+		t.Fatalf("display name = %q", got)
+	}
+	if len(entry.PrintAreas) != 1 || entry.Price == nil || entry.Price.SalesTotalGross != 2500 {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	if _, _, err := svc.TypeCatalog(ctx, article.ArticleTypeMug); !errors.Is(err, article.ErrNotRegisteredType) {
+		t.Fatalf("catalog of a built-in type: err = %v", err)
+	}
+
+	poster.OptionAxes = []string{"size"}
+	if _, err := svc.UpdateArticleType(ctx, &poster); !errors.Is(err, article.ErrArticleTypeInUse) {
+		t.Fatalf("changing axes of a used type: err = %v", err)
+	}
+	if err := svc.DeleteArticleType(ctx, "POSTER"); !errors.Is(err, article.ErrArticleTypeInUse) {
+		t.Fatalf("deleting a used type: err = %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+)
+
+// --- Galleries ---
+
+func (r *Repository) ListGalleryImages(ctx context.Context, articleID int) ([]article.GalleryImage, error) {
+	var rows []galleryImageRow
+	if err := r.db.WithContext(ctx).
+		Where("article_id = ?", articleID).
+		Order("position asc, id asc").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]article.GalleryImage, 0, len(rows))
+	for i := range rows {
+		out = append(out, toGalleryImage(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) GetGalleryImage(ctx context.Context, id int) (article.GalleryImage, error) {
+	var row galleryImageRow
+	if err := r.db.WithContext(ctx).First(&row, "id = ?", id).Error; err != nil {
+		return article.GalleryImage{}, err
+	}
+	return toGalleryImage(&row), nil
+}
+
+func (r *Repository) CreateGalleryImage(ctx context.Context, image *article.GalleryImage) error {
+	row := fromGalleryImage(image)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := galleryScope(tx.Model(&galleryImageRow{}), row.ArticleID, row.VariantID).
+			Select("coalesce(max(position), -1)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+		row.Position = last + 1
+		if err := clearPrimaryGalleryImage(tx, row); err != nil {
+			return err
+		}
+		return tx.Create(row).Error
+	})
+	if err != nil {
+		return err
+	}
+	image.ID = row.ID
+	image.Position = row.Position
+	image.CreatedAt = row.CreatedAt
+	image.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *Repository) UpdateGalleryImage(ctx context.Context, image *article.GalleryImage) error {
+	row := fromGalleryImage(image)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := clearPrimaryGalleryImage(tx, row); err != nil {
+			return err
+		}
+		return tx.Model(row).Select("alt_text", "is_primary", "updated_at").Updates(row).Error
+	})
+	if err != nil {
+		return err
+	}
+	image.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *Repository) DeleteGalleryImage(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var row galleryImageRow
+		if err := tx.First(&row, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&galleryImageRow{}, id).Error; err != nil {
+			return err
+		}
+		if !row.IsPrimary {
+			return nil
+		}
+		var next galleryImageRow
+		err := galleryScope(tx, row.ArticleID, row.VariantID).Order("position asc, id asc").First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&next).Update("is_primary", true).Error
+	})
+}
+
+func (r *Repository) ReorderGallery(ctx context.Context, imageIDs []int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range imageIDs {
+			if err := tx.Model(&galleryImageRow{}).Where("id = ?", id).Update("position", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// galleryScope restricts a query to the gallery of the article (nil variant)
+// or of one of its variants.
+func galleryScope(tx *gorm.DB, articleID int, variantID *int) *gorm.DB {
+	tx = tx.Where("article_id = ?", articleID)
+	if variantID == nil {
+		return tx.Where("variant_id IS NULL")
+	}
+	return tx.Where("variant_id = ?", *variantID)
+}
+
+func clearPrimaryGalleryImage(tx *gorm.DB, row *galleryImageRow) error {
+	if !row.IsPrimary {
+		return nil
+	}
+	return galleryScope(tx.Model(&galleryImageRow{}), row.ArticleID, row.VariantID).
+		Where("id <> ? AND is_primary = ?", row.ID, true).
+		Update("is_primary", false).Error
+}
+
+// deleteVariantGallery removes the gallery rows of a variant stored in
+// variantTable. The article is taken from the variant so variants of other
+// kinds sharing the id keep their galleries.
+func deleteVariantGallery(tx *gorm.DB, variantTable string, variantID int) error {
+	return tx.
+		Where("variant_id = ? AND article_id IN (?)", variantID, tx.Table(variantTable).Select("article_id").Where("id = ?", variantID)).
+		Delete(&galleryImageRow{}).Error
+}
+
+func groupGalleryImages(rows []galleryImageRow) map[int][]article.GalleryImage {
+	out := make(map[int][]article.GalleryImage)
+	for i := range rows {
+		out[rows[i].ArticleID] = append(out[rows[i].ArticleID], toGalleryImage(&rows[i]))
+	}
+	return out
+}
+
+func toGalleryImage(row *galleryImageRow) article.GalleryImage {
+	return article.GalleryImage{
+		ID:        row.ID,
+		ArticleID: row.ArticleID,
+		VariantID: row.VariantID,
+		Filename:  row.Filename,
+		AltText:   row.AltText,
+		Position:  row.Position,
+		IsPrimary: row.IsPrimary,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+func fromGalleryImage(image *article.GalleryImage) *galleryImageRow {
+	return &galleryImageRow{
+		ID:        image.ID,
+		ArticleID: image.ArticleID,
+		VariantID: image.VariantID,
+		Filename:  image.Filename,
+		AltText:   image.AltText,
+		Position:  image.Position,
+		IsPrimary: image.IsPrimary,
+		CreatedAt: image.CreatedAt,
+		UpdatedAt: image.UpdatedAt,
+	}
+}
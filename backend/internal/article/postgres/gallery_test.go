@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+	img "voenix/backend/internal/image"
+)
+
+// fileUploader stores uploads as-is in the admin directory of their type.
+type fileUploader struct{ n int }
+
+func (u *fileUploader) UploadAdminImage(_ context.Context, r io.Reader, imageType string, _ *img.CropArea) (string, string, error) {
+	loc, err := img.NewStorageLocations()
+	if err != nil {
+		return "", "", err
+	}
+	dir, err := loc.ResolveAdminDir(imageType)
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", "", err
+	}
+	u.n++
+	name := fmt.Sprintf("gallery-%d.webp", u.n)
+	return name, imageType, os.WriteFile(filepath.Join(dir, name), data, 0o644)
+}
+
+func TestArticleGalleryLifecycle(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("STORAGE_ROOT", root)
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&articleCategoryRow{}, &articleSubCategoryRow{}, &articleRow{}, &articleTypeRow{},
+		&articleVariantRow{}, &printAreaRow{}, &mugVariantRow{}, &shirtVariantRow{}, &mugDetailsRow{}, &shirtDetailsRow{}, &priceRow{},
+		&priceHistoryRow{}, &priceTierRow{}, &articleSlugRedirectRow{}, &galleryImageRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	if err := db.Create(&articleTypeRow{Code: article.ArticleTypeMug, Name: "Mug", BuiltIn: true}).Error; err != nil {
+		t.Fatalf("seed built-in type: %v", err)
+	}
+	if err := db.Create(&articleCategoryRow{ID: 1, Name: "Mugs", Slug: "mugs"}).Error; err != nil {
+		t.Fatalf("seed category: %v", err)
+	}
+	ctx := context.Background()
+	svc := article.NewService(NewRepository(db))
+	uploader := &fileUploader{}
+
+	newMug := func(name string) article.Article {
+		a := article.Article{Name: name, ArticleType: article.ArticleTypeMug, CategoryID: 1, Active: true}
+		if _, err := svc.CreateArticle(ctx, &a, &article.MugDetails{HeightMm: 95}, nil, nil, nil, nil); err != nil {
+			t.Fatalf("create article: %v", err)
+		}
+		return a
+	}
+	mug := newMug("Mug")
+	other := newMug("Other mug")
+	white, err := svc.CreateMugVariant(ctx, &article.MugVariant{ArticleID: mug.ID, Name: "White", Active: true})
+	if err != nil {
+		t.Fatalf("create variant: %v", err)
+	}
+	foreign, err := svc.CreateMugVariant(ctx, &article.MugVariant{ArticleID: other.ID, Name: "Black", Active: true})
+	if err != nil {
+		t.Fatalf("create foreign variant: %v", err)
+	}
+
+	add := func(variantID *int, alt string, primary bool) article.GalleryImage {
+		t.Helper()
+		g, err := svc.AddGalleryImage(ctx, uploader, &article.GalleryImage{ArticleID: mug.ID, VariantID: variantID, AltText: alt, IsPrimary: primary}, strings.NewReader("img"), nil)
+		if err != nil {
+			t.Fatalf("add image %q: %v", alt, err)
+		}
+		return g
+	}
+	lifestyle := add(nil, " Lifestyle ", false)
+	detail := add(nil, "Detail", false)
+	sizeChart := add(nil, "Size chart", true)
+	whiteFront := add(&white.ID, "White front", false)
+
+	if lifestyle.AltText != "Lifestyle" || !lifestyle.IsPrimary || lifestyle.Position != 0 || sizeChart.Position != 2 {
+		t.Fatalf("unexpected images: %+v, %+v", lifestyle, sizeChart)
+	}
+	if !whiteFront.IsPrimary || whiteFront.Position != 0 {
+		t.Fatalf("first variant image should be primary at position 0: %+v", whiteFront)
+	}
+	if _, err := svc.AddGalleryImage(ctx, uploader, &article.GalleryImage{ArticleID: mug.ID, VariantID: &foreign.ID}, strings.NewReader("img"), nil); !errors.Is(err, article.ErrInvalidGalleryVariant) {
+		t.Fatalf("foreign variant: err = %v", err)
+	}
+
+	images, err := svc.ListGallery(ctx, mug.ID)
+	if err != nil {
+		t.Fatalf("list gallery: %v", err)
+	}
+	own := article.GalleryOf(images, nil)
+	if len(own) != 3 || own[0].IsPrimary || !own[2].IsPrimary {
+		t.Fatalf("adding a primary image should clear the previous one: %+v", own)
+	}
+
+	if _, err := svc.ReorderGallery(ctx, mug.ID, nil, []int{detail.ID, sizeChart.ID}); !errors.Is(err, article.ErrInvalidGalleryOrder) {
+		t.Fatalf("incomplete order: err = %v", err)
+	}
+	if _, err := svc.ReorderGallery(ctx, mug.ID, nil, []int{detail.ID, sizeChart.ID, whiteFront.ID}); !errors.Is(err, article.ErrInvalidGalleryOrder) {
+		t.Fatalf("image of another gallery: err = %v", err)
+	}
+	ordered, err := svc.ReorderGallery(ctx, mug.ID, nil, []int{sizeChart.ID, lifestyle.ID, detail.ID})
+	if err != nil {
+		t.Fatalf("reorder: %v", err)
+	}
+	if len(ordered) != 3 || ordered[0].ID != sizeChart.ID || ordered[2].ID != detail.ID || ordered[2].Position != 2 {
+		t.Fatalf("unexpected order: %+v", ordered)
+	}
+
+	updated, err := svc.UpdateGalleryImage(ctx, detail.ID, "Handle detail", true)
+	if err != nil || !updated.IsPrimary || updated.AltText != "Handle detail" {
+		t.Fatalf("update image: %+v, %v", updated, err)
+	}
+	if err := svc.DeleteGalleryImage(ctx, detail.ID); err != nil {
+		t.Fatalf("delete image: %v", err)
+	}
+	galleryDir := filepath.Join(root, "public", "images", "articles", "gallery")
+	if _, err := os.Stat(filepath.Join(galleryDir, detail.Filename)); !os.IsNotExist(err) {
+		t.Fatalf("deleted image file should be removed, stat err = %v", err)
+	}
+	images, err = svc.ListGallery(ctx, mug.ID)
+	if err != nil {
+		t.Fatalf("list gallery: %v", err)
+	}
+	own = article.GalleryOf(images, nil)
+	if len(own) != 2 || own[0].ID != sizeChart.ID || !own[0].IsPrimary || own[1].IsPrimary {
+		t.Fatalf("next image should take over as primary: %+v", own)
+	}
+
+	entries, err := svc.MugCatalog(ctx)
+	if err != nil {
+		t.Fatalf("catalog: %v", err)
+	}
+	for _, e := range entries {
+		if e.Article.ID == mug.ID && len(e.Gallery) != 3 {
+			t.Fatalf("catalog gallery = %+v", e.Gallery)
+		}
+	}
+
+	// Purging checks orders and clears carts of the article.
+	for _, stmt := range []string{
+		"CREATE TABLE order_items (id integer primary key, article_id integer, variant_id integer)",
+		"CREATE TABLE carts (id integer primary key, status text)",
+		"CREATE TABLE cart_items (id integer primary key, cart_id integer, article_id integer, variant_id integer)",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+	}
+	if _, err := svc.ArchiveMugVariant(ctx, white.ID); err != nil {
+		t.Fatalf("archive variant: %v", err)
+	}
+	if err := svc.PurgeMugVariant(ctx, white.ID); err != nil {
+		t.Fatalf("purge variant: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(galleryDir, whiteFront.Filename)); !os.IsNotExist(err) {
+		t.Fatalf("variant gallery file should be removed, stat err = %v", err)
+	}
+	if _, err := svc.ArchiveArticle(ctx, mug.ID); err != nil {
+		t.Fatalf("archive article: %v", err)
+	}
+	if err := svc.PurgeArticle(ctx, mug.ID); err != nil {
+		t.Fatalf("purge article: %v", err)
+	}
+	var left int64
+	if err := db.Model(&galleryImageRow{}).Count(&left).Error; err != nil || left != 0 {
+		t.Fatalf("gallery rows left = %d, %v", left, err)
+	}
+	if _, err := os.Stat(filepath.Join(galleryDir, lifestyle.Filename)); !os.IsNotExist(err) {
+		t.Fatalf("article gallery file should be removed, stat err = %v", err)
+	}
+}
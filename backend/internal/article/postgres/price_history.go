@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+)
+
+// RecordPriceHistory stores the current values of a saved price as a history
+// entry effective immediately. Packages that write prices outside this
+// repository call it within the same transaction.
+func RecordPriceHistory(db *gorm.DB, price *article.Price) error {
+	return recordPriceHistory(db, fromCostCalculation(price))
+}
+
+func recordPriceHistory(db *gorm.DB, price *priceRow) error {
+	now := time.Now()
+	return db.Create(&priceHistoryRow{
+		PriceID:       price.ID,
+		EffectiveFrom: now,
+		AppliedAt:     &now,
+		Calculation:   price.Calculation,
+	}).Error
+}
+
+func (r *Repository) ListPriceHistory(ctx context.Context, priceID int) ([]article.PriceHistoryEntry, error) {
+	var rows []priceHistoryRow
+	err := r.db.WithContext(ctx).
+		Where("price_id = ?", priceID).
+		Order("effective_from asc, id asc").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]article.PriceHistoryEntry, 0, len(rows))
+	for i := range rows {
+		out = append(out, toPriceHistoryEntry(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) PriceHistoryAt(ctx context.Context, priceID int, at time.Time) (*article.PriceHistoryEntry, error) {
+	var row priceHistoryRow
+	err := r.db.WithContext(ctx).
+		Where("price_id = ? AND effective_from <= ?", priceID, at).
+		Order("effective_from desc, id desc").
+		First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entry := toPriceHistoryEntry(&row)
+	return &entry, nil
+}
+
+func (r *Repository) CreatePriceHistoryEntry(ctx context.Context, entry *article.PriceHistoryEntry) error {
+	row := priceHistoryRow{
+		PriceID:       entry.PriceID,
+		EffectiveFrom: entry.EffectiveFrom,
+		AppliedAt:     entry.AppliedAt,
+		Calculation:   fromPriceCalculation(&entry.Calculation),
+	}
+	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return err
+	}
+	*entry = toPriceHistoryEntry(&row)
+	return nil
+}
+
+func (r *Repository) DeleteScheduledPriceChange(ctx context.Context, priceID, entryID int, now time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Where("id = ? AND price_id = ? AND applied_at IS NULL AND effective_from > ?", entryID, priceID, now).
+		Delete(&priceHistoryRow{})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *Repository) ApplyDuePriceChanges(ctx context.Context, now time.Time) (int, error) {
+	changed := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var due []priceHistoryRow
+		err := tx.Where("applied_at IS NULL AND effective_from <= ?", now).
+			Order("price_id asc, effective_from desc, id desc").
+			Find(&due).Error
+		if err != nil || len(due) == 0 {
+			return err
+		}
+		ids := make([]int, 0, len(due))
+		for i := range due {
+			ids = append(ids, due[i].ID)
+			// Only the latest due entry of each price matters.
+			if i > 0 && due[i].PriceID == due[i-1].PriceID {
+				continue
+			}
+			var newer int64
+			err := tx.Model(&priceHistoryRow{}).
+				Where("price_id = ? AND applied_at IS NOT NULL AND effective_from > ?", due[i].PriceID, due[i].EffectiveFrom).
+				Count(&newer).Error
+			if err != nil {
+				return err
+			}
+			if newer > 0 {
+				continue
+			}
+			var price priceRow
+			if err := tx.First(&price, "id = ?", due[i].PriceID).Error; err != nil {
+				return err
+			}
+			price.Calculation = due[i].Calculation
+			if err := tx.Save(&price).Error; err != nil {
+				return err
+			}
+			changed++
+		}
+		return tx.Model(&priceHistoryRow{}).Where("id IN ?", ids).Update("applied_at", now).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return changed, nil
+}
+
+func toPriceHistoryEntry(row *priceHistoryRow) article.PriceHistoryEntry {
+	return article.PriceHistoryEntry{
+		ID:            row.ID,
+		PriceID:       row.PriceID,
+		EffectiveFrom: row.EffectiveFrom,
+		AppliedAt:     row.AppliedAt,
+		Calculation:   toPriceCalculation(&row.Calculation),
+		CreatedAt:     row.CreatedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+)
+
+func TestScheduledPriceChangesTakeEffect(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&priceRow{}, &priceHistoryRow{}, &priceTierRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	ctx := context.Background()
+	svc := article.NewService(NewRepository(db))
+
+	if err := svc.UpsertCostCalculation(ctx, 1, &article.Price{SalesTotalGross: 1000}); err != nil {
+		t.Fatalf("save price: %v", err)
+	}
+	price, err := svc.GetCostCalculation(ctx, 1)
+	if err != nil || price == nil {
+		t.Fatalf("load price: %v", err)
+	}
+
+	if _, err := svc.SchedulePriceChange(ctx, price.ID, &article.Price{SalesTotalGross: 900}, time.Now().Add(-time.Minute)); !errors.Is(err, article.ErrPriceChangeNotInFuture) {
+		t.Fatalf("scheduling in the past: err = %v", err)
+	}
+	effective := time.Now().Add(time.Hour)
+	if _, err := svc.SchedulePriceChange(ctx, price.ID, &article.Price{SalesTotalGross: 1200}, effective); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	cancelled, err := svc.SchedulePriceChange(ctx, price.ID, &article.Price{SalesTotalGross: 1500}, effective.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("schedule second change: %v", err)
+	}
+	if err := svc.CancelScheduledPriceChange(ctx, price.ID, cancelled.ID); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if err := svc.CancelScheduledPriceChange(ctx, price.ID, cancelled.ID); !errors.Is(err, article.ErrScheduledPriceChangeNotFound) {
+		t.Fatalf("cancelling twice: err = %v", err)
+	}
+
+	now, err := svc.GetCostCalculationAt(ctx, 1, time.Now())
+	if err != nil || now.SalesTotalGross != 1000 {
+		t.Fatalf("price now = %+v, %v", now, err)
+	}
+	later, err := svc.GetCostCalculationAt(ctx, 1, effective.Add(time.Minute))
+	if err != nil || later.SalesTotalGross != 1200 || later.ID != price.ID {
+		t.Fatalf("price after the change = %+v, %v", later, err)
+	}
+
+	changed, err := NewRepository(db).ApplyDuePriceChanges(ctx, effective.Add(time.Minute))
+	if err != nil || changed != 1 {
+		t.Fatalf("apply due changes: changed=%d err=%v", changed, err)
+	}
+	stored, err := svc.GetCostCalculation(ctx, 1)
+	if err != nil || stored.SalesTotalGross != 1200 {
+		t.Fatalf("stored price = %+v, %v", stored, err)
+	}
+
+	timeline, err := svc.PriceTimeline(ctx, price.ID)
+	if err != nil {
+		t.Fatalf("timeline: %v", err)
+	}
+	if len(timeline.Entries) != 2 {
+		t.Fatalf("expected the initial and the applied entry, got %+v", timeline.Entries)
+	}
+	for i, e := range timeline.Entries {
+		if e.AppliedAt == nil {
+			t.Fatalf("entry %d not marked applied", i)
+		}
+	}
+	if got := timeline.Status(0, time.Now()); got != article.PriceHistoryActive {
+		t.Fatalf("status of the initial entry = %s", got)
+	}
+	if got := timeline.Status(1, time.Now()); got != article.PriceHistoryScheduled {
+		t.Fatalf("status of the scheduled entry = %s", got)
+	}
+}
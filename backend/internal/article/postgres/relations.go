@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+)
+
+// --- Relations ---
+
+func (r *Repository) ListArticleRelations(ctx context.Context, articleIDs []int) ([]article.ArticleRelation, error) {
+	if len(articleIDs) == 0 {
+		return []article.ArticleRelation{}, nil
+	}
+	var rows []articleRelationRow
+	if err := r.db.WithContext(ctx).
+		Where("article_id IN ?", articleIDs).
+		Order("article_id asc, position asc, id asc").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]article.ArticleRelation, 0, len(rows))
+	for i := range rows {
+		out = append(out, article.ArticleRelation{
+			ID:               rows[i].ID,
+			ArticleID:        rows[i].ArticleID,
+			RelatedArticleID: rows[i].RelatedArticleID,
+			RelatedVariantID: rows[i].RelatedVariantID,
+			Position:         rows[i].Position,
+			CreatedAt:        rows[i].CreatedAt,
+		})
+	}
+	return out, nil
+}
+
+func (r *Repository) ReplaceArticleRelations(ctx context.Context, articleID int, relations []article.ArticleRelation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", articleID).Delete(&articleRelationRow{}).Error; err != nil {
+			return err
+		}
+		if len(relations) == 0 {
+			return nil
+		}
+		rows := make([]articleRelationRow, 0, len(relations))
+		for _, rel := range relations {
+			rows = append(rows, articleRelationRow{
+				ArticleID:        articleID,
+				RelatedArticleID: rel.RelatedArticleID,
+				RelatedVariantID: rel.RelatedVariantID,
+				Position:         rel.Position,
+			})
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+func (r *Repository) ListBoughtTogether(ctx context.Context, articleIDs []int) ([]article.BoughtTogether, error) {
+	if len(articleIDs) == 0 {
+		return []article.BoughtTogether{}, nil
+	}
+	var rows []boughtTogetherRow
+	if err := r.db.WithContext(ctx).
+		Where("article_id IN ?", articleIDs).
+		Order("order_count desc, article_id asc, related_article_id asc").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]article.BoughtTogether, 0, len(rows))
+	for i := range rows {
+		out = append(out, article.BoughtTogether{
+			ArticleID:        rows[i].ArticleID,
+			RelatedArticleID: rows[i].RelatedArticleID,
+			OrderCount:       rows[i].OrderCount,
+			UpdatedAt:        rows[i].UpdatedAt,
+		})
+	}
+	return out, nil
+}
+
+func (r *Repository) RefreshBoughtTogether(ctx context.Context, minOrders int) (int, error) {
+	var pairs int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&boughtTogetherRow{}).Error; err != nil {
+			return err
+		}
+		res := tx.Exec(`INSERT INTO article_bought_together (article_id, related_article_id, order_count, updated_at)
+			SELECT a.article_id, b.article_id, COUNT(DISTINCT a.order_id), ?
+			FROM order_items a
+			JOIN order_items b ON b.order_id = a.order_id AND b.article_id <> a.article_id
+			GROUP BY a.article_id, b.article_id
+			HAVING COUNT(DISTINCT a.order_id) >= ?`, time.Now(), minOrders)
+		pairs = res.RowsAffected
+		return res.Error
+	})
+	return int(pairs), err
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+)
+
+func TestRelationsAndRecommendations(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&articleCategoryRow{}, &articleSubCategoryRow{}, &articleRow{}, &articleTypeRow{},
+		&articleVariantRow{}, &printAreaRow{}, &mugVariantRow{}, &shirtVariantRow{}, &mugDetailsRow{}, &shirtDetailsRow{},
+		&priceRow{}, &priceHistoryRow{}, &priceTierRow{}, &articleSlugRedirectRow{}, &galleryImageRow{},
+		&articleRelationRow{}, &boughtTogetherRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	for _, stmt := range []string{
+		"CREATE TABLE order_items (id integer primary key, order_id integer, article_id integer, variant_id integer)",
+		"CREATE TABLE carts (id integer primary key, status text)",
+		"CREATE TABLE cart_items (id integer primary key, cart_id integer, article_id integer, variant_id integer)",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+	}
+	for _, code := range []string{article.ArticleTypeMug, article.ArticleTypeShirt} {
+		if err := db.Create(&articleTypeRow{Code: code, Name: code, BuiltIn: true}).Error; err != nil {
+			t.Fatalf("seed built-in type: %v", err)
+		}
+	}
+	if err := db.Create(&articleCategoryRow{ID: 1, Name: "Gifts", Slug: "gifts"}).Error; err != nil {
+		t.Fatalf("seed category: %v", err)
+	}
+	ctx := context.Background()
+	svc := article.NewService(NewRepository(db))
+
+	newMug := func(name string) (article.Article, article.MugVariant) {
+		t.Helper()
+		a := article.Article{Name: name, ArticleType: article.ArticleTypeMug, CategoryID: 1, Active: true}
+		details := &article.MugDetails{HeightMm: 95, PrintTemplateWidthMm: 200, PrintTemplateHeightMm: 80}
+		if _, err := svc.CreateArticle(ctx, &a, details, nil, nil, nil, nil); err != nil {
+			t.Fatalf("create mug: %v", err)
+		}
+		v, err := svc.CreateMugVariant(ctx, &article.MugVariant{ArticleID: a.ID, Name: "White", IsDefault: true, Active: true})
+		if err != nil {
+			t.Fatalf("create mug variant: %v", err)
+		}
+		return a, v
+	}
+	mug, _ := newMug("Mug")
+	large, white := newMug("Large mug")
+	black, err := svc.CreateMugVariant(ctx, &article.MugVariant{ArticleID: large.ID, Name: "Black", Active: true})
+	if err != nil {
+		t.Fatalf("create mug variant: %v", err)
+	}
+	often, _ := newMug("Often ordered mug")
+	once, _ := newMug("Once ordered mug")
+	shirt := article.Article{Name: "Shirt", ArticleType: article.ArticleTypeShirt, CategoryID: 1, Active: true}
+	shirtDetails := &article.ShirtDetails{Material: "Cotton", FitType: "REGULAR", AvailableSizes: "M", PrintAreaWidthMm: 300, PrintAreaHeightMm: 300}
+	if _, err := svc.CreateArticle(ctx, &shirt, nil, shirtDetails, nil, nil, nil); err != nil {
+		t.Fatalf("create shirt: %v", err)
+	}
+	if _, err := svc.CreateShirtVariant(ctx, &article.ShirtVariant{ArticleID: shirt.ID, Color: "Black", Size: "M"}); err != nil {
+		t.Fatalf("create shirt variant: %v", err)
+	}
+
+	if _, err := svc.SetRelations(ctx, mug.ID, []article.ArticleRelation{{RelatedArticleID: mug.ID}}); !errors.Is(err, article.ErrInvalidRelation) {
+		t.Fatalf("self relation: err = %v", err)
+	}
+	if _, err := svc.SetRelations(ctx, mug.ID, []article.ArticleRelation{{RelatedArticleID: shirt.ID, RelatedVariantID: &white.ID}}); !errors.Is(err, article.ErrInvalidRelation) {
+		t.Fatalf("variant of another article: err = %v", err)
+	}
+	relations, err := svc.SetRelations(ctx, mug.ID, []article.ArticleRelation{
+		{RelatedArticleID: shirt.ID},
+		{RelatedArticleID: large.ID, RelatedVariantID: &black.ID},
+	})
+	if err != nil || len(relations) != 2 || relations[0].RelatedArticleID != shirt.ID || relations[1].Position != 1 {
+		t.Fatalf("set relations: %+v, %v", relations, err)
+	}
+
+	if err := db.Exec("INSERT INTO order_items (order_id, article_id, variant_id) VALUES (1, ?, 1), (1, ?, 1), (2, ?, 1), (2, ?, 1), (2, ?, 1), (3, ?, 1), (3, ?, 1)",
+		mug.ID, often.ID, mug.ID, often.ID, often.ID, mug.ID, once.ID).Error; err != nil {
+		t.Fatalf("seed order items: %v", err)
+	}
+	pairs, err := svc.RefreshBoughtTogether(ctx)
+	if err != nil || pairs != 2 {
+		t.Fatalf("refresh: %d pairs, %v", pairs, err)
+	}
+	together, err := svc.ListBoughtTogether(ctx, often.ID)
+	if err != nil || len(together) != 1 || together[0].RelatedArticleID != mug.ID || together[0].OrderCount != 2 {
+		t.Fatalf("bought together: %+v, %v", together, err)
+	}
+
+	design := 7
+	recs, err := svc.Recommend(ctx, []article.RecommendationSeed{{ArticleID: mug.ID, GeneratedImageID: &design}}, article.RecommendOptions{Limit: 10})
+	if err != nil || len(recs) != 3 {
+		t.Fatalf("recommend: %+v, %v", recs, err)
+	}
+	if recs[0].Article.ID != shirt.ID || recs[0].Source != article.RecommendationCurated || recs[0].GeneratedImageID != nil {
+		t.Fatalf("the square shirt print should not reuse the mug design: %+v", recs[0])
+	}
+	if recs[1].Article.ID != large.ID || recs[1].VariantID != black.ID || recs[1].GeneratedImageID == nil || *recs[1].GeneratedImageID != design {
+		t.Fatalf("pinned variant with the design: %+v", recs[1])
+	}
+	if recs[2].Article.ID != often.ID || recs[2].Source != article.RecommendationBoughtTogether || recs[2].SeedArticleID != mug.ID {
+		t.Fatalf("bought together: %+v", recs[2])
+	}
+
+	// Articles in the cart are not suggested; an unavailable pinned variant
+	// falls back to the default one.
+	if _, err := svc.ArchiveMugVariant(ctx, black.ID); err != nil {
+		t.Fatalf("archive variant: %v", err)
+	}
+	recs, err = svc.Recommend(ctx, []article.RecommendationSeed{{ArticleID: mug.ID}, {ArticleID: often.ID}}, article.RecommendOptions{
+		Limit:        10,
+		ArticleTypes: []string{article.ArticleTypeMug},
+	})
+	if err != nil || len(recs) != 1 || recs[0].Article.ID != large.ID || recs[0].VariantID != white.ID || recs[0].GeneratedImageID != nil {
+		t.Fatalf("recommend for mugs: %+v, %v", recs, err)
+	}
+	if recs, err = svc.Recommend(ctx, []article.RecommendationSeed{{ArticleID: mug.ID}}, article.RecommendOptions{Limit: 1}); err != nil || len(recs) != 1 {
+		t.Fatalf("limit: %+v, %v", recs, err)
+	}
+}
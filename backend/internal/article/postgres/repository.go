@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 
 	"voenix/backend/internal/article"
+	img "voenix/backend/internal/image"
 	"voenix/backend/internal/supplier"
 	"voenix/backend/internal/vat"
 )
@@ -42,7 +43,13 @@ func (r *Repository) GetCategory(ctx context.Context, id int) (article.ArticleCa
 
 func (r *Repository) CreateCategory(ctx context.Context, cat *article.ArticleCategory) error {
 	row := fromArticleCategory(cat)
-	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(row).Error; err != nil {
+			return err
+		}
+		return moveSlug(tx, &articleCategorySlugRedirectRow{}, "", row.Slug, nil)
+	})
+	if err != nil {
 		return err
 	}
 	cat.ID = row.ID
@@ -53,7 +60,18 @@ func (r *Repository) CreateCategory(ctx context.Context, cat *article.ArticleCat
 
 func (r *Repository) UpdateCategory(ctx context.Context, cat *article.ArticleCategory) error {
 	row := fromArticleCategory(cat)
-	if err := r.db.WithContext(ctx).Save(row).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		previous, err := currentSlug(tx, &articleCategoryRow{}, row.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Save(row).Error; err != nil {
+			return err
+		}
+		redirect := &articleCategorySlugRedirectRow{Slug: previous, CategoryID: row.ID}
+		return moveSlug(tx, &articleCategorySlugRedirectRow{}, previous, row.Slug, redirect)
+	})
+	if err != nil {
 		return err
 	}
 	cat.CreatedAt = row.CreatedAt
@@ -198,6 +216,11 @@ func (r *Repository) ListArticles(ctx context.Context, opts article.ArticleListO
 	if opts.Active != nil {
 		tx = tx.Where("active = ?", *opts.Active)
 	}
+	if opts.Archived {
+		tx = tx.Where("archived_at IS NOT NULL")
+	} else {
+		tx = tx.Where("archived_at IS NULL")
+	}
 	if search := strings.TrimSpace(opts.Search); search != "" {
 		like := "%" + strings.ToLower(search) + "%"
 		tx = tx.Where("LOWER(name) LIKE ? OR LOWER(description_short) LIKE ?", like, like)
@@ -235,7 +258,13 @@ func (r *Repository) GetArticle(ctx context.Context, id int) (article.Article, e
 
 func (r *Repository) CreateArticle(ctx context.Context, art *article.Article) error {
 	row := fromArticle(art)
-	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(row).Error; err != nil {
+			return err
+		}
+		return moveSlug(tx, &articleSlugRedirectRow{}, "", row.Slug, nil)
+	})
+	if err != nil {
 		return err
 	}
 	art.ID = row.ID
@@ -246,7 +275,18 @@ func (r *Repository) CreateArticle(ctx context.Context, art *article.Article) er
 
 func (r *Repository) UpdateArticle(ctx context.Context, art *article.Article) error {
 	row := fromArticle(art)
-	if err := r.db.WithContext(ctx).Save(row).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		previous, err := currentSlug(tx, &articleRow{}, row.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Omit("archived_at").Save(row).Error; err != nil {
+			return err
+		}
+		redirect := &articleSlugRedirectRow{Slug: previous, ArticleID: row.ID}
+		return moveSlug(tx, &articleSlugRedirectRow{}, previous, row.Slug, redirect)
+	})
+	if err != nil {
 		return err
 	}
 	art.CreatedAt = row.CreatedAt
@@ -283,6 +323,18 @@ func (r *Repository) DeleteArticle(ctx context.Context, id int) error {
 		if err := transaction.Delete(&shirtVariantRow{}, "article_id = ?", id).Error; err != nil {
 			return err
 		}
+		if err := transaction.Delete(&articleVariantRow{}, "article_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := transaction.Delete(&printAreaRow{}, "article_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := transaction.Delete(&galleryImageRow{}, "article_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := transaction.Delete(&articleSlugRedirectRow{}, "article_id = ?", id).Error; err != nil {
+			return err
+		}
 		if err := transaction.Delete(&mugDetailsRow{}, "article_id = ?", id).Error; err != nil {
 			return err
 		}
@@ -301,7 +353,7 @@ func (r *Repository) DeleteArticle(ctx context.Context, id int) error {
 func (r *Repository) ListMugVariants(ctx context.Context, articleID int, onlyActive bool) ([]article.MugVariant, error) {
 	tx := r.db.WithContext(ctx).Where("article_id = ?", articleID)
 	if onlyActive {
-		tx = tx.Where("active = ?", true)
+		tx = tx.Where("active = ? AND archived_at IS NULL", true)
 	}
 	var rows []mugVariantRow
 	if err := tx.Order("id asc").Find(&rows).Error; err != nil {
@@ -335,7 +387,7 @@ func (r *Repository) CreateMugVariant(ctx context.Context, variant *article.MugV
 
 func (r *Repository) UpdateMugVariant(ctx context.Context, variant *article.MugVariant) error {
 	row := fromMugVariant(variant)
-	if err := r.db.WithContext(ctx).Save(row).Error; err != nil {
+	if err := r.db.WithContext(ctx).Omit("archived_at").Save(row).Error; err != nil {
 		return err
 	}
 	variant.CreatedAt = row.CreatedAt
@@ -344,14 +396,25 @@ func (r *Repository) UpdateMugVariant(ctx context.Context, variant *article.MugV
 }
 
 func (r *Repository) DeleteMugVariant(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&mugVariantRow{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deleteVariantGallery(tx, "article_mug_variants", id); err != nil {
+			return err
+		}
+		return tx.Delete(&mugVariantRow{}, id).Error
+	})
 }
 
 // --- Shirt variants ---
 
-func (r *Repository) ListShirtVariants(ctx context.Context, articleID int, _ bool) ([]article.ShirtVariant, error) {
+// ListShirtVariants treats onlyActive as "not archived"; shirt variants have
+// no active flag.
+func (r *Repository) ListShirtVariants(ctx context.Context, articleID int, onlyActive bool) ([]article.ShirtVariant, error) {
+	tx := r.db.WithContext(ctx).Where("article_id = ?", articleID)
+	if onlyActive {
+		tx = tx.Where("archived_at IS NULL")
+	}
 	var rows []shirtVariantRow
-	if err := r.db.WithContext(ctx).Where("article_id = ?", articleID).Order("id asc").Find(&rows).Error; err != nil {
+	if err := tx.Order("id asc").Find(&rows).Error; err != nil {
 		return nil, err
 	}
 	out := make([]article.ShirtVariant, 0, len(rows))
@@ -382,7 +445,7 @@ func (r *Repository) CreateShirtVariant(ctx context.Context, variant *article.Sh
 
 func (r *Repository) UpdateShirtVariant(ctx context.Context, variant *article.ShirtVariant) error {
 	row := fromShirtVariant(variant)
-	if err := r.db.WithContext(ctx).Save(row).Error; err != nil {
+	if err := r.db.WithContext(ctx).Omit("archived_at").Save(row).Error; err != nil {
 		return err
 	}
 	variant.CreatedAt = row.CreatedAt
@@ -391,7 +454,12 @@ func (r *Repository) UpdateShirtVariant(ctx context.Context, variant *article.Sh
 }
 
 func (r *Repository) DeleteShirtVariant(ctx context.Context, id int) error {
-	return r.db.WithContext(ctx).Delete(&shirtVariantRow{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deleteVariantGallery(tx, "article_shirt_variants", id); err != nil {
+			return err
+		}
+		return tx.Delete(&shirtVariantRow{}, id).Error
+	})
 }
 
 // --- Details & pricing ---
@@ -494,6 +562,9 @@ func (r *Repository) GetCostCalculation(ctx context.Context, articleID int) (*ar
 		return nil, err
 	}
 	res := toCostCalculation(&row)
+	if res.Tiers, err = r.listPriceTiers(ctx, row.ID); err != nil {
+		return nil, err
+	}
 	return &res, nil
 }
 
@@ -507,6 +578,9 @@ func (r *Repository) GetCostCalculationByID(ctx context.Context, id int) (*artic
 		return nil, err
 	}
 	res := toCostCalculation(&row)
+	if res.Tiers, err = r.listPriceTiers(ctx, row.ID); err != nil {
+		return nil, err
+	}
 	return &res, nil
 }
 
@@ -516,26 +590,28 @@ func (r *Repository) UpsertCostCalculation(ctx context.Context, articleID int, c
 	}
 	row := fromCostCalculation(calc)
 	row.ArticleID = &articleID
-	var existing priceRow
-	err := r.db.WithContext(ctx).First(&existing, "article_id = ?", articleID).Error
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing priceRow
+		err := tx.First(&existing, "article_id = ?", articleID).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(row).Error; err != nil {
+				return err
+			}
+		case err != nil:
 			return err
+		default:
+			row.ID = existing.ID
+			row.CreatedAt = existing.CreatedAt
+			if err := tx.Save(row).Error; err != nil {
+				return err
+			}
 		}
-		calc.ID = row.ID
-		calc.ArticleID = row.ArticleID
-		calc.CreatedAt = row.CreatedAt
-		calc.UpdatedAt = row.UpdatedAt
-		return nil
-	}
+		return recordPriceHistory(tx, row)
+	})
 	if err != nil {
 		return err
 	}
-	row.ID = existing.ID
-	row.CreatedAt = existing.CreatedAt
-	if err := r.db.WithContext(ctx).Save(row).Error; err != nil {
-		return err
-	}
 	calc.ID = row.ID
 	calc.ArticleID = row.ArticleID
 	calc.CreatedAt = row.CreatedAt
@@ -543,6 +619,34 @@ func (r *Repository) UpsertCostCalculation(ctx context.Context, articleID int, c
 	return nil
 }
 
+func (r *Repository) listPriceTiers(ctx context.Context, priceID int) ([]article.PriceTier, error) {
+	var rows []priceTierRow
+	if err := r.db.WithContext(ctx).Where("price_id = ?", priceID).Order("min_quantity asc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]article.PriceTier, 0, len(rows))
+	for i := range rows {
+		out = append(out, toPriceTier(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) ReplacePriceTiers(ctx context.Context, priceID int, tiers []article.PriceTier) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("price_id = ?", priceID).Delete(&priceTierRow{}).Error; err != nil {
+			return err
+		}
+		for i := range tiers {
+			row := priceTierRow{PriceID: priceID, MinQuantity: tiers[i].MinQuantity, SalesTotalGross: tiers[i].SalesTotalGross}
+			if err := tx.Create(&row).Error; err != nil {
+				return err
+			}
+			tiers[i] = toPriceTier(&row)
+		}
+		return nil
+	})
+}
+
 func (r *Repository) DeleteCostCalculation(ctx context.Context, articleID int) error {
 	return r.db.WithContext(ctx).Delete(&priceRow{}, "article_id = ?", articleID).Error
 }
@@ -550,9 +654,33 @@ func (r *Repository) DeleteCostCalculation(ctx context.Context, articleID int) e
 // --- Listings & helpers ---
 
 func (r *Repository) ListMugArticles(ctx context.Context, onlyActive bool, excludeID *int) ([]article.Article, error) {
-	tx := r.db.WithContext(ctx).Where("article_type = ?", article.ArticleTypeMug)
+	return r.listArticlesByType(ctx, article.ArticleTypeMug, onlyActive, excludeID)
+}
+
+func (r *Repository) ListShirtArticles(ctx context.Context, onlyActive bool, excludeID *int) ([]article.Article, error) {
+	return r.listArticlesByType(ctx, article.ArticleTypeShirt, onlyActive, excludeID)
+}
+
+func (r *Repository) ListActiveArticles(ctx context.Context, categoryID *int) ([]article.Article, error) {
+	tx := r.db.WithContext(ctx).Where("active = ? AND archived_at IS NULL", true)
+	if categoryID != nil {
+		tx = tx.Where("category_id = ?", *categoryID)
+	}
+	var rows []articleRow
+	if err := tx.Order("id desc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]article.Article, 0, len(rows))
+	for i := range rows {
+		out = append(out, toArticle(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) listArticlesByType(ctx context.Context, articleType string, onlyActive bool, excludeID *int) ([]article.Article, error) {
+	tx := r.db.WithContext(ctx).Where("article_type = ?", articleType)
 	if onlyActive {
-		tx = tx.Where("active = ?", true)
+		tx = tx.Where("active = ? AND archived_at IS NULL", true)
 	}
 	if excludeID != nil {
 		tx = tx.Where("id <> ?", *excludeID)
@@ -568,12 +696,93 @@ func (r *Repository) ListMugArticles(ctx context.Context, onlyActive bool, exclu
 	return out, nil
 }
 
+func (r *Repository) ListMugCatalog(ctx context.Context) ([]article.MugCatalogEntry, error) {
+	var articles []articleRow
+	if err := r.db.WithContext(ctx).
+		Where("article_type = ? AND active = ? AND archived_at IS NULL", article.ArticleTypeMug, true).
+		Order("id desc").
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	if len(articles) == 0 {
+		return []article.MugCatalogEntry{}, nil
+	}
+	ids := make([]int, 0, len(articles))
+	for i := range articles {
+		ids = append(ids, articles[i].ID)
+	}
+
+	var details []mugDetailsRow
+	if err := r.db.WithContext(ctx).Where("article_id IN ?", ids).Find(&details).Error; err != nil {
+		return nil, err
+	}
+	var variants []mugVariantRow
+	if err := r.db.WithContext(ctx).
+		Where("article_id IN ? AND active = ? AND archived_at IS NULL", ids, true).
+		Order("id asc").
+		Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	var gallery []galleryImageRow
+	if err := r.db.WithContext(ctx).Where("article_id IN ?", ids).Order("position asc, id asc").Find(&gallery).Error; err != nil {
+		return nil, err
+	}
+	var prices []priceRow
+	if err := r.db.WithContext(ctx).Where("article_id IN ?", ids).Find(&prices).Error; err != nil {
+		return nil, err
+	}
+
+	galleryByArticle := groupGalleryImages(gallery)
+	detailsByArticle := make(map[int]article.MugDetails, len(details))
+	for i := range details {
+		detailsByArticle[details[i].ArticleID] = toMugDetails(&details[i])
+	}
+	variantsByArticle := make(map[int][]article.MugVariant)
+	for i := range variants {
+		variantsByArticle[variants[i].ArticleID] = append(variantsByArticle[variants[i].ArticleID], toMugVariant(&variants[i]))
+	}
+	pricesByArticle := make(map[int]*article.Price, len(prices))
+	for i := range prices {
+		if prices[i].ArticleID == nil {
+			continue
+		}
+		price := toCostCalculation(&prices[i])
+		pricesByArticle[*prices[i].ArticleID] = &price
+	}
+
+	out := make([]article.MugCatalogEntry, 0, len(articles))
+	for i := range articles {
+		a := articles[i]
+		md, ok := detailsByArticle[a.ID]
+		if !ok {
+			continue
+		}
+		vs := variantsByArticle[a.ID]
+		if vs == nil {
+			vs = []article.MugVariant{}
+		}
+		images := galleryByArticle[a.ID]
+		if images == nil {
+			images = []article.GalleryImage{}
+		}
+		out = append(out, article.MugCatalogEntry{
+			Article:  toArticle(&a),
+			Details:  md,
+			Variants: vs,
+			Gallery:  images,
+			Price:    pricesByArticle[a.ID],
+		})
+	}
+	return out, nil
+}
+
 // --- Conversion helpers ---
 
 func toArticleCategory(row *articleCategoryRow) article.ArticleCategory {
 	return article.ArticleCategory{
 		ID:          row.ID,
 		Name:        row.Name,
+		Slug:        row.Slug,
 		Description: row.Description,
 		CreatedAt:   row.CreatedAt,
 		UpdatedAt:   row.UpdatedAt,
@@ -587,6 +796,7 @@ func fromArticleCategory(cat *article.ArticleCategory) *articleCategoryRow {
 	return &articleCategoryRow{
 		ID:          cat.ID,
 		Name:        cat.Name,
+		Slug:        cat.Slug,
 		Description: cat.Description,
 		CreatedAt:   cat.CreatedAt,
 		UpdatedAt:   cat.UpdatedAt,
@@ -622,6 +832,7 @@ func toArticle(row *articleRow) article.Article {
 	return article.Article{
 		ID:                    row.ID,
 		Name:                  row.Name,
+		Slug:                  row.Slug,
 		DescriptionShort:      row.DescriptionShort,
 		DescriptionLong:       row.DescriptionLong,
 		Active:                row.Active,
@@ -631,6 +842,8 @@ func toArticle(row *articleRow) article.Article {
 		SupplierID:            row.SupplierID,
 		SupplierArticleName:   row.SupplierArticleName,
 		SupplierArticleNumber: row.SupplierArticleNumber,
+		Attributes:            decodeAttributes(row.Attributes),
+		ArchivedAt:            row.ArchivedAt,
 		CreatedAt:             row.CreatedAt,
 		UpdatedAt:             row.UpdatedAt,
 	}
@@ -643,6 +856,7 @@ func fromArticle(art *article.Article) *articleRow {
 	return &articleRow{
 		ID:                    art.ID,
 		Name:                  art.Name,
+		Slug:                  art.Slug,
 		DescriptionShort:      art.DescriptionShort,
 		DescriptionLong:       art.DescriptionLong,
 		Active:                art.Active,
@@ -652,12 +866,22 @@ func fromArticle(art *article.Article) *articleRow {
 		SupplierID:            art.SupplierID,
 		SupplierArticleName:   art.SupplierArticleName,
 		SupplierArticleNumber: art.SupplierArticleNumber,
+		Attributes:            encodeAttributes(art.Attributes),
+		ArchivedAt:            art.ArchivedAt,
 		CreatedAt:             art.CreatedAt,
 		UpdatedAt:             art.UpdatedAt,
 	}
 }
 
 func toMugVariant(row *mugVariantRow) article.MugVariant {
+	var printArea *img.MockupQuad
+	if row.MockupPrintArea != nil {
+		// Rows are validated on write; skip anything unreadable rather than
+		// failing every variant read.
+		if q, err := img.ParseMockupQuad(*row.MockupPrintArea); err == nil {
+			printArea = &q
+		}
+	}
 	return article.MugVariant{
 		ID:                   row.ID,
 		ArticleID:            row.ArticleID,
@@ -668,6 +892,9 @@ func toMugVariant(row *mugVariantRow) article.MugVariant {
 		ArticleVariantNumber: row.ArticleVariantNumber,
 		IsDefault:            row.IsDefault,
 		Active:               row.Active,
+		MockupPrintArea:      printArea,
+		MockupCurvature:      row.MockupCurvature,
+		ArchivedAt:           row.ArchivedAt,
 		CreatedAt:            row.CreatedAt,
 		UpdatedAt:            row.UpdatedAt,
 	}
@@ -677,6 +904,11 @@ func fromMugVariant(v *article.MugVariant) *mugVariantRow {
 	if v == nil {
 		return nil
 	}
+	var printArea *string
+	if v.MockupPrintArea != nil {
+		s := v.MockupPrintArea.String()
+		printArea = &s
+	}
 	return &mugVariantRow{
 		ID:                   v.ID,
 		ArticleID:            v.ArticleID,
@@ -687,6 +919,9 @@ func fromMugVariant(v *article.MugVariant) *mugVariantRow {
 		ArticleVariantNumber: v.ArticleVariantNumber,
 		IsDefault:            v.IsDefault,
 		Active:               v.Active,
+		MockupPrintArea:      printArea,
+		MockupCurvature:      v.MockupCurvature,
+		ArchivedAt:           v.ArchivedAt,
 		CreatedAt:            v.CreatedAt,
 		UpdatedAt:            v.UpdatedAt,
 	}
@@ -699,6 +934,7 @@ func toShirtVariant(row *shirtVariantRow) article.ShirtVariant {
 		Color:                row.Color,
 		Size:                 row.Size,
 		ExampleImageFilename: row.ExampleImageFilename,
+		ArchivedAt:           row.ArchivedAt,
 		CreatedAt:            row.CreatedAt,
 		UpdatedAt:            row.UpdatedAt,
 	}
@@ -714,6 +950,7 @@ func fromShirtVariant(v *article.ShirtVariant) *shirtVariantRow {
 		Color:                v.Color,
 		Size:                 v.Size,
 		ExampleImageFilename: v.ExampleImageFilename,
+		ArchivedAt:           v.ArchivedAt,
 		CreatedAt:            v.CreatedAt,
 		UpdatedAt:            v.UpdatedAt,
 	}
@@ -758,13 +995,16 @@ func fromMugDetails(d *article.MugDetails) *mugDetailsRow {
 
 func toShirtDetails(row *shirtDetailsRow) article.ShirtDetails {
 	return article.ShirtDetails{
-		ArticleID:        row.ArticleID,
-		Material:         row.Material,
-		CareInstructions: row.CareInstructions,
-		FitType:          row.FitType,
-		AvailableSizes:   row.AvailableSizes,
-		CreatedAt:        row.CreatedAt,
-		UpdatedAt:        row.UpdatedAt,
+		ArticleID:         row.ArticleID,
+		Material:          row.Material,
+		CareInstructions:  row.CareInstructions,
+		FitType:           row.FitType,
+		AvailableSizes:    row.AvailableSizes,
+		PrintAreaWidthMm:  row.PrintAreaWidthMm,
+		PrintAreaHeightMm: row.PrintAreaHeightMm,
+		PrintAreaPosition: row.PrintAreaPosition,
+		CreatedAt:         row.CreatedAt,
+		UpdatedAt:         row.UpdatedAt,
 	}
 }
 
@@ -773,61 +1013,30 @@ func fromShirtDetails(d *article.ShirtDetails) *shirtDetailsRow {
 		return nil
 	}
 	return &shirtDetailsRow{
-		ArticleID:        d.ArticleID,
-		Material:         d.Material,
-		CareInstructions: d.CareInstructions,
-		FitType:          d.FitType,
-		AvailableSizes:   d.AvailableSizes,
-		CreatedAt:        d.CreatedAt,
-		UpdatedAt:        d.UpdatedAt,
+		ArticleID:         d.ArticleID,
+		Material:          d.Material,
+		CareInstructions:  d.CareInstructions,
+		FitType:           d.FitType,
+		AvailableSizes:    d.AvailableSizes,
+		PrintAreaWidthMm:  d.PrintAreaWidthMm,
+		PrintAreaHeightMm: d.PrintAreaHeightMm,
+		PrintAreaPosition: d.PrintAreaPosition,
+		CreatedAt:         d.CreatedAt,
+		UpdatedAt:         d.UpdatedAt,
 	}
 }
 
 func toCostCalculation(row *priceRow) article.Price {
-	return article.Price{
-		ID:                       row.ID,
-		ArticleID:                row.ArticleID,
-		PurchasePriceNet:         row.PurchasePriceNet,
-		PurchasePriceTax:         row.PurchasePriceTax,
-		PurchasePriceGross:       row.PurchasePriceGross,
-		PurchaseCostNet:          row.PurchaseCostNet,
-		PurchaseCostTax:          row.PurchaseCostTax,
-		PurchaseCostGross:        row.PurchaseCostGross,
-		PurchaseCostPercent:      row.PurchaseCostPercent,
-		PurchaseTotalNet:         row.PurchaseTotalNet,
-		PurchaseTotalTax:         row.PurchaseTotalTax,
-		PurchaseTotalGross:       row.PurchaseTotalGross,
-		PurchasePriceUnit:        row.PurchasePriceUnit,
-		PurchaseVatRateID:        row.PurchaseVatRateID,
-		PurchaseVatRatePercent:   row.PurchaseVatRatePercent,
-		PurchaseCalculationMode:  row.PurchaseCalculationMode,
-		SalesVatRateID:           row.SalesVatRateID,
-		SalesVatRatePercent:      row.SalesVatRatePercent,
-		SalesMarginNet:           row.SalesMarginNet,
-		SalesMarginTax:           row.SalesMarginTax,
-		SalesMarginGross:         row.SalesMarginGross,
-		SalesMarginPercent:       row.SalesMarginPercent,
-		SalesTotalNet:            row.SalesTotalNet,
-		SalesTotalTax:            row.SalesTotalTax,
-		SalesTotalGross:          row.SalesTotalGross,
-		SalesPriceUnit:           row.SalesPriceUnit,
-		SalesCalculationMode:     row.SalesCalculationMode,
-		PurchasePriceCorresponds: row.PurchasePriceCorresponds,
-		SalesPriceCorresponds:    row.SalesPriceCorresponds,
-		PurchaseActiveRow:        row.PurchaseActiveRow,
-		SalesActiveRow:           row.SalesActiveRow,
-		CreatedAt:                row.CreatedAt,
-		UpdatedAt:                row.UpdatedAt,
-	}
+	p := toPriceCalculation(&row.Calculation)
+	p.ID = row.ID
+	p.ArticleID = row.ArticleID
+	p.CreatedAt = row.CreatedAt
+	p.UpdatedAt = row.UpdatedAt
+	return p
 }
 
-func fromCostCalculation(c *article.Price) *priceRow {
-	if c == nil {
-		return nil
-	}
-	return &priceRow{
-		ID:                       c.ID,
-		ArticleID:                c.ArticleID,
+func toPriceCalculation(c *priceCalculation) article.Price {
+	return article.Price{
 		PurchasePriceNet:         c.PurchasePriceNet,
 		PurchasePriceTax:         c.PurchasePriceTax,
 		PurchasePriceGross:       c.PurchasePriceGross,
@@ -857,7 +1066,63 @@ func fromCostCalculation(c *article.Price) *priceRow {
 		SalesPriceCorresponds:    c.SalesPriceCorresponds,
 		PurchaseActiveRow:        c.PurchaseActiveRow,
 		SalesActiveRow:           c.SalesActiveRow,
-		CreatedAt:                c.CreatedAt,
-		UpdatedAt:                c.UpdatedAt,
+	}
+}
+
+func toPriceTier(row *priceTierRow) article.PriceTier {
+	return article.PriceTier{
+		ID:              row.ID,
+		PriceID:         row.PriceID,
+		MinQuantity:     row.MinQuantity,
+		SalesTotalGross: row.SalesTotalGross,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	}
+}
+
+func fromCostCalculation(c *article.Price) *priceRow {
+	if c == nil {
+		return nil
+	}
+	return &priceRow{
+		ID:          c.ID,
+		ArticleID:   c.ArticleID,
+		Calculation: fromPriceCalculation(c),
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+func fromPriceCalculation(p *article.Price) priceCalculation {
+	return priceCalculation{
+		PurchasePriceNet:         p.PurchasePriceNet,
+		PurchasePriceTax:         p.PurchasePriceTax,
+		PurchasePriceGross:       p.PurchasePriceGross,
+		PurchaseCostNet:          p.PurchaseCostNet,
+		PurchaseCostTax:          p.PurchaseCostTax,
+		PurchaseCostGross:        p.PurchaseCostGross,
+		PurchaseCostPercent:      p.PurchaseCostPercent,
+		PurchaseTotalNet:         p.PurchaseTotalNet,
+		PurchaseTotalTax:         p.PurchaseTotalTax,
+		PurchaseTotalGross:       p.PurchaseTotalGross,
+		PurchasePriceUnit:        p.PurchasePriceUnit,
+		PurchaseVatRateID:        p.PurchaseVatRateID,
+		PurchaseVatRatePercent:   p.PurchaseVatRatePercent,
+		PurchaseCalculationMode:  p.PurchaseCalculationMode,
+		SalesVatRateID:           p.SalesVatRateID,
+		SalesVatRatePercent:      p.SalesVatRatePercent,
+		SalesMarginNet:           p.SalesMarginNet,
+		SalesMarginTax:           p.SalesMarginTax,
+		SalesMarginGross:         p.SalesMarginGross,
+		SalesMarginPercent:       p.SalesMarginPercent,
+		SalesTotalNet:            p.SalesTotalNet,
+		SalesTotalTax:            p.SalesTotalTax,
+		SalesTotalGross:          p.SalesTotalGross,
+		SalesPriceUnit:           p.SalesPriceUnit,
+		SalesCalculationMode:     p.SalesCalculationMode,
+		PurchasePriceCorresponds: p.PurchasePriceCorresponds,
+		SalesPriceCorresponds:    p.SalesPriceCorresponds,
+		PurchaseActiveRow:        p.PurchaseActiveRow,
+		SalesActiveRow:           p.SalesActiveRow,
 	}
 }
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestListMugCatalogUsesConstantQueries(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&articleCategoryRow{}, &articleSubCategoryRow{}, &articleRow{}, &mugVariantRow{}, &shirtVariantRow{}, &mugDetailsRow{}, &priceRow{}, &galleryImageRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	for id := 1; id <= 3; id++ {
+		articleID := id
+		if err := db.Create(&articleRow{ID: id, Name: "Mug", Slug: "mug-" + strconv.Itoa(id), Active: true, ArticleType: "MUG", CategoryID: 1}).Error; err != nil {
+			t.Fatalf("seed article: %v", err)
+		}
+		if id != 3 {
+			if err := db.Create(&mugDetailsRow{ArticleID: id, HeightMm: 95}).Error; err != nil {
+				t.Fatalf("seed details: %v", err)
+			}
+		}
+		if err := db.Create(&mugVariantRow{ArticleID: id, Name: "White", Active: true}).Error; err != nil {
+			t.Fatalf("seed variant: %v", err)
+		}
+		hidden := mugVariantRow{ArticleID: id, Name: "Hidden"}
+		if err := db.Create(&hidden).Error; err != nil {
+			t.Fatalf("seed inactive variant: %v", err)
+		}
+		if err := db.Model(&hidden).Update("active", false).Error; err != nil {
+			t.Fatalf("deactivate variant: %v", err)
+		}
+		if err := db.Create(&priceRow{ArticleID: &articleID, Calculation: priceCalculation{SalesTotalGross: 1000 * id}}).Error; err != nil {
+			t.Fatalf("seed price: %v", err)
+		}
+	}
+	inactive := articleRow{ID: 4, Name: "Inactive", ArticleType: "MUG", CategoryID: 1}
+	if err := db.Create(&inactive).Error; err != nil {
+		t.Fatalf("seed inactive article: %v", err)
+	}
+	if err := db.Model(&inactive).Update("active", false).Error; err != nil {
+		t.Fatalf("deactivate article: %v", err)
+	}
+	if err := db.Create(&mugDetailsRow{ArticleID: 4, HeightMm: 95}).Error; err != nil {
+		t.Fatalf("seed inactive details: %v", err)
+	}
+
+	queries := 0
+	if err := db.Callback().Query().After("gorm:query").Register("count_queries", func(*gorm.DB) { queries++ }); err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+
+	entries, err := NewRepository(db).ListMugCatalog(context.Background())
+	if err != nil {
+		t.Fatalf("list catalog: %v", err)
+	}
+	if queries != 5 {
+		t.Fatalf("queries = %d, want 5", queries)
+	}
+	if len(entries) != 2 || entries[0].Article.ID != 2 || entries[1].Article.ID != 1 {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	if len(entries[0].Variants) != 1 || entries[0].Variants[0].Name != "White" {
+		t.Fatalf("expected only the active variant, got %+v", entries[0].Variants)
+	}
+	if entries[0].Price == nil || entries[0].Price.SalesTotalGross != 2000 {
+		t.Fatalf("unexpected price: %+v", entries[0].Price)
+	}
+}
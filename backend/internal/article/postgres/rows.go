@@ -7,6 +7,7 @@ import (
 type articleCategoryRow struct {
 	ID          int     `gorm:"primaryKey"`
 	Name        string  `gorm:"size:255;not null"`
+	Slug        string  `gorm:"size:255;not null;uniqueIndex"`
 	Description *string `gorm:"type:text"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
@@ -29,6 +30,7 @@ func (articleSubCategoryRow) TableName() string { return "article_sub_categories
 type articleRow struct {
 	ID                    int                    `gorm:"primaryKey"`
 	Name                  string                 `gorm:"size:255;not null"`
+	Slug                  string                 `gorm:"size:255;not null;uniqueIndex"`
 	DescriptionShort      string                 `gorm:"type:text;not null"`
 	DescriptionLong       string                 `gorm:"type:text;not null"`
 	Active                bool                   `gorm:"not null;default:true"`
@@ -40,9 +42,11 @@ type articleRow struct {
 	SupplierID            *int                   `gorm:"column:supplier_id"`
 	SupplierArticleName   *string                `gorm:"column:supplier_article_name;size:255"`
 	SupplierArticleNumber *string                `gorm:"column:supplier_article_number;size:100"`
+	Attributes            string                 `gorm:"type:jsonb;not null;default:'{}'"`
 	MugVariants           []mugVariantRow        `gorm:"foreignKey:ArticleID;references:ID"`
 	ShirtVariants         []shirtVariantRow      `gorm:"foreignKey:ArticleID;references:ID"`
 	CostCalculation       *priceRow              `gorm:"foreignKey:ArticleID;references:ID"`
+	ArchivedAt            *time.Time             `gorm:"column:archived_at"`
 	CreatedAt             time.Time
 	UpdatedAt             time.Time
 }
@@ -53,13 +57,16 @@ type mugVariantRow struct {
 	ID                   int `gorm:"primaryKey"`
 	ArticleID            int `gorm:"column:article_id;not null"`
 	Article              *articleRow
-	InsideColorCode      string  `gorm:"size:7;not null"`
-	OutsideColorCode     string  `gorm:"size:7;not null"`
-	Name                 string  `gorm:"size:255;not null"`
-	ExampleImageFilename *string `gorm:"size:500;column:example_image_filename"`
-	ArticleVariantNumber *string `gorm:"size:100;column:article_variant_number"`
-	IsDefault            bool    `gorm:"column:is_default;not null;default:false"`
-	Active               bool    `gorm:"not null;default:true"`
+	InsideColorCode      string     `gorm:"size:7;not null"`
+	OutsideColorCode     string     `gorm:"size:7;not null"`
+	Name                 string     `gorm:"size:255;not null"`
+	ExampleImageFilename *string    `gorm:"size:500;column:example_image_filename"`
+	ArticleVariantNumber *string    `gorm:"size:100;column:article_variant_number"`
+	IsDefault            bool       `gorm:"column:is_default;not null;default:false"`
+	Active               bool       `gorm:"not null;default:true"`
+	MockupPrintArea      *string    `gorm:"size:255;column:mockup_print_area"`
+	MockupCurvature      float64    `gorm:"not null;default:0;column:mockup_curvature"`
+	ArchivedAt           *time.Time `gorm:"column:archived_at"`
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 }
@@ -70,15 +77,68 @@ type shirtVariantRow struct {
 	ID                   int `gorm:"primaryKey"`
 	ArticleID            int `gorm:"column:article_id;not null"`
 	Article              *articleRow
-	Color                string  `gorm:"size:100;not null"`
-	Size                 string  `gorm:"size:50;not null"`
-	ExampleImageFilename *string `gorm:"size:500;column:example_image_filename"`
+	Color                string     `gorm:"size:100;not null"`
+	Size                 string     `gorm:"size:50;not null"`
+	ExampleImageFilename *string    `gorm:"size:500;column:example_image_filename"`
+	ArchivedAt           *time.Time `gorm:"column:archived_at"`
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 }
 
 func (shirtVariantRow) TableName() string { return "article_shirt_variants" }
 
+type articleTypeRow struct {
+	Code            string  `gorm:"primaryKey;size:50"`
+	Name            string  `gorm:"size:255;not null"`
+	Description     *string `gorm:"type:text"`
+	BuiltIn         bool    `gorm:"column:built_in;not null;default:false"`
+	AttributeSchema string  `gorm:"column:attribute_schema;type:jsonb;not null;default:'[]'"`
+	OptionAxes      string  `gorm:"column:option_axes;type:jsonb;not null;default:'[]'"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (articleTypeRow) TableName() string { return "article_types" }
+
+// attributeDefinitionJSON is the stored form of an attribute definition in
+// article_types.attribute_schema.
+type attributeDefinitionJSON struct {
+	Key      string   `json:"key"`
+	Label    string   `json:"label"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"`
+	Unit     string   `json:"unit,omitempty"`
+}
+
+type articleVariantRow struct {
+	ID                   int        `gorm:"primaryKey"`
+	ArticleID            int        `gorm:"column:article_id;not null"`
+	Options              string     `gorm:"type:jsonb;not null;default:'{}'"`
+	OptionsKey           string     `gorm:"column:options_key;size:500;not null"`
+	ArticleVariantNumber *string    `gorm:"size:100;column:article_variant_number"`
+	ExampleImageFilename *string    `gorm:"size:500;column:example_image_filename"`
+	IsDefault            bool       `gorm:"column:is_default;not null;default:false"`
+	Active               bool       `gorm:"not null;default:true"`
+	ArchivedAt           *time.Time `gorm:"column:archived_at"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (articleVariantRow) TableName() string { return "article_variants" }
+
+type printAreaRow struct {
+	ID        int    `gorm:"primaryKey"`
+	ArticleID int    `gorm:"column:article_id;not null"`
+	Name      string `gorm:"size:100;not null"`
+	WidthMm   int    `gorm:"column:width_mm;not null"`
+	HeightMm  int    `gorm:"column:height_mm;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (printAreaRow) TableName() string { return "article_print_areas" }
+
 type mugDetailsRow struct {
 	ArticleID                    int     `gorm:"primaryKey;column:article_id"`
 	HeightMm                     int     `gorm:"not null;column:height_mm"`
@@ -102,17 +162,30 @@ type shirtDetailsRow struct {
 	CareInstructions *string `gorm:"type:text;column:care_instructions"`
 	FitType          string  `gorm:"size:50;not null;column:fit_type"`
 	// stored as comma-separated string for simplicity across sqlite/postgres
-	AvailableSizes string `gorm:"type:text;not null;column:available_sizes"`
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	AvailableSizes    string `gorm:"type:text;not null;column:available_sizes"`
+	PrintAreaWidthMm  int    `gorm:"not null;default:0;column:print_area_width_mm"`
+	PrintAreaHeightMm int    `gorm:"not null;default:0;column:print_area_height_mm"`
+	PrintAreaPosition string `gorm:"size:20;not null;default:FRONT;column:print_area_position"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (shirtDetailsRow) TableName() string { return "article_shirt_details" }
 
 type priceRow struct {
-	ID        int  `gorm:"primaryKey"`
-	ArticleID *int `gorm:"uniqueIndex;column:article_id"`
-	Article   *articleRow
+	ID          int  `gorm:"primaryKey"`
+	ArticleID   *int `gorm:"uniqueIndex;column:article_id"`
+	Article     *articleRow
+	Calculation priceCalculation `gorm:"embedded"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (priceRow) TableName() string { return "prices" }
+
+// priceCalculation holds the price columns shared by prices and their
+// history entries.
+type priceCalculation struct {
 	// Purchase section
 	PurchasePriceNet        int     `gorm:"not null;column:purchase_price_net"`
 	PurchasePriceTax        int     `gorm:"not null;column:purchase_price_tax"`
@@ -145,8 +218,78 @@ type priceRow struct {
 	SalesPriceCorresponds    string `gorm:"size:10;not null;column:sales_price_corresponds"`
 	PurchaseActiveRow        string `gorm:"size:20;not null;column:purchase_active_row"`
 	SalesActiveRow           string `gorm:"size:20;not null;column:sales_active_row"`
-	CreatedAt                time.Time
-	UpdatedAt                time.Time
 }
 
-func (priceRow) TableName() string { return "prices" }
+type priceHistoryRow struct {
+	ID            int              `gorm:"primaryKey"`
+	PriceID       int              `gorm:"column:price_id;not null;index"`
+	EffectiveFrom time.Time        `gorm:"column:effective_from;not null"`
+	AppliedAt     *time.Time       `gorm:"column:applied_at"`
+	Calculation   priceCalculation `gorm:"embedded"`
+	CreatedAt     time.Time
+}
+
+func (priceHistoryRow) TableName() string { return "price_history" }
+
+type priceTierRow struct {
+	ID              int `gorm:"primaryKey"`
+	PriceID         int `gorm:"column:price_id;not null"`
+	MinQuantity     int `gorm:"column:min_quantity;not null"`
+	SalesTotalGross int `gorm:"column:sales_total_gross;not null"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (priceTierRow) TableName() string { return "price_tiers" }
+
+// articleSlugRedirectRow remembers a slug an article had before it was
+// changed so old links keep resolving.
+type articleSlugRedirectRow struct {
+	Slug      string `gorm:"primaryKey;size:255"`
+	ArticleID int    `gorm:"column:article_id;not null"`
+	CreatedAt time.Time
+}
+
+func (articleSlugRedirectRow) TableName() string { return "article_slug_redirects" }
+
+type articleCategorySlugRedirectRow struct {
+	Slug       string `gorm:"primaryKey;size:255"`
+	CategoryID int    `gorm:"column:category_id;not null"`
+	CreatedAt  time.Time
+}
+
+func (articleCategorySlugRedirectRow) TableName() string { return "article_category_slug_redirects" }
+
+type galleryImageRow struct {
+	ID        int    `gorm:"primaryKey"`
+	ArticleID int    `gorm:"column:article_id;not null;index"`
+	VariantID *int   `gorm:"column:variant_id"`
+	Filename  string `gorm:"size:500;not null"`
+	AltText   string `gorm:"column:alt_text;size:500;not null;default:''"`
+	Position  int    `gorm:"not null;default:0"`
+	IsPrimary bool   `gorm:"column:is_primary;not null;default:false"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (galleryImageRow) TableName() string { return "article_gallery_images" }
+
+type articleRelationRow struct {
+	ID               int  `gorm:"primaryKey"`
+	ArticleID        int  `gorm:"column:article_id;not null;index"`
+	RelatedArticleID int  `gorm:"column:related_article_id;not null"`
+	RelatedVariantID *int `gorm:"column:related_variant_id"`
+	Position         int  `gorm:"not null;default:0"`
+	CreatedAt        time.Time
+}
+
+func (articleRelationRow) TableName() string { return "article_relations" }
+
+type boughtTogetherRow struct {
+	ArticleID        int `gorm:"column:article_id;primaryKey"`
+	RelatedArticleID int `gorm:"column:related_article_id;primaryKey"`
+	OrderCount       int `gorm:"column:order_count;not null"`
+	UpdatedAt        time.Time
+}
+
+func (boughtTogetherRow) TableName() string { return "article_bought_together" }
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+	"voenix/backend/internal/search"
+	searchPg "voenix/backend/internal/search/postgres"
+)
+
+func TestArticleWritesUpdateSearchIndex(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&articleCategoryRow{}, &articleSubCategoryRow{}, &articleRow{}, &articleTypeRow{},
+		&articleVariantRow{}, &printAreaRow{}, &mugVariantRow{}, &shirtVariantRow{}, &mugDetailsRow{}, &shirtDetailsRow{},
+		&priceRow{}, &priceHistoryRow{}, &priceTierRow{}, &articleSlugRedirectRow{}, &articleCategorySlugRedirectRow{}, &galleryImageRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	for _, stmt := range []string{
+		"CREATE TABLE carts (id integer primary key, status text)",
+		"CREATE TABLE cart_items (id integer primary key, cart_id integer, article_id integer, variant_id integer)",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+	}
+	if err := db.Create(&articleTypeRow{Code: article.ArticleTypeMug, Name: "Mug", BuiltIn: true}).Error; err != nil {
+		t.Fatalf("seed built-in type: %v", err)
+	}
+	if err := db.Create(&articleCategoryRow{ID: 1, Name: "Mugs", Slug: "mugs"}).Error; err != nil {
+		t.Fatalf("seed category: %v", err)
+	}
+	ctx := context.Background()
+	svc := article.NewService(NewRepository(db))
+	index := search.NewService(searchPg.NewRepository(db), svc)
+	svc.SetSearchIndex(index)
+
+	find := func(text string) []search.Result {
+		t.Helper()
+		results, err := index.Search(ctx, search.Query{Text: text})
+		if err != nil {
+			t.Fatalf("search %q: %v", text, err)
+		}
+		return results
+	}
+
+	a := article.Article{Name: "Thermobecher", DescriptionShort: "Keeps coffee hot", ArticleType: article.ArticleTypeMug, CategoryID: 1, Active: true}
+	if _, err := svc.CreateArticle(ctx, &a, &article.MugDetails{HeightMm: 95}, nil, nil, nil, nil); err != nil {
+		t.Fatalf("create article: %v", err)
+	}
+	if got := find("thermobeher"); len(got) != 1 || got[0].RefID != a.ID || got[0].Path != "/articles/"+a.Slug {
+		t.Fatalf("created article should be found despite the typo: %+v", got)
+	}
+
+	name := "Travel cups"
+	if _, err := svc.UpdateCategory(ctx, 1, &name, nil, nil); err != nil {
+		t.Fatalf("rename category: %v", err)
+	}
+	if got := find("travel"); len(got) != 1 {
+		t.Fatalf("article should be found by its new category name: %+v", got)
+	}
+
+	if _, err := svc.ArchiveArticle(ctx, a.ID); err != nil {
+		t.Fatalf("archive article: %v", err)
+	}
+	if got := find("thermobecher"); len(got) != 0 {
+		t.Fatalf("archived article should leave the index: %+v", got)
+	}
+	if _, err := svc.RestoreArticle(ctx, a.ID); err != nil {
+		t.Fatalf("restore article: %v", err)
+	}
+	if got := find("thermobecher"); len(got) != 1 {
+		t.Fatalf("restored article should be found again: %+v", got)
+	}
+}
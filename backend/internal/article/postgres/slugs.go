@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// --- Slug lookups ---
+
+func (r *Repository) ArticleIDBySlug(ctx context.Context, slug string) (int, error) {
+	var row articleRow
+	err := r.db.WithContext(ctx).Select("id").First(&row, "slug = ?", slug).Error
+	if err == nil {
+		return row.ID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	var redirect articleSlugRedirectRow
+	if err := r.db.WithContext(ctx).First(&redirect, "slug = ?", slug).Error; err != nil {
+		return 0, err
+	}
+	return redirect.ArticleID, nil
+}
+
+func (r *Repository) ArticleSlugTaken(ctx context.Context, slug string, exceptID int) (bool, error) {
+	var cnt int64
+	if err := r.db.WithContext(ctx).Model(&articleRow{}).Where("slug = ? AND id <> ?", slug, exceptID).Count(&cnt).Error; err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+func (r *Repository) CategoryIDBySlug(ctx context.Context, slug string) (int, error) {
+	var row articleCategoryRow
+	err := r.db.WithContext(ctx).Select("id").First(&row, "slug = ?", slug).Error
+	if err == nil {
+		return row.ID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	var redirect articleCategorySlugRedirectRow
+	if err := r.db.WithContext(ctx).First(&redirect, "slug = ?", slug).Error; err != nil {
+		return 0, err
+	}
+	return redirect.CategoryID, nil
+}
+
+func (r *Repository) CategorySlugTaken(ctx context.Context, slug string, exceptID int) (bool, error) {
+	var cnt int64
+	if err := r.db.WithContext(ctx).Model(&articleCategoryRow{}).Where("slug = ? AND id <> ?", slug, exceptID).Count(&cnt).Error; err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+// currentSlug reads the stored slug of the row with id in model's table.
+func currentSlug(tx *gorm.DB, model any, id int) (string, error) {
+	var slug string
+	err := tx.Model(model).Where("id = ?", id).Select("slug").Scan(&slug).Error
+	return slug, err
+}
+
+// moveSlug keeps a redirect table in step with a slug write: the slug now in
+// use leaves the history, and the previous slug, if it changed, is recorded
+// as redirect.
+func moveSlug(tx *gorm.DB, model any, previous, current string, redirect any) error {
+	if err := tx.Where("slug = ?", current).Delete(model).Error; err != nil {
+		return err
+	}
+	if previous == "" || previous == current {
+		return nil
+	}
+	if err := tx.Where("slug = ?", previous).Delete(model).Error; err != nil {
+		return err
+	}
+	return tx.Create(redirect).Error
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+	"voenix/backend/internal/utility"
+)
+
+func TestArticleSlugsAndRedirects(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&articleCategoryRow{}, &articleSubCategoryRow{}, &articleRow{}, &articleTypeRow{},
+		&articleVariantRow{}, &printAreaRow{}, &mugVariantRow{}, &shirtVariantRow{}, &mugDetailsRow{}, &priceRow{},
+		&priceHistoryRow{}, &priceTierRow{}, &articleSlugRedirectRow{}, &articleCategorySlugRedirectRow{}, &galleryImageRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	if err := db.Create(&articleTypeRow{Code: article.ArticleTypeMug, Name: "Mug", BuiltIn: true}).Error; err != nil {
+		t.Fatalf("seed built-in type: %v", err)
+	}
+	ctx := context.Background()
+	svc := article.NewService(NewRepository(db))
+
+	cat, err := svc.CreateCategory(ctx, "Tassen & Becher", nil, nil)
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	if cat.Slug != "tassen-becher" {
+		t.Fatalf("category slug = %q", cat.Slug)
+	}
+
+	newMug := func() article.Article {
+		return article.Article{Name: "Große Tasse", ArticleType: article.ArticleTypeMug, CategoryID: cat.ID, Active: true}
+	}
+	first := newMug()
+	if _, err := svc.CreateArticle(ctx, &first, &article.MugDetails{HeightMm: 95}, nil, nil, nil, nil); err != nil {
+		t.Fatalf("create article: %v", err)
+	}
+	second := newMug()
+	if _, err := svc.CreateArticle(ctx, &second, &article.MugDetails{HeightMm: 95}, nil, nil, nil, nil); err != nil {
+		t.Fatalf("create second article: %v", err)
+	}
+	if first.Slug != "grosse-tasse" || second.Slug != "grosse-tasse-2" {
+		t.Fatalf("slugs = %q, %q", first.Slug, second.Slug)
+	}
+
+	first.Slug = "grosse-tasse-2"
+	if _, err := svc.UpdateArticle(ctx, &first, nil, nil, nil); !errors.Is(err, utility.ErrSlugTaken) {
+		t.Fatalf("taken slug: err = %v", err)
+	}
+	first.Slug = "Jumbo-Tasse"
+	if _, err := svc.UpdateArticle(ctx, &first, nil, nil, nil); err != nil {
+		t.Fatalf("rename slug: %v", err)
+	}
+	detail, err := svc.PublicArticle(ctx, "grosse-tasse")
+	if err != nil {
+		t.Fatalf("resolve former slug: %v", err)
+	}
+	if detail.Article.ID != first.ID || detail.Article.Slug != "jumbo-tasse" {
+		t.Fatalf("former slug resolved to %d/%q", detail.Article.ID, detail.Article.Slug)
+	}
+
+	// Another article may take over the former slug; it then stops redirecting.
+	second.Slug = "grosse-tasse"
+	if _, err := svc.UpdateArticle(ctx, &second, nil, nil, nil); err != nil {
+		t.Fatalf("claim former slug: %v", err)
+	}
+	if detail, err = svc.PublicArticle(ctx, "grosse-tasse"); err != nil || detail.Article.ID != second.ID {
+		t.Fatalf("claimed slug resolved to %d, %v", detail.Article.ID, err)
+	}
+	if detail, err = svc.PublicArticle(ctx, "grosse-tasse-2"); err != nil || detail.Article.ID != second.ID {
+		t.Fatalf("second's former slug resolved to %d, %v", detail.Article.ID, err)
+	}
+
+	second.Active = false
+	if _, err := svc.UpdateArticle(ctx, &second, nil, nil, nil); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	if _, err := svc.PublicArticle(ctx, "grosse-tasse"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("inactive article: err = %v", err)
+	}
+
+	renamed := "becher"
+	if _, err := svc.UpdateCategory(ctx, cat.ID, nil, &renamed, nil); err != nil {
+		t.Fatalf("rename category slug: %v", err)
+	}
+	got, articles, err := svc.PublicCategory(ctx, "tassen-becher")
+	if err != nil {
+		t.Fatalf("resolve former category slug: %v", err)
+	}
+	if got.Slug != "becher" || len(articles) != 1 || articles[0].ID != first.ID {
+		t.Fatalf("category = %q with %d articles", got.Slug, len(articles))
+	}
+
+	entries, err := svc.SitemapEntries(ctx)
+	if err != nil {
+		t.Fatalf("sitemap entries: %v", err)
+	}
+	paths := map[string]bool{}
+	for _, e := range entries {
+		paths[e.Path] = true
+	}
+	if len(paths) != 2 || !paths["/articles/jumbo-tasse"] || !paths["/articles/categories/becher"] {
+		t.Fatalf("sitemap paths = %v", paths)
+	}
+}
@@ -0,0 +1,68 @@
+package article
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrPriceChangeNotInFuture is returned when a price change is scheduled
+	// for a moment that has already passed.
+	ErrPriceChangeNotInFuture = errors.New("price change must take effect in the future")
+	// ErrScheduledPriceChangeNotFound is returned when cancelling a change that
+	// does not exist, belongs to another price or has already taken effect.
+	ErrScheduledPriceChangeNotFound = errors.New("scheduled price change not found")
+)
+
+// Price history entry states relative to a given moment.
+const (
+	PriceHistoryScheduled = "SCHEDULED"
+	PriceHistoryActive    = "ACTIVE"
+	PriceHistoryPast      = "PAST"
+)
+
+// PriceHistoryEntry is one version of a price's calculation, in effect from
+// EffectiveFrom until the next entry's EffectiveFrom. Entries in the future
+// are scheduled changes; AppliedAt is set once an entry has been copied onto
+// the price. Only the calculation fields of Calculation are used; quantity
+// tiers are not versioned.
+type PriceHistoryEntry struct {
+	ID            int
+	PriceID       int
+	EffectiveFrom time.Time
+	AppliedAt     *time.Time
+	Calculation   Price
+	CreatedAt     time.Time
+}
+
+// PriceTimeline is the history of a price ordered by EffectiveFrom, together
+// with the price as it is stored now.
+type PriceTimeline struct {
+	Current *Price
+	Entries []PriceHistoryEntry
+}
+
+// Status reports whether the entry of a timeline ordered by EffectiveFrom is
+// scheduled, in effect or superseded at now.
+func (t *PriceTimeline) Status(i int, now time.Time) string {
+	if t.Entries[i].EffectiveFrom.After(now) {
+		return PriceHistoryScheduled
+	}
+	if i+1 < len(t.Entries) && !t.Entries[i+1].EffectiveFrom.After(now) {
+		return PriceHistoryPast
+	}
+	return PriceHistoryActive
+}
+
+// WithCalculation returns a copy of p carrying the calculation fields of c
+// while keeping p's identity, article link and tiers.
+func (p *Price) WithCalculation(c *Price) *Price {
+	out := *c
+	out.ID = p.ID
+	out.ArticleID = p.ArticleID
+	out.Article = p.Article
+	out.Tiers = p.Tiers
+	out.CreatedAt = p.CreatedAt
+	out.UpdatedAt = p.UpdatedAt
+	return &out
+}
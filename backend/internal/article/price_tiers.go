@@ -0,0 +1,74 @@
+package article
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrInvalidPriceTiers is returned when tiers overlap, start below two units
+// or get more expensive as the quantity grows.
+var ErrInvalidPriceTiers = errors.New("invalid price tiers")
+
+// PriceTier is a quantity break on a price: from MinQuantity units on, each
+// unit costs SalesTotalGross instead of the base sales price.
+type PriceTier struct {
+	ID              int
+	PriceID         int
+	MinQuantity     int
+	SalesTotalGross int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// UnitGrossFor returns the gross unit price for quantity units and the
+// minimum quantity of the tier it comes from (1 for the base price). A nil
+// price costs nothing.
+func (p *Price) UnitGrossFor(quantity int) (gross int, tierMinQuantity int) {
+	if p == nil {
+		return 0, 1
+	}
+	gross, tierMinQuantity = p.SalesTotalGross, 1
+	for _, t := range p.Tiers {
+		if quantity >= t.MinQuantity && t.MinQuantity > tierMinQuantity {
+			gross, tierMinQuantity = t.SalesTotalGross, t.MinQuantity
+		}
+	}
+	return gross, tierMinQuantity
+}
+
+// NextTier returns the closest tier above quantity that lowers the unit
+// price, or nil when buying more would not save anything.
+func (p *Price) NextTier(quantity int) *PriceTier {
+	if p == nil {
+		return nil
+	}
+	current, _ := p.UnitGrossFor(quantity)
+	var next *PriceTier
+	for i := range p.Tiers {
+		t := &p.Tiers[i]
+		if t.MinQuantity <= quantity || t.SalesTotalGross >= current {
+			continue
+		}
+		if next == nil || t.MinQuantity < next.MinQuantity {
+			next = t
+		}
+	}
+	return next
+}
+
+// NormalizePriceTiers sorts tiers by quantity and checks that each starts at
+// two units or more, quantities are unique and unit prices do not rise.
+func NormalizePriceTiers(tiers []PriceTier) ([]PriceTier, error) {
+	out := append([]PriceTier(nil), tiers...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].MinQuantity < out[j].MinQuantity })
+	for i, t := range out {
+		if t.MinQuantity < 2 || t.SalesTotalGross < 0 {
+			return nil, ErrInvalidPriceTiers
+		}
+		if i > 0 && (t.MinQuantity == out[i-1].MinQuantity || t.SalesTotalGross > out[i-1].SalesTotalGross) {
+			return nil, ErrInvalidPriceTiers
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,55 @@
+package article
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnitGrossForPicksHighestReachedTier(t *testing.T) {
+	price := &Price{SalesTotalGross: 1500, Tiers: []PriceTier{{MinQuantity: 10, SalesTotalGross: 1300}, {MinQuantity: 50, SalesTotalGross: 1100}}}
+	cases := []struct{ quantity, gross, tier int }{
+		{1, 1500, 1},
+		{9, 1500, 1},
+		{10, 1300, 10},
+		{49, 1300, 10},
+		{50, 1100, 50},
+		{500, 1100, 50},
+	}
+	for _, c := range cases {
+		gross, tier := price.UnitGrossFor(c.quantity)
+		if gross != c.gross || tier != c.tier {
+			t.Fatalf("UnitGrossFor(%d) = %d, %d; want %d, %d", c.quantity, gross, tier, c.gross, c.tier)
+		}
+	}
+	if next := price.NextTier(9); next == nil || next.MinQuantity != 10 {
+		t.Fatalf("NextTier(9) = %+v, want the 10+ tier", next)
+	}
+	if next := price.NextTier(50); next != nil {
+		t.Fatalf("NextTier(50) = %+v, want nil", next)
+	}
+	var none *Price
+	if gross, tier := none.UnitGrossFor(3); gross != 0 || tier != 1 {
+		t.Fatalf("nil price = %d, %d", gross, tier)
+	}
+}
+
+func TestNormalizePriceTiers(t *testing.T) {
+	tiers, err := NormalizePriceTiers([]PriceTier{{MinQuantity: 50, SalesTotalGross: 1100}, {MinQuantity: 10, SalesTotalGross: 1300}})
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if tiers[0].MinQuantity != 10 || tiers[1].MinQuantity != 50 {
+		t.Fatalf("tiers not sorted: %+v", tiers)
+	}
+	invalid := [][]PriceTier{
+		{{MinQuantity: 1, SalesTotalGross: 100}},
+		{{MinQuantity: 10, SalesTotalGross: 100}, {MinQuantity: 10, SalesTotalGross: 90}},
+		{{MinQuantity: 10, SalesTotalGross: 100}, {MinQuantity: 20, SalesTotalGross: 120}},
+		{{MinQuantity: 10, SalesTotalGross: -1}},
+	}
+	for _, in := range invalid {
+		if _, err := NormalizePriceTiers(in); !errors.Is(err, ErrInvalidPriceTiers) {
+			t.Fatalf("NormalizePriceTiers(%+v) err = %v", in, err)
+		}
+	}
+}
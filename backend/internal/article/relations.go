@@ -0,0 +1,388 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ArticleRelation is an admin-curated suggestion of RelatedArticleID next to
+// ArticleID. RelatedVariantID pins the variant to suggest; without it the
+// related article's default variant is used.
+type ArticleRelation struct {
+	ID               int
+	ArticleID        int
+	RelatedArticleID int
+	RelatedVariantID *int
+	Position         int
+	CreatedAt        time.Time
+}
+
+// BoughtTogether counts the orders that contained both articles.
+type BoughtTogether struct {
+	ArticleID        int
+	RelatedArticleID int
+	OrderCount       int
+	UpdatedAt        time.Time
+}
+
+// RecommendationSource tells why an article is recommended.
+type RecommendationSource string
+
+const (
+	RecommendationCurated        RecommendationSource = "curated"
+	RecommendationBoughtTogether RecommendationSource = "bought_together"
+)
+
+// RecommendationSeed is an article the customer already chose, e.g. a cart
+// line, with the design printed on it if any.
+type RecommendationSeed struct {
+	ArticleID        int
+	GeneratedImageID *int
+}
+
+// Recommendation suggests a variant of an article because of the seed
+// article SeedArticleID. GeneratedImageID carries the seed's design over when
+// it fits the print template of the suggested article.
+type Recommendation struct {
+	Article          Article
+	VariantID        int
+	Source           RecommendationSource
+	SeedArticleID    int
+	GeneratedImageID *int
+}
+
+// RecommendOptions bounds the recommendations. ArticleTypes restricts them
+// to articles of those types; empty allows all types.
+type RecommendOptions struct {
+	Limit        int
+	ArticleTypes []string
+}
+
+var ErrInvalidRelation = errors.New("invalid article relation")
+
+const (
+	// minBoughtTogetherOrders is the number of shared orders from which two
+	// articles count as bought together.
+	minBoughtTogetherOrders = 2
+	// designAspectTolerance is how far, relative to the seed's, the aspect
+	// ratio of a print template may differ for a design to be reused on it.
+	designAspectTolerance = 0.05
+)
+
+// ListRelations returns the curated relations of an article in order.
+func (s *Service) ListRelations(ctx context.Context, articleID int) ([]ArticleRelation, error) {
+	if _, err := s.repo.GetArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListArticleRelations(ctx, []int{articleID})
+}
+
+// SetRelations replaces the curated relations of an article; their order is
+// the display order.
+func (s *Service) SetRelations(ctx context.Context, articleID int, relations []ArticleRelation) ([]ArticleRelation, error) {
+	if _, err := s.repo.GetArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+	seen := make(map[int]struct{}, len(relations))
+	out := make([]ArticleRelation, 0, len(relations))
+	for i, rel := range relations {
+		if rel.RelatedArticleID == articleID {
+			return nil, fmt.Errorf("%w: an article cannot be related to itself", ErrInvalidRelation)
+		}
+		if _, dup := seen[rel.RelatedArticleID]; dup {
+			return nil, fmt.Errorf("%w: article %d is listed twice", ErrInvalidRelation, rel.RelatedArticleID)
+		}
+		seen[rel.RelatedArticleID] = struct{}{}
+		related, err := s.repo.GetArticle(ctx, rel.RelatedArticleID)
+		if errorsIsNotFound(err) {
+			return nil, fmt.Errorf("%w: article %d not found", ErrInvalidRelation, rel.RelatedArticleID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if rel.RelatedVariantID != nil {
+			ids, _, err := s.activeVariantIDs(ctx, &related)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(ids, *rel.RelatedVariantID) {
+				return nil, fmt.Errorf("%w: variant %d is not an active variant of article %d", ErrInvalidRelation, *rel.RelatedVariantID, related.ID)
+			}
+		}
+		out = append(out, ArticleRelation{
+			ArticleID:        articleID,
+			RelatedArticleID: rel.RelatedArticleID,
+			RelatedVariantID: rel.RelatedVariantID,
+			Position:         i,
+		})
+	}
+	if err := s.repo.ReplaceArticleRelations(ctx, articleID, out); err != nil {
+		return nil, err
+	}
+	return s.repo.ListArticleRelations(ctx, []int{articleID})
+}
+
+// ListBoughtTogether returns the articles most often ordered together with
+// an article, as of the last refresh.
+func (s *Service) ListBoughtTogether(ctx context.Context, articleID int) ([]BoughtTogether, error) {
+	if _, err := s.repo.GetArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListBoughtTogether(ctx, []int{articleID})
+}
+
+// RefreshBoughtTogether recomputes the bought-together pairs from the order
+// items and returns the number of pairs stored.
+func (s *Service) RefreshBoughtTogether(ctx context.Context) (int, error) {
+	return s.repo.RefreshBoughtTogether(ctx, minBoughtTogetherOrders)
+}
+
+// RunBoughtTogetherRefresher refreshes the bought-together pairs every
+// interval until ctx is cancelled.
+func (s *Service) RunBoughtTogetherRefresher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if pairs, err := s.RefreshBoughtTogether(ctx); err != nil {
+			slog.Error("refreshing bought-together articles failed", "error", err)
+		} else {
+			slog.Debug("refreshed bought-together articles", "pairs", pairs)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Recommend suggests up to opts.Limit active articles for the seeds: curated
+// relations first, in the order of the seeds, then articles bought together
+// with any seed, most frequent first. Articles among the seeds are never
+// suggested.
+func (s *Service) Recommend(ctx context.Context, seeds []RecommendationSeed, opts RecommendOptions) ([]Recommendation, error) {
+	limit := opts.Limit
+	if len(seeds) == 0 || limit <= 0 {
+		return []Recommendation{}, nil
+	}
+	seedIDs := make([]int, 0, len(seeds))
+	seedRank := make(map[int]int, len(seeds))
+	designs := make(map[int]*int, len(seeds))
+	for _, seed := range seeds {
+		if _, ok := seedRank[seed.ArticleID]; !ok {
+			seedRank[seed.ArticleID] = len(seedIDs)
+			seedIDs = append(seedIDs, seed.ArticleID)
+		}
+		if designs[seed.ArticleID] == nil && seed.GeneratedImageID != nil {
+			designs[seed.ArticleID] = seed.GeneratedImageID
+		}
+	}
+
+	type candidate struct {
+		articleID, seedID int
+		variantID         *int
+		source            RecommendationSource
+	}
+	var candidates []candidate
+	relations, err := s.repo.ListArticleRelations(ctx, seedIDs)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(relations, func(i, j int) bool {
+		return seedRank[relations[i].ArticleID] < seedRank[relations[j].ArticleID]
+	})
+	for _, rel := range relations {
+		candidates = append(candidates, candidate{rel.RelatedArticleID, rel.ArticleID, rel.RelatedVariantID, RecommendationCurated})
+	}
+	together, err := s.repo.ListBoughtTogether(ctx, seedIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, bt := range together {
+		candidates = append(candidates, candidate{bt.RelatedArticleID, bt.ArticleID, nil, RecommendationBoughtTogether})
+	}
+
+	sizes := make(map[int][]printSize)
+	out := make([]Recommendation, 0, limit)
+	seen := make(map[int]struct{}, len(candidates))
+	for _, c := range candidates {
+		if len(out) == limit {
+			break
+		}
+		if _, isSeed := seedRank[c.articleID]; isSeed {
+			continue
+		}
+		if _, dup := seen[c.articleID]; dup {
+			continue
+		}
+		seen[c.articleID] = struct{}{}
+		art, err := s.repo.GetArticle(ctx, c.articleID)
+		if errorsIsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !art.Active || art.ArchivedAt != nil {
+			continue
+		}
+		if len(opts.ArticleTypes) > 0 && !slices.Contains(opts.ArticleTypes, art.ArticleType) {
+			continue
+		}
+		ids, defaultID, err := s.activeVariantIDs(ctx, &art)
+		if err != nil {
+			return nil, err
+		}
+		variantID := defaultID
+		if c.variantID != nil && slices.Contains(ids, *c.variantID) {
+			variantID = *c.variantID
+		}
+		if variantID == 0 {
+			continue
+		}
+		rec := Recommendation{Article: art, VariantID: variantID, Source: c.source, SeedArticleID: c.seedID}
+		if design := designs[c.seedID]; design != nil {
+			fits, err := s.designFits(ctx, sizes, c.seedID, &art)
+			if err != nil {
+				return nil, err
+			}
+			if fits {
+				rec.GeneratedImageID = design
+			}
+		}
+		out = append(out, rec)
+	}
+	return out, nil
+}
+
+// activeVariantIDs lists the variants of an article that can be ordered and
+// picks its default: the flagged default variant, else the first one. The
+// default is 0 when the article has no such variant.
+func (s *Service) activeVariantIDs(ctx context.Context, art *Article) ([]int, int, error) {
+	var ids []int
+	defaultID := 0
+	switch art.ArticleType {
+	case ArticleTypeMug:
+		variants, err := s.repo.ListMugVariants(ctx, art.ID, true)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, v := range variants {
+			ids = append(ids, v.ID)
+			if v.IsDefault {
+				defaultID = v.ID
+			}
+		}
+	case ArticleTypeShirt:
+		variants, err := s.repo.ListShirtVariants(ctx, art.ID, true)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, v := range variants {
+			ids = append(ids, v.ID)
+		}
+	default:
+		variants, err := s.repo.ListArticleVariants(ctx, art.ID, true)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, v := range variants {
+			ids = append(ids, v.ID)
+			if v.IsDefault {
+				defaultID = v.ID
+			}
+		}
+	}
+	if defaultID == 0 && len(ids) > 0 {
+		defaultID = ids[0]
+	}
+	return ids, defaultID, nil
+}
+
+type printSize struct{ widthMm, heightMm int }
+
+// designFits reports whether a design made for the seed article can be
+// printed on target: one of target's print templates must have about the
+// aspect ratio of one of the seed's. sizes caches the templates by article.
+func (s *Service) designFits(ctx context.Context, sizes map[int][]printSize, seedID int, target *Article) (bool, error) {
+	load := func(id int, art *Article) ([]printSize, error) {
+		if cached, ok := sizes[id]; ok {
+			return cached, nil
+		}
+		if art == nil {
+			a, err := s.repo.GetArticle(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			art = &a
+		}
+		loaded, err := s.printSizes(ctx, art)
+		if err != nil {
+			return nil, err
+		}
+		sizes[id] = loaded
+		return loaded, nil
+	}
+	from, err := load(seedID, nil)
+	if err != nil {
+		return false, err
+	}
+	to, err := load(target.ID, target)
+	if err != nil {
+		return false, err
+	}
+	for _, a := range from {
+		for _, b := range to {
+			ratio := float64(a.widthMm) / float64(a.heightMm)
+			if math.Abs(float64(b.widthMm)/float64(b.heightMm)-ratio) <= ratio*designAspectTolerance {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// printSizes returns the print templates of an article: the mug template,
+// the shirt print area or the print areas of a registered type. Templates
+// without both dimensions are left out.
+func (s *Service) printSizes(ctx context.Context, art *Article) ([]printSize, error) {
+	var out []printSize
+	add := func(w, h int) {
+		if w > 0 && h > 0 {
+			out = append(out, printSize{w, h})
+		}
+	}
+	switch art.ArticleType {
+	case ArticleTypeMug:
+		details, err := s.repo.GetMugDetails(ctx, art.ID)
+		if err != nil {
+			return nil, err
+		}
+		if details != nil {
+			add(details.PrintTemplateWidthMm, details.PrintTemplateHeightMm)
+		}
+	case ArticleTypeShirt:
+		details, err := s.repo.GetShirtDetails(ctx, art.ID)
+		if err != nil {
+			return nil, err
+		}
+		if details != nil {
+			add(details.PrintAreaWidthMm, details.PrintAreaHeightMm)
+		}
+	default:
+		areas, err := s.repo.ListPrintAreas(ctx, art.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range areas {
+			add(a.WidthMm, a.HeightMm)
+		}
+	}
+	return out, nil
+}
@@ -1,6 +1,9 @@
 package article
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // ArticleListOptions enumerates filters for admin article listing.
 type ArticleListOptions struct {
@@ -10,7 +13,9 @@ type ArticleListOptions struct {
 	CategoryID    *int
 	SubcategoryID *int
 	Active        *bool
-	Search        string
+	// Archived lists archived articles instead of current ones.
+	Archived bool
+	Search   string
 }
 
 // Repository defines storage operations required by the article service.
@@ -46,6 +51,25 @@ type Repository interface {
 	UpdateArticle(ctx context.Context, art *Article) error
 	DeleteArticle(ctx context.Context, id int) error
 
+	// Archiving. Archived articles and variants are left out wherever only
+	// active rows are listed and archiving removes them from active carts;
+	// orders keep resolving them. A nil archivedAt restores. Archiving a
+	// default variant clears its default flag.
+	SetArticleArchived(ctx context.Context, id int, archivedAt *time.Time) error
+	SetMugVariantArchived(ctx context.Context, id int, archivedAt *time.Time) error
+	SetShirtVariantArchived(ctx context.Context, id int, archivedAt *time.Time) error
+	SetArticleVariantArchived(ctx context.Context, id int, archivedAt *time.Time) error
+	// CountVariantOrders counts the order items of a variant of the article.
+	CountVariantOrders(ctx context.Context, articleID, variantID int) (int, error)
+
+	// Article types
+	ListArticleTypes(ctx context.Context) ([]ArticleTypeDefinition, error)
+	GetArticleType(ctx context.Context, code string) (ArticleTypeDefinition, error)
+	CreateArticleType(ctx context.Context, def *ArticleTypeDefinition) error
+	UpdateArticleType(ctx context.Context, def *ArticleTypeDefinition) error
+	DeleteArticleType(ctx context.Context, code string) error
+	CountArticlesByType(ctx context.Context, code string) (int, error)
+
 	// Variants - mugs
 	ListMugVariants(ctx context.Context, articleID int, onlyActive bool) ([]MugVariant, error)
 	GetMugVariant(ctx context.Context, id int) (MugVariant, error)
@@ -60,6 +84,41 @@ type Repository interface {
 	UpdateShirtVariant(ctx context.Context, variant *ShirtVariant) error
 	DeleteShirtVariant(ctx context.Context, id int) error
 
+	// Variants - registered types. Saving a default variant clears the
+	// default flag of the article's other variants.
+	ListArticleVariants(ctx context.Context, articleID int, onlyActive bool) ([]ArticleVariant, error)
+	GetArticleVariant(ctx context.Context, id int) (ArticleVariant, error)
+	CreateArticleVariant(ctx context.Context, variant *ArticleVariant) error
+	UpdateArticleVariant(ctx context.Context, variant *ArticleVariant) error
+	DeleteArticleVariant(ctx context.Context, id int) error
+
+	// Galleries. Images are ordered by position within the gallery of the
+	// article (nil variant) or of one variant. Creating appends to the
+	// gallery; saving a primary image clears the flag on the other images of
+	// its gallery and deleting the primary image promotes the next one.
+	// Deleting an article or variant deletes its gallery rows.
+	ListGalleryImages(ctx context.Context, articleID int) ([]GalleryImage, error)
+	GetGalleryImage(ctx context.Context, id int) (GalleryImage, error)
+	CreateGalleryImage(ctx context.Context, image *GalleryImage) error
+	UpdateGalleryImage(ctx context.Context, image *GalleryImage) error
+	DeleteGalleryImage(ctx context.Context, id int) error
+	// ReorderGallery sets the position of each image to its index in imageIDs.
+	ReorderGallery(ctx context.Context, imageIDs []int) error
+
+	// Relations. Curated relations are listed by article and position;
+	// replacing stores the given ones in place of all relations of the
+	// article. Bought-together pairs are listed most frequent first per
+	// article and RefreshBoughtTogether recomputes all of them from the order
+	// items, keeping pairs that share at least minOrders orders.
+	ListArticleRelations(ctx context.Context, articleIDs []int) ([]ArticleRelation, error)
+	ReplaceArticleRelations(ctx context.Context, articleID int, relations []ArticleRelation) error
+	ListBoughtTogether(ctx context.Context, articleIDs []int) ([]BoughtTogether, error)
+	RefreshBoughtTogether(ctx context.Context, minOrders int) (int, error)
+
+	// Print areas - registered types
+	ListPrintAreas(ctx context.Context, articleID int) ([]PrintArea, error)
+	ReplacePrintAreas(ctx context.Context, articleID int, areas []PrintArea) error
+
 	// Details & pricing
 	GetMugDetails(ctx context.Context, articleID int) (*MugDetails, error)
 	UpsertMugDetails(ctx context.Context, details *MugDetails) error
@@ -73,7 +132,41 @@ type Repository interface {
 	GetCostCalculationByID(ctx context.Context, id int) (*Price, error)
 	UpsertCostCalculation(ctx context.Context, articleID int, calc *Price) error
 	DeleteCostCalculation(ctx context.Context, articleID int) error
+	// ReplacePriceTiers swaps all tiers of a price for the given ones.
+	ReplacePriceTiers(ctx context.Context, priceID int, tiers []PriceTier) error
+
+	// Price history. UpsertCostCalculation records an entry effective
+	// immediately for every save.
+	ListPriceHistory(ctx context.Context, priceID int) ([]PriceHistoryEntry, error)
+	// PriceHistoryAt returns the entry in effect at the given moment, or nil
+	// when the price has no history that early.
+	PriceHistoryAt(ctx context.Context, priceID int, at time.Time) (*PriceHistoryEntry, error)
+	CreatePriceHistoryEntry(ctx context.Context, entry *PriceHistoryEntry) error
+	// DeleteScheduledPriceChange removes an entry of the price that has not
+	// taken effect by now and reports whether one was removed.
+	DeleteScheduledPriceChange(ctx context.Context, priceID, entryID int, now time.Time) (bool, error)
+	// ApplyDuePriceChanges copies the latest due, unapplied entry of every
+	// price onto it unless a newer save superseded it, marks all due entries
+	// applied and returns the number of prices changed.
+	ApplyDuePriceChanges(ctx context.Context, now time.Time) (int, error)
 
 	// Listings & helpers
 	ListMugArticles(ctx context.Context, onlyActive bool, excludeID *int) ([]Article, error)
+	ListShirtArticles(ctx context.Context, onlyActive bool, excludeID *int) ([]Article, error)
+	// ListMugCatalog loads active mugs that have details together with their
+	// active variants, galleries and prices in a constant number of queries.
+	ListMugCatalog(ctx context.Context) ([]MugCatalogEntry, error)
+	// ListTypeCatalog does the same for active articles of a registered type.
+	ListTypeCatalog(ctx context.Context, articleType string) ([]TypeCatalogEntry, error)
+	// ListActiveArticles returns active articles of all types, optionally of
+	// one category.
+	ListActiveArticles(ctx context.Context, categoryID *int) ([]Article, error)
+
+	// Slugs. Creating or updating an article or category takes its slug out of
+	// the redirect history; updating it to a new slug records the old one.
+	// The lookups match current slugs first, then former ones.
+	ArticleIDBySlug(ctx context.Context, slug string) (int, error)
+	ArticleSlugTaken(ctx context.Context, slug string, exceptID int) (bool, error)
+	CategoryIDBySlug(ctx context.Context, slug string) (int, error)
+	CategorySlugTaken(ctx context.Context, slug string, exceptID int) (bool, error)
 }
@@ -1,9 +1,7 @@
 package article
 
 import (
-	"cmp"
 	"path/filepath"
-	"strings"
 	"time"
 
 	img "voenix/backend/internal/image"
@@ -22,11 +20,25 @@ func toArticleMugVariantResponse(v *MugVariant) articleMugVariantResponse {
 		IsDefault:            v.IsDefault,
 		Active:               v.Active,
 		ExampleImageFilename: v.ExampleImageFilename,
+		MockupPrintArea:      toMockupPoints(v.MockupPrintArea),
+		MockupCurvature:      v.MockupCurvature,
+		ArchivedAt:           v.ArchivedAt,
 		CreatedAt:            timePtr(v.CreatedAt),
 		UpdatedAt:            timePtr(v.UpdatedAt),
 	}
 }
 
+func toMockupPoints(q *img.MockupQuad) []mockupPoint {
+	if q == nil {
+		return nil
+	}
+	out := make([]mockupPoint, 0, len(q))
+	for _, p := range q {
+		out = append(out, mockupPoint{X: p.X, Y: p.Y})
+	}
+	return out
+}
+
 func toArticleShirtVariantResponse(v *ShirtVariant) articleShirtVariantResponse {
 	return articleShirtVariantResponse{
 		ID:              v.ID,
@@ -34,6 +46,7 @@ func toArticleShirtVariantResponse(v *ShirtVariant) articleShirtVariantResponse
 		Color:           v.Color,
 		Size:            v.Size,
 		ExampleImageURL: strPtrOrNil(publicShirtVariantExampleURL(v.ExampleImageFilename)),
+		ArchivedAt:      v.ArchivedAt,
 		CreatedAt:       timePtr(v.CreatedAt),
 		UpdatedAt:       timePtr(v.UpdatedAt),
 	}
@@ -63,23 +76,17 @@ func toArticleShirtDetailsResponse(d *ShirtDetails) *articleShirtDetailsResponse
 	if d == nil {
 		return nil
 	}
-	sizes := []string{}
-	if strings.TrimSpace(d.AvailableSizes) != "" {
-		for _, s := range strings.Split(d.AvailableSizes, ",") {
-			trimmed := strings.TrimSpace(s)
-			if trimmed != "" {
-				sizes = append(sizes, trimmed)
-			}
-		}
-	}
 	return &articleShirtDetailsResponse{
-		ArticleID:        d.ArticleID,
-		Material:         d.Material,
-		CareInstructions: d.CareInstructions,
-		FitType:          d.FitType,
-		AvailableSizes:   sizes,
-		CreatedAt:        timePtr(d.CreatedAt),
-		UpdatedAt:        timePtr(d.UpdatedAt),
+		ArticleID:         d.ArticleID,
+		Material:          d.Material,
+		CareInstructions:  d.CareInstructions,
+		FitType:           d.FitType,
+		AvailableSizes:    d.Sizes(),
+		PrintAreaWidthMm:  d.PrintAreaWidthMm,
+		PrintAreaHeightMm: d.PrintAreaHeightMm,
+		PrintAreaPosition: d.PrintAreaPosition,
+		CreatedAt:         timePtr(d.CreatedAt),
+		UpdatedAt:         timePtr(d.UpdatedAt),
 	}
 }
 
@@ -87,9 +94,13 @@ func toCostCalculationResponse(c *Price) *costCalculationResponse {
 	if c == nil {
 		return nil
 	}
+	articleID := 0
+	if c.ArticleID != nil {
+		articleID = *c.ArticleID
+	}
 	return &costCalculationResponse{
 		ID:                       c.ID,
-		ArticleID:                cmp.Or(*c.ArticleID, 0),
+		ArticleID:                articleID,
 		PurchasePriceNet:         c.PurchasePriceNet,
 		PurchasePriceTax:         c.PurchasePriceTax,
 		PurchasePriceGross:       c.PurchasePriceGross,
@@ -119,6 +130,7 @@ func toCostCalculationResponse(c *Price) *costCalculationResponse {
 		SalesPriceCorresponds:    c.SalesPriceCorresponds,
 		PurchaseActiveRow:        c.PurchaseActiveRow,
 		SalesActiveRow:           c.SalesActiveRow,
+		Tiers:                    toPriceTierResponses(c.Tiers),
 		CreatedAt:                timePtr(c.CreatedAt),
 		UpdatedAt:                timePtr(c.UpdatedAt),
 	}
@@ -128,6 +140,7 @@ func toArticleResponse(a *Article, catName string, subcatName *string, supplierN
 	out := ArticleResponse{
 		ID:                    a.ID,
 		Name:                  a.Name,
+		Slug:                  a.Slug,
 		DescriptionShort:      a.DescriptionShort,
 		DescriptionLong:       a.DescriptionLong,
 		Active:                a.Active,
@@ -140,8 +153,12 @@ func toArticleResponse(a *Article, catName string, subcatName *string, supplierN
 		SupplierName:          supplierName,
 		SupplierArticleName:   a.SupplierArticleName,
 		SupplierArticleNumber: a.SupplierArticleNumber,
+		Attributes:            a.Attributes,
+		Variants:              []articleVariantResponse{},
+		PrintAreas:            []printAreaResponse{},
 		MugDetails:            toArticleMugDetailsResponse(mugDetails),
 		ShirtDetails:          toArticleShirtDetailsResponse(shirtDetails),
+		ArchivedAt:            a.ArchivedAt,
 		CreatedAt:             timePtr(a.CreatedAt),
 		UpdatedAt:             timePtr(a.UpdatedAt),
 	}
@@ -177,6 +194,52 @@ func publicShirtVariantExampleURL(filename *string) string {
 	return "/public/images/articles/shirts/variant-example-images/" + filepath.Base(*filename)
 }
 
+func publicArticleVariantExampleURL(filename *string) string {
+	if filename == nil || *filename == "" {
+		return ""
+	}
+	if loc, err := img.NewStorageLocations(); err == nil {
+		dir := loc.ArticleVariantExample()
+		if rel, rerr := filepath.Rel(loc.Root, dir); rerr == nil {
+			relURL := filepath.ToSlash(rel)
+			return "/" + relURL + "/" + filepath.Base(*filename)
+		}
+	}
+	return "/public/images/articles/variants/variant-example-images/" + filepath.Base(*filename)
+}
+
+func publicArticleGalleryURL(filename string) string {
+	if filename == "" {
+		return ""
+	}
+	if loc, err := img.NewStorageLocations(); err == nil {
+		dir := loc.ArticleGallery()
+		if rel, rerr := filepath.Rel(loc.Root, dir); rerr == nil {
+			relURL := filepath.ToSlash(rel)
+			return "/" + relURL + "/" + filepath.Base(filename)
+		}
+	}
+	return "/public/images/articles/gallery/" + filepath.Base(filename)
+}
+
+// toPublicGalleryResponses returns the gallery of the article (nil variantID)
+// or of one variant in display order.
+func toPublicGalleryResponses(images []GalleryImage, variantID *int) []publicGalleryImageResponse {
+	gallery := GalleryOf(images, variantID)
+	out := make([]publicGalleryImageResponse, 0, len(gallery))
+	for i := range gallery {
+		g := &gallery[i]
+		out = append(out, publicGalleryImageResponse{
+			ID:        g.ID,
+			URL:       publicArticleGalleryURL(g.Filename),
+			AltText:   g.AltText,
+			Position:  g.Position,
+			IsPrimary: g.IsPrimary,
+		})
+	}
+	return out
+}
+
 func timePtr(t time.Time) *time.Time { return &t }
 
 func strPtrOrNil(s string) *string {
@@ -0,0 +1,141 @@
+package article
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"voenix/backend/internal/search"
+)
+
+// SetSearchIndex makes admin writes update the search documents of the
+// articles they touch. It is set after construction because the search
+// service reads its documents from this service.
+func (s *Service) SetSearchIndex(idx search.Index) { s.index = idx }
+
+func (s *Service) SearchKind() search.Kind { return search.KindArticle }
+
+// SearchDocuments returns the search documents of all active articles.
+func (s *Service) SearchDocuments(ctx context.Context) ([]search.Document, error) {
+	articles, err := s.repo.ListActiveArticles(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.articleSearchDocuments(ctx, articles)
+}
+
+// ReindexArticles updates the search documents of articles written outside
+// this Service, e.g. by an import with its own transaction.
+func (s *Service) ReindexArticles(ctx context.Context, ids ...int) { s.reindexArticles(ctx, ids...) }
+
+// reindexArticles updates the search documents of articles after a write and
+// removes those of articles the storefront no longer shows. Failures are
+// logged only: the write has happened and a rebuild repairs the index.
+func (s *Service) reindexArticles(ctx context.Context, ids ...int) {
+	if s.index == nil {
+		return
+	}
+	var shown []Article
+	var hidden []int
+	for _, id := range ids {
+		art, err := s.repo.GetArticle(ctx, id)
+		switch {
+		case errorsIsNotFound(err):
+			hidden = append(hidden, id)
+		case err != nil:
+			slog.Warn("loading article for search index failed", "article", id, "error", err)
+		case !art.Active || art.ArchivedAt != nil:
+			hidden = append(hidden, id)
+		default:
+			shown = append(shown, art)
+		}
+	}
+	if err := s.index.Remove(ctx, search.KindArticle, hidden...); err != nil {
+		slog.Warn("removing articles from search index failed", "articles", hidden, "error", err)
+	}
+	s.upsertArticleDocuments(ctx, shown)
+}
+
+// reindexCategory updates the documents of the active articles of a
+// category, or of a subcategory when subcategoryID is set, after the
+// category was renamed.
+func (s *Service) reindexCategory(ctx context.Context, categoryID int, subcategoryID *int) {
+	if s.index == nil {
+		return
+	}
+	articles, err := s.repo.ListActiveArticles(ctx, &categoryID)
+	if err != nil {
+		slog.Warn("loading articles for search index failed", "category", categoryID, "error", err)
+		return
+	}
+	if subcategoryID != nil {
+		kept := articles[:0]
+		for _, a := range articles {
+			if a.SubcategoryID != nil && *a.SubcategoryID == *subcategoryID {
+				kept = append(kept, a)
+			}
+		}
+		articles = kept
+	}
+	s.upsertArticleDocuments(ctx, articles)
+}
+
+func (s *Service) upsertArticleDocuments(ctx context.Context, articles []Article) {
+	docs, err := s.articleSearchDocuments(ctx, articles)
+	if err == nil {
+		err = s.index.Upsert(ctx, docs...)
+	}
+	if err != nil {
+		slog.Warn("updating articles in search index failed", "error", err)
+	}
+}
+
+// articleSearchDocuments indexes the name of an article over its
+// descriptions, category and subcategory names, the subcategory description
+// and the supplier's article number.
+func (s *Service) articleSearchDocuments(ctx context.Context, articles []Article) ([]search.Document, error) {
+	if len(articles) == 0 {
+		return nil, nil
+	}
+	cats, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subs, err := s.repo.ListSubcategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	catNames := make(map[int]string, len(cats))
+	for _, c := range cats {
+		catNames[c.ID] = c.Name
+	}
+	subsByID := make(map[int]ArticleSubCategory, len(subs))
+	for _, sub := range subs {
+		subsByID[sub.ID] = sub
+	}
+	out := make([]search.Document, 0, len(articles))
+	for i := range articles {
+		a := &articles[i]
+		body := []string{a.DescriptionShort, a.DescriptionLong, catNames[a.CategoryID]}
+		if a.SubcategoryID != nil {
+			if sub, ok := subsByID[*a.SubcategoryID]; ok {
+				body = append(body, sub.Name)
+				if sub.Description != nil {
+					body = append(body, *sub.Description)
+				}
+			}
+		}
+		if a.SupplierArticleNumber != nil {
+			body = append(body, *a.SupplierArticleNumber)
+		}
+		out = append(out, search.Document{
+			Kind:    search.KindArticle,
+			RefID:   a.ID,
+			Title:   a.Name,
+			Body:    strings.Join(body, "\n"),
+			Summary: a.DescriptionShort,
+			Path:    "/articles/" + a.Slug,
+		})
+	}
+	return out, nil
+}
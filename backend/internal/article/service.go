@@ -4,18 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
 	img "voenix/backend/internal/image"
+	"voenix/backend/internal/search"
 )
 
 // Service exposes article domain operations backed by a repository implementation.
 type Service struct {
-	repo Repository
+	repo    Repository
+	catalog catalogCache
+	index   search.Index
 }
 
 func NewService(repo Repository) *Service { return &Service{repo: repo} }
@@ -26,6 +31,8 @@ var (
 	ErrSupplierNotFound    = errors.New("supplier not found")
 	ErrVatNotFound         = errors.New("vat not found")
 	ErrArticleHasOrders    = errors.New("article has orders")
+	ErrVariantHasOrders    = errors.New("variant has orders")
+	ErrNotArchived         = errors.New("only archived articles and variants can be purged")
 )
 
 // --- Category operations ---
@@ -55,15 +62,21 @@ func (s *Service) GetCategory(ctx context.Context, id int) (ArticleCategory, err
 	return s.repo.GetCategory(ctx, id)
 }
 
-func (s *Service) CreateCategory(ctx context.Context, name string, description *string) (ArticleCategory, error) {
+// CreateCategory derives the slug from the name unless slug is given.
+func (s *Service) CreateCategory(ctx context.Context, name string, slug *string, description *string) (ArticleCategory, error) {
 	cat := ArticleCategory{Name: name, Description: description}
+	if err := s.assignCategorySlug(ctx, &cat, slug); err != nil {
+		return ArticleCategory{}, err
+	}
 	if err := s.repo.CreateCategory(ctx, &cat); err != nil {
 		return ArticleCategory{}, err
 	}
 	return cat, nil
 }
 
-func (s *Service) UpdateCategory(ctx context.Context, id int, name *string, description *string) (ArticleCategory, error) {
+// UpdateCategory keeps the slug unless a different one is given; the old
+// slug then redirects to the category.
+func (s *Service) UpdateCategory(ctx context.Context, id int, name *string, slug *string, description *string) (ArticleCategory, error) {
 	cat, err := s.repo.GetCategory(ctx, id)
 	if err != nil {
 		return ArticleCategory{}, err
@@ -74,9 +87,15 @@ func (s *Service) UpdateCategory(ctx context.Context, id int, name *string, desc
 	if description != nil {
 		cat.Description = description
 	}
+	if slug != nil && *slug != cat.Slug {
+		if err := s.assignCategorySlug(ctx, &cat, slug); err != nil {
+			return ArticleCategory{}, err
+		}
+	}
 	if err := s.repo.UpdateCategory(ctx, &cat); err != nil {
 		return ArticleCategory{}, err
 	}
+	s.reindexCategory(ctx, cat.ID, nil)
 	return cat, nil
 }
 
@@ -156,6 +175,7 @@ func (s *Service) UpdateSubcategory(ctx context.Context, id int, categoryID *int
 	if err := s.repo.UpdateSubcategory(ctx, &sub); err != nil {
 		return ArticleSubCategory{}, err
 	}
+	s.reindexCategory(ctx, sub.ArticleCategoryID, &sub.ID)
 	return sub, nil
 }
 
@@ -190,12 +210,15 @@ type ArticleAdminItem struct {
 	SupplierName    *string
 	MugVariants     []MugVariant
 	ShirtVariants   []ShirtVariant
+	// Variants are those of articles of registered types.
+	Variants []ArticleVariant
 }
 
 type ArticleDetail struct {
 	ArticleAdminItem
 	MugDetails      *MugDetails
 	ShirtDetails    *ShirtDetails
+	PrintAreas      []PrintArea
 	CostCalculation *Price
 }
 
@@ -218,6 +241,10 @@ func (s *Service) ListArticles(ctx context.Context, opts ArticleListOptions) ([]
 		if err != nil {
 			return nil, 0, err
 		}
+		variants, err := s.repo.ListArticleVariants(ctx, articles[i].ID, false)
+		if err != nil {
+			return nil, 0, err
+		}
 		items = append(items, ArticleAdminItem{
 			Article:         articles[i],
 			CategoryName:    catName,
@@ -225,6 +252,7 @@ func (s *Service) ListArticles(ctx context.Context, opts ArticleListOptions) ([]
 			SupplierName:    suppName,
 			MugVariants:     mugs,
 			ShirtVariants:   shirts,
+			Variants:        variants,
 		})
 	}
 	return items, total, nil
@@ -247,6 +275,14 @@ func (s *Service) GetArticleDetail(ctx context.Context, id int) (ArticleDetail,
 	if err != nil {
 		return ArticleDetail{}, err
 	}
+	variants, err := s.repo.ListArticleVariants(ctx, art.ID, false)
+	if err != nil {
+		return ArticleDetail{}, err
+	}
+	printAreas, err := s.repo.ListPrintAreas(ctx, art.ID)
+	if err != nil {
+		return ArticleDetail{}, err
+	}
 	detail := ArticleDetail{
 		ArticleAdminItem: ArticleAdminItem{
 			Article:         art,
@@ -255,7 +291,9 @@ func (s *Service) GetArticleDetail(ctx context.Context, id int) (ArticleDetail,
 			SupplierName:    suppName,
 			MugVariants:     mugs,
 			ShirtVariants:   shirts,
+			Variants:        variants,
 		},
+		PrintAreas: printAreas,
 	}
 	if art.ArticleType == ArticleTypeMug {
 		detail.MugDetails, err = s.repo.GetMugDetails(ctx, art.ID)
@@ -294,9 +332,16 @@ func (s *Service) GetArticleSummary(ctx context.Context, id int) (ArticleRespons
 }
 
 func (s *Service) CreateArticle(ctx context.Context, art *Article, mugDetails *MugDetails, shirtDetails *ShirtDetails, cost *Price, mugVariants []MugVariant, shirtVariants []ShirtVariant) (ArticleDetail, error) {
+	defer s.InvalidateCatalog()
+	if err := s.prepareArticleType(ctx, art); err != nil {
+		return ArticleDetail{}, err
+	}
 	if err := s.validateArticleReferences(ctx, art.CategoryID, art.SubcategoryID, art.SupplierID); err != nil {
 		return ArticleDetail{}, err
 	}
+	if err := s.assignArticleSlug(ctx, art); err != nil {
+		return ArticleDetail{}, err
+	}
 	if err := s.repo.CreateArticle(ctx, art); err != nil {
 		return ArticleDetail{}, err
 	}
@@ -306,13 +351,21 @@ func (s *Service) CreateArticle(ctx context.Context, art *Article, mugDetails *M
 	if err := s.UpsertCostCalculation(ctx, art.ID, cost); err != nil {
 		return ArticleDetail{}, err
 	}
+	s.reindexArticles(ctx, art.ID)
 	return s.GetArticleDetail(ctx, art.ID)
 }
 
 func (s *Service) UpdateArticle(ctx context.Context, art *Article, mugDetails *MugDetails, shirtDetails *ShirtDetails, cost *Price) (ArticleDetail, error) {
+	defer s.InvalidateCatalog()
+	if err := s.prepareArticleType(ctx, art); err != nil {
+		return ArticleDetail{}, err
+	}
 	if err := s.validateArticleReferences(ctx, art.CategoryID, art.SubcategoryID, art.SupplierID); err != nil {
 		return ArticleDetail{}, err
 	}
+	if err := s.assignArticleSlug(ctx, art); err != nil {
+		return ArticleDetail{}, err
+	}
 	if err := s.repo.UpdateArticle(ctx, art); err != nil {
 		return ArticleDetail{}, err
 	}
@@ -322,14 +375,21 @@ func (s *Service) UpdateArticle(ctx context.Context, art *Article, mugDetails *M
 	if err := s.UpsertCostCalculation(ctx, art.ID, cost); err != nil {
 		return ArticleDetail{}, err
 	}
+	s.reindexArticles(ctx, art.ID)
 	return s.GetArticleDetail(ctx, art.ID)
 }
 
-func (s *Service) DeleteArticle(ctx context.Context, id int) error {
+// PurgeArticle permanently deletes an archived article that was never
+// ordered, together with its variants and their images.
+func (s *Service) PurgeArticle(ctx context.Context, id int) error {
+	defer s.InvalidateCatalog()
 	article, err := s.repo.GetArticle(ctx, id)
 	if err != nil {
 		return err
 	}
+	if article.ArchivedAt == nil {
+		return ErrNotArchived
+	}
 	var mugExampleImages []string
 	if article.ArticleType == ArticleTypeMug {
 		mugVariants, err := s.repo.ListMugVariants(ctx, id, false)
@@ -364,9 +424,18 @@ func (s *Service) DeleteArticle(ctx context.Context, id int) error {
 			shirtExampleImages = append(shirtExampleImages, filepath.Base(filename))
 		}
 	}
+	gallery, err := s.repo.ListGalleryImages(ctx, id)
+	if err != nil {
+		return err
+	}
 	if err := s.repo.DeleteArticle(ctx, id); err != nil {
 		return err
 	}
+	galleryFiles := make([]string, 0, len(gallery))
+	for i := range gallery {
+		galleryFiles = append(galleryFiles, gallery[i].Filename)
+	}
+	removeGalleryFiles(galleryFiles)
 	if len(mugExampleImages) == 0 && len(shirtExampleImages) == 0 {
 		return nil
 	}
@@ -453,6 +522,7 @@ func (s *Service) validateArticleReferences(ctx context.Context, categoryID int,
 // --- Variants ---
 
 func (s *Service) CreateMugVariant(ctx context.Context, variant *MugVariant) (MugVariant, error) {
+	defer s.InvalidateCatalog()
 	if err := s.repo.CreateMugVariant(ctx, variant); err != nil {
 		return MugVariant{}, err
 	}
@@ -460,24 +530,36 @@ func (s *Service) CreateMugVariant(ctx context.Context, variant *MugVariant) (Mu
 }
 
 func (s *Service) UpdateMugVariant(ctx context.Context, variant *MugVariant) (MugVariant, error) {
+	defer s.InvalidateCatalog()
 	if err := s.repo.UpdateMugVariant(ctx, variant); err != nil {
 		return MugVariant{}, err
 	}
 	return *variant, nil
 }
 
-func (s *Service) DeleteMugVariant(ctx context.Context, id int) error {
+// PurgeMugVariant permanently deletes an archived mug variant that was never
+// ordered.
+func (s *Service) PurgeMugVariant(ctx context.Context, id int) error {
+	defer s.InvalidateCatalog()
 	variant, err := s.repo.GetMugVariant(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := s.ensurePurgeable(ctx, variant.ArticleID, id, variant.ArchivedAt); err != nil {
+		return err
+	}
 	var exampleImageFilename string
 	if variant.ExampleImageFilename != nil && strings.TrimSpace(*variant.ExampleImageFilename) != "" {
 		exampleImageFilename = filepath.Base(*variant.ExampleImageFilename)
 	}
+	galleryFiles, err := s.variantGalleryFiles(ctx, variant.ArticleID, id)
+	if err != nil {
+		return err
+	}
 	if err := s.repo.DeleteMugVariant(ctx, id); err != nil {
 		return err
 	}
+	removeGalleryFiles(galleryFiles)
 	if strings.TrimSpace(exampleImageFilename) != "" {
 		if storageLocations, err := img.NewStorageLocations(); err == nil {
 			_ = os.Remove(filepath.Join(storageLocations.MugVariantExample(), exampleImageFilename))
@@ -504,8 +586,25 @@ func (s *Service) UpdateShirtVariant(ctx context.Context, variant *ShirtVariant)
 	return *variant, nil
 }
 
-func (s *Service) DeleteShirtVariant(ctx context.Context, id int) error {
-	return s.repo.DeleteShirtVariant(ctx, id)
+// PurgeShirtVariant permanently deletes an archived shirt variant that was
+// never ordered.
+func (s *Service) PurgeShirtVariant(ctx context.Context, id int) error {
+	variant, err := s.repo.GetShirtVariant(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.ensurePurgeable(ctx, variant.ArticleID, id, variant.ArchivedAt); err != nil {
+		return err
+	}
+	galleryFiles, err := s.variantGalleryFiles(ctx, variant.ArticleID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteShirtVariant(ctx, id); err != nil {
+		return err
+	}
+	removeGalleryFiles(galleryFiles)
+	return nil
 }
 
 func (s *Service) GetShirtVariant(ctx context.Context, id int) (ShirtVariant, error) {
@@ -516,14 +615,20 @@ func (s *Service) ListMugVariants(ctx context.Context, articleID int, onlyActive
 	return s.repo.ListMugVariants(ctx, articleID, onlyActive)
 }
 
-func (s *Service) ListShirtVariants(ctx context.Context, articleID int) ([]ShirtVariant, error) {
-	return s.repo.ListShirtVariants(ctx, articleID, false)
+// ListShirtVariants lists the variants of a shirt; onlyActive leaves out
+// archived ones.
+func (s *Service) ListShirtVariants(ctx context.Context, articleID int, onlyActive bool) ([]ShirtVariant, error) {
+	return s.repo.ListShirtVariants(ctx, articleID, onlyActive)
 }
 
 func (s *Service) ListMugArticles(ctx context.Context, onlyActive bool, excludeID *int) ([]Article, error) {
 	return s.repo.ListMugArticles(ctx, onlyActive, excludeID)
 }
 
+func (s *Service) ListShirtArticles(ctx context.Context, onlyActive bool, excludeID *int) ([]Article, error) {
+	return s.repo.ListShirtArticles(ctx, onlyActive, excludeID)
+}
+
 // --- Details & cost ---
 
 func (s *Service) GetMugDetails(ctx context.Context, articleID int) (*MugDetails, error) {
@@ -531,6 +636,7 @@ func (s *Service) GetMugDetails(ctx context.Context, articleID int) (*MugDetails
 }
 
 func (s *Service) UpsertMugDetails(ctx context.Context, details *MugDetails) error {
+	defer s.InvalidateCatalog()
 	return s.repo.UpsertMugDetails(ctx, details)
 }
 
@@ -551,9 +657,17 @@ func (s *Service) GetCostCalculationByID(ctx context.Context, id int) (*Price, e
 }
 
 func (s *Service) UpsertCostCalculation(ctx context.Context, articleID int, calc *Price) error {
+	defer s.InvalidateCatalog()
 	if calc == nil {
 		return nil
 	}
+	if err := s.checkPriceVats(ctx, calc); err != nil {
+		return err
+	}
+	return s.repo.UpsertCostCalculation(ctx, articleID, calc)
+}
+
+func (s *Service) checkPriceVats(ctx context.Context, calc *Price) error {
 	if calc.PurchaseVatRateID != nil {
 		exists, err := s.repo.VatExists(ctx, *calc.PurchaseVatRateID)
 		if err != nil {
@@ -572,7 +686,129 @@ func (s *Service) UpsertCostCalculation(ctx context.Context, articleID int, calc
 			return fmt.Errorf("sales %w: %d", ErrVatNotFound, *calc.SalesVatRateID)
 		}
 	}
-	return s.repo.UpsertCostCalculation(ctx, articleID, calc)
+	return nil
+}
+
+// SetPriceTiers replaces the quantity tiers of a price and returns them as
+// stored, ordered by quantity.
+func (s *Service) SetPriceTiers(ctx context.Context, priceID int, tiers []PriceTier) ([]PriceTier, error) {
+	defer s.InvalidateCatalog()
+	normalized, err := NormalizePriceTiers(tiers)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplacePriceTiers(ctx, priceID, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// GetCostCalculationAt returns the article's price as it was or will be in
+// effect at the given moment, or nil when the article has no price.
+func (s *Service) GetCostCalculationAt(ctx context.Context, articleID int, at time.Time) (*Price, error) {
+	price, err := s.repo.GetCostCalculation(ctx, articleID)
+	if err != nil || price == nil {
+		return price, err
+	}
+	return s.priceAt(ctx, price, at)
+}
+
+// GetCostCalculationByIDAt is GetCostCalculationAt for a price ID, e.g. a
+// prompt price.
+func (s *Service) GetCostCalculationByIDAt(ctx context.Context, id int, at time.Time) (*Price, error) {
+	price, err := s.repo.GetCostCalculationByID(ctx, id)
+	if err != nil || price == nil {
+		return price, err
+	}
+	return s.priceAt(ctx, price, at)
+}
+
+// priceAt overlays the history entry in effect at the given moment onto
+// price. Prices without history that early are returned unchanged.
+func (s *Service) priceAt(ctx context.Context, price *Price, at time.Time) (*Price, error) {
+	entry, err := s.repo.PriceHistoryAt(ctx, price.ID, at)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return price, nil
+	}
+	return price.WithCalculation(&entry.Calculation), nil
+}
+
+// PriceTimeline returns the stored price with its full history, including
+// scheduled changes. It fails with gorm.ErrRecordNotFound for unknown prices.
+func (s *Service) PriceTimeline(ctx context.Context, priceID int) (*PriceTimeline, error) {
+	price, err := s.repo.GetCostCalculationByID(ctx, priceID)
+	if err != nil {
+		return nil, err
+	}
+	if price == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	entries, err := s.repo.ListPriceHistory(ctx, priceID)
+	if err != nil {
+		return nil, err
+	}
+	return &PriceTimeline{Current: price, Entries: entries}, nil
+}
+
+// SchedulePriceChange records calc as the price's calculation from
+// effectiveFrom on. The price itself changes once the change is due and
+// ActivateDuePriceChanges runs.
+func (s *Service) SchedulePriceChange(ctx context.Context, priceID int, calc *Price, effectiveFrom time.Time) (PriceHistoryEntry, error) {
+	if !effectiveFrom.After(time.Now()) {
+		return PriceHistoryEntry{}, ErrPriceChangeNotInFuture
+	}
+	if err := s.checkPriceVats(ctx, calc); err != nil {
+		return PriceHistoryEntry{}, err
+	}
+	entry := PriceHistoryEntry{PriceID: priceID, EffectiveFrom: effectiveFrom, Calculation: *calc}
+	if err := s.repo.CreatePriceHistoryEntry(ctx, &entry); err != nil {
+		return PriceHistoryEntry{}, err
+	}
+	return entry, nil
+}
+
+// CancelScheduledPriceChange removes a change that has not taken effect yet.
+func (s *Service) CancelScheduledPriceChange(ctx context.Context, priceID, entryID int) error {
+	deleted, err := s.repo.DeleteScheduledPriceChange(ctx, priceID, entryID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrScheduledPriceChangeNotFound
+	}
+	return nil
+}
+
+// ActivateDuePriceChanges copies scheduled changes that are due onto their
+// prices and returns how many prices changed.
+func (s *Service) ActivateDuePriceChanges(ctx context.Context) (int, error) {
+	changed, err := s.repo.ApplyDuePriceChanges(ctx, time.Now())
+	if changed > 0 {
+		s.InvalidateCatalog()
+	}
+	return changed, err
+}
+
+// RunPriceScheduler activates due price changes every interval until ctx is
+// done.
+func (s *Service) RunPriceScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if changed, err := s.ActivateDuePriceChanges(ctx); err != nil {
+			slog.Error("activating scheduled price changes failed", "error", err)
+		} else if changed > 0 {
+			slog.Info("activated scheduled price changes", "prices", changed)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
 // --- Helper methods ---
@@ -0,0 +1,88 @@
+package article
+
+import (
+	"sort"
+	"strings"
+)
+
+// Shirt print area positions.
+const (
+	ShirtPrintAreaFront = "FRONT"
+	ShirtPrintAreaBack  = "BACK"
+)
+
+// Sizes returns the comma-separated AvailableSizes as a trimmed list.
+func (d *ShirtDetails) Sizes() []string {
+	sizes := []string{}
+	if d == nil || strings.TrimSpace(d.AvailableSizes) == "" {
+		return sizes
+	}
+	for _, s := range strings.Split(d.AvailableSizes, ",") {
+		trimmed := strings.TrimSpace(s)
+		if trimmed != "" {
+			sizes = append(sizes, trimmed)
+		}
+	}
+	return sizes
+}
+
+// HasSize reports whether size is one of the available sizes, ignoring case.
+// Details without any sizes accept every size.
+func (d *ShirtDetails) HasSize(size string) bool {
+	sizes := d.Sizes()
+	if len(sizes) == 0 {
+		return true
+	}
+	for _, s := range sizes {
+		if strings.EqualFold(s, strings.TrimSpace(size)) {
+			return true
+		}
+	}
+	return false
+}
+
+// DisplayName combines color and size, e.g. "Black / XL".
+func (v *ShirtVariant) DisplayName() string {
+	parts := make([]string, 0, 2)
+	if c := strings.TrimSpace(v.Color); c != "" {
+		parts = append(parts, c)
+	}
+	if s := strings.TrimSpace(v.Size); s != "" {
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " / ")
+}
+
+// groupShirtVariantsByColor groups variants by color in first-seen order.
+// Within a color, variants follow the order of sizes; sizes that are not
+// listed keep their original order after the listed ones.
+func groupShirtVariantsByColor(vs []ShirtVariant, sizes []string) [][]ShirtVariant {
+	rank := make(map[string]int, len(sizes))
+	for i, s := range sizes {
+		rank[strings.ToUpper(s)] = i
+	}
+	sizeRank := func(size string) int {
+		if r, ok := rank[strings.ToUpper(strings.TrimSpace(size))]; ok {
+			return r
+		}
+		return len(sizes)
+	}
+	index := make(map[string]int)
+	groups := make([][]ShirtVariant, 0)
+	for i := range vs {
+		key := strings.ToLower(strings.TrimSpace(vs[i].Color))
+		g, ok := index[key]
+		if !ok {
+			g = len(groups)
+			index[key] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], vs[i])
+	}
+	for _, g := range groups {
+		sort.SliceStable(g, func(a, b int) bool {
+			return sizeRank(g[a].Size) < sizeRank(g[b].Size)
+		})
+	}
+	return groups
+}
@@ -0,0 +1,30 @@
+package article
+
+import "testing"
+
+func TestGroupShirtVariantsByColor(t *testing.T) {
+	vs := []ShirtVariant{
+		{ID: 1, Color: "Black", Size: "XL"},
+		{ID: 2, Color: "White", Size: "M"},
+		{ID: 3, Color: "black", Size: "S"},
+		{ID: 4, Color: "Black", Size: "XXS"},
+		{ID: 5, Color: "Black", Size: "M"},
+	}
+	groups := groupShirtVariantsByColor(vs, []string{"S", "M", "L", "XL"})
+	if len(groups) != 2 {
+		t.Fatalf("groups = %d, want 2", len(groups))
+	}
+	var ids []int
+	for _, v := range groups[0] {
+		ids = append(ids, v.ID)
+	}
+	want := []int{3, 5, 1, 4}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("black order = %v, want %v", ids, want)
+		}
+	}
+	if len(groups[1]) != 1 || groups[1][0].ID != 2 {
+		t.Fatalf("unexpected white group: %+v", groups[1])
+	}
+}
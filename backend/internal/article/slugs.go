@@ -0,0 +1,114 @@
+package article
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/sitemap"
+	"voenix/backend/internal/utility"
+)
+
+// reservedArticleSlugs are static routes next to /api/articles/:slug.
+var reservedArticleSlugs = []string{"categories"}
+
+// assignArticleSlug settles art.Slug before a write. A new article without a
+// slug gets one derived from its name; an existing article without one keeps
+// its stored slug. Any other slug must be valid and not used by another
+// article.
+func (s *Service) assignArticleSlug(ctx context.Context, art *Article) error {
+	var requested *string
+	if art.Slug != "" {
+		requested = &art.Slug
+	}
+	if art.ID != 0 {
+		current, err := s.repo.GetArticle(ctx, art.ID)
+		if err != nil {
+			return err
+		}
+		if current.Slug != "" && (requested == nil || *requested == current.Slug) {
+			art.Slug = current.Slug
+			return nil
+		}
+	}
+	slug, err := utility.ResolveSlug(requested, art.Name, "article", reservedArticleSlugs, func(slug string) (bool, error) {
+		return s.repo.ArticleSlugTaken(ctx, slug, art.ID)
+	})
+	if err != nil {
+		return err
+	}
+	art.Slug = slug
+	return nil
+}
+
+func (s *Service) assignCategorySlug(ctx context.Context, cat *ArticleCategory, requested *string) error {
+	slug, err := utility.ResolveSlug(requested, cat.Name, "category", nil, func(slug string) (bool, error) {
+		return s.repo.CategorySlugTaken(ctx, slug, cat.ID)
+	})
+	if err != nil {
+		return err
+	}
+	cat.Slug = slug
+	return nil
+}
+
+// PublicArticle returns the active article known under slug, now or before
+// a rename, with its details. When Article.Slug differs from slug the caller
+// should redirect to the current slug.
+func (s *Service) PublicArticle(ctx context.Context, slug string) (ArticleDetail, error) {
+	id, err := s.repo.ArticleIDBySlug(ctx, slug)
+	if err != nil {
+		return ArticleDetail{}, err
+	}
+	detail, err := s.GetArticleDetail(ctx, id)
+	if err != nil {
+		return ArticleDetail{}, err
+	}
+	if !detail.Article.Active || detail.Article.ArchivedAt != nil {
+		return ArticleDetail{}, gorm.ErrRecordNotFound
+	}
+	return detail, nil
+}
+
+// PublicCategory returns the category known under slug, now or before a
+// rename, with its active articles.
+func (s *Service) PublicCategory(ctx context.Context, slug string) (ArticleCategory, []Article, error) {
+	id, err := s.repo.CategoryIDBySlug(ctx, slug)
+	if err != nil {
+		return ArticleCategory{}, nil, err
+	}
+	cat, err := s.repo.GetCategory(ctx, id)
+	if err != nil {
+		return ArticleCategory{}, nil, err
+	}
+	articles, err := s.repo.ListActiveArticles(ctx, &id)
+	if err != nil {
+		return ArticleCategory{}, nil, err
+	}
+	return cat, articles, nil
+}
+
+// SitemapEntries lists the storefront pages of active articles and of the
+// categories that have any.
+func (s *Service) SitemapEntries(ctx context.Context) ([]sitemap.Entry, error) {
+	articles, err := s.repo.ListActiveArticles(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	cats, err := s.repo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[int]bool)
+	out := make([]sitemap.Entry, 0, len(articles))
+	for i := range articles {
+		used[articles[i].CategoryID] = true
+		out = append(out, sitemap.Entry{Path: "/articles/" + articles[i].Slug, LastModified: articles[i].UpdatedAt})
+	}
+	for i := range cats {
+		if used[cats[i].ID] {
+			out = append(out, sitemap.Entry{Path: "/articles/categories/" + cats[i].Slug, LastModified: cats[i].UpdatedAt})
+		}
+	}
+	return out, nil
+}
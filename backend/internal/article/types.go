@@ -1,6 +1,10 @@
 package article
 
-import "time"
+import (
+	"time"
+
+	img "voenix/backend/internal/image"
+)
 
 // ArticleType constants
 const (
@@ -11,6 +15,7 @@ const (
 type ArticleCategory struct {
 	ID          int
 	Name        string
+	Slug        string
 	Description *string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
@@ -29,6 +34,7 @@ type ArticleSubCategory struct {
 type Article struct {
 	ID                    int
 	Name                  string
+	Slug                  string
 	DescriptionShort      string
 	DescriptionLong       string
 	Active                bool
@@ -40,11 +46,18 @@ type Article struct {
 	SupplierID            *int
 	SupplierArticleName   *string
 	SupplierArticleNumber *string
-	MugVariants           []MugVariant
-	ShirtVariants         []ShirtVariant
-	CostCalculation       *Price
-	CreatedAt             time.Time
-	UpdatedAt             time.Time
+	// Attributes holds the values of the type's attribute schema; it is empty
+	// for mugs and shirts. The repository stores it as JSON; gorm must not map
+	// it when the struct is used as a model directly.
+	Attributes      map[string]any `gorm:"-"`
+	MugVariants     []MugVariant
+	ShirtVariants   []ShirtVariant
+	CostCalculation *Price
+	// ArchivedAt is set while the article is archived: hidden from the
+	// catalog and carts but still resolvable for orders.
+	ArchivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 type MugVariant struct {
@@ -58,8 +71,15 @@ type MugVariant struct {
 	ArticleVariantNumber *string
 	IsDefault            bool
 	Active               bool
-	CreatedAt            time.Time
-	UpdatedAt            time.Time
+	// MockupPrintArea is where designs go on the example photo; nil when no
+	// mockup is configured. MockupCurvature bends them around the mug. The
+	// repository stores the quad as text; gorm must not map it when the
+	// struct is used as a model directly.
+	MockupPrintArea *img.MockupQuad `gorm:"-"`
+	MockupCurvature float64
+	ArchivedAt      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 type ShirtVariant struct {
@@ -69,6 +89,7 @@ type ShirtVariant struct {
 	Color                string
 	Size                 string
 	ExampleImageFilename *string
+	ArchivedAt           *time.Time
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 }
@@ -95,8 +116,12 @@ type ShirtDetails struct {
 	FitType          string
 	// stored as comma-separated string for simplicity across sqlite/postgres
 	AvailableSizes string
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	// Print area on the shirt in millimetres; PrintAreaPosition is FRONT or BACK.
+	PrintAreaWidthMm  int
+	PrintAreaHeightMm int
+	PrintAreaPosition string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 type Price struct {
@@ -135,6 +160,8 @@ type Price struct {
 	SalesPriceCorresponds    string
 	PurchaseActiveRow        string
 	SalesActiveRow           string
-	CreatedAt                time.Time
-	UpdatedAt                time.Time
+	// Tiers are the quantity breaks on the sales price, ordered by quantity.
+	Tiers     []PriceTier
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
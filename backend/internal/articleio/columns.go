@@ -0,0 +1,221 @@
+package articleio
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Spreadsheet columns. Import matches headers case-insensitively and ignores
+// unknown columns; export always writes all of them in this order. Prices are
+// decimal currency amounts (e.g. 12.50 or 12,50).
+const (
+	colArticleType           = "articleType"
+	colName                  = "name"
+	colDescriptionShort      = "descriptionShort"
+	colDescriptionLong       = "descriptionLong"
+	colActive                = "active"
+	colCategory              = "category"
+	colSubcategory           = "subcategory"
+	colSupplier              = "supplier"
+	colSupplierArticleName   = "supplierArticleName"
+	colSupplierArticleNumber = "supplierArticleNumber"
+
+	colHeightMm                     = "heightMm"
+	colDiameterMm                   = "diameterMm"
+	colPrintTemplateWidthMm         = "printTemplateWidthMm"
+	colPrintTemplateHeightMm        = "printTemplateHeightMm"
+	colDocumentFormatWidthMm        = "documentFormatWidthMm"
+	colDocumentFormatHeightMm       = "documentFormatHeightMm"
+	colDocumentFormatMarginBottomMm = "documentFormatMarginBottomMm"
+	colFillingQuantity              = "fillingQuantity"
+	colDishwasherSafe               = "dishwasherSafe"
+
+	colMaterial          = "material"
+	colCareInstructions  = "careInstructions"
+	colFitType           = "fitType"
+	colAvailableSizes    = "availableSizes"
+	colPrintAreaWidthMm  = "printAreaWidthMm"
+	colPrintAreaHeightMm = "printAreaHeightMm"
+	colPrintAreaPosition = "printAreaPosition"
+
+	colPurchasePriceNet = "purchasePriceNet"
+	colPurchaseCostNet  = "purchaseCostNet"
+	colPurchaseVat      = "purchaseVat"
+	colSalesVat         = "salesVat"
+	colSalesPriceGross  = "salesPriceGross"
+
+	colVariantName      = "variantName"
+	colInsideColorCode  = "insideColorCode"
+	colOutsideColorCode = "outsideColorCode"
+	colVariantNumber    = "variantNumber"
+	colVariantDefault   = "variantDefault"
+	colVariantActive    = "variantActive"
+	colColor            = "color"
+	colSize             = "size"
+)
+
+var columns = []string{
+	colArticleType, colName, colDescriptionShort, colDescriptionLong, colActive,
+	colCategory, colSubcategory, colSupplier, colSupplierArticleName, colSupplierArticleNumber,
+	colHeightMm, colDiameterMm, colPrintTemplateWidthMm, colPrintTemplateHeightMm,
+	colDocumentFormatWidthMm, colDocumentFormatHeightMm, colDocumentFormatMarginBottomMm,
+	colFillingQuantity, colDishwasherSafe,
+	colMaterial, colCareInstructions, colFitType, colAvailableSizes,
+	colPrintAreaWidthMm, colPrintAreaHeightMm, colPrintAreaPosition,
+	colPurchasePriceNet, colPurchaseCostNet, colPurchaseVat, colSalesVat, colSalesPriceGross,
+	colVariantName, colInsideColorCode, colOutsideColorCode, colVariantNumber, colVariantDefault, colVariantActive,
+	colColor, colSize,
+}
+
+var mugDetailColumns = []string{
+	colHeightMm, colDiameterMm, colPrintTemplateWidthMm, colPrintTemplateHeightMm,
+	colDocumentFormatWidthMm, colDocumentFormatHeightMm, colDocumentFormatMarginBottomMm,
+	colFillingQuantity, colDishwasherSafe,
+}
+
+var shirtDetailColumns = []string{
+	colMaterial, colCareInstructions, colFitType, colAvailableSizes,
+	colPrintAreaWidthMm, colPrintAreaHeightMm, colPrintAreaPosition,
+}
+
+var priceColumns = []string{colPurchasePriceNet, colPurchaseCostNet, colPurchaseVat, colSalesVat, colSalesPriceGross}
+
+// row reads typed cells from one data row and collects validation errors.
+type row struct {
+	number int
+	cells  []string
+	index  map[string]int
+	errs   *[]RowError
+}
+
+// headerIndex maps lower-cased column names to their position.
+func headerIndex(header []string) map[string]int {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if key == "" {
+			continue
+		}
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+	return index
+}
+
+func (r *row) fail(column, format string, args ...any) {
+	*r.errs = append(*r.errs, RowError{Row: r.number, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *row) str(column string) string {
+	i, ok := r.index[strings.ToLower(column)]
+	if !ok || i >= len(r.cells) {
+		return ""
+	}
+	return strings.TrimSpace(r.cells[i])
+}
+
+func (r *row) has(columns ...string) bool {
+	for _, c := range columns {
+		if r.str(c) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *row) required(column string) string {
+	v := r.str(column)
+	if v == "" {
+		r.fail(column, "is required")
+	}
+	return v
+}
+
+func (r *row) optStr(column string) *string {
+	if v := r.str(column); v != "" {
+		return &v
+	}
+	return nil
+}
+
+func (r *row) int(column string) int {
+	if v := r.optInt(column); v != nil {
+		return *v
+	}
+	return 0
+}
+
+func (r *row) optInt(column string) *int {
+	v := r.str(column)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		// Spreadsheets often store whole numbers as 95.0.
+		f, ferr := strconv.ParseFloat(v, 64)
+		if ferr != nil || f != math.Trunc(f) {
+			r.fail(column, "must be a whole number")
+			return nil
+		}
+		n = int(f)
+	}
+	if n < 0 {
+		r.fail(column, "must not be negative")
+		return nil
+	}
+	return &n
+}
+
+func (r *row) bool(column string, def bool) bool {
+	switch strings.ToLower(r.str(column)) {
+	case "":
+		return def
+	case "true", "yes", "y", "1", "x", "ja":
+		return true
+	case "false", "no", "n", "0", "nein":
+		return false
+	default:
+		r.fail(column, "must be true or false")
+		return def
+	}
+}
+
+// cents parses a decimal amount such as 12.5 or 12,50 into cents.
+func (r *row) cents(column string) int {
+	v := r.str(column)
+	if v == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", "."), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		r.fail(column, "must be a decimal amount")
+		return 0
+	}
+	if f < 0 {
+		r.fail(column, "must not be negative")
+		return 0
+	}
+	return int(math.Round(f * 100))
+}
+
+func formatCents(cents int) string {
+	return strconv.FormatFloat(float64(cents)/100, 'f', 2, 64)
+}
+
+func formatInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,166 @@
+package articleio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/auth"
+)
+
+// Supported file formats.
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// maxImportSize caps uploaded import files.
+const maxImportSize = 10 << 20
+
+type RowErrorResponse struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportedArticleResponse struct {
+	SupplierArticleNumber string `json:"supplierArticleNumber"`
+	Name                  string `json:"name"`
+	ArticleID             *int   `json:"articleId"`
+	Action                string `json:"action"`
+	Variants              int    `json:"variants"`
+	Rows                  []int  `json:"rows"`
+}
+
+type ImportResultResponse struct {
+	DryRun   bool                      `json:"dryRun"`
+	Created  int                       `json:"created"`
+	Updated  int                       `json:"updated"`
+	Articles []ImportedArticleResponse `json:"articles"`
+	Errors   []RowErrorResponse        `json:"errors"`
+}
+
+func RegisterRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
+	grp := r.Group("/api/admin/articles")
+	grp.Use(auth.RequireAdmin(db))
+
+	// POST /api/admin/articles/import takes a multipart "file" (CSV or XLSX).
+	// With ?dryRun=true the file is validated and the planned changes are
+	// returned without writing anything.
+	grp.POST("/import", func(c *gin.Context) {
+		fileHeader, err := c.FormFile("file")
+		if err != nil || fileHeader == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Missing file"})
+			return
+		}
+		if fileHeader.Size > maxImportSize {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"detail": "Import file is too large"})
+			return
+		}
+		format := strings.ToLower(strings.TrimSpace(c.Query("format")))
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+		}
+		f, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Failed to read file"})
+			return
+		}
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Failed to read file"})
+			return
+		}
+		var rows [][]string
+		switch format {
+		case FormatCSV:
+			rows, err = ReadCSV(bytes.NewReader(data))
+		case FormatXLSX:
+			rows, err = ReadXLSX(bytes.NewReader(data), int64(len(data)))
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Unsupported format: use csv or xlsx"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Failed to parse file: " + err.Error()})
+			return
+		}
+		dryRun := strings.EqualFold(c.Query("dryRun"), "true")
+		result, err := svc.Import(c.Request.Context(), rows, dryRun)
+		if err != nil {
+			if errors.Is(err, ErrEmptyFile) {
+				c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to import articles"})
+			return
+		}
+		status := http.StatusOK
+		if len(result.Errors) > 0 {
+			status = http.StatusUnprocessableEntity
+		}
+		c.JSON(status, toImportResultResponse(result))
+	})
+
+	// GET /api/admin/articles/export?format=csv|xlsx&articleType=MUG|SHIRT
+	grp.GET("/export", func(c *gin.Context) {
+		format := strings.ToLower(strings.TrimSpace(c.DefaultQuery("format", FormatCSV)))
+		if format != FormatCSV && format != FormatXLSX {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Unsupported format: use csv or xlsx"})
+			return
+		}
+		rows, err := svc.Export(c.Request.Context(), c.Query("articleType"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to export articles"})
+			return
+		}
+		var buf bytes.Buffer
+		contentType := "text/csv; charset=utf-8"
+		if format == FormatXLSX {
+			contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+			err = WriteXLSX(&buf, "Articles", rows)
+		} else {
+			err = WriteCSV(&buf, rows)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to export articles"})
+			return
+		}
+		filename := fmt.Sprintf("articles-%s.%s", time.Now().Format("20060102"), format)
+		c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+		c.Data(http.StatusOK, contentType, buf.Bytes())
+	})
+}
+
+func toImportResultResponse(r *ImportResult) ImportResultResponse {
+	out := ImportResultResponse{
+		DryRun:   r.DryRun,
+		Created:  r.Created,
+		Updated:  r.Updated,
+		Articles: make([]ImportedArticleResponse, 0, len(r.Articles)),
+		Errors:   make([]RowErrorResponse, 0, len(r.Errors)),
+	}
+	for _, a := range r.Articles {
+		out.Articles = append(out.Articles, ImportedArticleResponse{
+			SupplierArticleNumber: a.SupplierArticleNumber,
+			Name:                  a.Name,
+			ArticleID:             a.ArticleID,
+			Action:                a.Action,
+			Variants:              a.Variants,
+			Rows:                  a.Rows,
+		})
+	}
+	for _, e := range r.Errors {
+		out.Errors = append(out.Errors, RowErrorResponse{Row: e.Row, Column: e.Column, Message: e.Message})
+	}
+	return out
+}
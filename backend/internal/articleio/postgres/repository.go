@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+	articlepg "voenix/backend/internal/article/postgres"
+	"voenix/backend/internal/articleio"
+)
+
+// Repository provides a Postgres-backed implementation of articleio.Repository.
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+var _ articleio.Repository = (*Repository)(nil)
+
+func (r *Repository) with(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx)
+}
+
+func (r *Repository) LoadReferences(ctx context.Context) (*articleio.References, error) {
+	refs := &articleio.References{
+		Categories:    map[string]int{},
+		CategoryNames: map[int]string{},
+		Subcategories: map[int]map[string]int{},
+		Suppliers:     map[string]int{},
+		Vats:          map[string]articleio.VatRate{},
+		VatsByID:      map[int]articleio.VatRate{},
+	}
+
+	var categories []struct {
+		ID   int
+		Name string
+	}
+	if err := r.with(ctx).Table("article_categories").Select("id, name").Order("id").Scan(&categories).Error; err != nil {
+		return nil, err
+	}
+	for _, c := range categories {
+		addName(refs.Categories, c.Name, c.ID)
+		refs.CategoryNames[c.ID] = c.Name
+	}
+
+	var subcategories []struct {
+		ID                int
+		ArticleCategoryID int
+		Name              string
+	}
+	if err := r.with(ctx).Table("article_sub_categories").Select("id, article_category_id, name").Order("id").Scan(&subcategories).Error; err != nil {
+		return nil, err
+	}
+	for _, s := range subcategories {
+		names, ok := refs.Subcategories[s.ArticleCategoryID]
+		if !ok {
+			names = map[string]int{}
+			refs.Subcategories[s.ArticleCategoryID] = names
+		}
+		addName(names, s.Name, s.ID)
+	}
+
+	var suppliers []struct {
+		ID   int
+		Name *string
+	}
+	if err := r.with(ctx).Table("suppliers").Select("id, name").Order("id").Scan(&suppliers).Error; err != nil {
+		return nil, err
+	}
+	for _, s := range suppliers {
+		if s.Name != nil {
+			addName(refs.Suppliers, *s.Name, s.ID)
+		}
+	}
+
+	var vats []articleio.VatRate
+	if err := r.with(ctx).Table("value_added_taxes").Select("id, name, percent").Order("id").Scan(&vats).Error; err != nil {
+		return nil, err
+	}
+	for _, v := range vats {
+		refs.Vats[strings.ToLower(strings.TrimSpace(v.Name))] = v
+		refs.VatsByID[v.ID] = v
+	}
+	return refs, nil
+}
+
+// addName registers name → id, marking names shared by several records with 0.
+func addName(names map[string]int, name string, id int) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		return
+	}
+	if _, ok := names[key]; ok {
+		names[key] = 0
+		return
+	}
+	names[key] = id
+}
+
+func (r *Repository) ArticleIDsBySupplierNumber(ctx context.Context, numbers []string) (map[string]int, error) {
+	out := make(map[string]int, len(numbers))
+	if len(numbers) == 0 {
+		return out, nil
+	}
+	keys := make([]string, 0, len(numbers))
+	for _, n := range numbers {
+		keys = append(keys, strings.ToLower(n))
+	}
+	var rows []struct {
+		ID                    int
+		SupplierArticleNumber string
+	}
+	err := r.with(ctx).Table("articles").
+		Select("id, supplier_article_number").
+		Where("LOWER(supplier_article_number) IN ?", keys).
+		Order("id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		key := strings.ToLower(row.SupplierArticleNumber)
+		if _, ok := out[key]; !ok {
+			out[key] = row.ID
+		}
+	}
+	return out, nil
+}
+
+func (r *Repository) ListArticleIDs(ctx context.Context, articleType string) ([]int, error) {
+	q := r.with(ctx).Table("articles").Where("archived_at IS NULL").Order("id")
+	if articleType != "" {
+		q = q.Where("article_type = ?", articleType)
+	}
+	var ids []int
+	if err := q.Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *Repository) WithTransaction(ctx context.Context, fn func(articleio.ArticleStore) error) error {
+	return r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(article.NewService(articlepg.NewRepository(tx)))
+	})
+}
@@ -0,0 +1,37 @@
+package articleio
+
+import (
+	"context"
+
+	"voenix/backend/internal/article"
+)
+
+// ArticleStore is the subset of the article service used to read and write
+// articles; *article.Service implements it.
+type ArticleStore interface {
+	GetArticleDetail(ctx context.Context, id int) (article.ArticleDetail, error)
+	CreateArticle(ctx context.Context, art *article.Article, mugDetails *article.MugDetails, shirtDetails *article.ShirtDetails, cost *article.Price, mugVariants []article.MugVariant, shirtVariants []article.ShirtVariant) (article.ArticleDetail, error)
+	UpdateArticle(ctx context.Context, art *article.Article, mugDetails *article.MugDetails, shirtDetails *article.ShirtDetails, cost *article.Price) (article.ArticleDetail, error)
+	CreateMugVariant(ctx context.Context, variant *article.MugVariant) (article.MugVariant, error)
+	UpdateMugVariant(ctx context.Context, variant *article.MugVariant) (article.MugVariant, error)
+	CreateShirtVariant(ctx context.Context, variant *article.ShirtVariant) (article.ShirtVariant, error)
+	UpdateShirtVariant(ctx context.Context, variant *article.ShirtVariant) (article.ShirtVariant, error)
+	InvalidateCatalog()
+	ReindexArticles(ctx context.Context, ids ...int)
+}
+
+// Repository provides the lookups and transaction handling for imports.
+type Repository interface {
+	LoadReferences(ctx context.Context) (*References, error)
+	// ArticleIDsBySupplierNumber maps lower-cased supplier article numbers to
+	// article IDs, matching case-insensitively. Archived articles are
+	// included so imports can refuse to change them.
+	ArticleIDsBySupplierNumber(ctx context.Context, numbers []string) (map[string]int, error)
+	// ListArticleIDs returns the IDs of all articles that are not archived,
+	// optionally of one type, in ID order.
+	ListArticleIDs(ctx context.Context, articleType string) ([]int, error)
+
+	// WithTransaction runs fn with an ArticleStore bound to one database
+	// transaction. If fn returns an error, the transaction is rolled back.
+	WithTransaction(ctx context.Context, fn func(ArticleStore) error) error
+}
@@ -0,0 +1,574 @@
+package articleio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"voenix/backend/internal/article"
+	"voenix/backend/internal/pricing"
+)
+
+// ErrEmptyFile is returned when an import file has no header row.
+var ErrEmptyFile = errors.New("import file is empty")
+
+// Service imports and exports articles as spreadsheet rows.
+type Service struct {
+	repo     Repository
+	articles ArticleStore
+}
+
+func NewService(repo Repository, articles ArticleStore) *Service {
+	return &Service{repo: repo, articles: articles}
+}
+
+// articlePlan is the validated write for one supplier article number.
+type articlePlan struct {
+	summary       int
+	article       article.Article
+	mugDetails    *article.MugDetails
+	shirtDetails  *article.ShirtDetails
+	price         *article.Price
+	mugVariants   []article.MugVariant
+	shirtVariants []article.ShirtVariant
+}
+
+// Import validates rows (header first) and upserts one article per supplier
+// article number. Rows sharing a number describe variants of the same
+// article; article, detail and price columns are read from the first of
+// them. Blank detail or price columns keep what an existing article has.
+// Variants are matched by variant number or name for mugs and by color and
+// size for shirts; unmatched ones are created, none are deleted.
+func (s *Service) Import(ctx context.Context, rows [][]string, dryRun bool) (*ImportResult, error) {
+	if len(rows) == 0 {
+		return nil, ErrEmptyFile
+	}
+	result := &ImportResult{DryRun: dryRun, Articles: []ImportedArticle{}, Errors: []RowError{}}
+	index := headerIndex(rows[0])
+	for _, col := range []string{colSupplierArticleNumber, colName} {
+		if _, ok := index[strings.ToLower(col)]; !ok {
+			result.Errors = append(result.Errors, RowError{Row: 1, Column: col, Message: "column is missing"})
+		}
+	}
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	var order []string
+	groups := map[string][]*row{}
+	for i, cells := range rows[1:] {
+		r := &row{number: i + 2, cells: cells, index: index, errs: &result.Errors}
+		if blankRow(cells) {
+			continue
+		}
+		number := r.required(colSupplierArticleNumber)
+		if number == "" {
+			continue
+		}
+		key := strings.ToLower(number)
+		if _, ok := groups[key]; !ok {
+			order = append(order, number)
+		}
+		groups[key] = append(groups[key], r)
+	}
+
+	refs, err := s.repo.LoadReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existingIDs, err := s.repo.ArticleIDsBySupplierNumber(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*articlePlan, 0, len(order))
+	for _, number := range order {
+		var existing *article.ArticleDetail
+		if id, ok := existingIDs[strings.ToLower(number)]; ok {
+			detail, err := s.articles.GetArticleDetail(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if detail.Article.ArchivedAt != nil {
+				// Updating would change an article the shop no longer shows.
+				groups[strings.ToLower(number)][0].fail(colSupplierArticleNumber, "article %d is archived; restore it first", id)
+				continue
+			}
+			existing = &detail
+		}
+		group := groups[strings.ToLower(number)]
+		summary := ImportedArticle{SupplierArticleNumber: number, Action: ActionCreate}
+		for _, r := range group {
+			summary.Rows = append(summary.Rows, r.number)
+		}
+		if existing != nil {
+			id := existing.Article.ID
+			summary.ArticleID = &id
+			summary.Action = ActionUpdate
+		}
+		plan := planArticle(group, existing, refs)
+		plan.summary = len(result.Articles)
+		summary.Name = plan.article.Name
+		summary.Variants = len(plan.mugVariants) + len(plan.shirtVariants)
+		result.Articles = append(result.Articles, summary)
+		plans = append(plans, plan)
+		if existing != nil {
+			result.Updated++
+		} else {
+			result.Created++
+		}
+	}
+
+	if len(result.Errors) > 0 || dryRun {
+		return result, nil
+	}
+	err = s.repo.WithTransaction(ctx, func(store ArticleStore) error {
+		for _, plan := range plans {
+			if err := applyPlan(ctx, store, plan); err != nil {
+				return err
+			}
+			id := plan.article.ID
+			result.Articles[plan.summary].ArticleID = &id
+		}
+		return nil
+	})
+	s.articles.InvalidateCatalog()
+	if err != nil {
+		return nil, err
+	}
+	// The transaction's store has no search index, so the articles are
+	// reindexed once the import is committed.
+	ids := make([]int, 0, len(result.Articles))
+	for _, a := range result.Articles {
+		ids = append(ids, *a.ArticleID)
+	}
+	s.articles.ReindexArticles(ctx, ids...)
+	return result, nil
+}
+
+func applyPlan(ctx context.Context, store ArticleStore, plan *articlePlan) error {
+	if plan.article.ID == 0 {
+		_, err := store.CreateArticle(ctx, &plan.article, plan.mugDetails, plan.shirtDetails, plan.price, plan.mugVariants, plan.shirtVariants)
+		return err
+	}
+	if _, err := store.UpdateArticle(ctx, &plan.article, plan.mugDetails, plan.shirtDetails, plan.price); err != nil {
+		return err
+	}
+	for i := range plan.mugVariants {
+		v := &plan.mugVariants[i]
+		v.ArticleID = plan.article.ID
+		var err error
+		if v.ID == 0 {
+			_, err = store.CreateMugVariant(ctx, v)
+		} else {
+			_, err = store.UpdateMugVariant(ctx, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for i := range plan.shirtVariants {
+		v := &plan.shirtVariants[i]
+		v.ArticleID = plan.article.ID
+		var err error
+		if v.ID == 0 {
+			_, err = store.CreateShirtVariant(ctx, v)
+		} else {
+			_, err = store.UpdateShirtVariant(ctx, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func blankRow(cells []string) bool {
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// planArticle builds the write for one group of rows and records validation
+// errors on the rows.
+func planArticle(group []*row, existing *article.ArticleDetail, refs *References) *articlePlan {
+	first := group[0]
+	plan := &articlePlan{}
+	art := &plan.article
+	if existing != nil {
+		*art = existing.Article
+		art.MugVariants, art.ShirtVariants, art.CostCalculation = nil, nil, nil
+	} else {
+		art.Active = true
+	}
+
+	articleType := strings.ToUpper(first.str(colArticleType))
+	switch {
+	case articleType == "" && existing != nil:
+	case articleType == "":
+		first.fail(colArticleType, "is required")
+	case articleType != article.ArticleTypeMug && articleType != article.ArticleTypeShirt:
+		first.fail(colArticleType, "must be %s or %s", article.ArticleTypeMug, article.ArticleTypeShirt)
+	case existing != nil && articleType != existing.Article.ArticleType:
+		first.fail(colArticleType, "cannot change from %s to %s", existing.Article.ArticleType, articleType)
+	default:
+		art.ArticleType = articleType
+	}
+
+	art.Name = first.required(colName)
+	art.DescriptionShort = first.str(colDescriptionShort)
+	art.DescriptionLong = first.str(colDescriptionLong)
+	art.Active = first.bool(colActive, art.Active)
+	art.SupplierArticleName = first.optStr(colSupplierArticleName)
+	number := first.str(colSupplierArticleNumber)
+	art.SupplierArticleNumber = &number
+
+	if name := first.required(colCategory); name != "" {
+		if id, ok := resolveName(first, colCategory, name, refs.Categories); ok {
+			art.CategoryID = id
+		}
+	}
+	art.SubcategoryID = nil
+	if name := first.str(colSubcategory); name != "" && art.CategoryID != 0 {
+		if id, ok := resolveName(first, colSubcategory, name, refs.Subcategories[art.CategoryID]); ok {
+			art.SubcategoryID = &id
+		}
+	}
+	art.SupplierID = nil
+	if name := first.str(colSupplier); name != "" {
+		if id, ok := resolveName(first, colSupplier, name, refs.Suppliers); ok {
+			art.SupplierID = &id
+		}
+	}
+
+	var current *article.Price
+	if existing != nil {
+		current = existing.CostCalculation
+	}
+	plan.price = planPrice(first, current, refs)
+
+	switch art.ArticleType {
+	case article.ArticleTypeMug:
+		plan.mugDetails = planMugDetails(first)
+		if plan.mugDetails == nil && existing == nil {
+			first.fail("", "mug articles need %s, %s, %s and %s", colHeightMm, colDiameterMm, colPrintTemplateWidthMm, colPrintTemplateHeightMm)
+		}
+		var current []article.MugVariant
+		if existing != nil {
+			current = existing.MugVariants
+		}
+		plan.mugVariants = planMugVariants(group, current)
+	case article.ArticleTypeShirt:
+		plan.shirtDetails = planShirtDetails(first)
+		details := plan.shirtDetails
+		if details == nil && existing != nil {
+			details = existing.ShirtDetails
+		}
+		if details == nil {
+			first.fail("", "shirt articles need %s, %s and %s", colMaterial, colFitType, colAvailableSizes)
+		}
+		var current []article.ShirtVariant
+		if existing != nil {
+			current = existing.ShirtVariants
+		}
+		plan.shirtVariants = planShirtVariants(group, current, details)
+	}
+	return plan
+}
+
+// resolveName looks up a lower-cased name, reporting unknown and ambiguous
+// names on the row.
+func resolveName(r *row, column, name string, ids map[string]int) (int, bool) {
+	id, ok := ids[strings.ToLower(name)]
+	switch {
+	case !ok:
+		r.fail(column, "unknown %s %q", column, name)
+		return 0, false
+	case id == 0:
+		r.fail(column, "%q matches more than one %s", name, column)
+		return 0, false
+	}
+	return id, true
+}
+
+func resolveVat(r *row, column string, refs *References) (*VatRate, bool) {
+	name := r.str(column)
+	if name == "" {
+		return nil, true
+	}
+	vat, ok := refs.Vats[strings.ToLower(name)]
+	if !ok {
+		r.fail(column, "unknown VAT rate %q", name)
+		return nil, false
+	}
+	return &vat, true
+}
+
+func planPrice(r *row, current *article.Price, refs *References) *article.Price {
+	if !r.has(priceColumns...) {
+		return nil
+	}
+	price := &article.Price{
+		PurchasePriceUnit:        "PER_PIECE",
+		SalesPriceUnit:           "PER_PIECE",
+		PurchasePriceCorresponds: "NET",
+		SalesPriceCorresponds:    "NET",
+	}
+	if current != nil {
+		*price = *current
+	}
+	purchaseVat, ok1 := resolveVat(r, colPurchaseVat, refs)
+	salesVat, ok2 := resolveVat(r, colSalesVat, refs)
+	purchaseNet := r.cents(colPurchasePriceNet)
+	costNet := r.cents(colPurchaseCostNet)
+	salesGross := r.cents(colSalesPriceGross)
+	if !ok1 || !ok2 {
+		return nil
+	}
+	price.PurchaseVatRateID, price.PurchaseVatRatePercent = nil, 0
+	if purchaseVat != nil {
+		price.PurchaseVatRateID, price.PurchaseVatRatePercent = &purchaseVat.ID, float64(purchaseVat.Percent)
+	}
+	price.SalesVatRateID, price.SalesVatRatePercent = nil, 0
+	if salesVat != nil {
+		price.SalesVatRateID, price.SalesVatRatePercent = &salesVat.ID, float64(salesVat.Percent)
+	}
+	if err := pricing.CalculateFromSalesGross(price, purchaseNet, costNet, salesGross); err != nil {
+		r.fail(colSalesPriceGross, "%v", err)
+		return nil
+	}
+	return price
+}
+
+func planMugDetails(r *row) *article.MugDetails {
+	if !r.has(mugDetailColumns...) {
+		return nil
+	}
+	for _, col := range []string{colHeightMm, colDiameterMm, colPrintTemplateWidthMm, colPrintTemplateHeightMm} {
+		r.required(col)
+	}
+	return &article.MugDetails{
+		HeightMm:                     r.int(colHeightMm),
+		DiameterMm:                   r.int(colDiameterMm),
+		PrintTemplateWidthMm:         r.int(colPrintTemplateWidthMm),
+		PrintTemplateHeightMm:        r.int(colPrintTemplateHeightMm),
+		DocumentFormatWidthMm:        r.optInt(colDocumentFormatWidthMm),
+		DocumentFormatHeightMm:       r.optInt(colDocumentFormatHeightMm),
+		DocumentFormatMarginBottomMm: r.optInt(colDocumentFormatMarginBottomMm),
+		FillingQuantity:              r.optStr(colFillingQuantity),
+		DishwasherSafe:               r.bool(colDishwasherSafe, false),
+	}
+}
+
+func planShirtDetails(r *row) *article.ShirtDetails {
+	if !r.has(shirtDetailColumns...) {
+		return nil
+	}
+	details := &article.ShirtDetails{
+		Material:          r.required(colMaterial),
+		CareInstructions:  r.optStr(colCareInstructions),
+		FitType:           r.required(colFitType),
+		PrintAreaWidthMm:  r.int(colPrintAreaWidthMm),
+		PrintAreaHeightMm: r.int(colPrintAreaHeightMm),
+		PrintAreaPosition: article.ShirtPrintAreaFront,
+	}
+	var sizes []string
+	for _, s := range strings.Split(r.required(colAvailableSizes), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			sizes = append(sizes, s)
+		}
+	}
+	details.AvailableSizes = strings.Join(sizes, ",")
+	switch position := strings.ToUpper(r.str(colPrintAreaPosition)); position {
+	case "", article.ShirtPrintAreaFront:
+	case article.ShirtPrintAreaBack:
+		details.PrintAreaPosition = position
+	default:
+		r.fail(colPrintAreaPosition, "must be %s or %s", article.ShirtPrintAreaFront, article.ShirtPrintAreaBack)
+	}
+	return details
+}
+
+var mugVariantColumns = []string{colVariantName, colInsideColorCode, colOutsideColorCode, colVariantNumber, colVariantDefault, colVariantActive}
+
+func planMugVariants(group []*row, current []article.MugVariant) []article.MugVariant {
+	var out []article.MugVariant
+	seen := map[string]int{}
+	for _, r := range group {
+		if !r.has(mugVariantColumns...) {
+			continue
+		}
+		name := r.required(colVariantName)
+		number := r.optStr(colVariantNumber)
+		key := "name:" + strings.ToLower(name)
+		if number != nil {
+			key = "number:" + strings.ToLower(*number)
+		}
+		if prev, dup := seen[key]; dup {
+			r.fail(colVariantName, "duplicates the variant in row %d", prev)
+			continue
+		}
+		seen[key] = r.number
+
+		v := article.MugVariant{Active: true}
+		for i := range current {
+			c := &current[i]
+			if number != nil && c.ArticleVariantNumber != nil && strings.EqualFold(*c.ArticleVariantNumber, *number) ||
+				number == nil && strings.EqualFold(c.Name, name) {
+				v = *c
+				break
+			}
+		}
+		v.Name = name
+		v.ArticleVariantNumber = number
+		v.InsideColorCode = r.str(colInsideColorCode)
+		v.OutsideColorCode = r.str(colOutsideColorCode)
+		v.IsDefault = r.bool(colVariantDefault, v.IsDefault)
+		v.Active = r.bool(colVariantActive, v.Active)
+		out = append(out, v)
+	}
+	return out
+}
+
+func planShirtVariants(group []*row, current []article.ShirtVariant, details *article.ShirtDetails) []article.ShirtVariant {
+	var out []article.ShirtVariant
+	seen := map[string]int{}
+	for _, r := range group {
+		if !r.has(colColor, colSize) {
+			continue
+		}
+		color := r.required(colColor)
+		size := r.required(colSize)
+		if color == "" || size == "" {
+			continue
+		}
+		if details != nil && !details.HasSize(size) {
+			r.fail(colSize, "size %q is not one of the available sizes %s", size, details.AvailableSizes)
+			continue
+		}
+		key := strings.ToLower(color) + "\x00" + strings.ToLower(size)
+		if prev, dup := seen[key]; dup {
+			r.fail(colSize, "duplicates the variant in row %d", prev)
+			continue
+		}
+		seen[key] = r.number
+
+		v := article.ShirtVariant{}
+		for i := range current {
+			if strings.EqualFold(current[i].Color, color) && strings.EqualFold(current[i].Size, size) {
+				v = current[i]
+				break
+			}
+		}
+		v.Color = color
+		v.Size = size
+		out = append(out, v)
+	}
+	return out
+}
+
+// Export returns all articles, optionally of one type, as rows in the import
+// format: a header followed by one row per variant, or a single row for
+// articles without variants.
+func (s *Service) Export(ctx context.Context, articleType string) ([][]string, error) {
+	articleType = strings.ToUpper(strings.TrimSpace(articleType))
+	ids, err := s.repo.ListArticleIDs(ctx, articleType)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := s.repo.LoadReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows := [][]string{columns}
+	for _, id := range ids {
+		detail, err := s.articles.GetArticleDetail(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("export article %d: %w", id, err)
+		}
+		rows = append(rows, exportRows(&detail, refs)...)
+	}
+	return rows, nil
+}
+
+func exportRows(d *article.ArticleDetail, refs *References) [][]string {
+	base := map[string]string{}
+	a := &d.Article
+	base[colArticleType] = a.ArticleType
+	base[colName] = a.Name
+	base[colDescriptionShort] = a.DescriptionShort
+	base[colDescriptionLong] = a.DescriptionLong
+	base[colActive] = fmt.Sprint(a.Active)
+	base[colCategory] = d.CategoryName
+	base[colSubcategory] = deref(d.SubcategoryName)
+	base[colSupplier] = deref(d.SupplierName)
+	base[colSupplierArticleName] = deref(a.SupplierArticleName)
+	base[colSupplierArticleNumber] = deref(a.SupplierArticleNumber)
+
+	if m := d.MugDetails; m != nil {
+		base[colHeightMm] = fmt.Sprint(m.HeightMm)
+		base[colDiameterMm] = fmt.Sprint(m.DiameterMm)
+		base[colPrintTemplateWidthMm] = fmt.Sprint(m.PrintTemplateWidthMm)
+		base[colPrintTemplateHeightMm] = fmt.Sprint(m.PrintTemplateHeightMm)
+		base[colDocumentFormatWidthMm] = formatInt(m.DocumentFormatWidthMm)
+		base[colDocumentFormatHeightMm] = formatInt(m.DocumentFormatHeightMm)
+		base[colDocumentFormatMarginBottomMm] = formatInt(m.DocumentFormatMarginBottomMm)
+		base[colFillingQuantity] = deref(m.FillingQuantity)
+		base[colDishwasherSafe] = fmt.Sprint(m.DishwasherSafe)
+	}
+	if sd := d.ShirtDetails; sd != nil {
+		base[colMaterial] = sd.Material
+		base[colCareInstructions] = deref(sd.CareInstructions)
+		base[colFitType] = sd.FitType
+		base[colAvailableSizes] = sd.AvailableSizes
+		base[colPrintAreaWidthMm] = fmt.Sprint(sd.PrintAreaWidthMm)
+		base[colPrintAreaHeightMm] = fmt.Sprint(sd.PrintAreaHeightMm)
+		base[colPrintAreaPosition] = sd.PrintAreaPosition
+	}
+	if p := d.CostCalculation; p != nil {
+		base[colPurchasePriceNet] = formatCents(p.PurchasePriceNet)
+		base[colPurchaseCostNet] = formatCents(p.PurchaseCostNet)
+		base[colSalesPriceGross] = formatCents(p.SalesTotalGross)
+		if p.PurchaseVatRateID != nil {
+			base[colPurchaseVat] = refs.VatsByID[*p.PurchaseVatRateID].Name
+		}
+		if p.SalesVatRateID != nil {
+			base[colSalesVat] = refs.VatsByID[*p.SalesVatRateID].Name
+		}
+	}
+
+	var variants []map[string]string
+	for _, v := range d.MugVariants {
+		variants = append(variants, map[string]string{
+			colVariantName:      v.Name,
+			colInsideColorCode:  v.InsideColorCode,
+			colOutsideColorCode: v.OutsideColorCode,
+			colVariantNumber:    deref(v.ArticleVariantNumber),
+			colVariantDefault:   fmt.Sprint(v.IsDefault),
+			colVariantActive:    fmt.Sprint(v.Active),
+		})
+	}
+	for _, v := range d.ShirtVariants {
+		variants = append(variants, map[string]string{colColor: v.Color, colSize: v.Size})
+	}
+	if len(variants) == 0 {
+		variants = append(variants, nil)
+	}
+	out := make([][]string, 0, len(variants))
+	for _, variant := range variants {
+		cells := make([]string, len(columns))
+		for i, col := range columns {
+			if v, ok := variant[col]; ok {
+				cells[i] = v
+			} else {
+				cells[i] = base[col]
+			}
+		}
+		out = append(out, cells)
+	}
+	return out
+}
@@ -0,0 +1,259 @@
+package articleio
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+	"voenix/backend/internal/search"
+	searchPg "voenix/backend/internal/search/postgres"
+)
+
+type fakeRepo struct {
+	refs  References
+	ids   map[string]int
+	txs   int
+	store ArticleStore
+}
+
+func (f *fakeRepo) LoadReferences(context.Context) (*References, error) { return &f.refs, nil }
+
+func (f *fakeRepo) ArticleIDsBySupplierNumber(_ context.Context, numbers []string) (map[string]int, error) {
+	out := map[string]int{}
+	for _, n := range numbers {
+		if id, ok := f.ids[strings.ToLower(n)]; ok {
+			out[strings.ToLower(n)] = id
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeRepo) ListArticleIDs(context.Context, string) ([]int, error) {
+	return []int{7}, nil
+}
+
+func (f *fakeRepo) WithTransaction(_ context.Context, fn func(ArticleStore) error) error {
+	f.txs++
+	return fn(f.store)
+}
+
+type fakeStore struct {
+	ArticleStore
+	details map[int]article.ArticleDetail
+	index   search.Index
+}
+
+func (f *fakeStore) CreateArticle(_ context.Context, art *article.Article, _ *article.MugDetails, _ *article.ShirtDetails, _ *article.Price, _ []article.MugVariant, _ []article.ShirtVariant) (article.ArticleDetail, error) {
+	art.ID = len(f.details) + 100
+	detail := article.ArticleDetail{ArticleAdminItem: article.ArticleAdminItem{Article: *art}}
+	f.details[art.ID] = detail
+	return detail, nil
+}
+
+// ReindexArticles indexes the names of the stored articles like the article
+// service does with its search index set.
+func (f *fakeStore) ReindexArticles(ctx context.Context, ids ...int) {
+	for _, id := range ids {
+		a := f.details[id].Article
+		_ = f.index.Upsert(ctx, search.Document{Kind: search.KindArticle, RefID: a.ID, Title: a.Name})
+	}
+}
+
+func (f *fakeStore) GetArticleDetail(_ context.Context, id int) (article.ArticleDetail, error) {
+	return f.details[id], nil
+}
+
+func (f *fakeStore) InvalidateCatalog() {}
+
+func newFakes() (*fakeRepo, *fakeStore) {
+	repo := &fakeRepo{
+		refs: References{
+			Categories:    map[string]int{"mugs": 1, "dup": 0},
+			CategoryNames: map[int]string{1: "Mugs"},
+			Subcategories: map[int]map[string]int{1: {"ceramic": 3}},
+			Suppliers:     map[string]int{"acme": 5},
+			Vats:          map[string]VatRate{"standard": {ID: 2, Name: "Standard", Percent: 19}},
+			VatsByID:      map[int]VatRate{2: {ID: 2, Name: "Standard", Percent: 19}},
+		},
+		ids: map[string]int{"m-1": 7},
+	}
+	sku := "WHITE-1"
+	number := "M-1"
+	salesVat := 2
+	store := &fakeStore{details: map[int]article.ArticleDetail{7: {
+		ArticleAdminItem: article.ArticleAdminItem{
+			Article:      article.Article{ID: 7, Name: "Classic", ArticleType: article.ArticleTypeMug, CategoryID: 1, Active: true, SupplierArticleNumber: &number},
+			CategoryName: "Mugs",
+			MugVariants:  []article.MugVariant{{ID: 11, ArticleID: 7, Name: "White", ArticleVariantNumber: &sku, Active: true}},
+		},
+		MugDetails:      &article.MugDetails{ArticleID: 7, HeightMm: 95, DiameterMm: 82, PrintTemplateWidthMm: 200, PrintTemplateHeightMm: 80},
+		CostCalculation: &article.Price{ID: 4, SalesVatRateID: &salesVat, SalesVatRatePercent: 19, SalesTotalGross: 1190},
+	}}}
+	repo.store = store
+	return repo, store
+}
+
+func TestImportDryRunPlansWithoutWriting(t *testing.T) {
+	repo, store := newFakes()
+	svc := NewService(repo, store)
+	rows := [][]string{
+		{"articleType", "Name", "category", "subcategory", "supplier", "supplierArticleNumber", "heightMm", "diameterMm", "printTemplateWidthMm", "printTemplateHeightMm", "salesVat", "salesPriceGross", "variantName", "variantNumber"},
+		{"MUG", "Classic", "Mugs", "Ceramic", "ACME", "M-1", "", "", "", "", "Standard", "14,28", "White", "WHITE-1"},
+		{"MUG", "Classic", "Mugs", "Ceramic", "ACME", "M-1", "", "", "", "", "", "", "Black", "BLACK-1"},
+		{"MUG", "Tall", "Mugs", "", "", "M-2", "120", "80", "210", "95", "", "", "", ""},
+	}
+	result, err := svc.Import(context.Background(), rows, true)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %+v", result.Errors)
+	}
+	if result.Created != 1 || result.Updated != 1 || repo.txs != 0 {
+		t.Fatalf("created=%d updated=%d txs=%d", result.Created, result.Updated, repo.txs)
+	}
+	if got := result.Articles[0]; got.Action != ActionUpdate || got.Variants != 2 || len(got.Rows) != 2 {
+		t.Fatalf("unexpected update plan: %+v", got)
+	}
+
+	existing := store.details[7]
+	plan := planArticle(testRows(rows, 1, 2), &existing, &repo.refs)
+	if plan.mugVariants[0].ID != 11 || plan.mugVariants[1].ID != 0 {
+		t.Fatalf("expected the first variant to match by number: %+v", plan.mugVariants)
+	}
+	if plan.price == nil || plan.price.SalesTotalGross != 1428 || plan.price.SalesTotalNet != 1200 {
+		t.Fatalf("unexpected price: %+v", plan.price)
+	}
+	if plan.article.SubcategoryID == nil || *plan.article.SubcategoryID != 3 || plan.article.SupplierID == nil || *plan.article.SupplierID != 5 {
+		t.Fatalf("references not resolved: %+v", plan.article)
+	}
+}
+
+func TestImportedArticlesCanBeFoundBySearch(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	ctx := context.Background()
+	index := search.NewService(searchPg.NewRepository(db))
+	repo, store := newFakes()
+	store.index = index
+	svc := NewService(repo, store)
+	rows := [][]string{
+		{"articleType", "Name", "category", "supplierArticleNumber", "heightMm", "diameterMm", "printTemplateWidthMm", "printTemplateHeightMm"},
+		{"MUG", "Thermobecher", "Mugs", "M-2", "120", "80", "210", "95"},
+	}
+	result, err := svc.Import(ctx, rows, false)
+	if err != nil || len(result.Errors) != 0 {
+		t.Fatalf("import: %+v, %v", result, err)
+	}
+	got, err := index.Search(ctx, search.Query{Text: "thermobecher"})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(got) != 1 || got[0].RefID != *result.Articles[0].ArticleID {
+		t.Fatalf("imported article should be found: %+v", got)
+	}
+}
+
+// testRows builds parsed rows for planArticle from the header and the given lines.
+func testRows(rows [][]string, lines ...int) []*row {
+	var errs []RowError
+	index := headerIndex(rows[0])
+	out := make([]*row, 0, len(lines))
+	for _, l := range lines {
+		out = append(out, &row{number: l + 1, cells: rows[l], index: index, errs: &errs})
+	}
+	return out
+}
+
+func TestImportReportsRowErrors(t *testing.T) {
+	repo, store := newFakes()
+	svc := NewService(repo, store)
+	rows := [][]string{
+		{"articleType", "name", "category", "supplier", "supplierArticleNumber", "salesVat", "salesPriceGross", "heightMm"},
+		{"CAP", "Cap", "Mugs", "", "C-1", "", "", ""},
+		{"MUG", "Mug", "Dup", "Nobody", "M-9", "Reduced", "abc", "9.5"},
+		{"MUG", "", "Mugs", "", "", "", "", ""},
+		{"MUG", "Old", "Mugs", "", "A-1", "", "", ""},
+	}
+	archivedAt := time.Now()
+	repo.ids["a-1"] = 8
+	store.details[8] = article.ArticleDetail{ArticleAdminItem: article.ArticleAdminItem{
+		Article: article.Article{ID: 8, Name: "Old", ArticleType: article.ArticleTypeMug, CategoryID: 1, ArchivedAt: &archivedAt},
+	}}
+	result, err := svc.Import(context.Background(), rows, false)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if repo.txs != 0 {
+		t.Fatalf("nothing should be written when rows are invalid")
+	}
+	want := map[string]bool{
+		"row 2, articleType: must be MUG or SHIRT":                              true,
+		"row 3, category: \"Dup\" matches more than one category":               true,
+		"row 3, supplier: unknown supplier \"Nobody\"":                          true,
+		"row 3, salesVat: unknown VAT rate \"Reduced\"":                         true,
+		"row 3, salesPriceGross: must be a decimal amount":                      true,
+		"row 3, heightMm: must be a whole number":                               true,
+		"row 4, supplierArticleNumber: is required":                             true,
+		"row 3, diameterMm: is required":                                        true,
+		"row 3, printTemplateWidthMm: is required":                              true,
+		"row 3, printTemplateHeightMm: is required":                             true,
+		"row 5, supplierArticleNumber: article 8 is archived; restore it first": true,
+	}
+	for _, e := range result.Errors {
+		delete(want, e.Error())
+	}
+	if len(want) != 0 {
+		t.Fatalf("missing errors %v in %+v", want, result.Errors)
+	}
+}
+
+func TestExportRoundTripsThroughXLSX(t *testing.T) {
+	repo, store := newFakes()
+	svc := NewService(repo, store)
+	rows, err := svc.Export(context.Background(), "")
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, "Articles", rows); err != nil {
+		t.Fatalf("write xlsx: %v", err)
+	}
+	read, err := ReadXLSX(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("read xlsx: %v", err)
+	}
+	if len(read) != 2 || len(read[0]) != len(columns) {
+		t.Fatalf("unexpected rows: %v", read)
+	}
+	cell := func(col string) string { return read[1][headerIndex(read[0])[strings.ToLower(col)]] }
+	if cell(colSalesPriceGross) != "11.90" || cell(colSalesVat) != "Standard" || cell(colVariantNumber) != "WHITE-1" {
+		t.Fatalf("unexpected export row: %v", read[1])
+	}
+
+	result, err := svc.Import(context.Background(), read, true)
+	if err != nil {
+		t.Fatalf("re-import: %v", err)
+	}
+	if len(result.Errors) != 0 || result.Updated != 1 {
+		t.Fatalf("exported file should re-import cleanly: %+v", result)
+	}
+}
+
+func TestReadCSVDetectsSemicolons(t *testing.T) {
+	rows, err := ReadCSV(strings.NewReader("\ufeffname;salesPriceGross\nMug;\"12,50\"\n"))
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(rows) != 2 || rows[1][1] != "12,50" {
+		t.Fatalf("unexpected rows: %v", rows)
+	}
+}
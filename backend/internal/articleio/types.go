@@ -0,0 +1,64 @@
+package articleio
+
+import "fmt"
+
+// Import actions reported per article.
+const (
+	ActionCreate = "CREATE"
+	ActionUpdate = "UPDATE"
+)
+
+// RowError is a validation problem in one spreadsheet row. Row is the
+// 1-based line number including the header, as shown by spreadsheet tools.
+type RowError struct {
+	Row     int
+	Column  string
+	Message string
+}
+
+func (e RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+	}
+	return fmt.Sprintf("row %d, %s: %s", e.Row, e.Column, e.Message)
+}
+
+// ImportedArticle summarizes what an import does (or would do in a dry run)
+// with the rows sharing one supplier article number.
+type ImportedArticle struct {
+	SupplierArticleNumber string
+	Name                  string
+	ArticleID             *int
+	Action                string
+	Variants              int
+	Rows                  []int
+}
+
+// ImportResult is the outcome of an import. Nothing is written when Errors is
+// non-empty or DryRun is set.
+type ImportResult struct {
+	DryRun   bool
+	Created  int
+	Updated  int
+	Articles []ImportedArticle
+	Errors   []RowError
+}
+
+// VatRate is a VAT entry resolvable by name.
+type VatRate struct {
+	ID      int
+	Name    string
+	Percent int
+}
+
+// References holds the names that import rows may refer to. Name keys are
+// lower-cased; an ID of 0 marks a name shared by several records.
+type References struct {
+	Categories    map[string]int
+	CategoryNames map[int]string
+	// Subcategories maps category ID to subcategory name to ID.
+	Subcategories map[int]map[string]int
+	Suppliers     map[string]int
+	Vats          map[string]VatRate
+	VatsByID      map[int]VatRate
+}
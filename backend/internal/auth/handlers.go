@@ -57,6 +57,13 @@ func sessionTTL() time.Duration {
 	return def
 }
 
+// LoginHook runs after a user signed in, before the login response is
+// written, e.g. to carry over state the visitor built up before.
+type LoginHook func(c *gin.Context, u *User)
+
+// OnLogin registers hook to run on every successful login.
+func (s *Service) OnLogin(hook LoginHook) { s.loginHooks = append(s.loginHooks, hook) }
+
 // RegisterRoutes mounts the auth handlers under /api/auth
 func RegisterRoutes(r *gin.Engine, svc *Service) {
 	UseService(svc)
@@ -117,6 +124,9 @@ func RegisterRoutes(r *gin.Engine, svc *Service) {
 		// gin SetCookie(name, value, maxAge, path, domain, secure, httpOnly)
 		c.SetSameSite(http.SameSiteLaxMode)
 		c.SetCookie("session_id", sid, maxAge, "/", "", false, true)
+		for _, hook := range svc.loginHooks {
+			hook(c, u)
+		}
 
 		roles := RoleNames(u)
 		c.JSON(http.StatusOK, loginResponse{User: toPublic(u), SessionID: sid, Roles: roles})
@@ -16,8 +16,9 @@ var activeService atomic.Pointer[Service]
 
 // Service coordinates auth workflows on top of the repository abstraction.
 type Service struct {
-	repo Repository
-	now  func() time.Time
+	repo       Repository
+	now        func() time.Time
+	loginHooks []LoginHook
 }
 
 // NewService constructs a Service with the provided repository.
@@ -0,0 +1,224 @@
+package cart
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+const (
+	// defaultAbandonedCartIdle is how long a user's cart may stay unchanged
+	// before it is marked abandoned unless SetAbandonedCartIdle says otherwise.
+	defaultAbandonedCartIdle = 24 * time.Hour
+	// recoveryTTL is how long after a cart was abandoned its reminder is
+	// sent and its recovery link works.
+	recoveryTTL = 14 * 24 * time.Hour
+	// maxReportDays bounds the range of the abandoned cart report.
+	maxReportDays = 366
+)
+
+var (
+	ErrRecoveryNotFound = errors.New("recovery link not found")
+	ErrRecoveryExpired  = errors.New("recovery link expired")
+)
+
+// AbandonedCartReminder is what a notifier needs to remind a user of the
+// cart they left.
+type AbandonedCartReminder struct {
+	CartID      int
+	UserID      int
+	ItemCount   int
+	Value       int
+	AbandonedAt time.Time
+	// RecoveryPath is the storefront path that restores the cart, relative
+	// to the shop's base URL.
+	RecoveryPath string
+}
+
+// AbandonedCartNotifier sends the reminder for an abandoned cart, e.g. by
+// email.
+type AbandonedCartNotifier interface {
+	NotifyAbandonedCart(ctx context.Context, reminder AbandonedCartReminder) error
+}
+
+// LogNotifier logs reminders instead of sending them. It stands in until the
+// shop sends emails.
+type LogNotifier struct{}
+
+func (LogNotifier) NotifyAbandonedCart(_ context.Context, r AbandonedCartReminder) error {
+	slog.Info("abandoned cart reminder", "cart", r.CartID, "user", r.UserID, "value", r.Value, "recoveryPath", r.RecoveryPath)
+	return nil
+}
+
+// SetAbandonedCartNotifier sets the notifier reminding users of abandoned
+// carts. Without one no reminders are sent.
+func (s *Service) SetAbandonedCartNotifier(n AbandonedCartNotifier) { s.notifier = n }
+
+// SetAbandonedCartIdle sets how long a user's cart may stay unchanged before
+// it is marked abandoned. Zero or less keeps the default of 24 hours.
+func (s *Service) SetAbandonedCartIdle(d time.Duration) { s.abandonedIdle = d }
+
+func (s *Service) abandonedCartIdle() time.Duration {
+	if s.abandonedIdle <= 0 {
+		return defaultAbandonedCartIdle
+	}
+	return s.abandonedIdle
+}
+
+// DetectAbandonedCarts marks carts of users abandoned once they have been
+// idle for longer than the idle time, then reminds the users of abandoned
+// carts that were not reminded yet. A failed reminder is retried on the next
+// run.
+func (s *Service) DetectAbandonedCarts(ctx context.Context) (abandoned, reminded int, err error) {
+	now := time.Now()
+	idle, err := s.repo.ListIdleCarts(ctx, now.Add(-s.abandonedCartIdle()))
+	if err != nil {
+		return 0, 0, err
+	}
+	for i := range idle {
+		ok, err := s.repo.MarkAbandoned(ctx, idle[i].ID, now, itemsTotal(idle[i].Items), rand.Text())
+		if err != nil {
+			return abandoned, 0, err
+		}
+		if ok {
+			abandoned++
+		}
+	}
+	if s.notifier == nil {
+		return abandoned, 0, nil
+	}
+	due, err := s.repo.ListAbandonedCartsToRemind(ctx, now.Add(-recoveryTTL))
+	if err != nil {
+		return abandoned, 0, err
+	}
+	for i := range due {
+		c := &due[i]
+		itemCount := 0
+		for _, it := range c.Items {
+			itemCount += it.Quantity
+		}
+		reminder := AbandonedCartReminder{
+			CartID:       c.ID,
+			UserID:       c.UserID,
+			ItemCount:    itemCount,
+			Value:        c.AbandonedValue,
+			AbandonedAt:  *c.AbandonedAt,
+			RecoveryPath: "/cart/recover?token=" + url.QueryEscape(c.RecoveryToken),
+		}
+		if err := s.notifier.NotifyAbandonedCart(ctx, reminder); err != nil {
+			slog.Warn("sending abandoned cart reminder failed", "cart", c.ID, "error", err)
+			continue
+		}
+		if err := s.repo.MarkReminderSent(ctx, c.ID, time.Now()); err != nil {
+			return abandoned, reminded, err
+		}
+		reminded++
+	}
+	return abandoned, reminded, nil
+}
+
+// RunAbandonedCartDetector detects abandoned carts every interval until ctx
+// is cancelled.
+func (s *Service) RunAbandonedCartDetector(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if abandoned, reminded, err := s.DetectAbandonedCarts(ctx); err != nil {
+			slog.Error("detecting abandoned carts failed", "error", err)
+		} else {
+			slog.Debug("detected abandoned carts", "abandoned", abandoned, "reminded", reminded)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RecoverCart restores the items of the abandoned cart with the recovery
+// token into the user's active cart and returns that cart. Using a link
+// again returns the active cart without restoring the items twice.
+func (s *Service) RecoverCart(ctx context.Context, token string, userID int) (*CartDetail, error) {
+	abandoned, err := s.repo.CartByRecoveryToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if abandoned == nil || abandoned.UserID != userID || abandoned.Status != CartStatusAbandoned {
+		return nil, ErrRecoveryNotFound
+	}
+	if abandoned.RecoveredAt == nil {
+		if abandoned.AbandonedAt == nil || time.Since(*abandoned.AbandonedAt) > recoveryTTL {
+			return nil, ErrRecoveryExpired
+		}
+		err := s.mergeIntoUserCart(ctx, abandoned.Items, userID, func(tx Repository) error {
+			return tx.MarkRecovered(ctx, abandoned.ID, time.Now())
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.GetCart(ctx, UserOwner(userID))
+}
+
+// AbandonedCartDay sums the carts abandoned on one day and how many of them
+// were recovered since. Values are in cents.
+type AbandonedCartDay struct {
+	Date           time.Time
+	Abandoned      int
+	AbandonedValue int
+	Recovered      int
+	RecoveredValue int
+}
+
+// AbandonedCartReport lists every day of [From, To), including days without
+// abandoned carts, and their totals.
+type AbandonedCartReport struct {
+	From   time.Time
+	To     time.Time
+	Days   []AbandonedCartDay
+	Totals AbandonedCartDay
+}
+
+// AbandonedCartReport reports the value of carts abandoned per UTC day
+// within [from, to). from and to are truncated to days; without them the
+// report covers the last 30 days.
+func (s *Service) AbandonedCartReport(ctx context.Context, from, to *time.Time) (*AbandonedCartReport, error) {
+	end := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if to != nil {
+		end = to.UTC().Truncate(24 * time.Hour)
+	}
+	start := end.AddDate(0, 0, -30)
+	if from != nil {
+		start = from.UTC().Truncate(24 * time.Hour)
+	}
+	if !start.Before(end) || end.Sub(start) > maxReportDays*24*time.Hour {
+		return nil, newValidationError("invalid report range")
+	}
+	carts, err := s.repo.ListAbandonedCarts(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	report := &AbandonedCartReport{From: start, To: end}
+	index := make(map[string]int)
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		index[day.Format(time.DateOnly)] = len(report.Days)
+		report.Days = append(report.Days, AbandonedCartDay{Date: day})
+	}
+	for i := range carts {
+		c := &carts[i]
+		day := &report.Days[index[c.AbandonedAt.UTC().Format(time.DateOnly)]]
+		for _, sum := range []*AbandonedCartDay{day, &report.Totals} {
+			sum.Abandoned++
+			sum.AbandonedValue += c.AbandonedValue
+			if c.RecoveredAt != nil {
+				sum.Recovered++
+				sum.RecoveredValue += c.AbandonedValue
+			}
+		}
+	}
+	return report, nil
+}
@@ -2,6 +2,7 @@ package cart
 
 import (
 	"context"
+	"time"
 
 	"voenix/backend/internal/article"
 )
@@ -11,6 +12,9 @@ type ArticleService interface {
 	GetArticleSummary(ctx context.Context, id int) (article.ArticleResponse, error)
 	GetArticle(ctx context.Context, id int) (article.Article, error)
 	GetMugVariant(ctx context.Context, id int) (article.MugVariant, error)
-	GetCostCalculation(ctx context.Context, articleID int) (*article.Price, error)
-	GetCostCalculationByID(ctx context.Context, id int) (*article.Price, error)
+	GetShirtVariant(ctx context.Context, id int) (article.ShirtVariant, error)
+	GetShirtDetails(ctx context.Context, articleID int) (*article.ShirtDetails, error)
+	GetCostCalculationAt(ctx context.Context, articleID int, at time.Time) (*article.Price, error)
+	GetCostCalculationByIDAt(ctx context.Context, id int, at time.Time) (*article.Price, error)
+	Recommend(ctx context.Context, seeds []article.RecommendationSeed, opts article.RecommendOptions) ([]article.Recommendation, error)
 }
@@ -2,8 +2,16 @@ package cart_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
@@ -12,6 +20,8 @@ import (
 	authpostgres "voenix/backend/internal/auth/postgres"
 	cartpkg "voenix/backend/internal/cart"
 	cartpostgres "voenix/backend/internal/cart/postgres"
+	"voenix/backend/internal/promotion"
+	promotionpostgres "voenix/backend/internal/promotion/postgres"
 	"voenix/backend/internal/prompt"
 	promptpostgres "voenix/backend/internal/prompt/postgres"
 )
@@ -60,9 +70,29 @@ func (s *stubArticleService) GetMugVariant(ctx context.Context, id int) (article
 	return v, nil
 }
 
-func (s *stubArticleService) GetCostCalculation(ctx context.Context, articleID int) (*article.Price, error) {
+func (s *stubArticleService) GetShirtVariant(ctx context.Context, id int) (article.ShirtVariant, error) {
+	var v article.ShirtVariant
+	if err := s.db.WithContext(ctx).First(&v, "id = ?", id).Error; err != nil {
+		return article.ShirtVariant{}, err
+	}
+	return v, nil
+}
+
+func (s *stubArticleService) GetShirtDetails(ctx context.Context, articleID int) (*article.ShirtDetails, error) {
+	var d article.ShirtDetails
+	err := s.db.WithContext(ctx).First(&d, "article_id = ?", articleID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (s *stubArticleService) GetCostCalculationAt(ctx context.Context, articleID int, _ time.Time) (*article.Price, error) {
 	var cc article.Price
-	err := s.db.WithContext(ctx).First(&cc, "article_id = ?", articleID).Error
+	err := s.db.WithContext(ctx).Preload("Tiers").First(&cc, "article_id = ?", articleID).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -72,9 +102,9 @@ func (s *stubArticleService) GetCostCalculation(ctx context.Context, articleID i
 	return &cc, nil
 }
 
-func (s *stubArticleService) GetCostCalculationByID(ctx context.Context, id int) (*article.Price, error) {
+func (s *stubArticleService) GetCostCalculationByIDAt(ctx context.Context, id int, _ time.Time) (*article.Price, error) {
 	var cc article.Price
-	err := s.db.WithContext(ctx).First(&cc, "id = ?", id).Error
+	err := s.db.WithContext(ctx).Preload("Tiers").First(&cc, "id = ?", id).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -84,6 +114,10 @@ func (s *stubArticleService) GetCostCalculationByID(ctx context.Context, id int)
 	return &cc, nil
 }
 
+func (s *stubArticleService) Recommend(context.Context, []article.RecommendationSeed, article.RecommendOptions) ([]article.Recommendation, error) {
+	return nil, nil
+}
+
 func setupCartTestDB(t *testing.T) *gorm.DB {
 	t.Helper()
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
@@ -95,7 +129,10 @@ func setupCartTestDB(t *testing.T) *gorm.DB {
 		&article.ArticleCategory{},
 		&article.ArticleSubCategory{},
 		&article.MugVariant{},
+		&article.ShirtVariant{},
+		&article.ShirtDetails{},
 		&article.Price{},
+		&article.PriceTier{},
 		&cartpostgres.CartRow{},
 		&cartpostgres.CartItemRow{},
 		&authpostgres.UserRow{},
@@ -107,6 +144,10 @@ func setupCartTestDB(t *testing.T) *gorm.DB {
 		&promptpostgres.PromptSlotVariantRow{},
 		&promptpostgres.PromptSlotVariantMappingRow{},
 		&promptpostgres.PromptRow{},
+		&promotionpostgres.PromotionRow{},
+		&promotionpostgres.PromotionTargetRow{},
+		&promotionpostgres.PromotionRedemptionRow{},
+		&cartpostgres.SettingsRow{},
 	)
 	if err := db.AutoMigrate(models...); err != nil {
 		t.Fatalf("migrate: %v", err)
@@ -136,7 +177,7 @@ func TestCartResponseIncludesPromptPricing(t *testing.T) {
 	}
 	user := auth.User{ID: userRow.ID, Email: userRow.Email}
 	repo := cartpostgres.NewRepository(db)
-	cartDomain, err := repo.GetOrCreateActiveCart(context.Background(), user.ID)
+	cartDomain, err := repo.GetOrCreateActiveCart(context.Background(), cartpkg.UserOwner(user.ID))
 	if err != nil {
 		t.Fatalf("create cart: %v", err)
 	}
@@ -174,8 +215,8 @@ func TestCartResponseIncludesPromptPricing(t *testing.T) {
 	}
 
 	promptSvc := prompt.NewService(promptRepo, []string{"test-llm"})
-	svc := cartpkg.NewService(repo, &stubArticleService{db: db}, promptSvc)
-	detail, err := svc.GetCart(context.Background(), user.ID)
+	svc := cartpkg.NewService(repo, &stubArticleService{db: db}, promptSvc, nil)
+	detail, err := svc.GetCart(context.Background(), cartpkg.UserOwner(user.ID))
 	if err != nil {
 		t.Fatalf("load cart: %v", err)
 	}
@@ -256,7 +297,7 @@ func TestGetCartSummaryIncludesPromptPrice(t *testing.T) {
 	}
 	user := auth.User{ID: userRow.ID, Email: userRow.Email}
 	repo := cartpostgres.NewRepository(db)
-	cartDomain, err := repo.GetOrCreateActiveCart(context.Background(), user.ID)
+	cartDomain, err := repo.GetOrCreateActiveCart(context.Background(), cartpkg.UserOwner(user.ID))
 	if err != nil {
 		t.Fatalf("create cart: %v", err)
 	}
@@ -281,7 +322,7 @@ func TestGetCartSummaryIncludesPromptPrice(t *testing.T) {
 	}
 
 	promptSvc := prompt.NewService(promptRepo, []string{"test-llm"})
-	summary, err := cartpkg.NewService(repo, &stubArticleService{db: db}, promptSvc).GetCartSummary(context.Background(), user.ID)
+	summary, err := cartpkg.NewService(repo, &stubArticleService{db: db}, promptSvc, nil).GetCartSummary(context.Background(), cartpkg.UserOwner(user.ID))
 	if err != nil {
 		t.Fatalf("get summary: %v", err)
 	}
@@ -295,3 +336,732 @@ func TestGetCartSummaryIncludesPromptPrice(t *testing.T) {
 		t.Fatalf("expected hasItems true")
 	}
 }
+
+func TestAddItemValidatesShirtVariants(t *testing.T) {
+	db := setupCartTestDB(t)
+
+	shirt := article.Article{ID: 7, Name: "Shirt", DescriptionShort: "s", DescriptionLong: "l", Active: true, ArticleType: article.ArticleTypeShirt}
+	if err := db.Create(&shirt).Error; err != nil {
+		t.Fatalf("seed article: %v", err)
+	}
+	if err := db.Create(&article.ShirtDetails{ArticleID: shirt.ID, Material: "Cotton", FitType: "REGULAR", AvailableSizes: "S,M,L"}).Error; err != nil {
+		t.Fatalf("seed shirt details: %v", err)
+	}
+	available := article.ShirtVariant{ID: 11, ArticleID: shirt.ID, Color: "Black", Size: "M"}
+	unavailable := article.ShirtVariant{ID: 12, ArticleID: shirt.ID, Color: "Black", Size: "XXL"}
+	for _, v := range []*article.ShirtVariant{&available, &unavailable} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed shirt variant: %v", err)
+		}
+	}
+	userRow := authpostgres.UserRow{ID: 88, Email: "shirt@example.com"}
+	if err := db.Create(&userRow).Error; err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	promptSvc := prompt.NewService(promptpostgres.NewRepository(db), []string{"test-llm"})
+	svc := cartpkg.NewService(cartpostgres.NewRepository(db), &stubArticleService{db: db}, promptSvc, nil)
+
+	detail, err := svc.AddItem(context.Background(), cartpkg.UserOwner(userRow.ID), cartpkg.AddItemInput{ArticleID: shirt.ID, VariantID: available.ID, Quantity: 1})
+	if err != nil {
+		t.Fatalf("add shirt: %v", err)
+	}
+	if len(detail.Cart.Items) != 1 || detail.Cart.Items[0].VariantType != article.ArticleTypeShirt {
+		t.Fatalf("expected one SHIRT item, got %+v", detail.Cart.Items)
+	}
+	dto, err := svc.ToCartResponse(context.Background(), detail)
+	if err != nil {
+		t.Fatalf("assemble dto: %v", err)
+	}
+	item := dto.Items[0]
+	if item.Variant != nil || item.ShirtVariant == nil || item.ShirtVariant.Size != "M" {
+		t.Fatalf("expected shirt variant in response, got variant=%v shirtVariant=%v", item.Variant, item.ShirtVariant)
+	}
+
+	if _, err := svc.AddItem(context.Background(), cartpkg.UserOwner(userRow.ID), cartpkg.AddItemInput{ArticleID: shirt.ID, VariantID: unavailable.ID, Quantity: 1}); err == nil || err.Error() != "size is not available for this shirt" {
+		t.Fatalf("expected unavailable size error, got %v", err)
+	}
+	if _, err := svc.AddItem(context.Background(), cartpkg.UserOwner(userRow.ID), cartpkg.AddItemInput{ArticleID: shirt.ID, VariantID: 999, Quantity: 1}); err == nil || err.Error() != "variant not found" {
+		t.Fatalf("expected variant not found error, got %v", err)
+	}
+}
+
+type stubAvailability map[int]article.VariantAvailability
+
+func (s stubAvailability) VariantAvailability(_ context.Context, _ string, ids []int) (map[int]article.VariantAvailability, error) {
+	out := make(map[int]article.VariantAvailability)
+	for _, id := range ids {
+		if a, ok := s[id]; ok {
+			out[id] = a
+		}
+	}
+	return out, nil
+}
+
+func TestAddItemRefusesQuantitiesBeyondStock(t *testing.T) {
+	db := setupCartTestDB(t)
+
+	shirt := article.Article{ID: 7, Name: "Shirt", DescriptionShort: "s", DescriptionLong: "l", Active: true, ArticleType: article.ArticleTypeShirt}
+	if err := db.Create(&shirt).Error; err != nil {
+		t.Fatalf("seed article: %v", err)
+	}
+	limited := article.ShirtVariant{ID: 11, ArticleID: shirt.ID, Color: "Black", Size: "M"}
+	soldOut := article.ShirtVariant{ID: 12, ArticleID: shirt.ID, Color: "White", Size: "M"}
+	for _, v := range []*article.ShirtVariant{&limited, &soldOut} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("seed shirt variant: %v", err)
+		}
+	}
+	userRow := authpostgres.UserRow{ID: 89, Email: "stock@example.com"}
+	if err := db.Create(&userRow).Error; err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	stock := stubAvailability{
+		limited.ID: {Tracked: true, Available: 2, InStock: true, LowStock: true},
+		soldOut.ID: {Tracked: true, Available: 0},
+	}
+	promptSvc := prompt.NewService(promptpostgres.NewRepository(db), []string{"test-llm"})
+	svc := cartpkg.NewService(cartpostgres.NewRepository(db), &stubArticleService{db: db}, promptSvc, stock)
+	ctx := context.Background()
+
+	detail, err := svc.AddItem(ctx, cartpkg.UserOwner(userRow.ID), cartpkg.AddItemInput{ArticleID: shirt.ID, VariantID: limited.ID, Quantity: 2})
+	if err != nil {
+		t.Fatalf("add within stock: %v", err)
+	}
+	dto, err := svc.ToCartResponse(ctx, detail)
+	if err != nil {
+		t.Fatalf("assemble dto: %v", err)
+	}
+	got := dto.Items[0].Availability
+	if !got.InStock || !got.LowStock || got.AvailableQuantity == nil || *got.AvailableQuantity != 2 {
+		t.Fatalf("unexpected availability: %+v", got)
+	}
+
+	if _, err := svc.AddItem(ctx, cartpkg.UserOwner(userRow.ID), cartpkg.AddItemInput{ArticleID: shirt.ID, VariantID: limited.ID, Quantity: 1}); err == nil || err.Error() != "only 2 left in stock for this variant" {
+		t.Fatalf("expected stock limit error, got %v", err)
+	}
+	if _, err := svc.AddItem(ctx, cartpkg.UserOwner(userRow.ID), cartpkg.AddItemInput{ArticleID: shirt.ID, VariantID: soldOut.ID, Quantity: 1}); err == nil || err.Error() != "variant is out of stock" {
+		t.Fatalf("expected out of stock error, got %v", err)
+	}
+	if _, err := svc.UpdateItemQuantity(ctx, cartpkg.UserOwner(userRow.ID), cartpkg.UpdateItemQuantityInput{ItemID: detail.Cart.Items[0].ID, Quantity: 3}); err == nil {
+		t.Fatalf("expected quantity update beyond stock to fail")
+	}
+}
+
+func TestQuantityChangesPickPriceTier(t *testing.T) {
+	db := setupCartTestDB(t)
+
+	art := article.Article{ID: 3, Name: "Mug", DescriptionShort: "s", DescriptionLong: "l", Active: true, ArticleType: article.ArticleTypeMug}
+	if err := db.Create(&art).Error; err != nil {
+		t.Fatalf("seed article: %v", err)
+	}
+	variant := article.MugVariant{ID: 4, ArticleID: art.ID, Name: "White", Active: true}
+	if err := db.Create(&variant).Error; err != nil {
+		t.Fatalf("seed variant: %v", err)
+	}
+	price := article.Price{ArticleID: &art.ID, SalesTotalGross: 1500}
+	if err := db.Create(&price).Error; err != nil {
+		t.Fatalf("seed price: %v", err)
+	}
+	for _, tier := range []article.PriceTier{{PriceID: price.ID, MinQuantity: 10, SalesTotalGross: 1300}, {PriceID: price.ID, MinQuantity: 50, SalesTotalGross: 1100}} {
+		if err := db.Create(&tier).Error; err != nil {
+			t.Fatalf("seed tier: %v", err)
+		}
+	}
+	userRow := authpostgres.UserRow{ID: 90, Email: "tiers@example.com"}
+	if err := db.Create(&userRow).Error; err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	promptSvc := prompt.NewService(promptpostgres.NewRepository(db), []string{"test-llm"})
+	svc := cartpkg.NewService(cartpostgres.NewRepository(db), &stubArticleService{db: db}, promptSvc, nil)
+	ctx := context.Background()
+
+	detail, err := svc.AddItem(ctx, cartpkg.UserOwner(userRow.ID), cartpkg.AddItemInput{ArticleID: art.ID, VariantID: variant.ID, Quantity: 8})
+	if err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+	dto, err := svc.ToCartResponse(ctx, detail)
+	if err != nil {
+		t.Fatalf("assemble dto: %v", err)
+	}
+	item := dto.Items[0]
+	if item.PriceAtTime != 1500 || item.PriceTierMinQuantity != 1 {
+		t.Fatalf("expected base price, got %d (tier %d)", item.PriceAtTime, item.PriceTierMinQuantity)
+	}
+	if item.NextTier == nil || item.NextTier.MinQuantity != 10 || item.NextTier.AdditionalQuantity != 2 || item.NextTier.SavingPerItem != 200 {
+		t.Fatalf("unexpected next tier: %+v", item.NextTier)
+	}
+
+	detail, err = svc.AddItem(ctx, cartpkg.UserOwner(userRow.ID), cartpkg.AddItemInput{ArticleID: art.ID, VariantID: variant.ID, Quantity: 4})
+	if err != nil {
+		t.Fatalf("add more: %v", err)
+	}
+	if len(detail.Cart.Items) != 1 || detail.Cart.Items[0].Quantity != 12 || detail.Cart.Items[0].PriceAtTime != 1300 || detail.Cart.Items[0].PriceTierMinQuantity != 10 {
+		t.Fatalf("expected merged line at the 10+ tier, got %+v", detail.Cart.Items)
+	}
+
+	detail, err = svc.UpdateItemQuantity(ctx, cartpkg.UserOwner(userRow.ID), cartpkg.UpdateItemQuantityInput{ItemID: detail.Cart.Items[0].ID, Quantity: 60})
+	if err != nil {
+		t.Fatalf("update quantity: %v", err)
+	}
+	got := detail.Cart.Items[0]
+	if got.PriceAtTime != 1100 || got.OriginalPrice != 1100 || got.PriceTierMinQuantity != 50 {
+		t.Fatalf("expected the 50+ tier after the update, got %+v", got)
+	}
+	dto, err = svc.ToCartResponse(ctx, detail)
+	if err != nil {
+		t.Fatalf("assemble dto: %v", err)
+	}
+	if dto.Items[0].NextTier != nil || dto.Items[0].HasPriceChanged {
+		t.Fatalf("unexpected pricing state: %+v", dto.Items[0])
+	}
+}
+
+func TestGuestCartMergesIntoUserCartOnLogin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupCartTestDB(t)
+
+	art := article.Article{ID: 2, Name: "Mug", DescriptionShort: "s", DescriptionLong: "l", Active: true, ArticleType: article.ArticleTypeMug}
+	if err := db.Create(&art).Error; err != nil {
+		t.Fatalf("seed article: %v", err)
+	}
+	variant := article.MugVariant{ID: 3, ArticleID: art.ID, Name: "White", Active: true}
+	if err := db.Create(&variant).Error; err != nil {
+		t.Fatalf("seed variant: %v", err)
+	}
+	userRow := authpostgres.UserRow{ID: 91, Email: "guest@example.com"}
+	if err := db.Create(&userRow).Error; err != nil {
+		t.Fatalf("seed user: %v", err)
+	}
+
+	promptSvc := prompt.NewService(promptpostgres.NewRepository(db), []string{"test-llm"})
+	svc := cartpkg.NewService(cartpostgres.NewRepository(db), &stubArticleService{db: db}, promptSvc, nil)
+	guests := cartpkg.NewGuestCookies("test-secret")
+	r := gin.New()
+	cartpkg.RegisterRoutes(r, func(c *gin.Context) { c.AbortWithStatus(http.StatusUnauthorized) }, svc, guests)
+	ctx := context.Background()
+
+	countCarts := func() int64 {
+		var n int64
+		db.Model(&cartpostgres.CartRow{}).Count(&n)
+		return n
+	}
+	serve := func(method, path, body string, cookie *http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	guestCookie := func(w *httptest.ResponseRecorder) *http.Cookie {
+		for _, c := range w.Result().Cookies() {
+			if c.Name == "guest_cart" {
+				return c
+			}
+		}
+		return nil
+	}
+
+	w := serve(http.MethodGet, "/api/guest/cart", "", nil)
+	if w.Code != http.StatusOK || guestCookie(w) == nil || countCarts() != 0 {
+		t.Fatalf("viewing the guest cart should hand out a cookie without storing a cart: %d %s", w.Code, w.Body)
+	}
+	cookie := guestCookie(w)
+	body := fmt.Sprintf(`{"articleId":%d,"variantId":%d,"quantity":2}`, art.ID, variant.ID)
+	if w := serve(http.MethodPost, "/api/guest/cart/items", body, cookie); w.Code != http.StatusCreated || guestCookie(w) != nil {
+		t.Fatalf("add item as guest: %d %s", w.Code, w.Body)
+	}
+	if w := serve(http.MethodGet, "/api/guest/cart/summary", "", cookie); !strings.Contains(w.Body.String(), `"itemCount":2`) {
+		t.Fatalf("guest cart summary: %s", w.Body)
+	}
+	forged := &http.Cookie{Name: "guest_cart", Value: strings.Split(cookie.Value, ".")[0] + ".forged"}
+	if w := serve(http.MethodGet, "/api/guest/cart/summary", "", forged); !strings.Contains(w.Body.String(), `"itemCount":0`) || guestCookie(w) == nil {
+		t.Fatalf("a forged cookie must not reach the cart: %s", w.Body)
+	}
+
+	if _, err := svc.AddItem(ctx, cartpkg.UserOwner(userRow.ID), cartpkg.AddItemInput{ArticleID: art.ID, VariantID: variant.ID, Quantity: 1}); err != nil {
+		t.Fatalf("add item as user: %v", err)
+	}
+	w = httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+	c.Request.AddCookie(cookie)
+	guests.MergeOnLogin(svc)(c, &auth.User{ID: userRow.ID})
+	if cleared := guestCookie(w); cleared == nil || cleared.MaxAge >= 0 {
+		t.Fatalf("login should drop the guest cookie, got %+v", cleared)
+	}
+	detail, err := svc.GetCart(ctx, cartpkg.UserOwner(userRow.ID))
+	if err != nil {
+		t.Fatalf("load user cart: %v", err)
+	}
+	if len(detail.Cart.Items) != 1 || detail.Cart.Items[0].Quantity != 3 {
+		t.Fatalf("guest items should merge into the user's line, got %+v", detail.Cart.Items)
+	}
+	if countCarts() != 1 {
+		t.Fatalf("the guest cart should be deleted after the merge, %d carts left", countCarts())
+	}
+
+	stale, err := svc.AddItem(ctx, cartpkg.GuestOwner("stale"), cartpkg.AddItemInput{ArticleID: art.ID, VariantID: variant.ID})
+	if err != nil {
+		t.Fatalf("add item to stale guest cart: %v", err)
+	}
+	old := time.Now().Add(-40 * 24 * time.Hour)
+	db.Model(&cartpostgres.CartRow{}).Where("id = ?", stale.Cart.ID).UpdateColumn("updated_at", old)
+	db.Model(&cartpostgres.CartItemRow{}).Where("cart_id = ?", stale.Cart.ID).UpdateColumn("updated_at", old)
+	if deleted, err := svc.CleanupGuestCarts(ctx); err != nil || deleted != 1 || countCarts() != 1 {
+		t.Fatalf("cleanup should delete only the stale guest cart: %d, %v", deleted, err)
+	}
+}
+
+type recordingNotifier struct {
+	reminders []cartpkg.AbandonedCartReminder
+}
+
+func (n *recordingNotifier) NotifyAbandonedCart(_ context.Context, r cartpkg.AbandonedCartReminder) error {
+	n.reminders = append(n.reminders, r)
+	return nil
+}
+
+func TestAbandonedCartsAreDetectedRemindedAndRecovered(t *testing.T) {
+	db := setupCartTestDB(t)
+
+	art := article.Article{ID: 4, Name: "Mug", DescriptionShort: "s", DescriptionLong: "l", Active: true, ArticleType: article.ArticleTypeMug}
+	if err := db.Create(&art).Error; err != nil {
+		t.Fatalf("seed article: %v", err)
+	}
+	variant := article.MugVariant{ID: 5, ArticleID: art.ID, Name: "White", Active: true}
+	if err := db.Create(&variant).Error; err != nil {
+		t.Fatalf("seed variant: %v", err)
+	}
+	if err := db.Create(&article.Price{ArticleID: &art.ID, SalesTotalGross: 1500}).Error; err != nil {
+		t.Fatalf("seed price: %v", err)
+	}
+	idleUser := authpostgres.UserRow{ID: 92, Email: "idle@example.com"}
+	busyUser := authpostgres.UserRow{ID: 93, Email: "busy@example.com"}
+	for _, u := range []*authpostgres.UserRow{&idleUser, &busyUser} {
+		if err := db.Create(u).Error; err != nil {
+			t.Fatalf("seed user: %v", err)
+		}
+	}
+
+	promptSvc := prompt.NewService(promptpostgres.NewRepository(db), []string{"test-llm"})
+	svc := cartpkg.NewService(cartpostgres.NewRepository(db), &stubArticleService{db: db}, promptSvc, nil)
+	notifier := &recordingNotifier{}
+	svc.SetAbandonedCartNotifier(notifier)
+	ctx := context.Background()
+
+	idle, err := svc.AddItem(ctx, cartpkg.UserOwner(idleUser.ID), cartpkg.AddItemInput{ArticleID: art.ID, VariantID: variant.ID, Quantity: 2})
+	if err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+	if _, err := svc.AddItem(ctx, cartpkg.UserOwner(busyUser.ID), cartpkg.AddItemInput{ArticleID: art.ID, VariantID: variant.ID}); err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	db.Model(&cartpostgres.CartRow{}).Where("id = ?", idle.Cart.ID).UpdateColumn("updated_at", old)
+	db.Model(&cartpostgres.CartItemRow{}).Where("cart_id = ?", idle.Cart.ID).UpdateColumn("updated_at", old)
+
+	svc.SetAbandonedCartIdle(72 * time.Hour)
+	if abandoned, _, err := svc.DetectAbandonedCarts(ctx); err != nil || abandoned != 0 {
+		t.Fatalf("a cart idle for less than the configured time must stay active: abandoned=%d err=%v", abandoned, err)
+	}
+	svc.SetAbandonedCartIdle(36 * time.Hour)
+	abandoned, reminded, err := svc.DetectAbandonedCarts(ctx)
+	if err != nil || abandoned != 1 || reminded != 1 {
+		t.Fatalf("detect: abandoned=%d reminded=%d err=%v", abandoned, reminded, err)
+	}
+	reminder := notifier.reminders[0]
+	if reminder.CartID != idle.Cart.ID || reminder.UserID != idleUser.ID || reminder.Value != 3000 || reminder.ItemCount != 2 {
+		t.Fatalf("unexpected reminder: %+v", reminder)
+	}
+	if abandoned, reminded, err := svc.DetectAbandonedCarts(ctx); err != nil || abandoned != 0 || reminded != 0 {
+		t.Fatalf("second run should do nothing: abandoned=%d reminded=%d err=%v", abandoned, reminded, err)
+	}
+
+	token := strings.TrimPrefix(reminder.RecoveryPath, "/cart/recover?token=")
+	if _, err := svc.RecoverCart(ctx, token, busyUser.ID); !errors.Is(err, cartpkg.ErrRecoveryNotFound) {
+		t.Fatalf("another user must not recover the cart: %v", err)
+	}
+	for range 2 {
+		detail, err := svc.RecoverCart(ctx, token, idleUser.ID)
+		if err != nil {
+			t.Fatalf("recover: %v", err)
+		}
+		if detail.Cart.ID == idle.Cart.ID || detail.Cart.Status != cartpkg.CartStatusActive ||
+			len(detail.Cart.Items) != 1 || detail.Cart.Items[0].Quantity != 2 {
+			t.Fatalf("items should be restored once into a new active cart: %+v", detail.Cart)
+		}
+	}
+
+	report, err := svc.AbandonedCartReport(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if len(report.Days) != 30 || report.Totals.Abandoned != 1 || report.Totals.AbandonedValue != 3000 || report.Totals.RecoveredValue != 3000 {
+		t.Fatalf("unexpected report: %d days, totals %+v", len(report.Days), report.Totals)
+	}
+	if last := report.Days[len(report.Days)-1]; last.Abandoned != 1 {
+		t.Fatalf("today should hold the abandoned cart: %+v", last)
+	}
+}
+
+// racingRepository lets another write of the cart win the race against the
+// next item update.
+type racingRepository struct {
+	cartpkg.Repository
+	race func()
+}
+
+func (r *racingRepository) UpdateItem(ctx context.Context, cartID int, version int64, item cartpkg.CartItem) (bool, error) {
+	if race := r.race; race != nil {
+		r.race = nil
+		race()
+	}
+	return r.Repository.UpdateItem(ctx, cartID, version, item)
+}
+
+func TestConcurrentCartUpdatesAreDetected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupCartTestDB(t)
+
+	art := article.Article{ID: 5, Name: "Mug", DescriptionShort: "s", DescriptionLong: "l", Active: true, ArticleType: article.ArticleTypeMug}
+	if err := db.Create(&art).Error; err != nil {
+		t.Fatalf("seed article: %v", err)
+	}
+	variant := article.MugVariant{ID: 6, ArticleID: art.ID, Name: "White", Active: true}
+	if err := db.Create(&variant).Error; err != nil {
+		t.Fatalf("seed variant: %v", err)
+	}
+	owner := cartpkg.GuestOwner("tabs")
+	baseRepo := cartpostgres.NewRepository(db)
+	repo := &racingRepository{Repository: baseRepo}
+	promptSvc := prompt.NewService(promptpostgres.NewRepository(db), []string{"test-llm"})
+	svc := cartpkg.NewService(repo, &stubArticleService{db: db}, promptSvc, nil)
+	ctx := context.Background()
+
+	added, err := svc.AddItem(ctx, owner, cartpkg.AddItemInput{ArticleID: art.ID, VariantID: variant.ID, Quantity: 1})
+	if err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+	itemID := added.Cart.Items[0].ID
+
+	// Both tabs loaded the same version; only the first save wins.
+	first, _ := baseRepo.LoadActiveCart(ctx, owner)
+	second, _ := baseRepo.LoadActiveCart(ctx, owner)
+	first.Items[0].Quantity = 2
+	if _, err := baseRepo.SaveCart(ctx, *first); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+	second.Items[0].Quantity = 5
+	if _, err := baseRepo.SaveCart(ctx, *second); !errors.Is(err, cartpkg.ErrCartConflict) {
+		t.Fatalf("stale save should conflict, got %v", err)
+	}
+	if _, err := baseRepo.DeleteItem(ctx, second.ID, second.Version, itemID); !errors.Is(err, cartpkg.ErrCartConflict) {
+		t.Fatalf("stale delete should conflict, got %v", err)
+	}
+
+	// Without If-Match a write that lost the race is applied to the new cart.
+	repo.race = func() {
+		if _, err := svc.AddItem(ctx, owner, cartpkg.AddItemInput{ArticleID: art.ID, VariantID: variant.ID, Quantity: 1}); err != nil {
+			t.Errorf("racing add: %v", err)
+		}
+	}
+	detail, err := svc.UpdateItemQuantity(ctx, owner, cartpkg.UpdateItemQuantityInput{ItemID: itemID, Quantity: 4})
+	if err != nil || detail.Cart.Items[0].Quantity != 4 {
+		t.Fatalf("update should be retried on the current cart: %+v, %v", detail, err)
+	}
+
+	// With If-Match the client has to decide again.
+	current := cartpkg.CartVersion{CartID: detail.Cart.ID, Version: detail.Cart.Version}
+	repo.race = func() {
+		if _, err := svc.UpdateItemQuantity(ctx, owner, cartpkg.UpdateItemQuantityInput{ItemID: itemID, Quantity: 7}); err != nil {
+			t.Errorf("racing update: %v", err)
+		}
+	}
+	if _, err := svc.UpdateItemQuantity(ctx, owner, cartpkg.UpdateItemQuantityInput{ItemID: itemID, Quantity: 3, IfMatch: &current}); !errors.Is(err, cartpkg.ErrCartConflict) {
+		t.Fatalf("update against a version that lost the race should conflict, got %v", err)
+	}
+	if _, err := svc.UpdateItemQuantity(ctx, owner, cartpkg.UpdateItemQuantityInput{ItemID: itemID, Quantity: 3, IfMatch: &current}); !errors.Is(err, cartpkg.ErrPreconditionFailed) {
+		t.Fatalf("update against an old version should fail its precondition, got %v", err)
+	}
+
+	guests := cartpkg.NewGuestCookies("test-secret")
+	r := gin.New()
+	cartpkg.RegisterRoutes(r, func(c *gin.Context) { c.AbortWithStatus(http.StatusUnauthorized) }, svc, guests)
+	serve := func(method, path, body, ifMatch string, cookie *http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	w := serve(http.MethodGet, "/api/guest/cart", "", "", nil)
+	cookie := w.Result().Cookies()[0]
+	body := fmt.Sprintf(`{"articleId":%d,"variantId":%d}`, art.ID, variant.ID)
+	w = serve(http.MethodPost, "/api/guest/cart/items", body, "", cookie)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("add item: %d %s", w.Code, w.Body)
+	}
+	var cartDTO cartpkg.CartResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &cartDTO); err != nil {
+		t.Fatalf("decode cart: %v", err)
+	}
+	stale := w.Header().Get("ETag")
+	if want := fmt.Sprintf(`"%d-%d"`, cartDTO.ID, cartDTO.Version); stale != want {
+		t.Fatalf("expected ETag %s, got %q", want, stale)
+	}
+	itemPath := fmt.Sprintf("/api/guest/cart/items/%d", cartDTO.Items[0].ID)
+
+	w = serve(http.MethodPut, itemPath, `{"quantity":2}`, stale, cookie)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") == stale {
+		t.Fatalf("update with the current ETag: %d %s", w.Code, w.Body)
+	}
+	fresh := w.Header().Get("ETag")
+
+	for _, method := range []string{http.MethodPut, http.MethodDelete} {
+		w = serve(method, itemPath, `{"quantity":3}`, stale, cookie)
+		if w.Code != http.StatusPreconditionFailed || w.Header().Get("ETag") != fresh || !strings.Contains(w.Body.String(), `"quantity":2`) {
+			t.Fatalf("%s with a stale ETag should return 412 and the current cart: %d %s", method, w.Code, w.Body)
+		}
+	}
+	if w = serve(http.MethodDelete, itemPath, "", fresh, cookie); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"isEmpty":true`) {
+		t.Fatalf("delete with the current ETag: %d %s", w.Code, w.Body)
+	}
+}
+
+func TestPromotionCodesAddDiscountLinesToTheCart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupCartTestDB(t)
+
+	art := article.Article{ID: 7, Name: "Mug", DescriptionShort: "s", DescriptionLong: "l", Active: true, ArticleType: article.ArticleTypeMug, CategoryID: 3}
+	if err := db.Create(&art).Error; err != nil {
+		t.Fatalf("seed article: %v", err)
+	}
+	variant := article.MugVariant{ID: 8, ArticleID: art.ID, Name: "White", Active: true}
+	if err := db.Create(&variant).Error; err != nil {
+		t.Fatalf("seed variant: %v", err)
+	}
+	if err := db.Create(&article.Price{ArticleID: &art.ID, SalesTotalGross: 1500}).Error; err != nil {
+		t.Fatalf("seed price: %v", err)
+	}
+
+	promotions := promotion.NewService(promotionpostgres.NewRepository(db))
+	ctx := context.Background()
+	summer, err := promotions.Create(ctx, promotion.PromotionInput{Code: "SUMMER", Kind: promotion.KindPercentage, Value: 20, Active: true, CategoryIDs: []int{3}})
+	if err != nil {
+		t.Fatalf("create promotion: %v", err)
+	}
+	if _, err := promotions.Create(ctx, promotion.PromotionInput{Code: "SHIRTS", Kind: promotion.KindFixedAmount, Value: 500, Active: true, CategoryIDs: []int{4}}); err != nil {
+		t.Fatalf("create promotion: %v", err)
+	}
+	promptSvc := prompt.NewService(promptpostgres.NewRepository(db), []string{"test-llm"})
+	svc := cartpkg.NewService(cartpostgres.NewRepository(db), &stubArticleService{db: db}, promptSvc, nil)
+	svc.SetPromotions(promotions)
+	owner := cartpkg.GuestOwner("promo")
+	if _, err := svc.AddItem(ctx, owner, cartpkg.AddItemInput{ArticleID: art.ID, VariantID: variant.ID, Quantity: 2}); err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+
+	cartResponse := func(detail *cartpkg.CartDetail) *cartpkg.CartResponse {
+		t.Helper()
+		dto, err := svc.ToCartResponse(ctx, detail)
+		if err != nil {
+			t.Fatalf("assemble dto: %v", err)
+		}
+		return dto
+	}
+	if _, err := svc.ApplyPromotion(ctx, owner, "nope"); !errors.Is(err, promotion.ErrNotFound) {
+		t.Fatalf("expected an unknown code to be refused, got %v", err)
+	}
+	if _, err := svc.ApplyPromotion(ctx, owner, "shirts"); !errors.Is(err, promotion.ErrNotApplicable) {
+		t.Fatalf("expected a code for other categories to be refused, got %v", err)
+	}
+	detail, err := svc.ApplyPromotion(ctx, owner, " summer ")
+	if err != nil {
+		t.Fatalf("apply promotion: %v", err)
+	}
+	dto := cartResponse(detail)
+	if dto.PromotionCode == nil || *dto.PromotionCode != "SUMMER" || len(dto.Discounts) != 1 || dto.Discounts[0].Amount != 600 {
+		t.Fatalf("expected a 20%% discount line, got %+v", dto.Discounts)
+	}
+	if dto.DiscountTotal != 600 || dto.TotalAfterDiscount != 2400 || dto.TotalPrice != 3000 {
+		t.Fatalf("unexpected totals: %d off %d is %d", dto.DiscountTotal, dto.TotalPrice, dto.TotalAfterDiscount)
+	}
+
+	// A code that stops applying stays on the cart with the reason.
+	ended := time.Now().Add(-time.Hour)
+	if _, err := promotions.Update(ctx, summer.ID, promotion.PromotionInput{Code: "SUMMER", Kind: promotion.KindPercentage, Value: 20, Active: true, EndsAt: &ended}); err != nil {
+		t.Fatalf("end promotion: %v", err)
+	}
+	detail, err = svc.GetCart(ctx, owner)
+	if err != nil {
+		t.Fatalf("load cart: %v", err)
+	}
+	dto = cartResponse(detail)
+	if dto.PromotionError == nil || len(dto.Discounts) != 0 || dto.TotalAfterDiscount != 3000 {
+		t.Fatalf("expected the ended code to give no discount, got %+v", dto)
+	}
+
+	detail, err = svc.RemovePromotion(ctx, owner)
+	if err != nil {
+		t.Fatalf("remove promotion: %v", err)
+	}
+	if dto = cartResponse(detail); dto.PromotionCode != nil || dto.PromotionError != nil {
+		t.Fatalf("expected the code to be removed, got %+v", dto)
+	}
+}
+
+func TestPriceChangesNeedAcknowledgmentUnlessLocked(t *testing.T) {
+	db := setupCartTestDB(t)
+
+	art := article.Article{ID: 11, Name: "Mug", DescriptionShort: "s", DescriptionLong: "l", Active: true, ArticleType: article.ArticleTypeMug}
+	if err := db.Create(&art).Error; err != nil {
+		t.Fatalf("seed article: %v", err)
+	}
+	for _, id := range []int{12, 13} {
+		if err := db.Create(&article.MugVariant{ID: id, ArticleID: art.ID, Name: fmt.Sprintf("Variant %d", id), Active: true}).Error; err != nil {
+			t.Fatalf("seed variant: %v", err)
+		}
+	}
+	if err := db.Create(&article.Price{ArticleID: &art.ID, SalesTotalGross: 1500}).Error; err != nil {
+		t.Fatalf("seed price: %v", err)
+	}
+	setPrice := func(gross int) {
+		t.Helper()
+		if err := db.Model(&article.Price{}).Where("article_id = ?", art.ID).Update("sales_total_gross", gross).Error; err != nil {
+			t.Fatalf("change price: %v", err)
+		}
+	}
+
+	promptSvc := prompt.NewService(promptpostgres.NewRepository(db), []string{"test-llm"})
+	svc := cartpkg.NewService(cartpostgres.NewRepository(db), &stubArticleService{db: db}, promptSvc, nil)
+	ctx := context.Background()
+	owner := cartpkg.GuestOwner("prices")
+	if _, err := svc.AddItem(ctx, owner, cartpkg.AddItemInput{ArticleID: art.ID, VariantID: 12, Quantity: 2}); err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+	load := func() (*cartpkg.CartDetail, *cartpkg.CartResponse) {
+		t.Helper()
+		detail, err := svc.GetCart(ctx, owner)
+		if err != nil {
+			t.Fatalf("load cart: %v", err)
+		}
+		dto, err := svc.ToCartResponse(ctx, detail)
+		if err != nil {
+			t.Fatalf("assemble dto: %v", err)
+		}
+		return detail, dto
+	}
+
+	setPrice(1800)
+	detail, dto := load()
+	changes := dto.Items[0].PriceChanges
+	if len(changes) != 1 || changes[0].Component != "ARTICLE" || changes[0].OldPrice != 1500 || changes[0].NewPrice != 1800 || changes[0].Reason != "PRICE_INCREASED" || changes[0].Locked {
+		t.Fatalf("expected an article price increase notice, got %+v", changes)
+	}
+	if !dto.RequiresPriceAcknowledgment || dto.TotalPrice != 3000 {
+		t.Fatalf("expected the old price to be charged until acknowledged, got %+v", dto)
+	}
+	if err := svc.CheckPrices(ctx, detail.Cart); !errors.Is(err, cartpkg.ErrPriceChangesPending) {
+		t.Fatalf("expected checkout to be refused, got %v", err)
+	}
+	stale := &cartpkg.CartVersion{CartID: detail.Cart.ID, Version: detail.Cart.Version - 1}
+	if _, err := svc.AcknowledgePriceChanges(ctx, owner, stale); !errors.Is(err, cartpkg.ErrPreconditionFailed) {
+		t.Fatalf("expected a stale acknowledgment to be refused, got %v", err)
+	}
+	detail, err := svc.AcknowledgePriceChanges(ctx, owner, &cartpkg.CartVersion{CartID: detail.Cart.ID, Version: detail.Cart.Version})
+	if err != nil {
+		t.Fatalf("acknowledge: %v", err)
+	}
+	if got := detail.Cart.Items[0]; got.PriceAtTime != 1800 || got.OriginalPrice != 1800 {
+		t.Fatalf("expected the line at the new price, got %+v", got)
+	}
+	if err := svc.CheckPrices(ctx, detail.Cart); err != nil {
+		t.Fatalf("expected checkout after acknowledging, got %v", err)
+	}
+
+	// Lines added while price locks are on keep their price for the window.
+	if _, err := svc.UpdateSettings(ctx, cartpkg.Settings{PriceLockMinutes: -1}); err == nil {
+		t.Fatalf("expected a negative lock window to be refused")
+	}
+	if _, err := svc.UpdateSettings(ctx, cartpkg.Settings{PriceLockMinutes: 60}); err != nil {
+		t.Fatalf("update settings: %v", err)
+	}
+	if settings, err := svc.Settings(ctx); err != nil || settings.PriceLockMinutes != 60 {
+		t.Fatalf("expected the lock window to be stored, got %+v (%v)", settings, err)
+	}
+	if _, err := svc.AddItem(ctx, owner, cartpkg.AddItemInput{ArticleID: art.ID, VariantID: 13, Quantity: 1}); err != nil {
+		t.Fatalf("add locked item: %v", err)
+	}
+	setPrice(1400)
+	detail, dto = load()
+	locked := dto.Items[1]
+	if locked.PriceLockedUntil == nil || time.Until(*locked.PriceLockedUntil) < 59*time.Minute {
+		t.Fatalf("expected the new line to be locked for an hour, got %v", locked.PriceLockedUntil)
+	}
+	if len(locked.PriceChanges) != 1 || !locked.PriceChanges[0].Locked || locked.PriceChanges[0].Reason != "PRICE_DECREASED" {
+		t.Fatalf("expected a locked price notice, got %+v", locked.PriceChanges)
+	}
+	if !dto.RequiresPriceAcknowledgment {
+		t.Fatalf("the unlocked line still needs an acknowledgment")
+	}
+	detail, err = svc.AcknowledgePriceChanges(ctx, owner, nil)
+	if err != nil {
+		t.Fatalf("acknowledge: %v", err)
+	}
+	if detail.Cart.Items[0].PriceAtTime != 1400 || detail.Cart.Items[1].PriceAtTime != 1800 {
+		t.Fatalf("expected only the unlocked line to be repriced, got %+v", detail.Cart.Items)
+	}
+	if err := svc.CheckPrices(ctx, detail.Cart); err != nil {
+		t.Fatalf("locked lines should not block checkout, got %v", err)
+	}
+}
+
+func TestQuantityChangeKeepsPendingPriceChange(t *testing.T) {
+	db := setupCartTestDB(t)
+
+	art := article.Article{ID: 21, Name: "Mug", DescriptionShort: "s", DescriptionLong: "l", Active: true, ArticleType: article.ArticleTypeMug}
+	if err := db.Create(&art).Error; err != nil {
+		t.Fatalf("seed article: %v", err)
+	}
+	if err := db.Create(&article.MugVariant{ID: 22, ArticleID: art.ID, Name: "White", Active: true}).Error; err != nil {
+		t.Fatalf("seed variant: %v", err)
+	}
+	if err := db.Create(&article.Price{ArticleID: &art.ID, SalesTotalGross: 1500}).Error; err != nil {
+		t.Fatalf("seed price: %v", err)
+	}
+
+	promptSvc := prompt.NewService(promptpostgres.NewRepository(db), []string{"test-llm"})
+	svc := cartpkg.NewService(cartpostgres.NewRepository(db), &stubArticleService{db: db}, promptSvc, nil)
+	ctx := context.Background()
+	owner := cartpkg.GuestOwner("quantity")
+	added, err := svc.AddItem(ctx, owner, cartpkg.AddItemInput{ArticleID: art.ID, VariantID: 22, Quantity: 1})
+	if err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+	if err := db.Model(&article.Price{}).Where("article_id = ?", art.ID).Update("sales_total_gross", 1800).Error; err != nil {
+		t.Fatalf("change price: %v", err)
+	}
+
+	detail, err := svc.UpdateItemQuantity(ctx, owner, cartpkg.UpdateItemQuantityInput{ItemID: added.Cart.Items[0].ID, Quantity: 3})
+	if err != nil {
+		t.Fatalf("update quantity: %v", err)
+	}
+	if got := detail.Cart.Items[0]; got.Quantity != 3 || got.PriceAtTime != 1500 {
+		t.Fatalf("expected the new quantity at the old price, got %+v", got)
+	}
+	if err := svc.CheckPrices(ctx, detail.Cart); !errors.Is(err, cartpkg.ErrPriceChangesPending) {
+		t.Fatalf("expected checkout to wait for the acknowledgment, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package cart
+
+import "errors"
+
+// maxConflictRetries bounds how often a write without a precondition is
+// repeated after losing a race against another write of the same cart.
+const maxConflictRetries = 3
+
+var (
+	// ErrCartConflict means another write changed the cart between loading
+	// and saving it.
+	ErrCartConflict = errors.New("cart was changed concurrently")
+	// ErrPreconditionFailed means the cart is no longer at the version the
+	// client last saw.
+	ErrPreconditionFailed = errors.New("cart has changed")
+)
+
+// CartVersion identifies one state of a cart. The cart ID is part of it
+// because a user's active cart is replaced after checkout or abandonment.
+type CartVersion struct {
+	CartID  int
+	Version int64
+}
+
+// checkVersion fails with ErrPreconditionFailed when expected is set and
+// differs from the cart's version.
+func checkVersion(c *Cart, expected *CartVersion) error {
+	if expected != nil && *expected != (CartVersion{CartID: c.ID, Version: c.Version}) {
+		return ErrPreconditionFailed
+	}
+	return nil
+}
+
+// retryOnConflict repeats write when it lost a race against another write
+// of the cart. write reloads the cart, so a retry applies the change to the
+// current state. Writes made against ifMatch are not repeated: the client
+// saw an older cart and has to decide again.
+func retryOnConflict(ifMatch *CartVersion, write func() (*CartDetail, error)) (*CartDetail, error) {
+	for attempt := 1; ; attempt++ {
+		detail, err := write()
+		if ifMatch != nil || attempt == maxConflictRetries || !errors.Is(err, ErrCartConflict) {
+			return detail, err
+		}
+	}
+}
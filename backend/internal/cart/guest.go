@@ -0,0 +1,48 @@
+package cart
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// guestCartTTL is how long a guest cart is kept after its last change. The
+// guest cookie lives as long.
+const guestCartTTL = 30 * 24 * time.Hour
+
+// MergeGuestCart moves the items of a guest cart into the active cart of the
+// user and deletes the guest cart.
+func (s *Service) MergeGuestCart(ctx context.Context, guestToken string, userID int) error {
+	guest, err := s.repo.LoadActiveCart(ctx, GuestOwner(guestToken))
+	if err != nil || guest == nil {
+		return err
+	}
+	return s.mergeIntoUserCart(ctx, guest.Items, userID, func(tx Repository) error {
+		return tx.DeleteCart(ctx, guest.ID)
+	})
+}
+
+// CleanupGuestCarts deletes guest carts untouched for longer than
+// guestCartTTL and returns how many it deleted.
+func (s *Service) CleanupGuestCarts(ctx context.Context) (int64, error) {
+	return s.repo.DeleteStaleGuestCarts(ctx, time.Now().Add(-guestCartTTL))
+}
+
+// RunGuestCartCleanup deletes stale guest carts every interval until ctx is
+// cancelled.
+func (s *Service) RunGuestCartCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if deleted, err := s.CleanupGuestCarts(ctx); err != nil {
+			slog.Error("deleting stale guest carts failed", "error", err)
+		} else {
+			slog.Debug("deleted stale guest carts", "carts", deleted)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package cart
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type recoverCartRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type abandonedCartDayResponse struct {
+	Date           string `json:"date,omitempty"`
+	Abandoned      int    `json:"abandoned"`
+	AbandonedValue int    `json:"abandonedValue"`
+	Recovered      int    `json:"recovered"`
+	RecoveredValue int    `json:"recoveredValue"`
+}
+
+type abandonedCartReportResponse struct {
+	From   string                     `json:"from"`
+	To     string                     `json:"to"`
+	Days   []abandonedCartDayResponse `json:"days"`
+	Totals abandonedCartDayResponse   `json:"totals"`
+}
+
+// RegisterAdminRoutes mounts the abandoned cart report and the cart settings
+// under /api/admin/carts.
+func RegisterAdminRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service) {
+	grp := r.Group("/api/admin/carts")
+	grp.Use(adminMiddleware)
+	registerAdminSettingsRoutes(grp, svc)
+
+	// GET /api/admin/carts/abandoned/report?from=2025-01-01&to=2025-01-31
+	// sums abandoned and recovered carts per day; to is inclusive.
+	grp.GET("/abandoned/report", func(c *gin.Context) {
+		from, err := parseReportDate(c.Query("from"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid from date"})
+			return
+		}
+		to, err := parseReportDate(c.Query("to"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid to date"})
+			return
+		}
+		if to != nil {
+			next := to.AddDate(0, 0, 1)
+			to = &next
+		}
+		report, err := svc.AbandonedCartReport(c.Request.Context(), from, to)
+		if err != nil {
+			writeServiceError(c, err)
+			return
+		}
+		out := abandonedCartReportResponse{
+			From:   report.From.Format(time.DateOnly),
+			To:     report.To.AddDate(0, 0, -1).Format(time.DateOnly),
+			Days:   make([]abandonedCartDayResponse, 0, len(report.Days)),
+			Totals: toAbandonedCartDayResponse("", report.Totals),
+		}
+		for _, d := range report.Days {
+			out.Days = append(out.Days, toAbandonedCartDayResponse(d.Date.Format(time.DateOnly), d))
+		}
+		c.JSON(http.StatusOK, out)
+	})
+}
+
+// recoverCartHandler restores an abandoned cart from the token of its
+// reminder link into the signed-in user's cart.
+func recoverCartHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		owner, ok := requireOwner(c)
+		if !ok {
+			return
+		}
+		var req recoverCartRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid request"})
+			return
+		}
+		detail, err := svc.RecoverCart(c.Request.Context(), strings.TrimSpace(req.Token), owner.UserID)
+		if err != nil {
+			writeServiceError(c, err)
+			return
+		}
+		writeCart(c, svc, http.StatusOK, detail)
+	}
+}
+
+func toAbandonedCartDayResponse(date string, d AbandonedCartDay) abandonedCartDayResponse {
+	return abandonedCartDayResponse{
+		Date:           date,
+		Abandoned:      d.Abandoned,
+		AbandonedValue: d.AbandonedValue,
+		Recovered:      d.Recovered,
+		RecoveredValue: d.RecoveredValue,
+	}
+}
+
+func parseReportDate(raw string) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
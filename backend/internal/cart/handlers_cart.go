@@ -3,11 +3,13 @@ package cart
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"voenix/backend/internal/article"
+	"voenix/backend/internal/promotion"
 )
 
 type cartSummaryResponse struct {
@@ -26,71 +28,120 @@ type MugVariantResponse struct {
 	ExampleImageFilename  *string `json:"exampleImageFilename"`
 }
 
+type ShirtVariantResponse struct {
+	ID              int     `json:"id"`
+	ArticleID       int     `json:"articleId"`
+	Color           string  `json:"color"`
+	Size            string  `json:"size"`
+	ExampleImageURL *string `json:"exampleImageUrl"`
+}
+
 type CartItemResponse struct {
-	ID                     int                     `json:"id"`
-	Article                article.ArticleResponse `json:"article"`
-	Variant                *MugVariantResponse     `json:"variant"`
-	Quantity               int                     `json:"quantity"`
-	PriceAtTime            int                     `json:"priceAtTime"`
-	OriginalPrice          int                     `json:"originalPrice"`
-	ArticlePriceAtTime     int                     `json:"articlePriceAtTime"`
-	PromptPriceAtTime      int                     `json:"promptPriceAtTime"`
-	ArticleOriginalPrice   int                     `json:"articleOriginalPrice"`
-	PromptOriginalPrice    int                     `json:"promptOriginalPrice"`
-	HasPriceChanged        bool                    `json:"hasPriceChanged"`
-	HasPromptPriceChanged  bool                    `json:"hasPromptPriceChanged"`
-	TotalPrice             int                     `json:"totalPrice"`
-	CustomData             map[string]any          `json:"customData"`
-	GeneratedImageID       *int                    `json:"generatedImageId"`
-	GeneratedImageFilename *string                 `json:"generatedImageFilename"`
-	PromptID               *int                    `json:"promptId"`
-	PromptTitle            *string                 `json:"promptTitle,omitempty"`
-	Position               int                     `json:"position"`
-	CreatedAt              time.Time               `json:"createdAt"`
-	UpdatedAt              time.Time               `json:"updatedAt"`
+	ID                     int                                 `json:"id"`
+	Article                article.ArticleResponse             `json:"article"`
+	VariantType            string                              `json:"variantType"`
+	Variant                *MugVariantResponse                 `json:"variant"`
+	ShirtVariant           *ShirtVariantResponse               `json:"shirtVariant,omitempty"`
+	Availability           article.VariantAvailabilityResponse `json:"availability"`
+	Quantity               int                                 `json:"quantity"`
+	PriceAtTime            int                                 `json:"priceAtTime"`
+	OriginalPrice          int                                 `json:"originalPrice"`
+	ArticlePriceAtTime     int                                 `json:"articlePriceAtTime"`
+	PromptPriceAtTime      int                                 `json:"promptPriceAtTime"`
+	ArticleOriginalPrice   int                                 `json:"articleOriginalPrice"`
+	PromptOriginalPrice    int                                 `json:"promptOriginalPrice"`
+	HasPriceChanged        bool                                `json:"hasPriceChanged"`
+	HasPromptPriceChanged  bool                                `json:"hasPromptPriceChanged"`
+	PriceTierMinQuantity   int                                 `json:"priceTierMinQuantity"`
+	NextTier               *NextTierResponse                   `json:"nextTier"`
+	TotalPrice             int                                 `json:"totalPrice"`
+	CustomData             map[string]any                      `json:"customData"`
+	GeneratedImageID       *int                                `json:"generatedImageId"`
+	GeneratedImageFilename *string                             `json:"generatedImageFilename"`
+	PromptID               *int                                `json:"promptId"`
+	PromptTitle            *string                             `json:"promptTitle,omitempty"`
+	Position               int                                 `json:"position"`
+	PriceChanges           []PriceChangeResponse               `json:"priceChanges"`
+	PriceLockedUntil       *time.Time                          `json:"priceLockedUntil"`
+	CreatedAt              time.Time                           `json:"createdAt"`
+	UpdatedAt              time.Time                           `json:"updatedAt"`
+}
+
+// NextTierResponse tells how many more units of a line unlock a lower unit
+// price and how much each unit would save.
+type NextTierResponse struct {
+	MinQuantity        int `json:"minQuantity"`
+	AdditionalQuantity int `json:"additionalQuantity"`
+	UnitPrice          int `json:"unitPrice"`
+	SavingPerItem      int `json:"savingPerItem"`
 }
 
 type CartResponse struct {
-	ID             int                `json:"id"`
-	UserID         int                `json:"userId"`
-	Status         string             `json:"status"`
-	Version        int64              `json:"version"`
-	ExpiresAt      *time.Time         `json:"expiresAt"`
-	Items          []CartItemResponse `json:"items"`
-	TotalItemCount int                `json:"totalItemCount"`
-	TotalPrice     int                `json:"totalPrice"`
-	IsEmpty        bool               `json:"isEmpty"`
-	CreatedAt      time.Time          `json:"createdAt"`
-	UpdatedAt      time.Time          `json:"updatedAt"`
+	ID                 int                    `json:"id"`
+	UserID             int                    `json:"userId"`
+	Status             string                 `json:"status"`
+	Version            int64                  `json:"version"`
+	ExpiresAt          *time.Time             `json:"expiresAt"`
+	Items              []CartItemResponse     `json:"items"`
+	TotalItemCount     int                    `json:"totalItemCount"`
+	TotalPrice         int                    `json:"totalPrice"`
+	IsEmpty            bool                   `json:"isEmpty"`
+	PromotionCode      *string                `json:"promotionCode"`
+	PromotionError     *string                `json:"promotionError,omitempty"`
+	Discounts          []CartDiscountResponse `json:"discounts"`
+	DiscountTotal      int                    `json:"discountTotal"`
+	TotalAfterDiscount int                    `json:"totalAfterDiscount"`
+	// RequiresPriceAcknowledgment is set while a line has a price change
+	// that has to be acknowledged before checkout.
+	RequiresPriceAcknowledgment bool      `json:"requiresPriceAcknowledgment"`
+	CreatedAt                   time.Time `json:"createdAt"`
+	UpdatedAt                   time.Time `json:"updatedAt"`
+}
+
+// PriceChangeResponse tells that the current unit price of a line's article
+// or prompt differs from the price the line is charged. Locked changes do not
+// apply before LockedUntil.
+type PriceChangeResponse struct {
+	Component   string     `json:"component"`
+	OldPrice    int        `json:"oldPrice"`
+	NewPrice    int        `json:"newPrice"`
+	Reason      string     `json:"reason"`
+	Locked      bool       `json:"locked"`
+	LockedUntil *time.Time `json:"lockedUntil,omitempty"`
+}
+
+// CartDiscountResponse is a discount line of the cart. Amount is taken off
+// the items; free shipping is applied at checkout.
+type CartDiscountResponse struct {
+	Code         string  `json:"code"`
+	Kind         string  `json:"kind"`
+	Description  *string `json:"description,omitempty"`
+	Amount       int     `json:"amount"`
+	FreeShipping bool    `json:"freeShipping"`
 }
 
 func getCartHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		u, ok := requireUser(c)
+		owner, ok := requireOwner(c)
 		if !ok {
 			return
 		}
-		detail, err := svc.GetCart(c.Request.Context(), u.ID)
-		if err != nil {
-			writeServiceError(c, err)
-			return
-		}
-		dto, err := svc.ToCartResponse(c.Request.Context(), detail)
+		detail, err := svc.GetCart(c.Request.Context(), owner)
 		if err != nil {
 			writeServiceError(c, err)
 			return
 		}
-		c.JSON(http.StatusOK, dto)
+		writeCart(c, svc, http.StatusOK, detail)
 	}
 }
 
 func getCartSummaryHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		u, ok := requireUser(c)
+		owner, ok := requireOwner(c)
 		if !ok {
 			return
 		}
-		summary, err := svc.GetCartSummary(c.Request.Context(), u.ID)
+		summary, err := svc.GetCartSummary(c.Request.Context(), owner)
 		if err != nil {
 			writeServiceError(c, err)
 			return
@@ -99,43 +150,81 @@ func getCartSummaryHandler(svc *Service) gin.HandlerFunc {
 	}
 }
 
-func clearCartHandler(svc *Service) gin.HandlerFunc {
+// getRecommendationsHandler suggests articles for the cart; ?limit= caps the
+// number of suggestions.
+func getRecommendationsHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		u, ok := requireUser(c)
+		owner, ok := requireOwner(c)
 		if !ok {
 			return
 		}
-		detail, err := svc.ClearCart(c.Request.Context(), u.ID)
+		limit := 0
+		if raw := c.Query("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid limit"})
+				return
+			}
+			limit = n
+		}
+		recs, err := svc.Recommendations(c.Request.Context(), owner, limit)
 		if err != nil {
 			writeServiceError(c, err)
 			return
 		}
-		dto, err := svc.ToCartResponse(c.Request.Context(), detail)
+		c.JSON(http.StatusOK, recs)
+	}
+}
+
+func clearCartHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		owner, ok := requireOwner(c)
+		if !ok {
+			return
+		}
+		detail, err := svc.ClearCart(c.Request.Context(), owner)
 		if err != nil {
-			writeServiceError(c, err)
+			writeCartError(c, svc, owner, err)
 			return
 		}
-		c.JSON(http.StatusOK, dto)
+		writeCart(c, svc, http.StatusOK, detail)
 	}
 }
 
 func refreshPricesHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		u, ok := requireUser(c)
+		owner, ok := requireOwner(c)
 		if !ok {
 			return
 		}
-		detail, err := svc.RefreshPrices(c.Request.Context(), u.ID)
+		detail, err := svc.RefreshPrices(c.Request.Context(), owner)
 		if err != nil {
-			writeServiceError(c, err)
+			writeCartError(c, svc, owner, err)
+			return
+		}
+		writeCart(c, svc, http.StatusOK, detail)
+	}
+}
+
+// acknowledgePricesHandler accepts the current prices of the cart's lines;
+// an If-Match header makes sure they are the prices the customer saw.
+func acknowledgePricesHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		owner, ok := requireOwner(c)
+		if !ok {
 			return
 		}
-		dto, err := svc.ToCartResponse(c.Request.Context(), detail)
+		ifMatch, ok := parseIfMatch(c)
+		if !ok {
+			writeCartError(c, svc, owner, ErrPreconditionFailed)
+			return
+		}
+		detail, err := svc.AcknowledgePriceChanges(c.Request.Context(), owner, ifMatch)
 		if err != nil {
-			writeServiceError(c, err)
+			writeCartError(c, svc, owner, err)
 			return
 		}
-		c.JSON(http.StatusOK, dto)
+		writeCart(c, svc, http.StatusOK, detail)
 	}
 }
 
@@ -145,6 +234,16 @@ func writeServiceError(c *gin.Context, err error) {
 		c.JSON(http.StatusNotFound, gin.H{"detail": ErrCartNotFound.Error()})
 	case errors.Is(err, ErrCartItemNotFound):
 		c.JSON(http.StatusNotFound, gin.H{"detail": ErrCartItemNotFound.Error()})
+	case errors.Is(err, ErrCartConflict):
+		c.JSON(http.StatusConflict, gin.H{"detail": ErrCartConflict.Error()})
+	case errors.Is(err, promotion.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": promotion.ErrNotFound.Error()})
+	case promotion.Rejected(err):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+	case errors.Is(err, ErrRecoveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": ErrRecoveryNotFound.Error()})
+	case errors.Is(err, ErrRecoveryExpired):
+		c.JSON(http.StatusGone, gin.H{"detail": ErrRecoveryExpired.Error()})
 	case isValidationError(err):
 		c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
 	default:
@@ -22,7 +22,7 @@ type updateCartItemRequest struct {
 
 func addItemHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		u, ok := requireUser(c)
+		owner, ok := requireOwner(c)
 		if !ok {
 			return
 		}
@@ -34,23 +34,18 @@ func addItemHandler(svc *Service) gin.HandlerFunc {
 		}
 
 		input := AddItemInput(req)
-		detail, err := svc.AddItem(c.Request.Context(), u.ID, input)
+		detail, err := svc.AddItem(c.Request.Context(), owner, input)
 		if err != nil {
-			writeServiceError(c, err)
+			writeCartError(c, svc, owner, err)
 			return
 		}
-		dto, err := svc.ToCartResponse(c.Request.Context(), detail)
-		if err != nil {
-			writeServiceError(c, err)
-			return
-		}
-		c.JSON(http.StatusCreated, dto)
+		writeCart(c, svc, http.StatusCreated, detail)
 	}
 }
 
 func updateItemHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		u, ok := requireUser(c)
+		owner, ok := requireOwner(c)
 		if !ok {
 			return
 		}
@@ -67,24 +62,24 @@ func updateItemHandler(svc *Service) gin.HandlerFunc {
 			return
 		}
 
-		input := UpdateItemQuantityInput{ItemID: itemID, Quantity: req.Quantity}
-		detail, err := svc.UpdateItemQuantity(c.Request.Context(), u.ID, input)
-		if err != nil {
-			writeServiceError(c, err)
+		ifMatch, ok := parseIfMatch(c)
+		if !ok {
+			writeCartError(c, svc, owner, ErrPreconditionFailed)
 			return
 		}
-		dto, err := svc.ToCartResponse(c.Request.Context(), detail)
+		input := UpdateItemQuantityInput{ItemID: itemID, Quantity: req.Quantity, IfMatch: ifMatch}
+		detail, err := svc.UpdateItemQuantity(c.Request.Context(), owner, input)
 		if err != nil {
-			writeServiceError(c, err)
+			writeCartError(c, svc, owner, err)
 			return
 		}
-		c.JSON(http.StatusOK, dto)
+		writeCart(c, svc, http.StatusOK, detail)
 	}
 }
 
 func deleteItemHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		u, ok := requireUser(c)
+		owner, ok := requireOwner(c)
 		if !ok {
 			return
 		}
@@ -95,16 +90,16 @@ func deleteItemHandler(svc *Service) gin.HandlerFunc {
 			return
 		}
 
-		detail, err := svc.DeleteItem(c.Request.Context(), u.ID, itemID)
-		if err != nil {
-			writeServiceError(c, err)
+		ifMatch, ok := parseIfMatch(c)
+		if !ok {
+			writeCartError(c, svc, owner, ErrPreconditionFailed)
 			return
 		}
-		dto, err := svc.ToCartResponse(c.Request.Context(), detail)
+		detail, err := svc.DeleteItem(c.Request.Context(), owner, itemID, ifMatch)
 		if err != nil {
-			writeServiceError(c, err)
+			writeCartError(c, svc, owner, err)
 			return
 		}
-		c.JSON(http.StatusOK, dto)
+		writeCart(c, svc, http.StatusOK, detail)
 	}
 }
@@ -0,0 +1,84 @@
+package cart
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag formats a cart version as a strong entity tag.
+func etag(v CartVersion) string {
+	return fmt.Sprintf(`"%d-%d"`, v.CartID, v.Version)
+}
+
+// parseIfMatch reads the If-Match header. It returns nil when the header is
+// missing or "*", and ok=false when it holds no entity tag of ours, which
+// can never match the cart.
+func parseIfMatch(c *gin.Context) (*CartVersion, bool) {
+	raw := strings.TrimSpace(c.GetHeader("If-Match"))
+	if raw == "" || raw == "*" {
+		return nil, true
+	}
+	tag, ok := strings.CutPrefix(raw, `"`)
+	if !ok {
+		return nil, false
+	}
+	tag, ok = strings.CutSuffix(tag, `"`)
+	if !ok {
+		return nil, false
+	}
+	rawID, rawVersion, ok := strings.Cut(tag, "-")
+	if !ok {
+		return nil, false
+	}
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		return nil, false
+	}
+	version, err := strconv.ParseInt(rawVersion, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return &CartVersion{CartID: id, Version: version}, true
+}
+
+// writeCart responds with the cart and its version as ETag.
+func writeCart(c *gin.Context, svc *Service, status int, detail *CartDetail) {
+	dto, err := svc.ToCartResponse(c.Request.Context(), detail)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+	c.Header("ETag", etag(CartVersion{CartID: dto.ID, Version: dto.Version}))
+	c.JSON(status, dto)
+}
+
+// writeCartError answers a lost write with 409, or 412 when If-Match did
+// not match, and the current cart so the client can apply its change again.
+// Other errors are written by writeServiceError.
+func writeCartError(c *gin.Context, svc *Service, owner Owner, err error) {
+	status := http.StatusConflict
+	switch {
+	case errors.Is(err, ErrPreconditionFailed):
+		status = http.StatusPreconditionFailed
+	case !errors.Is(err, ErrCartConflict):
+		writeServiceError(c, err)
+		return
+	}
+	detail, loadErr := svc.GetCart(c.Request.Context(), owner)
+	if loadErr != nil {
+		writeServiceError(c, loadErr)
+		return
+	}
+	dto, loadErr := svc.ToCartResponse(c.Request.Context(), detail)
+	if loadErr != nil {
+		writeServiceError(c, loadErr)
+		return
+	}
+	c.Header("ETag", etag(CartVersion{CartID: dto.ID, Version: dto.Version}))
+	c.JSON(status, gin.H{"detail": err.Error(), "cart": dto})
+}
@@ -0,0 +1,95 @@
+package cart
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"voenix/backend/internal/auth"
+)
+
+const (
+	guestCookieName = "guest_cart"
+	guestTokenKey   = "guestCartToken"
+)
+
+// GuestCookies hands out and verifies the cookie identifying a visitor's
+// guest cart. The cookie holds a random token and its HMAC, so visitors
+// cannot pick the token of another cart.
+type GuestCookies struct {
+	secret []byte
+}
+
+// NewGuestCookies signs guest cookies with secret. Without a secret a random
+// one is used, which invalidates all guest cookies when the server restarts.
+func NewGuestCookies(secret string) *GuestCookies {
+	if strings.TrimSpace(secret) == "" {
+		slog.Warn("GUEST_CART_SECRET not set; guest carts are lost on restart")
+		return &GuestCookies{secret: []byte(rand.Text())}
+	}
+	return &GuestCookies{secret: []byte(secret)}
+}
+
+func (g *GuestCookies) sign(token string) string {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// token returns the guest token of the request's cookie if its signature
+// is valid.
+func (g *GuestCookies) token(c *gin.Context) (string, bool) {
+	raw, err := c.Cookie(guestCookieName)
+	if err != nil {
+		return "", false
+	}
+	token, sig, ok := strings.Cut(raw, ".")
+	if !ok || token == "" || !hmac.Equal([]byte(sig), []byte(g.sign(token))) {
+		return "", false
+	}
+	return token, true
+}
+
+func (g *GuestCookies) set(c *gin.Context, token string) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(guestCookieName, token+"."+g.sign(token), int(guestCartTTL.Seconds()), "/", "", false, true)
+}
+
+func (g *GuestCookies) clear(c *gin.Context) {
+	c.SetCookie(guestCookieName, "", -1, "/", "", false, true)
+}
+
+// middleware identifies the visitor by the guest cookie and hands out a new
+// one when it is missing or not signed by us.
+func (g *GuestCookies) middleware(c *gin.Context) {
+	token, ok := g.token(c)
+	if !ok {
+		token = rand.Text()
+		g.set(c, token)
+	}
+	c.Set(guestTokenKey, token)
+	c.Next()
+}
+
+// MergeOnLogin returns a login hook that moves the visitor's guest cart into
+// the cart of the user who just signed in and drops the guest cookie. A
+// failed merge keeps the cookie so the next login retries it.
+func (g *GuestCookies) MergeOnLogin(svc *Service) auth.LoginHook {
+	return func(c *gin.Context, u *auth.User) {
+		token, ok := g.token(c)
+		if !ok {
+			return
+		}
+		if err := svc.MergeGuestCart(c.Request.Context(), token, u.ID); err != nil {
+			slog.Error("merging guest cart failed", "user", u.ID, "error", err)
+			return
+		}
+		g.clear(c)
+	}
+}
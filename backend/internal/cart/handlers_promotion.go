@@ -0,0 +1,46 @@
+package cart
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type applyPromotionRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+func applyPromotionHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		owner, ok := requireOwner(c)
+		if !ok {
+			return
+		}
+		var req applyPromotionRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid request"})
+			return
+		}
+		detail, err := svc.ApplyPromotion(c.Request.Context(), owner, req.Code)
+		if err != nil {
+			writeCartError(c, svc, owner, err)
+			return
+		}
+		writeCart(c, svc, http.StatusOK, detail)
+	}
+}
+
+func removePromotionHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		owner, ok := requireOwner(c)
+		if !ok {
+			return
+		}
+		detail, err := svc.RemovePromotion(c.Request.Context(), owner)
+		if err != nil {
+			writeCartError(c, svc, owner, err)
+			return
+		}
+		writeCart(c, svc, http.StatusOK, detail)
+	}
+}
@@ -0,0 +1,44 @@
+package cart
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type settingsRequest struct {
+	PriceLockMinutes *int `json:"priceLockMinutes" binding:"required"`
+}
+
+type settingsResponse struct {
+	PriceLockMinutes int       `json:"priceLockMinutes"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+func registerAdminSettingsRoutes(grp *gin.RouterGroup, svc *Service) {
+	grp.GET("/settings", func(c *gin.Context) {
+		settings, err := svc.Settings(c.Request.Context())
+		if err != nil {
+			writeServiceError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, settingsResponse(settings))
+	})
+
+	// PUT /api/admin/carts/settings sets how many minutes lines added to a
+	// cart keep their prices; 0 turns price locks off.
+	grp.PUT("/settings", func(c *gin.Context) {
+		var req settingsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid request"})
+			return
+		}
+		settings, err := svc.UpdateSettings(c.Request.Context(), Settings{PriceLockMinutes: *req.PriceLockMinutes})
+		if err != nil {
+			writeServiceError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, settingsResponse(settings))
+	})
+}
@@ -12,10 +12,16 @@ import (
 func buildCartResponse(
 	ctx context.Context,
 	articleSvc ArticleService,
+	stock article.AvailabilityLookup,
 	c *Cart,
+	prices []linePrices,
 	generatedImageFilenames map[int]string,
 	promptTitles map[int]string,
 ) (*CartResponse, error) {
+	availability, err := loadCartAvailability(ctx, stock, c)
+	if err != nil {
+		return nil, err
+	}
 	items := make([]CartItemResponse, 0, len(c.Items))
 	totalCount := 0
 	totalPrice := 0
@@ -25,7 +31,14 @@ func buildCartResponse(
 		if err != nil {
 			return nil, err
 		}
-		mv, _ := loadMugVariantResponse(ctx, articleSvc, ci.VariantID)
+		variantType := itemVariantType(ci)
+		var mv *MugVariantResponse
+		var sv *ShirtVariantResponse
+		if variantType == article.ArticleTypeShirt {
+			sv, _ = loadShirtVariantResponse(ctx, articleSvc, ci.VariantID)
+		} else {
+			mv, _ = loadMugVariantResponse(ctx, articleSvc, ci.VariantID)
+		}
 		cd := parseJSONMap(ci.CustomData)
 		var genFilename *string
 		if ci.GeneratedImageID != nil && generatedImageFilenames != nil {
@@ -51,7 +64,10 @@ func buildCartResponse(
 		item := CartItemResponse{
 			ID:                     ci.ID,
 			Article:                art,
+			VariantType:            variantType,
 			Variant:                mv,
+			ShirtVariant:           sv,
+			Availability:           availability[variantType][ci.VariantID],
 			Quantity:               ci.Quantity,
 			PriceAtTime:            articlePriceAtTime,
 			OriginalPrice:          articleOriginalPrice,
@@ -61,6 +77,7 @@ func buildCartResponse(
 			PromptOriginalPrice:    promptOriginalPrice,
 			HasPriceChanged:        hasPriceChanged,
 			HasPromptPriceChanged:  hasPromptPriceChanged,
+			PriceTierMinQuantity:   max(ci.PriceTierMinQuantity, 1),
 			TotalPrice:             totalPerItem,
 			CustomData:             cd,
 			GeneratedImageID:       ci.GeneratedImageID,
@@ -71,6 +88,9 @@ func buildCartResponse(
 			CreatedAt:              ci.CreatedAt,
 			UpdatedAt:              ci.UpdatedAt,
 		}
+		if i < len(prices) {
+			item.NextTier = prices[i].nextTier(ci.Quantity)
+		}
 		items = append(items, item)
 		totalCount += ci.Quantity
 		totalPrice += item.TotalPrice
@@ -91,6 +111,25 @@ func buildCartResponse(
 	return dto, nil
 }
 
+// loadCartAvailability resolves the availability of every variant in the cart,
+// keyed by variant type and ID. Untracked variants are reported in stock.
+func loadCartAvailability(ctx context.Context, stock article.AvailabilityLookup, c *Cart) (map[string]map[int]article.VariantAvailabilityResponse, error) {
+	idsByType := make(map[string][]int)
+	for i := range c.Items {
+		variantType := itemVariantType(c.Items[i])
+		idsByType[variantType] = append(idsByType[variantType], c.Items[i].VariantID)
+	}
+	out := make(map[string]map[int]article.VariantAvailabilityResponse, len(idsByType))
+	for variantType, ids := range idsByType {
+		responses, err := article.LookupAvailability(ctx, stock, variantType, ids)
+		if err != nil {
+			return nil, err
+		}
+		out[variantType] = responses
+	}
+	return out, nil
+}
+
 // loadArticleResponse produces article.ArticleResponse for the given ID using the article service.
 func loadArticleResponse(ctx context.Context, articleSvc ArticleService, id int) (article.ArticleResponse, error) {
 	resp, err := articleSvc.GetArticleSummary(ctx, id)
@@ -126,6 +165,29 @@ func BuildMugVariantResponse(variant *article.MugVariant) *MugVariantResponse {
 	}
 }
 
+// loadShirtVariantResponse builds a simplified shirt variant response for cart.
+func loadShirtVariantResponse(ctx context.Context, articleSvc ArticleService, id int) (*ShirtVariantResponse, error) {
+	v, err := articleSvc.GetShirtVariant(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return BuildShirtVariantResponse(&v), nil
+}
+
+// BuildShirtVariantResponse converts an article shirt variant into the response schema shared with the order API.
+func BuildShirtVariantResponse(variant *article.ShirtVariant) *ShirtVariantResponse {
+	if variant == nil {
+		return nil
+	}
+	return &ShirtVariantResponse{
+		ID:              variant.ID,
+		ArticleID:       variant.ArticleID,
+		Color:           variant.Color,
+		Size:            variant.Size,
+		ExampleImageURL: strPtrOrNil(publicShirtVariantExampleURL(variant.ExampleImageFilename)),
+	}
+}
+
 func strPtrOrNil(s string) *string {
 	if s == "" {
 		return nil
@@ -146,3 +208,17 @@ func publicMugVariantExampleURL(filename *string) string {
 	}
 	return "/public/images/articles/mugs/variant-example-images/" + filepath.Base(*filename)
 }
+
+func publicShirtVariantExampleURL(filename *string) string {
+	if filename == nil || *filename == "" {
+		return ""
+	}
+	if loc, err := img.NewStorageLocations(); err == nil {
+		dir := loc.ShirtVariantExample()
+		if rel, rerr := filepath.Rel(loc.Root, dir); rerr == nil {
+			relURL := filepath.ToSlash(rel)
+			return "/" + relURL + "/" + filepath.Base(*filename)
+		}
+	}
+	return "/public/images/articles/shirts/variant-example-images/" + filepath.Base(*filename)
+}
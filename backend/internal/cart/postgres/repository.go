@@ -22,11 +22,12 @@ func NewRepository(db *gorm.DB) *Repository {
 
 var _ cart.Repository = (*Repository)(nil)
 
-func (r *Repository) GetOrCreateActiveCart(ctx context.Context, userID int) (*cart.Cart, error) {
+func (r *Repository) GetOrCreateActiveCart(ctx context.Context, owner cart.Owner) (*cart.Cart, error) {
 	var row CartRow
 	err := r.db.WithContext(ctx).
 		Preload("Items", withItemOrder).
-		Where("user_id = ? AND status = ?", userID, string(cart.CartStatusActive)).
+		Scopes(ownedBy(owner)).
+		Where("status = ?", string(cart.CartStatusActive)).
 		First(&row).Error
 	if err == nil {
 		domain := row.ToDomain()
@@ -36,7 +37,7 @@ func (r *Repository) GetOrCreateActiveCart(ctx context.Context, userID int) (*ca
 		return nil, err
 	}
 	expiry := time.Now().Add(defaultCartExpiryDays * 24 * time.Hour)
-	row = CartRow{UserID: userID, Status: string(cart.CartStatusActive), ExpiresAt: &expiry}
+	row = FromDomainCart(cart.Cart{UserID: owner.UserID, GuestToken: owner.GuestToken, Status: cart.CartStatusActive, ExpiresAt: &expiry})
 	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
 		return nil, err
 	}
@@ -47,11 +48,12 @@ func (r *Repository) GetOrCreateActiveCart(ctx context.Context, userID int) (*ca
 	return &domain, nil
 }
 
-func (r *Repository) LoadActiveCart(ctx context.Context, userID int) (*cart.Cart, error) {
+func (r *Repository) LoadActiveCart(ctx context.Context, owner cart.Owner) (*cart.Cart, error) {
 	var row CartRow
 	err := r.db.WithContext(ctx).
 		Preload("Items", withItemOrder).
-		Where("user_id = ? AND status = ?", userID, string(cart.CartStatusActive)).
+		Scopes(ownedBy(owner)).
+		Where("status = ?", string(cart.CartStatusActive)).
 		First(&row).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -65,9 +67,14 @@ func (r *Repository) LoadActiveCart(ctx context.Context, userID int) (*cart.Cart
 
 func (r *Repository) SaveCart(ctx context.Context, c cart.Cart) (*cart.Cart, error) {
 	row := FromDomainCart(c)
-	if err := r.db.WithContext(ctx).
-		Session(&gorm.Session{FullSaveAssociations: true}).
-		Save(&row).Error; err != nil {
+	row.Version = c.Version + 1
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := claimVersion(tx, c.ID, c.Version); err != nil {
+			return err
+		}
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(&row).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 	if err := r.db.WithContext(ctx).Preload("Items", withItemOrder).First(&row, row.ID).Error; err != nil {
@@ -77,53 +84,200 @@ func (r *Repository) SaveCart(ctx context.Context, c cart.Cart) (*cart.Cart, err
 	return &domain, nil
 }
 
-func (r *Repository) UpdateItemQuantity(ctx context.Context, cartID, itemID, quantity int) (bool, error) {
+func (r *Repository) UpdateItem(ctx context.Context, cartID int, version int64, item cart.CartItem) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := claimVersion(tx, cartID, version); err != nil {
+			return err
+		}
+		res := tx.Model(&CartItemRow{}).
+			Where("id = ? AND cart_id = ?", item.ID, cartID).
+			Updates(map[string]any{
+				"quantity":                       item.Quantity,
+				"price_at_time":                  item.PriceAtTime,
+				"original_price":                 item.OriginalPrice,
+				"prompt_price_at_time":           item.PromptPriceAtTime,
+				"prompt_original_price":          item.PromptOriginalPrice,
+				"price_tier_min_quantity":        item.PriceTierMinQuantity,
+				"prompt_price_tier_min_quantity": item.PromptPriceTierMinQuantity,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errItemMissing
+		}
+		return nil
+	})
+	return itemWritten(err)
+}
+
+func (r *Repository) DeleteItem(ctx context.Context, cartID int, version int64, itemID int) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := claimVersion(tx, cartID, version); err != nil {
+			return err
+		}
+		res := tx.Where("id = ? AND cart_id = ?", itemID, cartID).Delete(&CartItemRow{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errItemMissing
+		}
+		var items []CartItemRow
+		if err := tx.Where("cart_id = ?", cartID).Order("position asc, created_at asc").Find(&items).Error; err != nil {
+			return err
+		}
+		for idx := range items {
+			if items[idx].Position != idx {
+				if err := tx.Model(&CartItemRow{}).Where("id = ?", items[idx].ID).Update("position", idx).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	return itemWritten(err)
+}
+
+func (r *Repository) ClearCartItems(ctx context.Context, cartID int, version int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := claimVersion(tx, cartID, version); err != nil {
+			return err
+		}
+		return tx.Where("cart_id = ?", cartID).Delete(&CartItemRow{}).Error
+	})
+}
+
+func (r *Repository) SetPromotionCode(ctx context.Context, cartID int, version int64, code string) error {
+	var value *string
+	if code != "" {
+		value = &code
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := claimVersion(tx, cartID, version); err != nil {
+			return err
+		}
+		return tx.Model(&CartRow{}).Where("id = ?", cartID).Update("promotion_code", value).Error
+	})
+}
+
+func (r *Repository) ReloadCart(ctx context.Context, cartID int) (*cart.Cart, error) {
+	var row CartRow
+	if err := r.db.WithContext(ctx).Preload("Items", withItemOrder).First(&row, cartID).Error; err != nil {
+		return nil, err
+	}
+	domain := row.ToDomain()
+	return &domain, nil
+}
+
+func (r *Repository) DeleteCart(ctx context.Context, cartID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cart_id = ?", cartID).Delete(&CartItemRow{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&CartRow{}, cartID).Error
+	})
+}
+
+func (r *Repository) DeleteStaleGuestCarts(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []int
+		if err := tx.Model(&CartRow{}).
+			Scopes(unchangedSince(before)).
+			Where("guest_token IS NOT NULL").
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Where("cart_id IN ?", ids).Delete(&CartItemRow{}).Error; err != nil {
+			return err
+		}
+		res := tx.Where("id IN ?", ids).Delete(&CartRow{})
+		deleted = res.RowsAffected
+		return res.Error
+	})
+	return deleted, err
+}
+
+func (r *Repository) ListIdleCarts(ctx context.Context, before time.Time) ([]cart.Cart, error) {
+	var rows []CartRow
+	if err := r.db.WithContext(ctx).
+		Preload("Items", withItemOrder).
+		Scopes(unchangedSince(before)).
+		Where("user_id IS NOT NULL AND status = ?", string(cart.CartStatusActive)).
+		Where("EXISTS (SELECT 1 FROM cart_items WHERE cart_items.cart_id = carts.id)").
+		Order("id asc").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return toDomainCarts(rows), nil
+}
+
+func (r *Repository) MarkAbandoned(ctx context.Context, cartID int, at time.Time, value int, recoveryToken string) (bool, error) {
 	res := r.db.WithContext(ctx).
-		Model(&CartItemRow{}).
-		Where("id = ? AND cart_id = ?", itemID, cartID).
-		Update("quantity", quantity)
+		Model(&CartRow{}).
+		Where("id = ? AND status = ?", cartID, string(cart.CartStatusActive)).
+		Updates(map[string]any{
+			"status":          string(cart.CartStatusAbandoned),
+			"abandoned_at":    at,
+			"abandoned_value": value,
+			"recovery_token":  recoveryToken,
+			"version":         gorm.Expr("version + 1"),
+		})
 	if res.Error != nil {
 		return false, res.Error
 	}
 	return res.RowsAffected > 0, nil
 }
 
-func (r *Repository) DeleteItem(ctx context.Context, cartID, itemID int) (bool, error) {
-	tx := r.db.WithContext(ctx)
-	res := tx.Where("id = ? AND cart_id = ?", itemID, cartID).Delete(&CartItemRow{})
-	if res.Error != nil {
-		return false, res.Error
-	}
-	if res.RowsAffected == 0 {
-		return false, nil
-	}
-	var items []CartItemRow
-	if err := tx.Where("cart_id = ?", cartID).Order("position asc, created_at asc").Find(&items).Error; err != nil {
-		return false, err
-	}
-	for idx := range items {
-		if items[idx].Position != idx {
-			if err := tx.Model(&CartItemRow{}).Where("id = ?", items[idx].ID).Update("position", idx).Error; err != nil {
-				return false, err
-			}
-		}
+func (r *Repository) ListAbandonedCartsToRemind(ctx context.Context, since time.Time) ([]cart.Cart, error) {
+	var rows []CartRow
+	if err := r.db.WithContext(ctx).
+		Preload("Items", withItemOrder).
+		Where("status = ? AND abandoned_at > ?", string(cart.CartStatusAbandoned), since).
+		Where("reminder_sent_at IS NULL AND recovered_at IS NULL").
+		Order("abandoned_at asc").
+		Find(&rows).Error; err != nil {
+		return nil, err
 	}
-	return true, nil
+	return toDomainCarts(rows), nil
 }
 
-func (r *Repository) ClearCartItems(ctx context.Context, cartID int) error {
-	return r.db.WithContext(ctx).Where("cart_id = ?", cartID).Delete(&CartItemRow{}).Error
+func (r *Repository) MarkReminderSent(ctx context.Context, cartID int, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&CartRow{}).Where("id = ?", cartID).UpdateColumn("reminder_sent_at", at).Error
 }
 
-func (r *Repository) ReloadCart(ctx context.Context, cartID int) (*cart.Cart, error) {
+func (r *Repository) CartByRecoveryToken(ctx context.Context, token string) (*cart.Cart, error) {
 	var row CartRow
-	if err := r.db.WithContext(ctx).Preload("Items", withItemOrder).First(&row, cartID).Error; err != nil {
+	err := r.db.WithContext(ctx).Preload("Items", withItemOrder).Where("recovery_token = ?", token).First(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
 		return nil, err
 	}
 	domain := row.ToDomain()
 	return &domain, nil
 }
 
+func (r *Repository) MarkRecovered(ctx context.Context, cartID int, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&CartRow{}).Where("id = ?", cartID).UpdateColumn("recovered_at", at).Error
+}
+
+func (r *Repository) ListAbandonedCarts(ctx context.Context, from, to time.Time) ([]cart.Cart, error) {
+	var rows []CartRow
+	if err := r.db.WithContext(ctx).
+		Where("abandoned_at >= ? AND abandoned_at < ?", from, to).
+		Order("abandoned_at asc").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return toDomainCarts(rows), nil
+}
+
 func (r *Repository) FetchGeneratedImageFilenames(ctx context.Context, ids []int) (map[int]string, error) {
 	result := make(map[int]string, len(ids))
 	if len(ids) == 0 {
@@ -170,12 +324,88 @@ func (r *Repository) FetchPromptTitles(ctx context.Context, ids []int) (map[int]
 	return result, nil
 }
 
+// Settings returns the stored cart settings, or the defaults when none are
+// stored.
+func (r *Repository) Settings(ctx context.Context) (cart.Settings, error) {
+	var row SettingsRow
+	err := r.db.WithContext(ctx).First(&row, settingsRowID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return cart.Settings{}, nil
+	}
+	if err != nil {
+		return cart.Settings{}, err
+	}
+	return cart.Settings{PriceLockMinutes: row.PriceLockMinutes, UpdatedAt: row.UpdatedAt}, nil
+}
+
+func (r *Repository) SaveSettings(ctx context.Context, s cart.Settings) (cart.Settings, error) {
+	row := SettingsRow{ID: settingsRowID, PriceLockMinutes: s.PriceLockMinutes}
+	if err := r.db.WithContext(ctx).Save(&row).Error; err != nil {
+		return cart.Settings{}, err
+	}
+	return cart.Settings{PriceLockMinutes: row.PriceLockMinutes, UpdatedAt: row.UpdatedAt}, nil
+}
+
 func (r *Repository) WithTx(ctx context.Context, fn func(cart.Repository) error) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		return fn(&Repository{db: tx})
 	})
 }
 
+// errItemMissing rolls back a write to an item that is not in the cart.
+var errItemMissing = errors.New("cart item missing")
+
+// claimVersion bumps the version of the cart if it is still at version, so
+// of two writes that loaded the same version only the first one succeeds.
+func claimVersion(tx *gorm.DB, cartID int, version int64) error {
+	res := tx.Model(&CartRow{}).
+		Where("id = ? AND version = ?", cartID, version).
+		Updates(map[string]any{"version": gorm.Expr("version + 1"), "updated_at": time.Now()})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return cart.ErrCartConflict
+	}
+	return nil
+}
+
+// itemWritten reports whether a write to an item happened, treating a
+// missing item as no write rather than an error.
+func itemWritten(err error) (bool, error) {
+	if errors.Is(err, errItemMissing) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// unchangedSince restricts a cart query to carts whose row and items were
+// last updated before the given time.
+func unchangedSince(before time.Time) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("carts.updated_at < ?", before).
+			Where("NOT EXISTS (SELECT 1 FROM cart_items WHERE cart_items.cart_id = carts.id AND cart_items.updated_at >= ?)", before)
+	}
+}
+
+func toDomainCarts(rows []CartRow) []cart.Cart {
+	out := make([]cart.Cart, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].ToDomain())
+	}
+	return out
+}
+
+// ownedBy restricts a cart query to the carts of owner.
+func ownedBy(owner cart.Owner) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if owner.IsGuest() {
+			return tx.Where("guest_token = ?", owner.GuestToken)
+		}
+		return tx.Where("user_id = ?", owner.UserID)
+	}
+}
+
 func withItemOrder(tx *gorm.DB) *gorm.DB {
 	return tx.Order("position asc, created_at asc")
 }
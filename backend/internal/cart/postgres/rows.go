@@ -7,72 +7,116 @@ import (
 )
 
 type CartRow struct {
-	ID        int           `gorm:"primaryKey"`
-	UserID    int           `gorm:"column:user_id;not null"`
-	Status    string        `gorm:"size:20;not null"`
-	Version   int64         `gorm:"column:version;not null;default:0;version"`
-	ExpiresAt *time.Time    `gorm:"column:expires_at"`
-	Items     []CartItemRow `gorm:"foreignKey:CartID;references:ID"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID             int           `gorm:"primaryKey"`
+	UserID         *int          `gorm:"column:user_id"`
+	GuestToken     *string       `gorm:"column:guest_token;size:64"`
+	Status         string        `gorm:"size:20;not null"`
+	Version        int64         `gorm:"column:version;not null;default:0;version"`
+	ExpiresAt      *time.Time    `gorm:"column:expires_at"`
+	Items          []CartItemRow `gorm:"foreignKey:CartID;references:ID"`
+	AbandonedAt    *time.Time    `gorm:"column:abandoned_at"`
+	AbandonedValue *int          `gorm:"column:abandoned_value"`
+	RecoveryToken  *string       `gorm:"column:recovery_token;size:64"`
+	ReminderSentAt *time.Time    `gorm:"column:reminder_sent_at"`
+	RecoveredAt    *time.Time    `gorm:"column:recovered_at"`
+	PromotionCode  *string       `gorm:"column:promotion_code;size:64"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 func (CartRow) TableName() string { return "carts" }
 
 type CartItemRow struct {
-	ID                  int    `gorm:"primaryKey"`
-	CartID              int    `gorm:"column:cart_id;not null"`
-	ArticleID           int    `gorm:"column:article_id;not null"`
-	VariantID           int    `gorm:"column:variant_id;not null"`
-	Quantity            int    `gorm:"not null"`
-	PriceAtTime         int    `gorm:"column:price_at_time;not null"`
-	OriginalPrice       int    `gorm:"column:original_price;not null"`
-	PromptPriceAtTime   int    `gorm:"column:prompt_price_at_time;not null;default:0"`
-	PromptOriginalPrice int    `gorm:"column:prompt_original_price;not null;default:0"`
-	CustomData          string `gorm:"column:custom_data;type:text;not null"`
-	GeneratedImageID    *int   `gorm:"column:generated_image_id"`
-	PromptID            *int   `gorm:"column:prompt_id"`
-	Position            int    `gorm:"not null;default:0"`
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
+	ID                         int        `gorm:"primaryKey"`
+	CartID                     int        `gorm:"column:cart_id;not null"`
+	ArticleID                  int        `gorm:"column:article_id;not null"`
+	VariantID                  int        `gorm:"column:variant_id;not null"`
+	VariantType                string     `gorm:"column:variant_type;size:20;not null;default:MUG"`
+	Quantity                   int        `gorm:"not null"`
+	PriceAtTime                int        `gorm:"column:price_at_time;not null"`
+	OriginalPrice              int        `gorm:"column:original_price;not null"`
+	PromptPriceAtTime          int        `gorm:"column:prompt_price_at_time;not null;default:0"`
+	PromptOriginalPrice        int        `gorm:"column:prompt_original_price;not null;default:0"`
+	PriceTierMinQuantity       int        `gorm:"column:price_tier_min_quantity;not null;default:1"`
+	PromptPriceTierMinQuantity int        `gorm:"column:prompt_price_tier_min_quantity;not null;default:1"`
+	CustomData                 string     `gorm:"column:custom_data;type:text;not null"`
+	GeneratedImageID           *int       `gorm:"column:generated_image_id"`
+	PromptID                   *int       `gorm:"column:prompt_id"`
+	Position                   int        `gorm:"not null;default:0"`
+	PriceLockedUntil           *time.Time `gorm:"column:price_locked_until"`
+	CreatedAt                  time.Time
+	UpdatedAt                  time.Time
 }
 
 func (CartItemRow) TableName() string { return "cart_items" }
 
+type SettingsRow struct {
+	ID               int `gorm:"primaryKey;autoIncrement:false"`
+	PriceLockMinutes int `gorm:"column:price_lock_minutes;not null;default:0"`
+	UpdatedAt        time.Time
+}
+
+func (SettingsRow) TableName() string { return "cart_settings" }
+
+// settingsRowID is the ID of the only row of cart_settings.
+const settingsRowID = 1
+
 func (r *CartRow) ToDomain() cart.Cart {
 	items := make([]cart.CartItem, 0, len(r.Items))
 	for _, item := range r.Items {
 		items = append(items, item.ToDomain())
 	}
-	return cart.Cart{
-		ID:        r.ID,
-		UserID:    r.UserID,
-		Status:    cart.CartStatus(r.Status),
-		Version:   r.Version,
-		ExpiresAt: r.ExpiresAt,
-		Items:     items,
-		CreatedAt: r.CreatedAt,
-		UpdatedAt: r.UpdatedAt,
+	c := cart.Cart{
+		ID:             r.ID,
+		Status:         cart.CartStatus(r.Status),
+		Version:        r.Version,
+		ExpiresAt:      r.ExpiresAt,
+		Items:          items,
+		AbandonedAt:    r.AbandonedAt,
+		ReminderSentAt: r.ReminderSentAt,
+		RecoveredAt:    r.RecoveredAt,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+	if r.AbandonedValue != nil {
+		c.AbandonedValue = *r.AbandonedValue
+	}
+	if r.RecoveryToken != nil {
+		c.RecoveryToken = *r.RecoveryToken
+	}
+	if r.UserID != nil {
+		c.UserID = *r.UserID
 	}
+	if r.GuestToken != nil {
+		c.GuestToken = *r.GuestToken
+	}
+	if r.PromotionCode != nil {
+		c.PromotionCode = *r.PromotionCode
+	}
+	return c
 }
 
 func (r *CartItemRow) ToDomain() cart.CartItem {
 	return cart.CartItem{
-		ID:                  r.ID,
-		CartID:              r.CartID,
-		ArticleID:           r.ArticleID,
-		VariantID:           r.VariantID,
-		Quantity:            r.Quantity,
-		PriceAtTime:         r.PriceAtTime,
-		OriginalPrice:       r.OriginalPrice,
-		PromptPriceAtTime:   r.PromptPriceAtTime,
-		PromptOriginalPrice: r.PromptOriginalPrice,
-		CustomData:          r.CustomData,
-		GeneratedImageID:    r.GeneratedImageID,
-		PromptID:            r.PromptID,
-		Position:            r.Position,
-		CreatedAt:           r.CreatedAt,
-		UpdatedAt:           r.UpdatedAt,
+		ID:                         r.ID,
+		CartID:                     r.CartID,
+		ArticleID:                  r.ArticleID,
+		VariantID:                  r.VariantID,
+		VariantType:                r.VariantType,
+		Quantity:                   r.Quantity,
+		PriceAtTime:                r.PriceAtTime,
+		OriginalPrice:              r.OriginalPrice,
+		PromptPriceAtTime:          r.PromptPriceAtTime,
+		PromptOriginalPrice:        r.PromptOriginalPrice,
+		PriceTierMinQuantity:       r.PriceTierMinQuantity,
+		PromptPriceTierMinQuantity: r.PromptPriceTierMinQuantity,
+		CustomData:                 r.CustomData,
+		GeneratedImageID:           r.GeneratedImageID,
+		PromptID:                   r.PromptID,
+		Position:                   r.Position,
+		PriceLockedUntil:           r.PriceLockedUntil,
+		CreatedAt:                  r.CreatedAt,
+		UpdatedAt:                  r.UpdatedAt,
 	}
 }
 
@@ -81,34 +125,56 @@ func FromDomainCart(c cart.Cart) CartRow {
 	for _, item := range c.Items {
 		items = append(items, FromDomainItem(item))
 	}
-	return CartRow{
-		ID:        c.ID,
-		UserID:    c.UserID,
-		Status:    string(c.Status),
-		Version:   c.Version,
-		ExpiresAt: c.ExpiresAt,
-		Items:     items,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: c.UpdatedAt,
+	row := CartRow{
+		ID:             c.ID,
+		Status:         string(c.Status),
+		Version:        c.Version,
+		ExpiresAt:      c.ExpiresAt,
+		Items:          items,
+		AbandonedAt:    c.AbandonedAt,
+		ReminderSentAt: c.ReminderSentAt,
+		RecoveredAt:    c.RecoveredAt,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+	}
+	if c.AbandonedAt != nil {
+		row.AbandonedValue = &c.AbandonedValue
+	}
+	if c.RecoveryToken != "" {
+		row.RecoveryToken = &c.RecoveryToken
+	}
+	if c.UserID != 0 {
+		row.UserID = &c.UserID
+	}
+	if c.GuestToken != "" {
+		row.GuestToken = &c.GuestToken
+	}
+	if c.PromotionCode != "" {
+		row.PromotionCode = &c.PromotionCode
 	}
+	return row
 }
 
 func FromDomainItem(item cart.CartItem) CartItemRow {
 	return CartItemRow{
-		ID:                  item.ID,
-		CartID:              item.CartID,
-		ArticleID:           item.ArticleID,
-		VariantID:           item.VariantID,
-		Quantity:            item.Quantity,
-		PriceAtTime:         item.PriceAtTime,
-		OriginalPrice:       item.OriginalPrice,
-		PromptPriceAtTime:   item.PromptPriceAtTime,
-		PromptOriginalPrice: item.PromptOriginalPrice,
-		CustomData:          item.CustomData,
-		GeneratedImageID:    item.GeneratedImageID,
-		PromptID:            item.PromptID,
-		Position:            item.Position,
-		CreatedAt:           item.CreatedAt,
-		UpdatedAt:           item.UpdatedAt,
+		ID:                         item.ID,
+		CartID:                     item.CartID,
+		ArticleID:                  item.ArticleID,
+		VariantID:                  item.VariantID,
+		VariantType:                item.VariantType,
+		Quantity:                   item.Quantity,
+		PriceAtTime:                item.PriceAtTime,
+		OriginalPrice:              item.OriginalPrice,
+		PromptPriceAtTime:          item.PromptPriceAtTime,
+		PromptOriginalPrice:        item.PromptOriginalPrice,
+		PriceTierMinQuantity:       item.PriceTierMinQuantity,
+		PromptPriceTierMinQuantity: item.PromptPriceTierMinQuantity,
+		CustomData:                 item.CustomData,
+		GeneratedImageID:           item.GeneratedImageID,
+		PromptID:                   item.PromptID,
+		Position:                   item.Position,
+		PriceLockedUntil:           item.PriceLockedUntil,
+		CreatedAt:                  item.CreatedAt,
+		UpdatedAt:                  item.UpdatedAt,
 	}
 }
@@ -0,0 +1,209 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// maxPriceLockMinutes bounds the price lock window to 30 days.
+const maxPriceLockMinutes = 30 * 24 * 60
+
+// ErrPriceChangesPending is returned at checkout while the cart has price
+// changes the customer has not acknowledged.
+var ErrPriceChangesPending = errors.New("prices in the cart changed; review and acknowledge them before checkout")
+
+// PriceComponent is the part of a line's unit price that changed.
+type PriceComponent string
+
+const (
+	PriceComponentArticle PriceComponent = "ARTICLE"
+	PriceComponentPrompt  PriceComponent = "PROMPT"
+)
+
+type PriceChangeReason string
+
+const (
+	PriceIncreased PriceChangeReason = "PRICE_INCREASED"
+	PriceDecreased PriceChangeReason = "PRICE_DECREASED"
+)
+
+// PriceChange is a difference between the unit price a line is charged and
+// the current price of its article or prompt for the line's quantity.
+type PriceChange struct {
+	ItemID    int
+	Component PriceComponent
+	OldPrice  int
+	NewPrice  int
+	Reason    PriceChangeReason
+	// LockedUntil is set while the line keeps OldPrice. Such changes need no
+	// acknowledgment until the lock ends.
+	LockedUntil *time.Time
+}
+
+// pending reports whether the change has to be acknowledged before checkout.
+func (c PriceChange) pending() bool { return c.LockedUntil == nil }
+
+// changes compares the prices the item is charged with p, the current
+// prices.
+func (p linePrices) changes(it CartItem, now time.Time) []PriceChange {
+	articleGross, promptGross := p.unitGross(it.Quantity)
+	var lockedUntil *time.Time
+	if it.priceLocked(now) {
+		lockedUntil = it.PriceLockedUntil
+	}
+	var out []PriceChange
+	add := func(component PriceComponent, oldPrice, newPrice int) {
+		if oldPrice == newPrice {
+			return
+		}
+		reason := PriceIncreased
+		if newPrice < oldPrice {
+			reason = PriceDecreased
+		}
+		out = append(out, PriceChange{
+			ItemID:      it.ID,
+			Component:   component,
+			OldPrice:    oldPrice,
+			NewPrice:    newPrice,
+			Reason:      reason,
+			LockedUntil: lockedUntil,
+		})
+	}
+	add(PriceComponentArticle, it.PriceAtTime, articleGross)
+	add(PriceComponentPrompt, it.PromptPriceAtTime, promptGross)
+	return out
+}
+
+// chargedLinePrices returns the prices the item is charged for any quantity:
+// those in effect when it was added while its price is locked, the current
+// ones otherwise.
+func (s *Service) chargedLinePrices(ctx context.Context, it CartItem, now time.Time) (linePrices, error) {
+	at := now
+	if it.priceLocked(now) {
+		at = it.CreatedAt
+	}
+	return s.currentLinePrices(ctx, it.ArticleID, it.PromptID, at)
+}
+
+// CheckPrices returns ErrPriceChangesPending when a line of c is charged a
+// price that is no longer current and is not locked. Checkout calls it so
+// customers are never charged prices they did not see.
+func (s *Service) CheckPrices(ctx context.Context, c *Cart) error {
+	now := time.Now()
+	for _, it := range c.Items {
+		prices, err := s.currentLinePrices(ctx, it.ArticleID, it.PromptID, now)
+		if err != nil {
+			return err
+		}
+		for _, change := range prices.changes(it, now) {
+			if change.pending() {
+				return ErrPriceChangesPending
+			}
+		}
+	}
+	return nil
+}
+
+// AcknowledgePriceChanges moves every line whose price changed and is not
+// locked to the current prices. With ifMatch it fails with
+// ErrPreconditionFailed when the cart changed since the client loaded it, so
+// customers only accept the prices they were shown.
+func (s *Service) AcknowledgePriceChanges(ctx context.Context, owner Owner, ifMatch *CartVersion) (*CartDetail, error) {
+	return retryOnConflict(ifMatch, func() (*CartDetail, error) { return s.acknowledgePriceChanges(ctx, owner, ifMatch) })
+}
+
+func (s *Service) acknowledgePriceChanges(ctx context.Context, owner Owner, ifMatch *CartVersion) (*CartDetail, error) {
+	cart, err := s.repo.LoadActiveCart(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		return nil, ErrCartNotFound
+	}
+	if err := checkVersion(cart, ifMatch); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	changed := false
+	for i := range cart.Items {
+		it := &cart.Items[i]
+		if it.priceLocked(now) {
+			continue
+		}
+		prices, err := s.currentLinePrices(ctx, it.ArticleID, it.PromptID, now)
+		if err != nil {
+			return nil, err
+		}
+		if prices.isCurrent(*it) {
+			continue
+		}
+		prices.apply(it)
+		it.PriceLockedUntil = nil
+		changed = true
+	}
+	if !changed {
+		return s.buildCartDetail(ctx, cart)
+	}
+	saved, err := s.repo.SaveCart(ctx, *cart)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildCartDetail(ctx, saved)
+}
+
+// addPriceChanges adds the price change notices of the cart's lines to dto.
+// current holds the current prices of each line.
+func addPriceChanges(dto *CartResponse, c *Cart, current []linePrices, now time.Time) {
+	for i := range c.Items {
+		it := c.Items[i]
+		item := &dto.Items[i]
+		item.PriceChanges = []PriceChangeResponse{}
+		if it.priceLocked(now) {
+			item.PriceLockedUntil = it.PriceLockedUntil
+		}
+		if i >= len(current) {
+			continue
+		}
+		for _, change := range current[i].changes(it, now) {
+			item.PriceChanges = append(item.PriceChanges, PriceChangeResponse{
+				Component:   string(change.Component),
+				OldPrice:    change.OldPrice,
+				NewPrice:    change.NewPrice,
+				Reason:      string(change.Reason),
+				Locked:      !change.pending(),
+				LockedUntil: change.LockedUntil,
+			})
+			if change.pending() {
+				dto.RequiresPriceAcknowledgment = true
+			}
+		}
+	}
+}
+
+// priceLockUntil returns until when a line added at now keeps its prices, or
+// nil when price locks are off.
+func (s *Service) priceLockUntil(ctx context.Context, now time.Time) (*time.Time, error) {
+	settings, err := s.repo.Settings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if settings.PriceLockMinutes <= 0 {
+		return nil, nil
+	}
+	until := now.Add(time.Duration(settings.PriceLockMinutes) * time.Minute)
+	return &until, nil
+}
+
+func (s *Service) Settings(ctx context.Context) (Settings, error) {
+	return s.repo.Settings(ctx)
+}
+
+// UpdateSettings stores the cart settings. A new price lock window applies
+// to lines added from now on.
+func (s *Service) UpdateSettings(ctx context.Context, in Settings) (Settings, error) {
+	if in.PriceLockMinutes < 0 || in.PriceLockMinutes > maxPriceLockMinutes {
+		return Settings{}, newValidationError("priceLockMinutes must be between 0 and 43200")
+	}
+	return s.repo.SaveSettings(ctx, in)
+}
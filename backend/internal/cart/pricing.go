@@ -0,0 +1,113 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+)
+
+// linePrices are the current prices of a cart line's article and prompt.
+// Either may be nil when no price is set.
+type linePrices struct {
+	article *article.Price
+	prompt  *article.Price
+}
+
+// unitGross returns the current gross unit prices for quantity units.
+func (p linePrices) unitGross(quantity int) (articleGross, promptGross int) {
+	articleGross, _ = p.article.UnitGrossFor(quantity)
+	promptGross, _ = p.prompt.UnitGrossFor(quantity)
+	return articleGross, promptGross
+}
+
+// isCurrent reports whether the item is charged the current prices for its
+// quantity.
+func (p linePrices) isCurrent(it CartItem) bool {
+	articleGross, promptGross := p.unitGross(it.Quantity)
+	return it.PriceAtTime == articleGross && it.PromptPriceAtTime == promptGross
+}
+
+// apply prices the item at the tiers matching its quantity.
+func (p linePrices) apply(it *CartItem) {
+	it.PriceAtTime, it.PriceTierMinQuantity = p.article.UnitGrossFor(it.Quantity)
+	it.PromptPriceAtTime, it.PromptPriceTierMinQuantity = p.prompt.UnitGrossFor(it.Quantity)
+	it.OriginalPrice = it.PriceAtTime
+	it.PromptOriginalPrice = it.PromptPriceAtTime
+}
+
+// nextTier returns the smallest quantity above the item's quantity at which
+// the combined unit price drops, or nil when ordering more saves nothing.
+func (p linePrices) nextTier(quantity int) *NextTierResponse {
+	articleGross, promptGross := p.unitGross(quantity)
+	current := articleGross + promptGross
+	var best *NextTierResponse
+	for _, tier := range []*article.PriceTier{p.article.NextTier(quantity), p.prompt.NextTier(quantity)} {
+		if tier == nil || (best != nil && tier.MinQuantity >= best.MinQuantity) {
+			continue
+		}
+		a, pr := p.unitGross(tier.MinQuantity)
+		if unit := a + pr; unit < current {
+			best = &NextTierResponse{
+				MinQuantity:        tier.MinQuantity,
+				AdditionalQuantity: tier.MinQuantity - quantity,
+				UnitPrice:          unit,
+				SavingPerItem:      current - unit,
+			}
+		}
+	}
+	return best
+}
+
+// currentLinePrices loads the article price and, if promptID is set, prompt
+// price in effect at the given moment including their tiers.
+func (s *Service) currentLinePrices(ctx context.Context, articleID int, promptID *int, at time.Time) (linePrices, error) {
+	var out linePrices
+	var err error
+	if out.article, err = currentPrice(ctx, s.articleSvc, articleID, at); err != nil {
+		return linePrices{}, err
+	}
+	if promptID != nil {
+		if out.prompt, err = promptCurrentPrice(ctx, s.promptSvc, s.articleSvc, *promptID, at); err != nil {
+			return linePrices{}, err
+		}
+	}
+	return out, nil
+}
+
+func currentPrice(ctx context.Context, articleSvc ArticleService, articleID int, at time.Time) (*article.Price, error) {
+	cc, err := articleSvc.GetCostCalculationAt(ctx, articleID, at)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cc, nil
+}
+
+func promptCurrentPrice(ctx context.Context, promptSvc PromptService, articleSvc ArticleService, promptID int, at time.Time) (*article.Price, error) {
+	promptRead, err := promptSvc.GetPrompt(ctx, promptID)
+	if err != nil {
+		return nil, err
+	}
+	if promptRead == nil {
+		return nil, nil
+	}
+	if promptRead.PriceID != nil {
+		cc, err := articleSvc.GetCostCalculationByIDAt(ctx, *promptRead.PriceID, at)
+		if err != nil {
+			return nil, err
+		}
+		if cc != nil {
+			return cc, nil
+		}
+	}
+	if promptRead.CostCalculation != nil {
+		return &article.Price{SalesTotalGross: promptRead.CostCalculation.SalesTotalGross}, nil
+	}
+	return nil, nil
+}
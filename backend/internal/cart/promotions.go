@@ -0,0 +1,108 @@
+package cart
+
+import (
+	"context"
+
+	"voenix/backend/internal/promotion"
+)
+
+// PromotionService quotes the discount of a promotion code.
+type PromotionService interface {
+	Quote(ctx context.Context, code string, userID int, lines []promotion.Line, shipping int) (*promotion.Discount, error)
+}
+
+// SetPromotions enables promotion codes on carts.
+func (s *Service) SetPromotions(p PromotionService) { s.promotions = p }
+
+// ApplyPromotion applies a promotion code to the cart after checking that it
+// discounts the cart now. It replaces a code applied before.
+func (s *Service) ApplyPromotion(ctx context.Context, owner Owner, code string) (*CartDetail, error) {
+	if s.promotions == nil {
+		return nil, newValidationError("promotion codes are not accepted")
+	}
+	code = promotion.NormalizeCode(code)
+	if code == "" {
+		return nil, newValidationError("code is required")
+	}
+	return retryOnConflict(nil, func() (*CartDetail, error) {
+		return s.setPromotionCode(ctx, owner, code, func(c *Cart) error {
+			_, err := s.promotions.Quote(ctx, code, c.UserID, PromotionLines(c.Items), 0)
+			return err
+		})
+	})
+}
+
+// RemovePromotion removes the promotion code from the cart.
+func (s *Service) RemovePromotion(ctx context.Context, owner Owner) (*CartDetail, error) {
+	return retryOnConflict(nil, func() (*CartDetail, error) {
+		return s.setPromotionCode(ctx, owner, "", func(*Cart) error { return nil })
+	})
+}
+
+func (s *Service) setPromotionCode(ctx context.Context, owner Owner, code string, check func(*Cart) error) (*CartDetail, error) {
+	cart, err := s.repo.LoadActiveCart(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		return nil, ErrCartNotFound
+	}
+	if err := check(cart); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetPromotionCode(ctx, cart.ID, cart.Version, code); err != nil {
+		return nil, err
+	}
+	refreshed, err := s.repo.ReloadCart(ctx, cart.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildCartDetail(ctx, refreshed)
+}
+
+// PromotionLines turns cart items into the lines a promotion discounts, at
+// the prices they were added at.
+func PromotionLines(items []CartItem) []promotion.Line {
+	lines := make([]promotion.Line, 0, len(items))
+	for _, it := range items {
+		lines = append(lines, promotion.Line{
+			ArticleID: it.ArticleID,
+			PromptID:  it.PromptID,
+			Quantity:  it.Quantity,
+			UnitPrice: it.PriceAtTime + it.PromptPriceAtTime,
+		})
+	}
+	return lines
+}
+
+// addDiscount adds the discount of the cart's promotion code to dto. A code
+// that no longer applies stays on the cart with the reason, so customers see
+// why the discount is gone.
+func (s *Service) addDiscount(ctx context.Context, c *Cart, dto *CartResponse) error {
+	dto.Discounts = []CartDiscountResponse{}
+	dto.TotalAfterDiscount = dto.TotalPrice
+	if c.PromotionCode == "" || s.promotions == nil {
+		return nil
+	}
+	code := c.PromotionCode
+	dto.PromotionCode = &code
+	d, err := s.promotions.Quote(ctx, code, c.UserID, PromotionLines(c.Items), 0)
+	if promotion.Rejected(err) {
+		msg := err.Error()
+		dto.PromotionError = &msg
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	dto.Discounts = append(dto.Discounts, CartDiscountResponse{
+		Code:         d.Promotion.Code,
+		Kind:         string(d.Promotion.Kind),
+		Description:  d.Promotion.Description,
+		Amount:       d.Items,
+		FreeShipping: d.Promotion.Kind == promotion.KindFreeShipping,
+	})
+	dto.DiscountTotal = d.Items
+	dto.TotalAfterDiscount = dto.TotalPrice - d.Items
+	return nil
+}
@@ -0,0 +1,102 @@
+package cart
+
+import (
+	"context"
+
+	"voenix/backend/internal/article"
+)
+
+const (
+	defaultRecommendationLimit = 4
+	maxRecommendationLimit     = 20
+)
+
+// RecommendationResponse suggests a variant of an article to go with the
+// cart. GeneratedImageID and PromptID repeat the design of the cart line the
+// suggestion is based on when it fits the suggested article; adding the
+// suggestion with them puts the same design on it.
+type RecommendationResponse struct {
+	Article                article.ArticleResponse `json:"article"`
+	VariantType            string                  `json:"variantType"`
+	Variant                *MugVariantResponse     `json:"variant"`
+	ShirtVariant           *ShirtVariantResponse   `json:"shirtVariant,omitempty"`
+	Reason                 string                  `json:"reason"`
+	BasedOnArticleID       int                     `json:"basedOnArticleId"`
+	GeneratedImageID       *int                    `json:"generatedImageId"`
+	GeneratedImageFilename *string                 `json:"generatedImageFilename"`
+	PromptID               *int                    `json:"promptId"`
+}
+
+// Recommendations suggests up to limit articles for the user's active cart,
+// based on curated relations and articles bought together with the ones in
+// the cart. Only article types the cart accepts are suggested.
+func (s *Service) Recommendations(ctx context.Context, owner Owner, limit int) ([]RecommendationResponse, error) {
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
+	limit = min(limit, maxRecommendationLimit)
+	cart, err := s.repo.LoadActiveCart(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil || len(cart.Items) == 0 {
+		return []RecommendationResponse{}, nil
+	}
+	seeds := make([]article.RecommendationSeed, 0, len(cart.Items))
+	prompts := make(map[int]*int, len(cart.Items))
+	for _, it := range cart.Items {
+		seeds = append(seeds, article.RecommendationSeed{ArticleID: it.ArticleID, GeneratedImageID: it.GeneratedImageID})
+		if it.GeneratedImageID != nil {
+			if _, ok := prompts[*it.GeneratedImageID]; !ok {
+				prompts[*it.GeneratedImageID] = it.PromptID
+			}
+		}
+	}
+	recs, err := s.articleSvc.Recommend(ctx, seeds, article.RecommendOptions{
+		Limit:        limit,
+		ArticleTypes: []string{article.ArticleTypeMug, article.ArticleTypeShirt},
+	})
+	if err != nil {
+		return nil, err
+	}
+	generatedIDs := make([]int, 0, len(recs))
+	for _, rec := range recs {
+		if rec.GeneratedImageID != nil {
+			generatedIDs = append(generatedIDs, *rec.GeneratedImageID)
+		}
+	}
+	filenames, err := s.repo.FetchGeneratedImageFilenames(ctx, generatedIDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]RecommendationResponse, 0, len(recs))
+	for _, rec := range recs {
+		art, err := loadArticleResponse(ctx, s.articleSvc, rec.Article.ID)
+		if err != nil {
+			return nil, err
+		}
+		resp := RecommendationResponse{
+			Article:          art,
+			VariantType:      rec.Article.ArticleType,
+			Reason:           string(rec.Source),
+			BasedOnArticleID: rec.SeedArticleID,
+			GeneratedImageID: rec.GeneratedImageID,
+		}
+		if rec.Article.ArticleType == article.ArticleTypeShirt {
+			resp.ShirtVariant, err = loadShirtVariantResponse(ctx, s.articleSvc, rec.VariantID)
+		} else {
+			resp.Variant, err = loadMugVariantResponse(ctx, s.articleSvc, rec.VariantID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if rec.GeneratedImageID != nil {
+			resp.PromptID = prompts[*rec.GeneratedImageID]
+			if fn := filenames[*rec.GeneratedImageID]; fn != "" {
+				resp.GeneratedImageFilename = &fn
+			}
+		}
+		out = append(out, resp)
+	}
+	return out, nil
+}
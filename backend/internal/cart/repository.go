@@ -1,16 +1,47 @@
 package cart
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Repository interface {
-	GetOrCreateActiveCart(ctx context.Context, userID int) (*Cart, error)
-	LoadActiveCart(ctx context.Context, userID int) (*Cart, error)
+	GetOrCreateActiveCart(ctx context.Context, owner Owner) (*Cart, error)
+	LoadActiveCart(ctx context.Context, owner Owner) (*Cart, error)
+	// SaveCart, UpdateItem, DeleteItem and ClearCartItems only write a cart
+	// still at the given version and bump it. They fail with ErrCartConflict
+	// when another write changed the cart since it was loaded.
 	SaveCart(ctx context.Context, cart Cart) (*Cart, error)
-	UpdateItemQuantity(ctx context.Context, cartID, itemID, quantity int) (bool, error)
-	DeleteItem(ctx context.Context, cartID, itemID int) (bool, error)
-	ClearCartItems(ctx context.Context, cartID int) error
+	// UpdateItem saves the quantity, prices and price tiers of an item.
+	UpdateItem(ctx context.Context, cartID int, version int64, item CartItem) (bool, error)
+	DeleteItem(ctx context.Context, cartID int, version int64, itemID int) (bool, error)
+	ClearCartItems(ctx context.Context, cartID int, version int64) error
+	// SetPromotionCode applies a promotion code to the cart; an empty code
+	// removes it. It checks the version like SaveCart.
+	SetPromotionCode(ctx context.Context, cartID int, version int64, code string) error
 	ReloadCart(ctx context.Context, cartID int) (*Cart, error)
+	DeleteCart(ctx context.Context, cartID int) error
+	// DeleteStaleGuestCarts deletes guest carts neither the cart nor any of
+	// its items changed since before and returns how many it deleted.
+	DeleteStaleGuestCarts(ctx context.Context, before time.Time) (int64, error)
+	// ListIdleCarts returns the active carts of users that have items and
+	// neither the cart nor any of its items changed since before.
+	ListIdleCarts(ctx context.Context, before time.Time) ([]Cart, error)
+	// MarkAbandoned marks an active cart abandoned. It returns false when the
+	// cart is no longer active.
+	MarkAbandoned(ctx context.Context, cartID int, at time.Time, value int, recoveryToken string) (bool, error)
+	// ListAbandonedCartsToRemind returns carts abandoned after since that are
+	// neither recovered nor reminded yet.
+	ListAbandonedCartsToRemind(ctx context.Context, since time.Time) ([]Cart, error)
+	MarkReminderSent(ctx context.Context, cartID int, at time.Time) error
+	// CartByRecoveryToken returns nil when no cart has the token.
+	CartByRecoveryToken(ctx context.Context, token string) (*Cart, error)
+	MarkRecovered(ctx context.Context, cartID int, at time.Time) error
+	// ListAbandonedCarts returns the carts abandoned within [from, to).
+	ListAbandonedCarts(ctx context.Context, from, to time.Time) ([]Cart, error)
 	FetchGeneratedImageFilenames(ctx context.Context, ids []int) (map[int]string, error)
 	FetchPromptTitles(ctx context.Context, ids []int) (map[int]string, error)
+	Settings(ctx context.Context) (Settings, error)
+	SaveSettings(ctx context.Context, s Settings) (Settings, error)
 	WithTx(ctx context.Context, fn func(Repository) error) error
 }
@@ -8,19 +8,32 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterRoutes mounts user cart routes under /api/user/cart
-func RegisterRoutes(r *gin.Engine, middleware gin.HandlerFunc, svc *Service) {
+// RegisterRoutes mounts user cart routes under /api/user/cart and the same
+// routes for visitors who are not signed in under /api/guest/cart. Only
+// users can recover an abandoned cart.
+func RegisterRoutes(r *gin.Engine, middleware gin.HandlerFunc, svc *Service, guests *GuestCookies) {
 	grp := r.Group("/api/user/cart")
-
 	grp.Use(middleware)
+	registerCartRoutes(grp, svc)
+	grp.POST("/recover", recoverCartHandler(svc))
+
+	guest := r.Group("/api/guest/cart")
+	guest.Use(guests.middleware)
+	registerCartRoutes(guest, svc)
+}
 
+func registerCartRoutes(grp *gin.RouterGroup, svc *Service) {
 	grp.GET("", getCartHandler(svc))
 	grp.GET("/summary", getCartSummaryHandler(svc))
+	grp.GET("/recommendations", getRecommendationsHandler(svc))
 	grp.POST("/items", addItemHandler(svc))
 	grp.PUT("/items/:itemId", updateItemHandler(svc))
 	grp.DELETE("/items/:itemId", deleteItemHandler(svc))
 	grp.DELETE("", clearCartHandler(svc))
 	grp.POST("/refresh-prices", refreshPricesHandler(svc))
+	grp.POST("/acknowledge-prices", acknowledgePricesHandler(svc))
+	grp.POST("/promotion", applyPromotionHandler(svc))
+	grp.DELETE("/promotion", removePromotionHandler(svc))
 }
 
 // currentUser extracts the authenticated user from context.
@@ -30,12 +43,15 @@ func currentUser(c *gin.Context) *auth.User {
 	return u
 }
 
-// requireUser writes 401 if no user and returns ok=false.
-func requireUser(c *gin.Context) (*auth.User, bool) {
-	u := currentUser(c)
-	if u == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"detail": "Not authenticated"})
-		return nil, false
+// requireOwner returns the signed-in user or, on guest routes, the visitor
+// as the cart owner. It writes 401 if there is neither and returns ok=false.
+func requireOwner(c *gin.Context) (Owner, bool) {
+	if u := currentUser(c); u != nil {
+		return UserOwner(u.ID), true
+	}
+	if token := c.GetString(guestTokenKey); token != "" {
+		return GuestOwner(token), true
 	}
-	return u, true
+	c.JSON(http.StatusUnauthorized, gin.H{"detail": "Not authenticated"})
+	return Owner{}, false
 }
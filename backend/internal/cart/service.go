@@ -4,9 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"voenix/backend/internal/prompt"
+	"fmt"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+	"voenix/backend/internal/prompt"
 )
 
 var (
@@ -36,22 +41,42 @@ type Service struct {
 	repo       Repository
 	articleSvc ArticleService
 	promptSvc  PromptService
+	stock      article.AvailabilityLookup
+	notifier   AbandonedCartNotifier
+	promotions PromotionService
+	// abandonedIdle is zero until SetAbandonedCartIdle is called.
+	abandonedIdle time.Duration
 }
 
-func NewService(repo Repository, articleSvc ArticleService, promptSvc PromptService) *Service {
-	return &Service{repo: repo, articleSvc: articleSvc, promptSvc: promptSvc}
+// NewService wires the cart service. stock may be nil, in which case every
+// variant is treated as available.
+func NewService(repo Repository, articleSvc ArticleService, promptSvc PromptService, stock article.AvailabilityLookup) *Service {
+	return &Service{repo: repo, articleSvc: articleSvc, promptSvc: promptSvc, stock: stock}
 }
 
-func (s *Service) GetCart(ctx context.Context, userID int) (*CartDetail, error) {
-	cart, err := s.repo.GetOrCreateActiveCart(ctx, userID)
+// GetCart returns the active cart of owner and creates it for users. Guests
+// get an empty cart that is only stored once they add an item, so visitors
+// who never do leave no rows behind.
+func (s *Service) GetCart(ctx context.Context, owner Owner) (*CartDetail, error) {
+	if owner.IsGuest() {
+		cart, err := s.repo.LoadActiveCart(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+		if cart == nil {
+			cart = &Cart{GuestToken: owner.GuestToken, Status: CartStatusActive}
+		}
+		return s.buildCartDetail(ctx, cart)
+	}
+	cart, err := s.repo.GetOrCreateActiveCart(ctx, owner)
 	if err != nil {
 		return nil, err
 	}
 	return s.buildCartDetail(ctx, cart)
 }
 
-func (s *Service) GetCartSummary(ctx context.Context, userID int) (CartSummary, error) {
-	cart, err := s.repo.LoadActiveCart(ctx, userID)
+func (s *Service) GetCartSummary(ctx context.Context, owner Owner) (CartSummary, error) {
+	cart, err := s.repo.LoadActiveCart(ctx, owner)
 	if err != nil {
 		return CartSummary{}, err
 	}
@@ -59,16 +84,26 @@ func (s *Service) GetCartSummary(ctx context.Context, userID int) (CartSummary,
 		return CartSummary{ItemCount: 0, TotalPrice: 0, HasItems: false}, nil
 	}
 	itemCount := 0
-	total := 0
 	for i := range cart.Items {
-		it := cart.Items[i]
-		itemCount += it.Quantity
+		itemCount += cart.Items[i].Quantity
+	}
+	return CartSummary{ItemCount: itemCount, TotalPrice: itemsTotal(cart.Items), HasItems: itemCount > 0}, nil
+}
+
+// itemsTotal is the total of items at the prices they were added at.
+func itemsTotal(items []CartItem) int {
+	total := 0
+	for _, it := range items {
 		total += (it.PriceAtTime + it.PromptPriceAtTime) * it.Quantity
 	}
-	return CartSummary{ItemCount: itemCount, TotalPrice: total, HasItems: itemCount > 0}, nil
+	return total
+}
+
+func (s *Service) AddItem(ctx context.Context, owner Owner, input AddItemInput) (*CartDetail, error) {
+	return retryOnConflict(nil, func() (*CartDetail, error) { return s.addItem(ctx, owner, input) })
 }
 
-func (s *Service) AddItem(ctx context.Context, userID int, input AddItemInput) (*CartDetail, error) {
+func (s *Service) addItem(ctx context.Context, owner Owner, input AddItemInput) (*CartDetail, error) {
 	quantity := input.Quantity
 	if quantity <= 0 {
 		quantity = 1
@@ -76,26 +111,25 @@ func (s *Service) AddItem(ctx context.Context, userID int, input AddItemInput) (
 	if input.CustomData == nil {
 		input.CustomData = map[string]any{}
 	}
-	if err := validateArticleAndVariant(ctx, s.articleSvc, input.ArticleID, input.VariantID); err != nil {
+	variantType, err := validateArticleAndVariant(ctx, s.articleSvc, input.ArticleID, input.VariantID)
+	if err != nil {
 		return nil, err
 	}
 	if err := validatePromptIfProvided(ctx, s.promptSvc, input.PromptID); err != nil {
 		return nil, err
 	}
-	cart, err := s.repo.GetOrCreateActiveCart(ctx, userID)
+	cart, err := s.repo.GetOrCreateActiveCart(ctx, owner)
 	if err != nil {
 		return nil, err
 	}
-	price, err := currentGrossPrice(ctx, s.articleSvc, input.ArticleID)
+	now := time.Now()
+	prices, err := s.currentLinePrices(ctx, input.ArticleID, input.PromptID, now)
 	if err != nil {
 		return nil, err
 	}
-	promptPriceAtTime := 0
-	if input.PromptID != nil {
-		promptPriceAtTime, err = promptCurrentGrossPrice(ctx, s.promptSvc, s.articleSvc, *input.PromptID)
-		if err != nil {
-			return nil, err
-		}
+	lockedUntil, err := s.priceLockUntil(ctx, now)
+	if err != nil {
+		return nil, err
 	}
 	cdStr := "{}"
 	if len(input.CustomData) > 0 {
@@ -104,19 +138,20 @@ func (s *Service) AddItem(ctx context.Context, userID int, input AddItemInput) (
 		}
 	}
 	item := CartItem{
-		CartID:              cart.ID,
-		ArticleID:           input.ArticleID,
-		VariantID:           input.VariantID,
-		Quantity:            quantity,
-		PriceAtTime:         price,
-		OriginalPrice:       price,
-		PromptPriceAtTime:   promptPriceAtTime,
-		PromptOriginalPrice: promptPriceAtTime,
-		CustomData:          cdStr,
-		GeneratedImageID:    input.GeneratedImageID,
-		PromptID:            input.PromptID,
-	}
-	mergeOrAppendItem(cart, item)
+		CartID:           cart.ID,
+		ArticleID:        input.ArticleID,
+		VariantID:        input.VariantID,
+		VariantType:      variantType,
+		Quantity:         quantity,
+		CustomData:       cdStr,
+		GeneratedImageID: input.GeneratedImageID,
+		PromptID:         input.PromptID,
+		PriceLockedUntil: lockedUntil,
+	}
+	mergeOrAppendItem(cart, item, prices)
+	if err := s.ensureInStock(ctx, cart, variantType, input.VariantID); err != nil {
+		return nil, err
+	}
 	saved, err := s.repo.SaveCart(ctx, *cart)
 	if err != nil {
 		return nil, err
@@ -124,28 +159,53 @@ func (s *Service) AddItem(ctx context.Context, userID int, input AddItemInput) (
 	return s.buildCartDetail(ctx, saved)
 }
 
-func (s *Service) UpdateItemQuantity(ctx context.Context, userID int, input UpdateItemQuantityInput) (*CartDetail, error) {
+// UpdateItemQuantity sets the quantity of an item. With input.IfMatch it
+// fails with ErrPreconditionFailed when the cart changed since the client
+// loaded it.
+func (s *Service) UpdateItemQuantity(ctx context.Context, owner Owner, input UpdateItemQuantityInput) (*CartDetail, error) {
 	if input.Quantity <= 0 {
 		return nil, newValidationError("quantity must be at least 1")
 	}
-	cart, err := s.repo.LoadActiveCart(ctx, userID)
+	return retryOnConflict(input.IfMatch, func() (*CartDetail, error) { return s.updateItemQuantity(ctx, owner, input) })
+}
+
+func (s *Service) updateItemQuantity(ctx context.Context, owner Owner, input UpdateItemQuantityInput) (*CartDetail, error) {
+	cart, err := s.repo.LoadActiveCart(ctx, owner)
 	if err != nil {
 		return nil, err
 	}
 	if cart == nil {
 		return nil, ErrCartNotFound
 	}
-	found := false
+	if err := checkVersion(cart, input.IfMatch); err != nil {
+		return nil, err
+	}
+	var target *CartItem
 	for i := range cart.Items {
 		if cart.Items[i].ID == input.ItemID {
-			found = true
+			target = &cart.Items[i]
 			break
 		}
 	}
-	if !found {
+	if target == nil {
 		return nil, ErrCartItemNotFound
 	}
-	updated, err := s.repo.UpdateItemQuantity(ctx, cart.ID, input.ItemID, input.Quantity)
+	before := *target
+	target.Quantity = input.Quantity
+	if err := s.ensureInStock(ctx, cart, itemVariantType(*target), target.VariantID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	prices, err := s.chargedLinePrices(ctx, *target, now)
+	if err != nil {
+		return nil, err
+	}
+	// A line with a pending price change keeps its prices so the change is
+	// still shown and has to be acknowledged; otherwise only the tier moves.
+	if target.priceLocked(now) || prices.isCurrent(before) {
+		prices.apply(target)
+	}
+	updated, err := s.repo.UpdateItem(ctx, cart.ID, cart.Version, *target)
 	if err != nil {
 		return nil, err
 	}
@@ -159,15 +219,24 @@ func (s *Service) UpdateItemQuantity(ctx context.Context, userID int, input Upda
 	return s.buildCartDetail(ctx, refreshed)
 }
 
-func (s *Service) DeleteItem(ctx context.Context, userID, itemID int) (*CartDetail, error) {
-	cart, err := s.repo.LoadActiveCart(ctx, userID)
+// DeleteItem removes an item from the cart. With ifMatch it fails with
+// ErrPreconditionFailed when the cart changed since the client loaded it.
+func (s *Service) DeleteItem(ctx context.Context, owner Owner, itemID int, ifMatch *CartVersion) (*CartDetail, error) {
+	return retryOnConflict(ifMatch, func() (*CartDetail, error) { return s.deleteItem(ctx, owner, itemID, ifMatch) })
+}
+
+func (s *Service) deleteItem(ctx context.Context, owner Owner, itemID int, ifMatch *CartVersion) (*CartDetail, error) {
+	cart, err := s.repo.LoadActiveCart(ctx, owner)
 	if err != nil {
 		return nil, err
 	}
 	if cart == nil {
 		return nil, ErrCartNotFound
 	}
-	deleted, err := s.repo.DeleteItem(ctx, cart.ID, itemID)
+	if err := checkVersion(cart, ifMatch); err != nil {
+		return nil, err
+	}
+	deleted, err := s.repo.DeleteItem(ctx, cart.ID, cart.Version, itemID)
 	if err != nil {
 		return nil, err
 	}
@@ -181,15 +250,19 @@ func (s *Service) DeleteItem(ctx context.Context, userID, itemID int) (*CartDeta
 	return s.buildCartDetail(ctx, refreshed)
 }
 
-func (s *Service) ClearCart(ctx context.Context, userID int) (*CartDetail, error) {
-	cart, err := s.repo.LoadActiveCart(ctx, userID)
+func (s *Service) ClearCart(ctx context.Context, owner Owner) (*CartDetail, error) {
+	return retryOnConflict(nil, func() (*CartDetail, error) { return s.clearCart(ctx, owner) })
+}
+
+func (s *Service) clearCart(ctx context.Context, owner Owner) (*CartDetail, error) {
+	cart, err := s.repo.LoadActiveCart(ctx, owner)
 	if err != nil {
 		return nil, err
 	}
 	if cart == nil {
 		return nil, ErrCartNotFound
 	}
-	if err := s.repo.ClearCartItems(ctx, cart.ID); err != nil {
+	if err := s.repo.ClearCartItems(ctx, cart.ID, cart.Version); err != nil {
 		return nil, err
 	}
 	refreshed, err := s.repo.ReloadCart(ctx, cart.ID)
@@ -199,31 +272,32 @@ func (s *Service) ClearCart(ctx context.Context, userID int) (*CartDetail, error
 	return s.buildCartDetail(ctx, refreshed)
 }
 
-func (s *Service) RefreshPrices(ctx context.Context, userID int) (*CartDetail, error) {
-	cart, err := s.repo.LoadActiveCart(ctx, userID)
+func (s *Service) RefreshPrices(ctx context.Context, owner Owner) (*CartDetail, error) {
+	return retryOnConflict(nil, func() (*CartDetail, error) { return s.refreshPrices(ctx, owner) })
+}
+
+func (s *Service) refreshPrices(ctx context.Context, owner Owner) (*CartDetail, error) {
+	cart, err := s.repo.LoadActiveCart(ctx, owner)
 	if err != nil {
 		return nil, err
 	}
 	if cart == nil {
 		return nil, ErrCartNotFound
 	}
+	// Every line is compared against the prices in effect right now, even
+	// when a scheduled change has not been copied onto the price yet.
+	now := time.Now()
 	changed := false
 	for i := range cart.Items {
-		articleCurrent, err := currentGrossPrice(ctx, s.articleSvc, cart.Items[i].ArticleID)
+		prices, err := s.currentLinePrices(ctx, cart.Items[i].ArticleID, cart.Items[i].PromptID, now)
 		if err != nil {
 			return nil, err
 		}
+		articleCurrent, promptCurrent := prices.unitGross(cart.Items[i].Quantity)
 		if cart.Items[i].OriginalPrice != articleCurrent {
 			cart.Items[i].OriginalPrice = articleCurrent
 			changed = true
 		}
-		promptCurrent := 0
-		if cart.Items[i].PromptID != nil {
-			promptCurrent, err = promptCurrentGrossPrice(ctx, s.promptSvc, s.articleSvc, *cart.Items[i].PromptID)
-			if err != nil {
-				return nil, err
-			}
-		}
 		if cart.Items[i].PromptOriginalPrice != promptCurrent {
 			cart.Items[i].PromptOriginalPrice = promptCurrent
 			changed = true
@@ -275,11 +349,75 @@ func (s *Service) ToCartResponse(ctx context.Context, detail *CartDetail) (*Cart
 	if detail == nil || detail.Cart == nil {
 		return nil, nil
 	}
-	return buildCartResponse(ctx, s.articleSvc, detail.Cart, detail.GeneratedImageFilenames, detail.PromptTitles)
+	now := time.Now()
+	current := make([]linePrices, 0, len(detail.Cart.Items))
+	charged := make([]linePrices, 0, len(detail.Cart.Items))
+	for _, it := range detail.Cart.Items {
+		p, err := s.currentLinePrices(ctx, it.ArticleID, it.PromptID, now)
+		if err != nil {
+			return nil, err
+		}
+		current = append(current, p)
+		if it.priceLocked(now) {
+			if p, err = s.chargedLinePrices(ctx, it, now); err != nil {
+				return nil, err
+			}
+		}
+		charged = append(charged, p)
+	}
+	dto, err := buildCartResponse(ctx, s.articleSvc, s.stock, detail.Cart, charged, detail.GeneratedImageFilenames, detail.PromptTitles)
+	if err != nil {
+		return nil, err
+	}
+	addPriceChanges(dto, detail.Cart, current, now)
+	if err := s.addDiscount(ctx, detail.Cart, dto); err != nil {
+		return nil, err
+	}
+	return dto, nil
 }
 
-// mergeOrAppendItem merges quantity if an item with same articleId, variantId and customData exists; otherwise appends.
-func mergeOrAppendItem(c *Cart, item CartItem) {
+// ensureInStock rejects the cart when its total quantity of a variant exceeds
+// the units available. Untracked variants always pass.
+func (s *Service) ensureInStock(ctx context.Context, c *Cart, variantType string, variantID int) error {
+	if s.stock == nil {
+		return nil
+	}
+	found, err := s.stock.VariantAvailability(ctx, variantType, []int{variantID})
+	if err != nil {
+		return err
+	}
+	availability, ok := found[variantID]
+	if !ok || !availability.Tracked {
+		return nil
+	}
+	if availability.Available <= 0 {
+		return newValidationError("variant is out of stock")
+	}
+	total := 0
+	for i := range c.Items {
+		if c.Items[i].VariantID == variantID && itemVariantType(c.Items[i]) == variantType {
+			total += c.Items[i].Quantity
+		}
+	}
+	if total > availability.Available {
+		return newValidationError(fmt.Sprintf("only %d left in stock for this variant", availability.Available))
+	}
+	return nil
+}
+
+// itemVariantType returns the variant type of an item; legacy items are mugs.
+func itemVariantType(it CartItem) string {
+	if it.VariantType == "" {
+		return article.ArticleTypeMug
+	}
+	return it.VariantType
+}
+
+// mergeOrAppendItem merges quantity into an item with the same article,
+// variant, prompt and customData that is still priced at the current prices;
+// otherwise it appends. Either way the resulting line is priced for its
+// quantity.
+func mergeOrAppendItem(c *Cart, item CartItem, prices linePrices) {
 	item.CustomData = canonicalizeJSON(item.CustomData)
 	for i := range c.Items {
 		it := &c.Items[i]
@@ -289,18 +427,67 @@ func mergeOrAppendItem(c *Cart, item CartItem) {
 		} else if it.PromptID != nil && item.PromptID != nil && *it.PromptID == *item.PromptID {
 			samePrompt = true
 		}
-		if it.ArticleID == item.ArticleID && it.VariantID == item.VariantID && samePrompt &&
+		if it.ArticleID == item.ArticleID && it.VariantID == item.VariantID && it.VariantType == item.VariantType && samePrompt &&
 			canonicalizeJSON(it.CustomData) == item.CustomData &&
-			it.PriceAtTime == item.PriceAtTime &&
-			it.PromptPriceAtTime == item.PromptPriceAtTime {
+			prices.isCurrent(*it) {
 			it.Quantity += item.Quantity
+			prices.apply(it)
 			return
 		}
 	}
+	prices.apply(&item)
 	item.Position = len(c.Items)
 	c.Items = append(c.Items, item)
 }
 
+// mergeIntoUserCart adds copies of items to the active cart of the user the
+// way AddItem merges them, at current prices. Items whose article or variant
+// is no longer available are dropped. finish runs in the same transaction.
+func (s *Service) mergeIntoUserCart(ctx context.Context, items []CartItem, userID int, finish func(Repository) error) error {
+	type line struct {
+		item   CartItem
+		prices linePrices
+	}
+	now := time.Now()
+	lockedUntil, err := s.priceLockUntil(ctx, now)
+	if err != nil {
+		return err
+	}
+	lines := make([]line, 0, len(items))
+	for _, it := range items {
+		if _, err := validateArticleAndVariant(ctx, s.articleSvc, it.ArticleID, it.VariantID); err != nil {
+			if isValidationError(err) {
+				continue
+			}
+			return err
+		}
+		prices, err := s.currentLinePrices(ctx, it.ArticleID, it.PromptID, now)
+		if err != nil {
+			return err
+		}
+		it.ID = 0
+		it.PriceLockedUntil = lockedUntil
+		it.CreatedAt, it.UpdatedAt = time.Time{}, time.Time{}
+		lines = append(lines, line{item: it, prices: prices})
+	}
+	return s.repo.WithTx(ctx, func(tx Repository) error {
+		if len(lines) > 0 {
+			target, err := tx.GetOrCreateActiveCart(ctx, UserOwner(userID))
+			if err != nil {
+				return err
+			}
+			for _, l := range lines {
+				l.item.CartID = target.ID
+				mergeOrAppendItem(target, l.item, l.prices)
+			}
+			if _, err := tx.SaveCart(ctx, *target); err != nil {
+				return err
+			}
+		}
+		return finish(tx)
+	})
+}
+
 func parseJSONMap(s string) map[string]any {
 	if s == "" {
 		return map[string]any{}
@@ -321,25 +508,78 @@ func canonicalizeJSON(s string) string {
 	return string(b)
 }
 
-func validateArticleAndVariant(ctx context.Context, articleSvc ArticleService, articleID, variantID int) error {
-	art, err := articleSvc.GetArticle(ctx, articleID)
+// ValidateItem checks that an item of the article, variant and prompt can be
+// added to a cart and returns the variant type. It fails with a
+// ValidationError otherwise.
+func (s *Service) ValidateItem(ctx context.Context, articleID, variantID int, promptID *int) (string, error) {
+	variantType, err := validateArticleAndVariant(ctx, s.articleSvc, articleID, variantID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return newValidationError("article not found")
-		}
-		return err
+		return "", err
 	}
-	variant, err := articleSvc.GetMugVariant(ctx, variantID)
+	if err := validatePromptIfProvided(ctx, s.promptSvc, promptID); err != nil {
+		return "", err
+	}
+	return variantType, nil
+}
+
+// validateArticleAndVariant checks that the variant exists in the variant
+// table of the article's type, belongs to the article and that neither is
+// archived. It returns the variant type to store on the cart item.
+func validateArticleAndVariant(ctx context.Context, articleSvc ArticleService, articleID, variantID int) (string, error) {
+	art, err := articleSvc.GetArticle(ctx, articleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return newValidationError("variant not found")
+			return "", newValidationError("article not found")
 		}
-		return err
+		return "", err
 	}
-	if variant.ArticleID != art.ID {
-		return newValidationError("variant does not belong to article")
+	if art.ArchivedAt != nil {
+		return "", newValidationError("article is no longer available")
+	}
+	switch art.ArticleType {
+	case article.ArticleTypeMug:
+		variant, err := articleSvc.GetMugVariant(ctx, variantID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "", newValidationError("variant not found")
+			}
+			return "", err
+		}
+		if variant.ArticleID != art.ID {
+			return "", newValidationError("variant does not belong to article")
+		}
+		if variant.ArchivedAt != nil {
+			return "", newValidationError("variant is no longer available")
+		}
+		return article.ArticleTypeMug, nil
+	case article.ArticleTypeShirt:
+		variant, err := articleSvc.GetShirtVariant(ctx, variantID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return "", newValidationError("variant not found")
+			}
+			return "", err
+		}
+		if variant.ArticleID != art.ID {
+			return "", newValidationError("variant does not belong to article")
+		}
+		if variant.ArchivedAt != nil {
+			return "", newValidationError("variant is no longer available")
+		}
+		if strings.TrimSpace(variant.Color) == "" || strings.TrimSpace(variant.Size) == "" {
+			return "", newValidationError("shirt variant requires a color and size")
+		}
+		details, err := articleSvc.GetShirtDetails(ctx, art.ID)
+		if err != nil {
+			return "", err
+		}
+		if details != nil && !details.HasSize(variant.Size) {
+			return "", newValidationError("size is not available for this shirt")
+		}
+		return article.ArticleTypeShirt, nil
+	default:
+		return "", newValidationError("unsupported article type")
 	}
-	return nil
 }
 
 func validatePromptIfProvided(ctx context.Context, promptSvc PromptService, promptID *int) error {
@@ -355,40 +595,3 @@ func validatePromptIfProvided(ctx context.Context, promptSvc PromptService, prom
 	}
 	return nil
 }
-
-func currentGrossPrice(ctx context.Context, articleSvc ArticleService, articleID int) (int, error) {
-	cc, err := articleSvc.GetCostCalculation(ctx, articleID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return 0, nil
-		}
-		return 0, err
-	}
-	if cc == nil {
-		return 0, nil
-	}
-	return cc.SalesTotalGross, nil
-}
-
-func promptCurrentGrossPrice(ctx context.Context, promptSvc PromptService, articleSvc ArticleService, promptID int) (int, error) {
-	promptRead, err := promptSvc.GetPrompt(ctx, promptID)
-	if err != nil {
-		return 0, err
-	}
-	if promptRead == nil {
-		return 0, nil
-	}
-	if promptRead.CostCalculation != nil {
-		return promptRead.CostCalculation.SalesTotalGross, nil
-	}
-	if promptRead.PriceID != nil {
-		cc, err := articleSvc.GetCostCalculationByID(ctx, *promptRead.PriceID)
-		if err != nil {
-			return 0, err
-		}
-		if cc != nil {
-			return cc.SalesTotalGross, nil
-		}
-	}
-	return 0, nil
-}
@@ -7,19 +7,48 @@ type CartStatus string
 
 const (
 	CartStatusActive    CartStatus = "active"
+	CartStatusAbandoned CartStatus = "abandoned"
 	CartStatusConverted CartStatus = "converted"
 )
 
-// Cart is the shopping cart aggregate root.
+// Owner identifies whose cart an operation works on: a signed-in user or a
+// visitor known by the token of their guest cart cookie.
+type Owner struct {
+	UserID     int
+	GuestToken string
+}
+
+func UserOwner(userID int) Owner { return Owner{UserID: userID} }
+
+func GuestOwner(token string) Owner { return Owner{GuestToken: token} }
+
+func (o Owner) IsGuest() bool { return o.UserID == 0 }
+
+// Cart is the shopping cart aggregate root. Guest carts have no UserID but a
+// GuestToken.
 type Cart struct {
-	ID        int
-	UserID    int
-	Status    CartStatus
-	Version   int64
+	ID         int
+	UserID     int
+	GuestToken string
+	Status     CartStatus
+	Version    int64
+	// ExpiresAt is set when the cart is created and is not moved when the
+	// cart changes, so nothing expires carts by it; abandonment goes by
+	// UpdatedAt instead.
 	ExpiresAt *time.Time
 	Items     []CartItem
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	// AbandonedAt, AbandonedValue and RecoveryToken are set when the cart is
+	// marked abandoned; AbandonedValue is its total in cents at that time.
+	AbandonedAt    *time.Time
+	AbandonedValue int
+	RecoveryToken  string
+	ReminderSentAt *time.Time
+	RecoveredAt    *time.Time
+	// PromotionCode is the code the customer applied; its discount is
+	// computed whenever the cart is shown.
+	PromotionCode string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 // CartItem is a line item within a cart.
@@ -28,17 +57,39 @@ type CartItem struct {
 	CartID              int
 	ArticleID           int
 	VariantID           int
+	VariantType         string
 	Quantity            int
 	PriceAtTime         int
 	OriginalPrice       int
 	PromptPriceAtTime   int
 	PromptOriginalPrice int
-	CustomData          string
-	GeneratedImageID    *int
-	PromptID            *int
-	Position            int
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
+	// PriceTierMinQuantity and PromptPriceTierMinQuantity record the tier
+	// PriceAtTime and PromptPriceAtTime come from; 1 is the base price.
+	PriceTierMinQuantity       int
+	PromptPriceTierMinQuantity int
+	CustomData                 string
+	GeneratedImageID           *int
+	PromptID                   *int
+	Position                   int
+	// PriceLockedUntil is set when the line keeps the prices it was added
+	// at for a while; see Settings.PriceLockMinutes.
+	PriceLockedUntil *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// priceLocked reports whether the item is still charged the prices it was
+// added at.
+func (it CartItem) priceLocked(now time.Time) bool {
+	return it.PriceLockedUntil != nil && now.Before(*it.PriceLockedUntil)
+}
+
+// Settings are the shop wide cart settings.
+type Settings struct {
+	// PriceLockMinutes is how long a line added to a cart keeps its prices
+	// when they change; 0 turns price locks off.
+	PriceLockMinutes int
+	UpdatedAt        time.Time
 }
 
 // AddItemInput represents the information needed to add an item to a cart.
@@ -55,6 +106,8 @@ type AddItemInput struct {
 type UpdateItemQuantityInput struct {
 	ItemID   int
 	Quantity int
+	// IfMatch, when set, is the cart version the client last saw.
+	IfMatch *CartVersion
 }
 
 // CartSummary captures high-level totals for a cart.
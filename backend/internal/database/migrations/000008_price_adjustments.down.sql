@@ -0,0 +1,2 @@
+drop table if exists price_adjustment_entries;
+drop table if exists price_adjustment_batches;
@@ -0,0 +1,52 @@
+-- Audit trail for bulk price adjustments of articles and prompts
+create table if not exists price_adjustment_batches
+(
+    id          bigserial,
+    target      varchar(30)                                        not null,
+    mode        varchar(20)                                        not null,
+    value       numeric(12, 4)                                     not null,
+    filter      jsonb                    default '{}'::jsonb       not null,
+    item_count  integer                  default 0                 not null,
+    created_by  bigint,
+    created_at  timestamp with time zone default CURRENT_TIMESTAMP not null,
+    primary key (id),
+    foreign key (created_by) references users
+        on delete set null,
+    constraint chk_price_adjustment_target
+        check ((target)::text = ANY ((ARRAY ['PURCHASE_PRICE'::character varying, 'MARGIN'::character varying])::text[])),
+    constraint chk_price_adjustment_mode
+        check ((mode)::text = ANY ((ARRAY ['PERCENT'::character varying, 'ABSOLUTE'::character varying])::text[]))
+);
+
+create table if not exists price_adjustment_entries
+(
+    id                        bigserial,
+    batch_id                  bigint                                             not null,
+    price_id                  bigint,
+    article_id                bigint,
+    prompt_id                 bigint,
+    old_purchase_price_net    integer                                            not null,
+    new_purchase_price_net    integer                                            not null,
+    old_purchase_total_net    integer                                            not null,
+    new_purchase_total_net    integer                                            not null,
+    old_sales_margin_net      integer                                            not null,
+    new_sales_margin_net      integer                                            not null,
+    old_sales_margin_percent  numeric(7, 2)                                      not null,
+    new_sales_margin_percent  numeric(7, 2)                                      not null,
+    old_sales_total_net       integer                                            not null,
+    new_sales_total_net       integer                                            not null,
+    old_sales_total_gross     integer                                            not null,
+    new_sales_total_gross     integer                                            not null,
+    created_at                timestamp with time zone default CURRENT_TIMESTAMP not null,
+    primary key (id),
+    foreign key (batch_id) references price_adjustment_batches
+        on delete cascade,
+    foreign key (price_id) references prices
+        on delete set null
+);
+
+create index if not exists idx_price_adjustment_entries_batch_id
+    on price_adjustment_entries (batch_id);
+
+create index if not exists idx_price_adjustment_entries_price_id
+    on price_adjustment_entries (price_id);
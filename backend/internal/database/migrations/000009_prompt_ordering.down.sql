@@ -0,0 +1,7 @@
+drop index if exists idx_prompts_category_position;
+
+alter table if exists prompts
+    drop column if exists featured_until,
+    drop column if exists featured_from,
+    drop column if exists featured,
+    drop column if exists position;
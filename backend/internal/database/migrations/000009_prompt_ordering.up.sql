@@ -0,0 +1,19 @@
+alter table if exists prompts
+    add column if not exists position integer not null default 0,
+    add column if not exists featured boolean not null default false,
+    add column if not exists featured_from timestamptz,
+    add column if not exists featured_until timestamptz;
+
+-- Seed positions so existing prompts keep their newest-first order within
+-- their category/subcategory.
+update prompts p
+set position = ranked.rn - 1
+from (
+    select id,
+           row_number() over (partition by category_id, subcategory_id order by id desc) as rn
+    from prompts
+) ranked
+where ranked.id = p.id;
+
+create index if not exists idx_prompts_category_position
+    on prompts (category_id, subcategory_id, position);
@@ -0,0 +1,38 @@
+-- Order items of other variant types cannot be expressed without
+-- variant_type; refuse to go down rather than delete order history.
+do $$
+begin
+    if exists (select 1 from order_items where variant_type <> 'MUG') then
+        raise exception 'Cannot revert polymorphic variants: order_items contains non-MUG items';
+    end if;
+end $$;
+
+alter table if exists article_shirt_details
+    drop column if exists print_area_position,
+    drop column if exists print_area_height_mm,
+    drop column if exists print_area_width_mm;
+
+alter table if exists article_shirt_details
+    alter column available_sizes type text[] using string_to_array(available_sizes, ',');
+
+delete from cart_items where variant_type <> 'MUG';
+
+alter table if exists order_items
+    drop constraint if exists chk_order_items_variant_type;
+
+alter table if exists order_items
+    drop column if exists variant_type;
+
+alter table if exists order_items
+    add constraint order_items_variant_id_fkey
+        foreign key (variant_id) references article_mug_variants;
+
+alter table if exists cart_items
+    drop constraint if exists chk_cart_items_variant_type;
+
+alter table if exists cart_items
+    drop column if exists variant_type;
+
+alter table if exists cart_items
+    add constraint cart_items_variant_id_fkey
+        foreign key (variant_id) references article_mug_variants;
@@ -0,0 +1,30 @@
+-- Cart and order items may reference mug or shirt variants; the variant table
+-- is selected by variant_type, so the mug-only foreign keys are dropped.
+alter table if exists cart_items
+    drop constraint if exists cart_items_variant_id_fkey;
+
+alter table if exists cart_items
+    add column if not exists variant_type varchar(20) not null default 'MUG';
+
+alter table if exists cart_items
+    add constraint chk_cart_items_variant_type
+        check ((variant_type)::text = ANY ((ARRAY ['MUG'::character varying, 'SHIRT'::character varying])::text[]));
+
+alter table if exists order_items
+    drop constraint if exists order_items_variant_id_fkey;
+
+alter table if exists order_items
+    add column if not exists variant_type varchar(20) not null default 'MUG';
+
+alter table if exists order_items
+    add constraint chk_order_items_variant_type
+        check ((variant_type)::text = ANY ((ARRAY ['MUG'::character varying, 'SHIRT'::character varying])::text[]));
+
+-- Shirt sizes are read and written as a comma-separated string.
+alter table if exists article_shirt_details
+    alter column available_sizes type text using array_to_string(available_sizes, ',');
+
+alter table if exists article_shirt_details
+    add column if not exists print_area_width_mm integer not null default 0,
+    add column if not exists print_area_height_mm integer not null default 0,
+    add column if not exists print_area_position varchar(20) not null default 'FRONT';
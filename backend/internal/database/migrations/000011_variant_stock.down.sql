@@ -0,0 +1,2 @@
+drop table if exists stock_movements;
+drop table if exists variant_stocks;
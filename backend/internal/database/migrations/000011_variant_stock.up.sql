@@ -0,0 +1,48 @@
+-- Per-variant stock levels. Variants without a row are not tracked and are
+-- always available; reserved counts units held by open orders.
+create table if not exists variant_stocks
+(
+    variant_type        varchar(20)                                        not null,
+    variant_id          bigint                                             not null,
+    on_hand             integer                  default 0                 not null,
+    reserved            integer                  default 0                 not null,
+    low_stock_threshold integer                  default 0                 not null,
+    created_at          timestamp with time zone default CURRENT_TIMESTAMP not null,
+    updated_at          timestamp with time zone default CURRENT_TIMESTAMP not null,
+    primary key (variant_type, variant_id),
+    constraint chk_variant_stocks_variant_type
+        check ((variant_type)::text = ANY ((ARRAY ['MUG'::character varying, 'SHIRT'::character varying])::text[])),
+    constraint chk_variant_stocks_quantities
+        check ((on_hand >= 0) AND (reserved >= 0) AND (reserved <= on_hand) AND (low_stock_threshold >= 0))
+);
+
+-- Every change to a stock level. quantity is the signed change to on_hand for
+-- ADJUSTMENT and FULFILLMENT and to reserved for RESERVATION and RELEASE.
+create table if not exists stock_movements
+(
+    id             bigserial,
+    variant_type   varchar(20)                                        not null,
+    variant_id     bigint                                             not null,
+    kind           varchar(20)                                        not null,
+    quantity       integer                                            not null,
+    on_hand_after  integer                                            not null,
+    reserved_after integer                                            not null,
+    order_id       bigint,
+    reason         text,
+    created_by     bigint,
+    created_at     timestamp with time zone default CURRENT_TIMESTAMP not null,
+    primary key (id),
+    foreign key (order_id) references orders
+        on delete set null,
+    foreign key (created_by) references users
+        on delete set null,
+    constraint chk_stock_movements_kind
+        check ((kind)::text = ANY
+               ((ARRAY ['ADJUSTMENT'::character varying, 'RESERVATION'::character varying, 'RELEASE'::character varying, 'FULFILLMENT'::character varying])::text[]))
+);
+
+create index if not exists idx_stock_movements_variant
+    on stock_movements (variant_type, variant_id, created_at);
+
+create index if not exists idx_stock_movements_order_id
+    on stock_movements (order_id);
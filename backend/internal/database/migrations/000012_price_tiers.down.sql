@@ -0,0 +1,8 @@
+alter table if exists order_items
+    drop column if exists price_tier_min_quantity;
+
+alter table if exists cart_items
+    drop column if exists prompt_price_tier_min_quantity,
+    drop column if exists price_tier_min_quantity;
+
+drop table if exists price_tiers;
@@ -0,0 +1,29 @@
+-- Quantity breaks on a price. From min_quantity units on, each unit costs
+-- sales_total_gross instead of the base sales price; quantities below the
+-- first tier use the base price.
+create table if not exists price_tiers
+(
+    id                bigserial,
+    price_id          bigint                                             not null,
+    min_quantity      integer                                            not null,
+    sales_total_gross integer                                            not null,
+    created_at        timestamp with time zone default CURRENT_TIMESTAMP not null,
+    updated_at        timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint price_tiers_pkey
+        primary key (id),
+    constraint price_tiers_price_id_min_quantity_key
+        unique (price_id, min_quantity),
+    constraint fk_price_tiers_price
+        foreign key (price_id) references prices
+            on delete cascade,
+    constraint chk_price_tiers_values
+        check ((min_quantity >= 2) AND (sales_total_gross >= 0))
+);
+
+-- The tier a line was priced with; 1 means the base price.
+alter table if exists cart_items
+    add column if not exists price_tier_min_quantity integer not null default 1,
+    add column if not exists prompt_price_tier_min_quantity integer not null default 1;
+
+alter table if exists order_items
+    add column if not exists price_tier_min_quantity integer not null default 1;
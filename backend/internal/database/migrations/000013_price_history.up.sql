@@ -0,0 +1,81 @@
+-- Every version of a price's calculation. Saving a price records a version
+-- effective immediately; versions with a future effective_from are scheduled
+-- changes that get copied onto the prices row once due (applied_at is set
+-- then). The version with the latest effective_from at or before a moment is
+-- the price in effect at that moment.
+create table if not exists price_history
+(
+    id                         bigserial,
+    price_id                   bigint                                             not null,
+    effective_from             timestamp with time zone                           not null,
+    applied_at                 timestamp with time zone,
+    purchase_price_net         integer                  default 0                 not null,
+    purchase_price_tax         integer                  default 0                 not null,
+    purchase_price_gross       integer                  default 0                 not null,
+    purchase_cost_net          integer                  default 0                 not null,
+    purchase_cost_tax          integer                  default 0                 not null,
+    purchase_cost_gross        integer                  default 0                 not null,
+    purchase_cost_percent      numeric(5, 2)            default 0                 not null,
+    purchase_total_net         integer                  default 0                 not null,
+    purchase_total_tax         integer                  default 0                 not null,
+    purchase_total_gross       integer                  default 0                 not null,
+    purchase_price_unit        varchar(50)                                        not null,
+    purchase_vat_rate_id       bigint,
+    purchase_vat_rate_percent  numeric(5, 2)            default 0                 not null,
+    purchase_calculation_mode  varchar(10)                                        not null,
+    sales_vat_rate_id          bigint,
+    sales_vat_rate_percent     numeric(5, 2)            default 0                 not null,
+    sales_margin_net           integer                  default 0                 not null,
+    sales_margin_tax           integer                  default 0                 not null,
+    sales_margin_gross         integer                  default 0                 not null,
+    sales_margin_percent       numeric(5, 2)            default 0                 not null,
+    sales_total_net            integer                  default 0                 not null,
+    sales_total_tax            integer                  default 0                 not null,
+    sales_total_gross          integer                  default 0                 not null,
+    sales_price_unit           varchar(50)                                        not null,
+    sales_calculation_mode     varchar(10)                                        not null,
+    purchase_price_corresponds varchar(10)                                        not null,
+    sales_price_corresponds    varchar(10)                                        not null,
+    purchase_active_row        varchar(20)                                        not null,
+    sales_active_row           varchar(20)                                        not null,
+    created_at                 timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint price_history_pkey
+        primary key (id),
+    constraint fk_price_history_price
+        foreign key (price_id) references prices
+            on delete cascade
+);
+
+create index if not exists idx_price_history_price_effective
+    on price_history (price_id, effective_from);
+
+create index if not exists idx_price_history_pending
+    on price_history (effective_from)
+    where applied_at is null;
+
+-- Existing prices start their history with the values they have now.
+insert into price_history (price_id, effective_from, applied_at,
+                           purchase_price_net, purchase_price_tax, purchase_price_gross,
+                           purchase_cost_net, purchase_cost_tax, purchase_cost_gross, purchase_cost_percent,
+                           purchase_total_net, purchase_total_tax, purchase_total_gross,
+                           purchase_price_unit, purchase_vat_rate_id, purchase_vat_rate_percent,
+                           purchase_calculation_mode,
+                           sales_vat_rate_id, sales_vat_rate_percent,
+                           sales_margin_net, sales_margin_tax, sales_margin_gross, sales_margin_percent,
+                           sales_total_net, sales_total_tax, sales_total_gross,
+                           sales_price_unit, sales_calculation_mode,
+                           purchase_price_corresponds, sales_price_corresponds,
+                           purchase_active_row, sales_active_row)
+select id, updated_at, updated_at,
+       purchase_price_net, purchase_price_tax, purchase_price_gross,
+       purchase_cost_net, purchase_cost_tax, purchase_cost_gross, purchase_cost_percent,
+       purchase_total_net, purchase_total_tax, purchase_total_gross,
+       purchase_price_unit, purchase_vat_rate_id, purchase_vat_rate_percent,
+       purchase_calculation_mode,
+       sales_vat_rate_id, sales_vat_rate_percent,
+       sales_margin_net, sales_margin_tax, sales_margin_gross, sales_margin_percent,
+       sales_total_net, sales_total_tax, sales_total_gross,
+       sales_price_unit, sales_calculation_mode,
+       purchase_price_corresponds, sales_price_corresponds,
+       purchase_active_row, sales_active_row
+from prices;
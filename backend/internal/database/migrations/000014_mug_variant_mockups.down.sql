@@ -0,0 +1,4 @@
+alter table if exists article_mug_variants
+    drop constraint if exists chk_article_mug_variants_mockup_curvature,
+    drop column if exists mockup_curvature,
+    drop column if exists mockup_print_area;
@@ -0,0 +1,11 @@
+-- Where designs go on a mug variant's example photo. mockup_print_area holds
+-- the print area corners as "x,y;x,y;x,y;x,y" in fractions of the photo size,
+-- clockwise from the top-left; mockup_curvature bends the design around the
+-- mug from 0 (flat) to 1.
+alter table if exists article_mug_variants
+    add column if not exists mockup_print_area varchar(255),
+    add column if not exists mockup_curvature  numeric(4, 3) default 0 not null;
+
+alter table if exists article_mug_variants
+    add constraint chk_article_mug_variants_mockup_curvature
+        check ((mockup_curvature >= 0) AND (mockup_curvature <= 1));
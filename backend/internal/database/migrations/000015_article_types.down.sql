@@ -0,0 +1,8 @@
+drop table if exists article_print_areas;
+
+drop table if exists article_variants;
+
+alter table if exists articles
+    drop column if exists attributes;
+
+drop table if exists article_types;
@@ -0,0 +1,77 @@
+-- Registry of article types. MUG and SHIRT are built in and keep their
+-- dedicated detail and variant tables; other types describe their attributes
+-- in attribute_schema and their variant dimensions in option_axes.
+create table if not exists article_types
+(
+    code             varchar(50),
+    name             varchar(255)                                       not null,
+    description      text,
+    built_in         boolean                  default false             not null,
+    attribute_schema jsonb                    default '[]'::jsonb       not null,
+    option_axes      jsonb                    default '[]'::jsonb       not null,
+    created_at       timestamp with time zone default CURRENT_TIMESTAMP not null,
+    updated_at       timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint article_types_pkey
+        primary key (code),
+    constraint chk_article_types_code
+        check ((code)::text ~ '^[A-Z][A-Z0-9_]*$')
+);
+
+insert into article_types (code, name, built_in)
+values ('MUG', 'Mug', true),
+       ('SHIRT', 'T-Shirt', true)
+on conflict (code) do nothing;
+
+-- Attribute values of articles of registered types, keyed by the attribute
+-- keys of the type's schema.
+alter table if exists articles
+    add column if not exists attributes jsonb default '{}'::jsonb not null;
+
+-- Variants of articles of registered types. options maps each option axis of
+-- the type to a value; options_key is its canonical form so every
+-- combination exists once per article.
+create table if not exists article_variants
+(
+    id                     bigserial,
+    article_id             bigint                                             not null,
+    options                jsonb                    default '{}'::jsonb       not null,
+    options_key            varchar(500)                                       not null,
+    article_variant_number varchar(100),
+    example_image_filename varchar(500),
+    is_default             boolean                  default false             not null,
+    active                 boolean                  default true              not null,
+    created_at             timestamp with time zone default CURRENT_TIMESTAMP not null,
+    updated_at             timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint article_variants_pkey
+        primary key (id),
+    constraint article_variants_article_id_options_key_key
+        unique (article_id, options_key),
+    constraint fk_article_variants_article
+        foreign key (article_id) references articles
+            on delete cascade
+);
+
+create unique index if not exists idx_article_variants_one_default_per_article
+    on article_variants (article_id)
+    where (is_default = true);
+
+-- Named areas of an article that designs are printed on, in millimetres.
+create table if not exists article_print_areas
+(
+    id         bigserial,
+    article_id bigint                                             not null,
+    name       varchar(100)                                       not null,
+    width_mm   integer                                            not null,
+    height_mm  integer                                            not null,
+    created_at timestamp with time zone default CURRENT_TIMESTAMP not null,
+    updated_at timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint article_print_areas_pkey
+        primary key (id),
+    constraint article_print_areas_article_id_name_key
+        unique (article_id, name),
+    constraint fk_article_print_areas_article
+        foreign key (article_id) references articles
+            on delete cascade,
+    constraint chk_article_print_areas_size
+        check ((width_mm > 0) AND (height_mm > 0))
+);
@@ -0,0 +1,15 @@
+drop table if exists prompt_category_slug_redirects;
+drop table if exists article_category_slug_redirects;
+drop table if exists article_slug_redirects;
+
+alter table if exists prompt_categories
+    drop constraint if exists prompt_categories_slug_key,
+    drop column if exists slug;
+
+alter table if exists article_categories
+    drop constraint if exists article_categories_slug_key,
+    drop column if exists slug;
+
+alter table if exists articles
+    drop constraint if exists articles_slug_key,
+    drop column if exists slug;
@@ -0,0 +1,111 @@
+-- Public slugs for articles, article categories and prompt categories. Slugs
+-- are backfilled from the names; duplicates get the row id appended.
+alter table if exists articles
+    add column if not exists slug varchar(255);
+
+with base as (select id,
+                     coalesce(nullif(left(trim(both '-' from regexp_replace(
+                                      replace(replace(replace(replace(lower(name), 'ä', 'ae'), 'ö', 'oe'),
+                                                      'ü', 'ue'), 'ß', 'ss'),
+                                      '[^a-z0-9]+', '-', 'g')), 200), ''), 'article') as slug
+              from articles),
+     ranked as (select id, slug, row_number() over (partition by slug order by id) as n
+                from base)
+update articles t
+set slug = case when r.n = 1 and r.slug <> 'categories' then r.slug else r.slug || '-' || r.id end
+from ranked r
+where r.id = t.id
+  and t.slug is null;
+
+alter table if exists articles
+    alter column slug set not null,
+    add constraint articles_slug_key unique (slug);
+
+alter table if exists article_categories
+    add column if not exists slug varchar(255);
+
+with base as (select id,
+                     coalesce(nullif(left(trim(both '-' from regexp_replace(
+                                      replace(replace(replace(replace(lower(name), 'ä', 'ae'), 'ö', 'oe'),
+                                                      'ü', 'ue'), 'ß', 'ss'),
+                                      '[^a-z0-9]+', '-', 'g')), 200), ''), 'category') as slug
+              from article_categories),
+     ranked as (select id, slug, row_number() over (partition by slug order by id) as n
+                from base)
+update article_categories t
+set slug = case when r.n = 1 then r.slug else r.slug || '-' || r.id end
+from ranked r
+where r.id = t.id
+  and t.slug is null;
+
+alter table if exists article_categories
+    alter column slug set not null,
+    add constraint article_categories_slug_key unique (slug);
+
+alter table if exists prompt_categories
+    add column if not exists slug varchar(255);
+
+with base as (select id,
+                     coalesce(nullif(left(trim(both '-' from regexp_replace(
+                                      replace(replace(replace(replace(lower(name), 'ä', 'ae'), 'ö', 'oe'),
+                                                      'ü', 'ue'), 'ß', 'ss'),
+                                      '[^a-z0-9]+', '-', 'g')), 200), ''), 'category') as slug
+              from prompt_categories),
+     ranked as (select id, slug, row_number() over (partition by slug order by id) as n
+                from base)
+update prompt_categories t
+set slug = case when r.n = 1 then r.slug else r.slug || '-' || r.id end
+from ranked r
+where r.id = t.id
+  and t.slug is null;
+
+alter table if exists prompt_categories
+    alter column slug set not null,
+    add constraint prompt_categories_slug_key unique (slug);
+
+-- Slugs an entity had before it was renamed, so old links redirect to the
+-- current one. A slug that is taken again is removed from the history.
+create table if not exists article_slug_redirects
+(
+    slug        varchar(255),
+    article_id  bigint                                             not null,
+    created_at  timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint article_slug_redirects_pkey
+        primary key (slug),
+    constraint fk_article_slug_redirects_article
+        foreign key (article_id) references articles
+            on delete cascade
+);
+
+create index if not exists idx_article_slug_redirects_article_id
+    on article_slug_redirects (article_id);
+
+create table if not exists article_category_slug_redirects
+(
+    slug        varchar(255),
+    category_id bigint                                             not null,
+    created_at  timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint article_category_slug_redirects_pkey
+        primary key (slug),
+    constraint fk_article_category_slug_redirects_category
+        foreign key (category_id) references article_categories
+            on delete cascade
+);
+
+create index if not exists idx_article_category_slug_redirects_category_id
+    on article_category_slug_redirects (category_id);
+
+create table if not exists prompt_category_slug_redirects
+(
+    slug        varchar(255),
+    category_id bigint                                             not null,
+    created_at  timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint prompt_category_slug_redirects_pkey
+        primary key (slug),
+    constraint fk_prompt_category_slug_redirects_category
+        foreign key (category_id) references prompt_categories
+            on delete cascade
+);
+
+create index if not exists idx_prompt_category_slug_redirects_category_id
+    on prompt_category_slug_redirects (category_id);
@@ -0,0 +1 @@
+drop table if exists article_gallery_images;
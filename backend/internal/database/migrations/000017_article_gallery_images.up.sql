@@ -0,0 +1,27 @@
+-- Ordered photo galleries of articles and their variants. variant_id is null
+-- for the article's own gallery; otherwise it refers to a variant in the
+-- table matching the article's type (mug, shirt or registered type variants).
+create table if not exists article_gallery_images
+(
+    id         bigserial,
+    article_id bigint                                             not null,
+    variant_id bigint,
+    filename   varchar(500)                                       not null,
+    alt_text   varchar(500)             default ''                not null,
+    position   integer                  default 0                 not null,
+    is_primary boolean                  default false             not null,
+    created_at timestamp with time zone default CURRENT_TIMESTAMP not null,
+    updated_at timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint article_gallery_images_pkey
+        primary key (id),
+    constraint fk_article_gallery_images_article
+        foreign key (article_id) references articles
+            on delete cascade
+);
+
+create index if not exists idx_article_gallery_images_article_id
+    on article_gallery_images (article_id, variant_id, position);
+
+create unique index if not exists idx_article_gallery_images_one_primary
+    on article_gallery_images (article_id, coalesce(variant_id, 0))
+    where (is_primary = true);
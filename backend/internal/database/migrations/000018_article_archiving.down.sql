@@ -0,0 +1,13 @@
+drop index if exists idx_articles_archived_at;
+
+alter table if exists article_variants
+    drop column if exists archived_at;
+
+alter table if exists article_shirt_variants
+    drop column if exists archived_at;
+
+alter table if exists article_mug_variants
+    drop column if exists archived_at;
+
+alter table if exists articles
+    drop column if exists archived_at;
@@ -0,0 +1,17 @@
+-- Archived articles and variants are hidden from the catalog and carts but
+-- kept so orders referencing them still resolve. Only archived rows that were
+-- never ordered may be purged.
+alter table if exists articles
+    add column if not exists archived_at timestamp with time zone;
+
+alter table if exists article_mug_variants
+    add column if not exists archived_at timestamp with time zone;
+
+alter table if exists article_shirt_variants
+    add column if not exists archived_at timestamp with time zone;
+
+alter table if exists article_variants
+    add column if not exists archived_at timestamp with time zone;
+
+create index if not exists idx_articles_archived_at
+    on articles (archived_at);
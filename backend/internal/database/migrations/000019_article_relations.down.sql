@@ -0,0 +1,3 @@
+drop table if exists article_bought_together;
+
+drop table if exists article_relations;
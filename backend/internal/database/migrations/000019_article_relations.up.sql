@@ -0,0 +1,48 @@
+-- Admin-curated related articles in display order. related_variant_id
+-- optionally pins the variant to suggest; it refers to the variant table
+-- matching the related article's type.
+create table if not exists article_relations
+(
+    id                 bigserial,
+    article_id         bigint                                             not null,
+    related_article_id bigint                                             not null,
+    related_variant_id bigint,
+    position           integer                  default 0                 not null,
+    created_at         timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint article_relations_pkey
+        primary key (id),
+    constraint fk_article_relations_article
+        foreign key (article_id) references articles
+            on delete cascade,
+    constraint fk_article_relations_related_article
+        foreign key (related_article_id) references articles
+            on delete cascade,
+    constraint uq_article_relations_article_related
+        unique (article_id, related_article_id),
+    constraint chk_article_relations_not_self
+        check (article_id <> related_article_id)
+);
+
+create index if not exists idx_article_relations_article_id
+    on article_relations (article_id, position);
+
+-- Articles ordered together, recomputed from order_items by a background
+-- job. Every pair is stored in both directions.
+create table if not exists article_bought_together
+(
+    article_id         bigint                                             not null,
+    related_article_id bigint                                             not null,
+    order_count        integer                                            not null,
+    updated_at         timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint article_bought_together_pkey
+        primary key (article_id, related_article_id),
+    constraint fk_article_bought_together_article
+        foreign key (article_id) references articles
+            on delete cascade,
+    constraint fk_article_bought_together_related_article
+        foreign key (related_article_id) references articles
+            on delete cascade
+);
+
+create index if not exists idx_article_bought_together_order_count
+    on article_bought_together (article_id, order_count desc);
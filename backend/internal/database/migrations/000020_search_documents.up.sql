@@ -0,0 +1,27 @@
+-- Unified storefront search over articles and prompts. The application keeps
+-- one row per searchable item up to date on admin writes; title is weighted
+-- above body. pg_trgm provides typo-tolerant matching on search_text.
+create extension if not exists pg_trgm;
+
+create table if not exists search_documents
+(
+    kind        varchar(20)                                        not null,
+    ref_id      bigint                                             not null,
+    title       varchar(500)                                       not null,
+    body        text                     default ''                not null,
+    summary     text                     default ''                not null,
+    path        varchar(500)                                       not null,
+    search_text text generated always as (lower(title || ' ' || body)) stored,
+    document    tsvector generated always as (
+                    setweight(to_tsvector('simple', title), 'A') ||
+                    setweight(to_tsvector('simple', body), 'B')) stored,
+    updated_at  timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint search_documents_pkey
+        primary key (kind, ref_id)
+);
+
+create index if not exists idx_search_documents_document
+    on search_documents using gin (document);
+
+create index if not exists idx_search_documents_search_text_trgm
+    on search_documents using gin (search_text gin_trgm_ops);
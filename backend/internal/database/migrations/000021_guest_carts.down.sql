@@ -0,0 +1,15 @@
+delete from carts
+where guest_token is not null;
+
+drop index if exists idx_carts_guest_updated_at;
+
+drop index if exists uk_guest_active_cart;
+
+alter table carts
+    drop constraint if exists chk_carts_owner;
+
+alter table carts
+    drop column if exists guest_token;
+
+alter table carts
+    alter column user_id set not null;
@@ -0,0 +1,19 @@
+-- Visitors who are not signed in get a cart owned by a random guest token,
+-- handed out in a signed cookie, instead of a user.
+alter table carts
+    alter column user_id drop not null;
+
+alter table carts
+    add column if not exists guest_token varchar(64);
+
+alter table carts
+    add constraint chk_carts_owner
+        check ((user_id is null) <> (guest_token is null));
+
+create unique index if not exists uk_guest_active_cart
+    on carts (guest_token)
+    where ((status)::text = 'active'::text);
+
+create index if not exists idx_carts_guest_updated_at
+    on carts (updated_at)
+    where guest_token is not null;
@@ -0,0 +1,18 @@
+drop index if exists idx_carts_abandoned_at;
+
+drop index if exists uk_carts_recovery_token;
+
+alter table carts
+    drop column if exists recovered_at;
+
+alter table carts
+    drop column if exists reminder_sent_at;
+
+alter table carts
+    drop column if exists recovery_token;
+
+alter table carts
+    drop column if exists abandoned_value;
+
+alter table carts
+    drop column if exists abandoned_at;
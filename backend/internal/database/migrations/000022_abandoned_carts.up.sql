@@ -0,0 +1,25 @@
+-- Carts of signed-in users idle for too long are marked abandoned. The value
+-- is recorded when a cart is abandoned for reporting; the recovery token
+-- restores its items through the link of the reminder.
+alter table carts
+    add column if not exists abandoned_at timestamp with time zone;
+
+alter table carts
+    add column if not exists abandoned_value integer;
+
+alter table carts
+    add column if not exists recovery_token varchar(64);
+
+alter table carts
+    add column if not exists reminder_sent_at timestamp with time zone;
+
+alter table carts
+    add column if not exists recovered_at timestamp with time zone;
+
+create unique index if not exists uk_carts_recovery_token
+    on carts (recovery_token)
+    where recovery_token is not null;
+
+create index if not exists idx_carts_abandoned_at
+    on carts (abandoned_at)
+    where abandoned_at is not null;
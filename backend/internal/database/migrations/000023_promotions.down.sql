@@ -0,0 +1,32 @@
+-- Discounted orders cannot satisfy the old total, so it is only checked for
+-- new rows.
+alter table orders
+    drop constraint if exists chk_total_calculation;
+
+alter table orders
+    add constraint chk_total_calculation
+        check (total_amount = ((subtotal + tax_amount) + shipping_amount)) not valid;
+
+alter table orders
+    drop constraint if exists chk_order_discount;
+
+alter table orders
+    drop constraint if exists fk_orders_promotion;
+
+alter table orders
+    drop column if exists discount_amount;
+
+alter table orders
+    drop column if exists promotion_code;
+
+alter table orders
+    drop column if exists promotion_id;
+
+alter table carts
+    drop column if exists promotion_code;
+
+drop table if exists promotion_redemptions;
+
+drop table if exists promotion_targets;
+
+drop table if exists promotions;
@@ -0,0 +1,107 @@
+-- Promotion codes. value is the percentage for PERCENTAGE and the amount in
+-- cents for FIXED_AMOUNT; BUY_X_GET_ONE makes one of every buy_quantity + 1
+-- eligible units free. times_used counts redemptions against max_uses.
+create table if not exists promotions
+(
+    id                bigserial,
+    code              varchar(64)                                        not null,
+    description       text,
+    kind              varchar(20)                                        not null,
+    value             integer                  default 0                 not null,
+    buy_quantity      integer                  default 0                 not null,
+    starts_at         timestamp with time zone,
+    ends_at           timestamp with time zone,
+    max_uses          integer,
+    max_uses_per_user integer,
+    times_used        integer                  default 0                 not null,
+    active            boolean                  default true              not null,
+    created_at        timestamp with time zone default CURRENT_TIMESTAMP not null,
+    updated_at        timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint promotions_pkey
+        primary key (id),
+    constraint uk_promotions_code
+        unique (code),
+    constraint chk_promotions_kind
+        check (kind in ('PERCENTAGE', 'FIXED_AMOUNT', 'FREE_SHIPPING', 'BUY_X_GET_ONE')),
+    constraint chk_promotions_value
+        check (value >= 0 and buy_quantity >= 0),
+    constraint chk_promotions_window
+        check (starts_at is null or ends_at is null or starts_at < ends_at),
+    constraint chk_promotions_limits
+        check ((max_uses is null or max_uses > 0) and (max_uses_per_user is null or max_uses_per_user > 0)),
+    constraint chk_promotions_times_used
+        check (times_used >= 0)
+);
+
+-- Restricts a promotion to items matching the targets. A promotion with
+-- targets of several types only applies to items matching one target of
+-- every type.
+create table if not exists promotion_targets
+(
+    promotion_id bigint      not null,
+    target_type  varchar(20) not null,
+    target_id    bigint      not null,
+    constraint promotion_targets_pkey
+        primary key (promotion_id, target_type, target_id),
+    constraint fk_promotion_targets_promotion
+        foreign key (promotion_id) references promotions
+            on delete cascade,
+    constraint chk_promotion_targets_type
+        check (target_type in ('ARTICLE', 'PROMPT', 'CATEGORY'))
+);
+
+create table if not exists promotion_redemptions
+(
+    id           bigserial,
+    promotion_id bigint                                             not null,
+    user_id      bigint                                             not null,
+    order_id     bigint                                             not null,
+    amount       bigint                                             not null,
+    created_at   timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint promotion_redemptions_pkey
+        primary key (id),
+    constraint fk_promotion_redemptions_promotion
+        foreign key (promotion_id) references promotions
+            on delete cascade,
+    constraint fk_promotion_redemptions_user
+        foreign key (user_id) references users
+            on delete cascade,
+    constraint fk_promotion_redemptions_order
+        foreign key (order_id) references orders
+            on delete cascade,
+    constraint uk_promotion_redemptions_order
+        unique (order_id)
+);
+
+create index if not exists idx_promotion_redemptions_promotion_user
+    on promotion_redemptions (promotion_id, user_id);
+
+alter table carts
+    add column if not exists promotion_code varchar(64);
+
+-- Orders keep the code and the discount on items and shipping; the total
+-- is reduced by it.
+alter table orders
+    add column if not exists promotion_id bigint;
+
+alter table orders
+    add column if not exists promotion_code varchar(64);
+
+alter table orders
+    add column if not exists discount_amount bigint default 0 not null;
+
+alter table orders
+    add constraint fk_orders_promotion
+        foreign key (promotion_id) references promotions
+            on delete set null;
+
+alter table orders
+    add constraint chk_order_discount
+        check (discount_amount >= 0 and discount_amount <= subtotal + shipping_amount);
+
+alter table orders
+    drop constraint if exists chk_total_calculation;
+
+alter table orders
+    add constraint chk_total_calculation
+        check (total_amount = subtotal + tax_amount + shipping_amount - discount_amount);
@@ -0,0 +1,4 @@
+alter table cart_items
+    drop column if exists price_locked_until;
+
+drop table if exists cart_settings;
@@ -0,0 +1,24 @@
+-- Shop wide cart settings, kept in a single row. price_lock_minutes is how
+-- long a line added to a cart keeps the prices it was added at; 0 turns
+-- price locks off.
+create table if not exists cart_settings
+(
+    id                 smallint                 default 1                 not null,
+    price_lock_minutes integer                  default 0                 not null,
+    updated_at         timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint cart_settings_pkey
+        primary key (id),
+    constraint chk_cart_settings_single_row
+        check (id = 1),
+    constraint chk_cart_settings_price_lock
+        check (price_lock_minutes >= 0)
+);
+
+insert into cart_settings (id, price_lock_minutes)
+values (1, 0)
+on conflict (id) do nothing;
+
+-- Until price_locked_until a line is charged the prices in effect when it
+-- was added, even if they changed since.
+alter table cart_items
+    add column if not exists price_locked_until timestamp with time zone;
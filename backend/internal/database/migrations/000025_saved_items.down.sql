@@ -0,0 +1,3 @@
+drop table if exists saved_item_shares;
+
+drop table if exists saved_items;
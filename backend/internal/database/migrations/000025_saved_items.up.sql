@@ -0,0 +1,52 @@
+-- Designs users keep without putting them in the cart. An entry goes away
+-- with its generated image.
+create table if not exists saved_items
+(
+    id                 bigserial,
+    user_id            bigint                                             not null,
+    article_id         bigint                                             not null,
+    variant_id         bigint                                             not null,
+    variant_type       varchar(20)              default 'MUG'             not null,
+    generated_image_id bigint,
+    prompt_id          bigint,
+    custom_data        jsonb                    default '{}'::jsonb       not null,
+    created_at         timestamp with time zone default CURRENT_TIMESTAMP not null,
+    updated_at         timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint saved_items_pkey
+        primary key (id),
+    constraint fk_saved_items_user
+        foreign key (user_id) references users
+            on delete cascade,
+    constraint fk_saved_items_article
+        foreign key (article_id) references articles
+            on delete cascade,
+    constraint fk_saved_items_generated_image
+        foreign key (generated_image_id) references generated_images
+            on delete cascade,
+    constraint fk_saved_items_prompt
+        foreign key (prompt_id) references prompts
+            on delete set null,
+    constraint chk_saved_items_variant_type
+        check (variant_type in ('MUG', 'SHIRT'))
+);
+
+create index if not exists idx_saved_items_user_created
+    on saved_items (user_id, created_at);
+
+create index if not exists idx_saved_items_generated_image_id
+    on saved_items (generated_image_id);
+
+-- A user's saved items can be shared read-only through the link with token.
+create table if not exists saved_item_shares
+(
+    user_id    bigint                                             not null,
+    token      varchar(64)                                        not null,
+    created_at timestamp with time zone default CURRENT_TIMESTAMP not null,
+    constraint saved_item_shares_pkey
+        primary key (user_id),
+    constraint uk_saved_item_shares_token
+        unique (token),
+    constraint fk_saved_item_shares_user
+        foreign key (user_id) references users
+            on delete cascade
+);
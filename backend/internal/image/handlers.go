@@ -1,6 +1,7 @@
 package image
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
@@ -109,6 +110,36 @@ func RegisterRoutes(router *gin.Engine, database *gorm.DB, service *Service) {
 		ctx.Data(http.StatusOK, contentType, imageBytes)
 	})
 
+	// GET /api/user/images/:filename/mockups/:variantId renders the generated
+	// image onto the mug variant's example photo.
+	userGroup.GET("/:filename/mockups/:variantId", func(ctx *gin.Context) {
+		userValue, _ := ctx.Get("currentUser")
+		currentUser, _ := userValue.(*auth.User)
+		if currentUser == nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"detail": "Not authenticated"})
+			return
+		}
+		variantID, err := strconv.Atoi(ctx.Param("variantId"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+			return
+		}
+		imageBytes, err := service.RenderMugMockup(ctx.Request.Context(), currentUser.ID, ctx.Param("filename"), variantID)
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrMockupImageNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"detail": "Not found"})
+			case errors.Is(err, ErrMockupNotConfigured), errors.Is(err, gorm.ErrRecordNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"detail": "No mockup available for this variant"})
+			default:
+				ctx.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to render mockup"})
+			}
+			return
+		}
+		ctx.Header("Cache-Control", "private, max-age=3600")
+		ctx.Data(http.StatusOK, "image/png", imageBytes)
+	})
+
 	userGroup.GET("", func(ctx *gin.Context) {
 		userValue, _ := ctx.Get("currentUser")
 		currentUser, _ := userValue.(*auth.User)
@@ -39,6 +39,11 @@ func (s *StorageLocations) PromptTest() string {
 	return filepath.Join(s.PrivateImages(), "0_prompt-test")
 }
 
+// MockupCache returns {root}/private/images/mockups
+func (s *StorageLocations) MockupCache() string {
+	return filepath.Join(s.PrivateImages(), "mockups")
+}
+
 // PromptExample returns {root}/public/images/prompt-example-images
 func (s *StorageLocations) PromptExample() string {
 	return filepath.Join(s.PublicImages(), "prompt-example-images")
@@ -54,12 +59,26 @@ func (s *StorageLocations) ShirtVariantExample() string {
 	return filepath.Join(s.PublicImages(), "articles", "shirts", "variant-example-images")
 }
 
+// ArticleVariantExample returns {root}/public/images/articles/variants/variant-example-images
+// for variants of registered article types.
+func (s *StorageLocations) ArticleVariantExample() string {
+	return filepath.Join(s.PublicImages(), "articles", "variants", "variant-example-images")
+}
+
+// ArticleGallery returns {root}/public/images/articles/gallery for the photo
+// galleries of articles and their variants.
+func (s *StorageLocations) ArticleGallery() string {
+	return filepath.Join(s.PublicImages(), "articles", "gallery")
+}
+
 // ResolveAdminDir maps an imageType to a directory.
 // Supported:
 // - PROMPT_EXAMPLE
 // - PROMPT_SLOT_VARIANT_EXAMPLE
 // - MUG_VARIANT_EXAMPLE
 // - SHIRT_VARIANT_EXAMPLE
+// - ARTICLE_VARIANT_EXAMPLE
+// - ARTICLE_GALLERY
 // - PROMPT_TEST
 // - PUBLIC
 // - PRIVATE
@@ -72,6 +91,10 @@ func (s *StorageLocations) ResolveAdminDir(imageType string) (string, error) {
 		return s.MugVariantExample(), nil
 	case "SHIRT_VARIANT_EXAMPLE":
 		return s.ShirtVariantExample(), nil
+	case "ARTICLE_VARIANT_EXAMPLE":
+		return s.ArticleVariantExample(), nil
+	case "ARTICLE_GALLERY":
+		return s.ArticleGallery(), nil
 	case "PROMPT_TEST":
 		return s.PromptTest(), nil
 	case "PUBLIC":
@@ -0,0 +1,240 @@
+package image
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidMockupPrintArea is returned for print areas that leave the photo,
+// are not convex or collapse to a line.
+var ErrInvalidMockupPrintArea = errors.New("invalid mockup print area")
+
+// MockupPoint is a position on a photo in fractions of its width and height.
+type MockupPoint struct {
+	X float64
+	Y float64
+}
+
+// MockupQuad is the print area on a product photo, clockwise from the top-left
+// corner: top-left, top-right, bottom-right, bottom-left.
+type MockupQuad [4]MockupPoint
+
+// ParseMockupQuad reads a quad stored as "x,y;x,y;x,y;x,y".
+func ParseMockupQuad(s string) (MockupQuad, error) {
+	var q MockupQuad
+	points := strings.Split(strings.TrimSpace(s), ";")
+	if len(points) != len(q) {
+		return q, fmt.Errorf("%w: want 4 points, got %d", ErrInvalidMockupPrintArea, len(points))
+	}
+	for i, p := range points {
+		x, y, ok := strings.Cut(p, ",")
+		if !ok {
+			return q, fmt.Errorf("%w: point %d is not x,y", ErrInvalidMockupPrintArea, i+1)
+		}
+		var errX, errY error
+		q[i].X, errX = strconv.ParseFloat(strings.TrimSpace(x), 64)
+		q[i].Y, errY = strconv.ParseFloat(strings.TrimSpace(y), 64)
+		if errX != nil || errY != nil {
+			return q, fmt.Errorf("%w: point %d is not numeric", ErrInvalidMockupPrintArea, i+1)
+		}
+	}
+	return q, q.Validate()
+}
+
+// String formats the quad the way ParseMockupQuad reads it.
+func (q MockupQuad) String() string {
+	parts := make([]string, len(q))
+	for i, p := range q {
+		parts[i] = strconv.FormatFloat(p.X, 'f', -1, 64) + "," + strconv.FormatFloat(p.Y, 'f', -1, 64)
+	}
+	return strings.Join(parts, ";")
+}
+
+// Validate checks that all corners lie on the photo and that they form a
+// convex quad in clockwise order.
+func (q MockupQuad) Validate() error {
+	for i, p := range q {
+		if p.X < 0 || p.X > 1 || p.Y < 0 || p.Y > 1 || math.IsNaN(p.X) || math.IsNaN(p.Y) {
+			return fmt.Errorf("%w: point %d is outside the photo", ErrInvalidMockupPrintArea, i+1)
+		}
+	}
+	for i := range q {
+		a, b, c := q[i], q[(i+1)%4], q[(i+2)%4]
+		// Image coordinates grow downwards, so clockwise turns are positive.
+		if (b.X-a.X)*(c.Y-b.Y)-(b.Y-a.Y)*(c.X-b.X) <= 0 {
+			return fmt.Errorf("%w: corners must form a convex quad listed clockwise from the top-left", ErrInvalidMockupPrintArea)
+		}
+	}
+	return nil
+}
+
+// MockupTemplate describes how designs are placed on a variant's photo.
+type MockupTemplate struct {
+	// PhotoPath is the product photo on disk.
+	PhotoPath string
+	PrintArea MockupQuad
+	// Curvature wraps the design around a cylinder: 0 keeps it flat, 1 lets
+	// the print area span the visible half of the mug.
+	Curvature float64
+	// PrintWidthMm and PrintHeightMm give the design's aspect ratio; designs
+	// of another ratio are centre-cropped. Zero keeps the design as is.
+	PrintWidthMm  int
+	PrintHeightMm int
+}
+
+// mockupEdgeShade is how much darker the design gets where the cylinder turns
+// away from the camera at full curvature.
+const mockupEdgeShade = 0.35
+
+// RenderMockup warps design onto the print area of photo, bending it around
+// a cylinder by tpl.Curvature, and returns the composite.
+func RenderMockup(photo, design image.Image, tpl MockupTemplate) *image.RGBA {
+	bounds := photo.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(out, out.Bounds(), photo, bounds.Min, draw.Src)
+
+	src := toRGBA(design)
+	crop := cropToAspect(src.Bounds(), tpl.PrintWidthMm, tpl.PrintHeightMm)
+
+	w, h := float64(out.Bounds().Dx()), float64(out.Bounds().Dy())
+	var corners [4][2]float64
+	for i, p := range tpl.PrintArea {
+		corners[i] = [2]float64{p.X * w, p.Y * h}
+	}
+	toSquare, ok := squareToQuad(corners).inverse()
+	if !ok {
+		return out
+	}
+
+	phi := math.Max(0, math.Min(tpl.Curvature, 1)) * math.Pi / 2
+	minX, minY, maxX, maxY := w, h, 0.0, 0.0
+	for _, c := range corners {
+		minX, maxX = math.Min(minX, c[0]), math.Max(maxX, c[0])
+		minY, maxY = math.Min(minY, c[1]), math.Max(maxY, c[1])
+	}
+	for y := int(minY); y < int(math.Ceil(maxY)) && y < out.Bounds().Dy(); y++ {
+		for x := int(minX); x < int(math.Ceil(maxX)) && x < out.Bounds().Dx(); x++ {
+			s, t := toSquare.apply(float64(x)+0.5, float64(y)+0.5)
+			if s < 0 || s > 1 || t < 0 || t > 1 {
+				continue
+			}
+			u, shade := s, 1.0
+			if phi > 0 {
+				// s is the position seen from the front; the cylinder angle
+				// behind it tells which part of the design shows there.
+				theta := math.Asin((2*s - 1) * math.Sin(phi))
+				u = 0.5 + theta/(2*phi)
+				shade = 1 - mockupEdgeShade*(1-math.Cos(theta))
+			}
+			r, g, b, a := sampleBilinear(src, crop,
+				float64(crop.Min.X)+u*float64(crop.Dx()),
+				float64(crop.Min.Y)+t*float64(crop.Dy()))
+			blendOver(out, x, y, r*shade, g*shade, b*shade, a)
+		}
+	}
+	return out
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
+
+// cropToAspect returns the centred part of r with the aspect ratio w:h.
+func cropToAspect(r image.Rectangle, w, h int) image.Rectangle {
+	if w <= 0 || h <= 0 || r.Empty() {
+		return r
+	}
+	if r.Dx()*h > r.Dy()*w {
+		cw := r.Dy() * w / h
+		off := (r.Dx() - cw) / 2
+		return image.Rect(r.Min.X+off, r.Min.Y, r.Min.X+off+cw, r.Max.Y)
+	}
+	ch := r.Dx() * h / w
+	off := (r.Dy() - ch) / 2
+	return image.Rect(r.Min.X, r.Min.Y+off, r.Max.X, r.Min.Y+off+ch)
+}
+
+// sampleBilinear returns the premultiplied colour at (x, y) within r, scaled
+// to 0..1.
+func sampleBilinear(img *image.RGBA, r image.Rectangle, x, y float64) (cr, cg, cb, ca float64) {
+	x, y = x-0.5, y-0.5
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+	clampX := func(v int) int { return clampInt(v, r.Min.X, r.Max.X-1) }
+	clampY := func(v int) int { return clampInt(v, r.Min.Y, r.Max.Y-1) }
+	xs := [2]int{clampX(int(x0)), clampX(int(x0) + 1)}
+	ys := [2]int{clampY(int(y0)), clampY(int(y0) + 1)}
+	weights := [4]float64{(1 - fx) * (1 - fy), fx * (1 - fy), (1 - fx) * fy, fx * fy}
+	for i, weight := range weights {
+		off := img.PixOffset(xs[i%2], ys[i/2])
+		cr += weight * float64(img.Pix[off])
+		cg += weight * float64(img.Pix[off+1])
+		cb += weight * float64(img.Pix[off+2])
+		ca += weight * float64(img.Pix[off+3])
+	}
+	return cr / 255, cg / 255, cb / 255, ca / 255
+}
+
+// blendOver composites a premultiplied colour over the pixel at (x, y).
+func blendOver(dst *image.RGBA, x, y int, r, g, b, a float64) {
+	off := dst.PixOffset(x, y)
+	px := dst.Pix[off : off+4 : off+4]
+	for i, c := range [4]float64{r, g, b, a} {
+		px[i] = uint8(math.Round(math.Min(255, c*255+float64(px[i])*(1-a))))
+	}
+}
+
+// homography is a 3x3 projective transform in row-major order.
+type homography [9]float64
+
+// squareToQuad maps the unit square onto the quad so that (0,0), (1,0),
+// (1,1) and (0,1) land on its corners in order.
+func squareToQuad(q [4][2]float64) homography {
+	x0, y0 := q[0][0], q[0][1]
+	x1, y1 := q[1][0], q[1][1]
+	x2, y2 := q[2][0], q[2][1]
+	x3, y3 := q[3][0], q[3][1]
+	dx1, dy1 := x1-x2, y1-y2
+	dx2, dy2 := x3-x2, y3-y2
+	dx3, dy3 := x0-x1+x2-x3, y0-y1+y2-y3
+	if dx3 == 0 && dy3 == 0 {
+		return homography{x1 - x0, x3 - x0, x0, y1 - y0, y3 - y0, y0, 0, 0, 1}
+	}
+	det := dx1*dy2 - dx2*dy1
+	g := (dx3*dy2 - dx2*dy3) / det
+	h := (dx1*dy3 - dx3*dy1) / det
+	return homography{
+		x1 - x0 + g*x1, x3 - x0 + h*x3, x0,
+		y1 - y0 + g*y1, y3 - y0 + h*y3, y0,
+		g, h, 1,
+	}
+}
+
+func (m homography) apply(x, y float64) (float64, float64) {
+	w := m[6]*x + m[7]*y + m[8]
+	return (m[0]*x + m[1]*y + m[2]) / w, (m[3]*x + m[4]*y + m[5]) / w
+}
+
+func (m homography) inverse() (homography, bool) {
+	a, b, c, d, e, f, g, h, i := m[0], m[1], m[2], m[3], m[4], m[5], m[6], m[7], m[8]
+	det := a*(e*i-f*h) - b*(d*i-f*g) + c*(d*h-e*g)
+	if math.Abs(det) < 1e-12 {
+		return homography{}, false
+	}
+	return homography{
+		(e*i - f*h) / det, (c*h - b*i) / det, (b*f - c*e) / det,
+		(f*g - d*i) / det, (a*i - c*g) / det, (c*d - a*f) / det,
+		(d*h - e*g) / det, (b*g - a*h) / det, (a*e - b*d) / det,
+	}, true
+}
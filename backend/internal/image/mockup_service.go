@@ -0,0 +1,96 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+var (
+	// ErrMockupNotConfigured is returned for variants without an example photo
+	// or print area.
+	ErrMockupNotConfigured = errors.New("variant has no mockup configured")
+	// ErrMockupImageNotFound is returned when the generated image does not
+	// exist or belongs to another user.
+	ErrMockupImageNotFound = errors.New("generated image not found")
+)
+
+// mockupVersion is part of every cache key; bump it when RenderMockup changes
+// its output so stale previews are not served.
+const mockupVersion = "1"
+
+// MockupTemplateSource looks up how designs are placed on a mug variant. It
+// returns nil when the variant has no mockup configured.
+type MockupTemplateSource interface {
+	MugVariantMockupTemplate(ctx context.Context, variantID int) (*MockupTemplate, error)
+}
+
+// RenderMugMockup returns a PNG preview of the user's generated image on the
+// given mug variant. Previews are cached on disk, keyed by everything that
+// affects the result.
+func (s *Service) RenderMugMockup(ctx context.Context, userID int, filename string, variantID int) ([]byte, error) {
+	safeFilename, err := SafeFilename(filename)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMockupImageNotFound, err)
+	}
+	generated, err := s.repository.GetGeneratedImageByFilename(ctx, safeFilename)
+	if err != nil || generated.UserID == nil || *generated.UserID != userID {
+		return nil, ErrMockupImageNotFound
+	}
+	if s.mockups == nil {
+		return nil, ErrMockupNotConfigured
+	}
+	tpl, err := s.mockups.MugVariantMockupTemplate(ctx, variantID)
+	if err != nil {
+		return nil, err
+	}
+	if tpl == nil {
+		return nil, ErrMockupNotConfigured
+	}
+
+	storageLocations, err := NewStorageLocations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	cacheName := mockupCacheKey(safeFilename, variantID, tpl) + ".png"
+	cachePath := filepath.Join(storageLocations.MockupCache(), cacheName)
+	if cached, err := os.ReadFile(cachePath); err == nil {
+		return cached, nil
+	}
+
+	userImageDir, err := UserImagesDir(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user image directory: %w", err)
+	}
+	design, err := decodeImageInput(filepath.Join(userImageDir, safeFilename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load generated image: %w", err)
+	}
+	photo, err := decodeImageInput(tpl.PhotoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load variant photo: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, RenderMockup(photo, design, *tpl)); err != nil {
+		return nil, fmt.Errorf("failed to encode mockup: %w", err)
+	}
+	if _, err := StoreImageBytes(buffer.Bytes(), storageLocations.MockupCache(), cacheName, "png", true); err != nil {
+		return nil, fmt.Errorf("failed to cache mockup: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func mockupCacheKey(filename string, variantID int, tpl *MockupTemplate) string {
+	sum := sha256.Sum256([]byte(mockupVersion + "|" + filename + "|" + strconv.Itoa(variantID) + "|" +
+		filepath.Base(tpl.PhotoPath) + "|" + tpl.PrintArea.String() + "|" +
+		strconv.FormatFloat(tpl.Curvature, 'f', -1, 64) + "|" +
+		strconv.Itoa(tpl.PrintWidthMm) + "x" + strconv.Itoa(tpl.PrintHeightMm)))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,77 @@
+package image
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestParseMockupQuadRoundTrips(t *testing.T) {
+	q, err := ParseMockupQuad("0.1,0.2;0.9,0.2;0.85,0.8;0.15,0.8")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	back, err := ParseMockupQuad(q.String())
+	if err != nil || back != q {
+		t.Fatalf("round trip = %v, %v; want %v", back, err, q)
+	}
+}
+
+func TestParseMockupQuadRejectsInvalidAreas(t *testing.T) {
+	for _, s := range []string{
+		"0.1,0.2;0.9,0.2;0.85,0.8",          // three points
+		"0.1,0.2;0.9,0.2;0.85,1.5;0.15,0.8", // off the photo
+		"0.1,0.2;0.15,0.8;0.85,0.8;0.9,0.2", // counter-clockwise
+		"0.1,0.1;0.9,0.1;0.2,0.2;0.1,0.9",   // concave
+		"0.1,0.2;0.9,x;0.85,0.8;0.15,0.8",   // not numeric
+	} {
+		if _, err := ParseMockupQuad(s); !errors.Is(err, ErrInvalidMockupPrintArea) {
+			t.Errorf("ParseMockupQuad(%q) err = %v, want ErrInvalidMockupPrintArea", s, err)
+		}
+	}
+}
+
+func TestRenderMockupPlacesDesignInPrintArea(t *testing.T) {
+	photo := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	white := color.RGBA{255, 255, 255, 255}
+	for i := 0; i < len(photo.Pix); i += 4 {
+		copy(photo.Pix[i:i+4], []uint8{white.R, white.G, white.B, white.A})
+	}
+	// Left half red, right half blue.
+	design := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 20; x++ {
+			if x < 10 {
+				design.Set(x, y, color.RGBA{255, 0, 0, 255})
+			} else {
+				design.Set(x, y, color.RGBA{0, 0, 255, 255})
+			}
+		}
+	}
+	tpl := MockupTemplate{PrintArea: MockupQuad{{0.2, 0.2}, {0.8, 0.2}, {0.8, 0.8}, {0.2, 0.8}}}
+
+	out := RenderMockup(photo, design, tpl)
+
+	checks := []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{5, 5, white},
+		{95, 50, white},
+		{30, 50, color.RGBA{255, 0, 0, 255}},
+		{70, 50, color.RGBA{0, 0, 255, 255}},
+	}
+	for _, c := range checks {
+		if got := out.RGBAAt(c.x, c.y); got != c.want {
+			t.Errorf("pixel (%d,%d) = %v, want %v", c.x, c.y, got, c.want)
+		}
+	}
+
+	tpl.Curvature = 1
+	curved := RenderMockup(photo, design, tpl)
+	centre, edge := curved.RGBAAt(35, 50), curved.RGBAAt(21, 50)
+	if centre.R <= edge.R {
+		t.Errorf("expected the edge to be shaded darker than the centre, got centre %v edge %v", centre, edge)
+	}
+}
@@ -10,10 +10,13 @@ import (
 
 type Service struct {
 	repository Repository
+	mockups    MockupTemplateSource
 }
 
-func NewService(repository Repository) *Service {
-	return &Service{repository: repository}
+// NewService wires the image service. mockups may be nil, in which case no
+// mockup previews are rendered.
+func NewService(repository Repository, mockups MockupTemplateSource) *Service {
+	return &Service{repository: repository, mockups: mockups}
 }
 
 func (s *Service) CreateUploadedImage(ctx context.Context, uploadedImage *UploadedImage) error {
@@ -26,7 +29,7 @@ func (s *Service) CreateGeneratedImage(ctx context.Context, generatedImage *Gene
 
 func (s *Service) WithTransaction(ctx context.Context, operation func(*Service) error) error {
 	return s.repository.WithTransaction(ctx, func(nestedRepository Repository) error {
-		transactionalService := &Service{repository: nestedRepository}
+		transactionalService := &Service{repository: nestedRepository, mockups: s.mockups}
 		return operation(transactionalService)
 	})
 }
@@ -0,0 +1,186 @@
+package inventory
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/auth"
+)
+
+type adjustmentRequest struct {
+	Delta             int     `json:"delta"`
+	OnHand            *int    `json:"onHand"`
+	LowStockThreshold *int    `json:"lowStockThreshold"`
+	Reason            *string `json:"reason"`
+}
+
+type StockResponse struct {
+	VariantType       string     `json:"variantType"`
+	VariantID         int        `json:"variantId"`
+	Tracked           bool       `json:"tracked"`
+	OnHand            int        `json:"onHand"`
+	Reserved          int        `json:"reserved"`
+	Available         int        `json:"available"`
+	LowStockThreshold int        `json:"lowStockThreshold"`
+	LowStock          bool       `json:"lowStock"`
+	UpdatedAt         *time.Time `json:"updatedAt"`
+}
+
+type MovementResponse struct {
+	ID            int       `json:"id"`
+	Kind          string    `json:"kind"`
+	Quantity      int       `json:"quantity"`
+	OnHandAfter   int       `json:"onHandAfter"`
+	ReservedAfter int       `json:"reservedAfter"`
+	OrderID       *int64    `json:"orderId"`
+	Reason        *string   `json:"reason"`
+	CreatedBy     *int      `json:"createdBy"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func RegisterRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
+	grp := r.Group("/api/admin/inventory")
+	grp.Use(auth.RequireAdmin(db))
+
+	// GET /api/admin/inventory?lowStock=true lists tracked variants.
+	grp.GET("", func(c *gin.Context) {
+		lowOnly := strings.EqualFold(c.Query("lowStock"), "true")
+		rows, err := svc.ListStocks(c.Request.Context(), lowOnly)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch stock"})
+			return
+		}
+		out := make([]StockResponse, 0, len(rows))
+		for i := range rows {
+			out = append(out, toStockResponse(&rows[i]))
+		}
+		c.JSON(http.StatusOK, out)
+	})
+
+	grp.GET("/:variantType/:variantId", func(c *gin.Context) {
+		variantID, ok := parseVariantID(c)
+		if !ok {
+			return
+		}
+		stock, err := svc.GetStock(c.Request.Context(), c.Param("variantType"), variantID)
+		if err != nil {
+			writeStockError(c, err, "Failed to fetch stock")
+			return
+		}
+		if stock == nil {
+			variantType, _ := NormalizeVariantType(c.Param("variantType"))
+			c.JSON(http.StatusOK, StockResponse{VariantType: variantType, VariantID: variantID})
+			return
+		}
+		c.JSON(http.StatusOK, toStockResponse(stock))
+	})
+
+	grp.GET("/:variantType/:variantId/movements", func(c *gin.Context) {
+		variantID, ok := parseVariantID(c)
+		if !ok {
+			return
+		}
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		rows, err := svc.ListMovements(c.Request.Context(), c.Param("variantType"), variantID, limit)
+		if err != nil {
+			writeStockError(c, err, "Failed to fetch stock movements")
+			return
+		}
+		out := make([]MovementResponse, 0, len(rows))
+		for i := range rows {
+			out = append(out, toMovementResponse(&rows[i]))
+		}
+		c.JSON(http.StatusOK, out)
+	})
+
+	// POST /api/admin/inventory/:variantType/:variantId/adjustments adds delta
+	// units (or sets onHand) and records the movement.
+	grp.POST("/:variantType/:variantId/adjustments", func(c *gin.Context) {
+		variantID, ok := parseVariantID(c)
+		if !ok {
+			return
+		}
+		var payload adjustmentRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		adj := Adjustment{
+			Delta:             payload.Delta,
+			OnHand:            payload.OnHand,
+			LowStockThreshold: payload.LowStockThreshold,
+			Reason:            payload.Reason,
+		}
+		if u, ok := c.Get("currentUser"); ok {
+			if user, _ := u.(*auth.User); user != nil {
+				id := user.ID
+				adj.CreatedBy = &id
+			}
+		}
+		stock, err := svc.Adjust(c.Request.Context(), c.Param("variantType"), variantID, adj)
+		if err != nil {
+			writeStockError(c, err, "Failed to adjust stock")
+			return
+		}
+		c.JSON(http.StatusOK, toStockResponse(stock))
+	})
+}
+
+func parseVariantID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("variantId"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid variant id"})
+		return 0, false
+	}
+	return id, true
+}
+
+func writeStockError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrInvalidVariantType):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Variant type must be MUG or SHIRT"})
+	case errors.Is(err, ErrInvalidAdjustment):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid adjustment: provide a non-zero delta, onHand or lowStockThreshold; quantities cannot be negative"})
+	case errors.Is(err, ErrBelowReserved):
+		c.JSON(http.StatusConflict, gin.H{"detail": "On-hand quantity cannot drop below reserved units"})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": "Variant not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
+
+func toStockResponse(s *Stock) StockResponse {
+	updatedAt := s.UpdatedAt
+	return StockResponse{
+		VariantType:       s.VariantType,
+		VariantID:         s.VariantID,
+		Tracked:           true,
+		OnHand:            s.OnHand,
+		Reserved:          s.Reserved,
+		Available:         s.Available(),
+		LowStockThreshold: s.LowStockThreshold,
+		LowStock:          s.IsLow(),
+		UpdatedAt:         &updatedAt,
+	}
+}
+
+func toMovementResponse(m *Movement) MovementResponse {
+	return MovementResponse{
+		ID:            m.ID,
+		Kind:          string(m.Kind),
+		Quantity:      m.Quantity,
+		OnHandAfter:   m.OnHandAfter,
+		ReservedAfter: m.ReservedAfter,
+		OrderID:       m.OrderID,
+		Reason:        m.Reason,
+		CreatedBy:     m.CreatedBy,
+		CreatedAt:     m.CreatedAt,
+	}
+}
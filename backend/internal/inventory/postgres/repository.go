@@ -0,0 +1,269 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"voenix/backend/internal/inventory"
+)
+
+// Repository provides a Postgres-backed implementation of inventory.Repository.
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+var _ inventory.Repository = (*Repository)(nil)
+
+func (r *Repository) with(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx)
+}
+
+func (r *Repository) WithTransaction(ctx context.Context, fn func(inventory.Repository) error) error {
+	return r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Repository{db: tx})
+	})
+}
+
+func (r *Repository) StockFor(ctx context.Context, variantType string, variantID int) (*inventory.Stock, error) {
+	return r.stockFor(r.with(ctx), variantType, variantID)
+}
+
+func (r *Repository) StockForUpdate(ctx context.Context, variantType string, variantID int) (*inventory.Stock, error) {
+	return r.stockFor(r.with(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), variantType, variantID)
+}
+
+func (r *Repository) stockFor(db *gorm.DB, variantType string, variantID int) (*inventory.Stock, error) {
+	var row StockRow
+	err := db.First(&row, "variant_type = ? AND variant_id = ?", variantType, variantID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	stock := toStock(&row)
+	return &stock, nil
+}
+
+func (r *Repository) StocksFor(ctx context.Context, variantType string, variantIDs []int) ([]inventory.Stock, error) {
+	if len(variantIDs) == 0 {
+		return []inventory.Stock{}, nil
+	}
+	var rows []StockRow
+	if err := r.with(ctx).
+		Where("variant_type = ? AND variant_id IN ?", variantType, variantIDs).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]inventory.Stock, 0, len(rows))
+	for i := range rows {
+		out = append(out, toStock(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) ListStocks(ctx context.Context, lowOnly bool) ([]inventory.Stock, error) {
+	q := r.with(ctx)
+	if lowOnly {
+		q = q.Where("low_stock_threshold > 0 AND on_hand - reserved <= low_stock_threshold")
+	}
+	var rows []StockRow
+	if err := q.Order("variant_type asc, variant_id asc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]inventory.Stock, 0, len(rows))
+	for i := range rows {
+		out = append(out, toStock(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) SaveStock(ctx context.Context, stock *inventory.Stock) error {
+	row := fromStock(stock)
+	// Reserved is left out of the update so a reservation made since the
+	// stock was read is kept.
+	if err := r.with(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "variant_type"}, {Name: "variant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"on_hand", "low_stock_threshold", "updated_at"}),
+	}).Create(&row).Error; err != nil {
+		return err
+	}
+	saved, err := r.StockFor(ctx, stock.VariantType, stock.VariantID)
+	if err != nil {
+		return err
+	}
+	*stock = *saved
+	return nil
+}
+
+func (r *Repository) CreateMovement(ctx context.Context, movement *inventory.Movement) error {
+	row := fromMovement(movement)
+	if err := r.with(ctx).Create(&row).Error; err != nil {
+		return err
+	}
+	*movement = toMovement(&row)
+	return nil
+}
+
+func (r *Repository) ListMovements(ctx context.Context, variantType string, variantID int, limit int) ([]inventory.Movement, error) {
+	var rows []MovementRow
+	if err := r.with(ctx).
+		Where("variant_type = ? AND variant_id = ?", variantType, variantID).
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]inventory.Movement, 0, len(rows))
+	for i := range rows {
+		out = append(out, toMovement(&rows[i]))
+	}
+	return out, nil
+}
+
+// variantKey identifies a variant across the mug and shirt tables.
+type variantKey struct {
+	VariantType string
+	VariantID   int
+}
+
+func (r *Repository) Reserve(ctx context.Context, orderID int64, lines []inventory.Line) error {
+	totals := make(map[variantKey]int)
+	for _, l := range lines {
+		if l.Quantity > 0 {
+			totals[variantKey{l.VariantType, l.VariantID}] += l.Quantity
+		}
+	}
+	return r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, key := range sortedKeys(totals) {
+			qty := totals[key]
+			// The guard on available units makes concurrent reservations of
+			// the last units fail instead of overselling.
+			res := tx.Model(&StockRow{}).
+				Where("variant_type = ? AND variant_id = ? AND on_hand - reserved >= ?", key.VariantType, key.VariantID, qty).
+				Updates(map[string]any{"reserved": gorm.Expr("reserved + ?", qty), "updated_at": time.Now().UTC()})
+			if res.Error != nil {
+				return res.Error
+			}
+			repo := &Repository{db: tx}
+			stock, err := repo.StockFor(ctx, key.VariantType, key.VariantID)
+			if err != nil {
+				return err
+			}
+			if stock == nil {
+				continue
+			}
+			if res.RowsAffected == 0 {
+				return &inventory.InsufficientStockError{
+					VariantType: key.VariantType,
+					VariantID:   key.VariantID,
+					Requested:   qty,
+					Available:   stock.Available(),
+				}
+			}
+			if err := repo.recordOrderMovement(ctx, stock, inventory.MovementReservation, qty, orderID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) Release(ctx context.Context, orderID int64) error {
+	return r.settleReservations(ctx, orderID, inventory.MovementRelease)
+}
+
+func (r *Repository) Fulfill(ctx context.Context, orderID int64) error {
+	return r.settleReservations(ctx, orderID, inventory.MovementFulfillment)
+}
+
+// settleReservations releases or fulfills everything still reserved for an
+// order. Outstanding units are derived from the order's movements, so calling
+// it twice has no further effect.
+func (r *Repository) settleReservations(ctx context.Context, orderID int64, kind inventory.MovementKind) error {
+	return r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		var outstanding []struct {
+			VariantType string
+			VariantID   int
+			Quantity    int
+		}
+		if err := tx.Model(&MovementRow{}).
+			Select("variant_type, variant_id, SUM(quantity) AS quantity").
+			Where("order_id = ? AND kind IN ?", orderID, []string{
+				string(inventory.MovementReservation),
+				string(inventory.MovementRelease),
+				string(inventory.MovementFulfillment),
+			}).
+			Group("variant_type, variant_id").
+			Having("SUM(quantity) > 0").
+			Order("variant_type, variant_id").
+			Scan(&outstanding).Error; err != nil {
+			return err
+		}
+		repo := &Repository{db: tx}
+		for _, o := range outstanding {
+			updates := map[string]any{
+				"reserved":   gorm.Expr("reserved - ?", o.Quantity),
+				"updated_at": time.Now().UTC(),
+			}
+			if kind == inventory.MovementFulfillment {
+				updates["on_hand"] = gorm.Expr("on_hand - ?", o.Quantity)
+			}
+			res := tx.Model(&StockRow{}).
+				Where("variant_type = ? AND variant_id = ? AND reserved >= ?", o.VariantType, o.VariantID, o.Quantity).
+				Updates(updates)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				continue
+			}
+			stock, err := repo.StockFor(ctx, o.VariantType, o.VariantID)
+			if err != nil {
+				return err
+			}
+			if err := repo.recordOrderMovement(ctx, stock, kind, -o.Quantity, orderID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) recordOrderMovement(ctx context.Context, stock *inventory.Stock, kind inventory.MovementKind, qty int, orderID int64) error {
+	id := orderID
+	return r.CreateMovement(ctx, &inventory.Movement{
+		VariantType:   stock.VariantType,
+		VariantID:     stock.VariantID,
+		Kind:          kind,
+		Quantity:      qty,
+		OnHandAfter:   stock.OnHand,
+		ReservedAfter: stock.Reserved,
+		OrderID:       &id,
+	})
+}
+
+// sortedKeys returns the variants in a fixed order so concurrent
+// reservations lock stock rows in the same sequence.
+func sortedKeys(totals map[variantKey]int) []variantKey {
+	keys := make([]variantKey, 0, len(totals))
+	for k := range totals {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].VariantType != keys[j].VariantType {
+			return keys[i].VariantType < keys[j].VariantType
+		}
+		return keys[i].VariantID < keys[j].VariantID
+	})
+	return keys
+}
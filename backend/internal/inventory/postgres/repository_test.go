@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/inventory"
+)
+
+func newTestRepository(t *testing.T) *Repository {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&StockRow{}, &MovementRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	return NewRepository(db)
+}
+
+func TestReserveFulfillAndRelease(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	if err := repo.SaveStock(ctx, &inventory.Stock{VariantType: "MUG", VariantID: 1, OnHand: 5}); err != nil {
+		t.Fatalf("save stock: %v", err)
+	}
+
+	lines := []inventory.Line{
+		{VariantType: "MUG", VariantID: 1, Quantity: 2},
+		{VariantType: "MUG", VariantID: 1, Quantity: 1},
+		{VariantType: "SHIRT", VariantID: 9, Quantity: 4}, // untracked
+	}
+	if err := repo.Reserve(ctx, 100, lines); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	stock, _ := repo.StockFor(ctx, "MUG", 1)
+	if stock.Reserved != 3 || stock.Available() != 2 {
+		t.Fatalf("after reserve: %+v", stock)
+	}
+
+	err := repo.Reserve(ctx, 101, []inventory.Line{{VariantType: "MUG", VariantID: 1, Quantity: 3}})
+	var insufficient *inventory.InsufficientStockError
+	if !errors.As(err, &insufficient) || insufficient.Available != 2 {
+		t.Fatalf("expected insufficient stock, got %v", err)
+	}
+
+	if err := repo.Fulfill(ctx, 100); err != nil {
+		t.Fatalf("fulfill: %v", err)
+	}
+	if err := repo.Release(ctx, 100); err != nil {
+		t.Fatalf("release after fulfill: %v", err)
+	}
+	stock, _ = repo.StockFor(ctx, "MUG", 1)
+	if stock.OnHand != 2 || stock.Reserved != 0 {
+		t.Fatalf("after fulfill: %+v", stock)
+	}
+
+	if err := repo.Reserve(ctx, 102, []inventory.Line{{VariantType: "MUG", VariantID: 1, Quantity: 2}}); err != nil {
+		t.Fatalf("reserve again: %v", err)
+	}
+	if err := repo.Release(ctx, 102); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	stock, _ = repo.StockFor(ctx, "MUG", 1)
+	if stock.OnHand != 2 || stock.Reserved != 0 {
+		t.Fatalf("after release: %+v", stock)
+	}
+
+	movements, err := repo.ListMovements(ctx, "MUG", 1, 10)
+	if err != nil {
+		t.Fatalf("list movements: %v", err)
+	}
+	if len(movements) != 4 {
+		t.Fatalf("movements = %d, want 4", len(movements))
+	}
+}
+
+// reservingRepository reserves units right after Adjust reads the stock, as
+// a concurrent checkout would without the row lock.
+type reservingRepository struct {
+	*Repository
+	orderID int64
+	lines   []inventory.Line
+}
+
+func (r *reservingRepository) WithTransaction(ctx context.Context, fn func(inventory.Repository) error) error {
+	return r.Repository.WithTransaction(ctx, func(tx inventory.Repository) error {
+		return fn(&reservingRepository{Repository: tx.(*Repository), orderID: r.orderID, lines: r.lines})
+	})
+}
+
+func (r *reservingRepository) StockForUpdate(ctx context.Context, variantType string, variantID int) (*inventory.Stock, error) {
+	stock, err := r.Repository.StockForUpdate(ctx, variantType, variantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Reserve(ctx, r.orderID, r.lines); err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+func TestAdjustKeepsReservationMadeAfterRead(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	if err := repo.SaveStock(ctx, &inventory.Stock{VariantType: "MUG", VariantID: 1, OnHand: 5}); err != nil {
+		t.Fatalf("save stock: %v", err)
+	}
+	svc := inventory.NewService(&reservingRepository{
+		Repository: repo,
+		orderID:    100,
+		lines:      []inventory.Line{{VariantType: "MUG", VariantID: 1, Quantity: 2}},
+	}, nil)
+
+	stock, err := svc.Adjust(ctx, "MUG", 1, inventory.Adjustment{Delta: 3})
+	if err != nil {
+		t.Fatalf("adjust: %v", err)
+	}
+	if stock.OnHand != 8 || stock.Reserved != 2 {
+		t.Fatalf("adjusted stock: %+v", stock)
+	}
+	stored, _ := repo.StockFor(ctx, "MUG", 1)
+	if stored.OnHand != 8 || stored.Reserved != 2 {
+		t.Fatalf("stored stock: %+v", stored)
+	}
+}
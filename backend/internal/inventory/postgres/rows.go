@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"time"
+
+	"voenix/backend/internal/inventory"
+)
+
+// StockRow is exported so other repositories can migrate it in tests.
+type StockRow struct {
+	VariantType       string `gorm:"primaryKey;size:20"`
+	VariantID         int    `gorm:"primaryKey;autoIncrement:false"`
+	OnHand            int    `gorm:"not null;default:0"`
+	Reserved          int    `gorm:"not null;default:0"`
+	LowStockThreshold int    `gorm:"not null;default:0"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (StockRow) TableName() string { return "variant_stocks" }
+
+// MovementRow is exported so other repositories can migrate it in tests.
+type MovementRow struct {
+	ID            int     `gorm:"primaryKey"`
+	VariantType   string  `gorm:"size:20;not null"`
+	VariantID     int     `gorm:"not null"`
+	Kind          string  `gorm:"size:20;not null"`
+	Quantity      int     `gorm:"not null"`
+	OnHandAfter   int     `gorm:"not null"`
+	ReservedAfter int     `gorm:"not null"`
+	OrderID       *int64  `gorm:"column:order_id;index"`
+	Reason        *string `gorm:"type:text"`
+	CreatedBy     *int    `gorm:"column:created_by"`
+	CreatedAt     time.Time
+}
+
+func (MovementRow) TableName() string { return "stock_movements" }
+
+func toStock(row *StockRow) inventory.Stock {
+	return inventory.Stock{
+		VariantType:       row.VariantType,
+		VariantID:         row.VariantID,
+		OnHand:            row.OnHand,
+		Reserved:          row.Reserved,
+		LowStockThreshold: row.LowStockThreshold,
+		CreatedAt:         row.CreatedAt,
+		UpdatedAt:         row.UpdatedAt,
+	}
+}
+
+func fromStock(s *inventory.Stock) StockRow {
+	return StockRow{
+		VariantType:       s.VariantType,
+		VariantID:         s.VariantID,
+		OnHand:            s.OnHand,
+		Reserved:          s.Reserved,
+		LowStockThreshold: s.LowStockThreshold,
+		CreatedAt:         s.CreatedAt,
+		UpdatedAt:         s.UpdatedAt,
+	}
+}
+
+func toMovement(row *MovementRow) inventory.Movement {
+	return inventory.Movement{
+		ID:            row.ID,
+		VariantType:   row.VariantType,
+		VariantID:     row.VariantID,
+		Kind:          inventory.MovementKind(row.Kind),
+		Quantity:      row.Quantity,
+		OnHandAfter:   row.OnHandAfter,
+		ReservedAfter: row.ReservedAfter,
+		OrderID:       row.OrderID,
+		Reason:        row.Reason,
+		CreatedBy:     row.CreatedBy,
+		CreatedAt:     row.CreatedAt,
+	}
+}
+
+func fromMovement(m *inventory.Movement) MovementRow {
+	return MovementRow{
+		ID:            m.ID,
+		VariantType:   m.VariantType,
+		VariantID:     m.VariantID,
+		Kind:          string(m.Kind),
+		Quantity:      m.Quantity,
+		OnHandAfter:   m.OnHandAfter,
+		ReservedAfter: m.ReservedAfter,
+		OrderID:       m.OrderID,
+		Reason:        m.Reason,
+		CreatedBy:     m.CreatedBy,
+		CreatedAt:     m.CreatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package inventory
+
+import "context"
+
+// Repository defines persistence for variant stock levels and movements.
+type Repository interface {
+	// StockFor returns the stock of a variant or nil when it is not tracked.
+	StockFor(ctx context.Context, variantType string, variantID int) (*Stock, error)
+	// StockForUpdate is StockFor that also locks the row until the
+	// surrounding transaction ends.
+	StockForUpdate(ctx context.Context, variantType string, variantID int) (*Stock, error)
+	StocksFor(ctx context.Context, variantType string, variantIDs []int) ([]Stock, error)
+	ListStocks(ctx context.Context, lowOnly bool) ([]Stock, error)
+	// SaveStock creates or updates the stock of a variant. Reserved units are
+	// only set when the variant starts being tracked; afterwards they change
+	// through Reserve, Release and Fulfill alone.
+	SaveStock(ctx context.Context, stock *Stock) error
+
+	CreateMovement(ctx context.Context, movement *Movement) error
+	ListMovements(ctx context.Context, variantType string, variantID int, limit int) ([]Movement, error)
+
+	// Reserve holds the quantity of every tracked line for orderID. It fails
+	// with ErrInsufficientStock without reserving anything when a tracked
+	// variant has fewer units available. Untracked variants are skipped.
+	Reserve(ctx context.Context, orderID int64, lines []Line) error
+	// Release returns all units still reserved for orderID.
+	Release(ctx context.Context, orderID int64) error
+	// Fulfill removes all units still reserved for orderID from stock.
+	Fulfill(ctx context.Context, orderID int64) error
+
+	// WithTransaction executes the given operation within a database transaction.
+	// If the operation returns an error, the transaction is rolled back.
+	WithTransaction(ctx context.Context, fn func(Repository) error) error
+}
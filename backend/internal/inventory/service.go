@@ -0,0 +1,198 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"voenix/backend/internal/article"
+)
+
+var (
+	ErrInsufficientStock  = errors.New("insufficient stock")
+	ErrInvalidVariantType = errors.New("variant type must be MUG or SHIRT")
+	ErrInvalidAdjustment  = errors.New("invalid stock adjustment")
+	ErrBelowReserved      = errors.New("on-hand quantity cannot drop below reserved units")
+)
+
+// InsufficientStockError reports the variant that could not be reserved.
+type InsufficientStockError struct {
+	VariantType string
+	VariantID   int
+	Requested   int
+	Available   int
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for %s variant %d: requested %d, available %d",
+		strings.ToLower(e.VariantType), e.VariantID, e.Requested, e.Available)
+}
+
+func (e *InsufficientStockError) Unwrap() error { return ErrInsufficientStock }
+
+// ArticleService exposes the variant lookups used to validate adjustments.
+type ArticleService interface {
+	GetMugVariant(ctx context.Context, id int) (article.MugVariant, error)
+	GetShirtVariant(ctx context.Context, id int) (article.ShirtVariant, error)
+}
+
+type Service struct {
+	repo       Repository
+	articleSvc ArticleService
+}
+
+func NewService(repo Repository, articleSvc ArticleService) *Service {
+	return &Service{repo: repo, articleSvc: articleSvc}
+}
+
+var _ article.AvailabilityLookup = (*Service)(nil)
+
+// NormalizeVariantType upper-cases variantType and checks that it names a
+// mug or shirt variant.
+func NormalizeVariantType(variantType string) (string, error) {
+	switch t := strings.ToUpper(strings.TrimSpace(variantType)); t {
+	case article.ArticleTypeMug, article.ArticleTypeShirt:
+		return t, nil
+	default:
+		return "", ErrInvalidVariantType
+	}
+}
+
+// VariantAvailability implements article.AvailabilityLookup. Only tracked
+// variants are part of the result.
+func (s *Service) VariantAvailability(ctx context.Context, variantType string, variantIDs []int) (map[int]article.VariantAvailability, error) {
+	out := make(map[int]article.VariantAvailability, len(variantIDs))
+	if len(variantIDs) == 0 {
+		return out, nil
+	}
+	stocks, err := s.repo.StocksFor(ctx, variantType, variantIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range stocks {
+		out[stocks[i].VariantID] = availabilityOf(&stocks[i])
+	}
+	return out, nil
+}
+
+func availabilityOf(stock *Stock) article.VariantAvailability {
+	return article.VariantAvailability{
+		Tracked:   true,
+		Available: stock.Available(),
+		InStock:   stock.Available() > 0,
+		LowStock:  stock.IsLow(),
+	}
+}
+
+// GetStock returns the stock of a variant or nil when it is not tracked.
+func (s *Service) GetStock(ctx context.Context, variantType string, variantID int) (*Stock, error) {
+	variantType, err := NormalizeVariantType(variantType)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.StockFor(ctx, variantType, variantID)
+}
+
+// ListStocks returns all tracked variants, optionally only those at or below
+// their low-stock threshold.
+func (s *Service) ListStocks(ctx context.Context, lowOnly bool) ([]Stock, error) {
+	return s.repo.ListStocks(ctx, lowOnly)
+}
+
+// ListMovements returns the most recent movements of a variant, newest first.
+func (s *Service) ListMovements(ctx context.Context, variantType string, variantID int, limit int) ([]Movement, error) {
+	variantType, err := NormalizeVariantType(variantType)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.repo.ListMovements(ctx, variantType, variantID, limit)
+}
+
+// Adjust changes the on-hand quantity and/or low-stock threshold of a variant
+// and records an ADJUSTMENT movement. The first adjustment starts tracking the
+// variant.
+func (s *Service) Adjust(ctx context.Context, variantType string, variantID int, adj Adjustment) (*Stock, error) {
+	variantType, err := NormalizeVariantType(variantType)
+	if err != nil {
+		return nil, err
+	}
+	if adj.OnHand == nil && adj.Delta == 0 && adj.LowStockThreshold == nil {
+		return nil, ErrInvalidAdjustment
+	}
+	if (adj.OnHand != nil && *adj.OnHand < 0) || (adj.LowStockThreshold != nil && *adj.LowStockThreshold < 0) {
+		return nil, ErrInvalidAdjustment
+	}
+	if err := s.ensureVariant(ctx, variantType, variantID); err != nil {
+		return nil, err
+	}
+	var out *Stock
+	err = s.repo.WithTransaction(ctx, func(tx Repository) error {
+		// The lock keeps reservations from changing the stock between the
+		// checks below and the update.
+		stock, err := tx.StockForUpdate(ctx, variantType, variantID)
+		if err != nil {
+			return err
+		}
+		if stock == nil {
+			stock = &Stock{VariantType: variantType, VariantID: variantID}
+		}
+		onHand := stock.OnHand + adj.Delta
+		if adj.OnHand != nil {
+			onHand = *adj.OnHand
+		}
+		if onHand < 0 {
+			return ErrInvalidAdjustment
+		}
+		if onHand < stock.Reserved {
+			return ErrBelowReserved
+		}
+		delta := onHand - stock.OnHand
+		stock.OnHand = onHand
+		if adj.LowStockThreshold != nil {
+			stock.LowStockThreshold = *adj.LowStockThreshold
+		}
+		stock.UpdatedAt = time.Now().UTC()
+		if err := tx.SaveStock(ctx, stock); err != nil {
+			return err
+		}
+		if delta != 0 {
+			if err := tx.CreateMovement(ctx, &Movement{
+				VariantType:   variantType,
+				VariantID:     variantID,
+				Kind:          MovementAdjustment,
+				Quantity:      delta,
+				OnHandAfter:   stock.OnHand,
+				ReservedAfter: stock.Reserved,
+				Reason:        adj.Reason,
+				CreatedBy:     adj.CreatedBy,
+			}); err != nil {
+				return err
+			}
+		}
+		out = stock
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ensureVariant returns gorm.ErrRecordNotFound (from the article service) when
+// the variant does not exist.
+func (s *Service) ensureVariant(ctx context.Context, variantType string, variantID int) error {
+	if s.articleSvc == nil {
+		return nil
+	}
+	if variantType == article.ArticleTypeShirt {
+		_, err := s.articleSvc.GetShirtVariant(ctx, variantID)
+		return err
+	}
+	_, err := s.articleSvc.GetMugVariant(ctx, variantID)
+	return err
+}
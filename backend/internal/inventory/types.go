@@ -0,0 +1,77 @@
+package inventory
+
+import "time"
+
+// MovementKind classifies a change to a variant's stock level.
+type MovementKind string
+
+const (
+	// MovementAdjustment is a manual correction of the on-hand quantity.
+	MovementAdjustment MovementKind = "ADJUSTMENT"
+	// MovementReservation holds units for a newly created order.
+	MovementReservation MovementKind = "RESERVATION"
+	// MovementRelease returns reserved units of a cancelled order.
+	MovementRelease MovementKind = "RELEASE"
+	// MovementFulfillment removes reserved units that were shipped.
+	MovementFulfillment MovementKind = "FULFILLMENT"
+)
+
+// Stock is the tracked stock level of a single mug or shirt variant.
+// Reserved units belong to open orders and cannot be sold again.
+type Stock struct {
+	VariantType       string
+	VariantID         int
+	OnHand            int
+	Reserved          int
+	LowStockThreshold int
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Available returns the number of units that can still be ordered.
+func (s *Stock) Available() int {
+	if s.OnHand <= s.Reserved {
+		return 0
+	}
+	return s.OnHand - s.Reserved
+}
+
+// IsLow reports whether the available quantity reached the low-stock threshold.
+func (s *Stock) IsLow() bool {
+	return s.LowStockThreshold > 0 && s.Available() <= s.LowStockThreshold
+}
+
+// Movement records one change to a stock level. Quantity is the signed change
+// to OnHand for adjustments and fulfillments and to Reserved for reservations
+// and releases.
+type Movement struct {
+	ID            int
+	VariantType   string
+	VariantID     int
+	Kind          MovementKind
+	Quantity      int
+	OnHandAfter   int
+	ReservedAfter int
+	OrderID       *int64
+	Reason        *string
+	CreatedBy     *int
+	CreatedAt     time.Time
+}
+
+// Line is a requested quantity of one variant, e.g. an order item.
+type Line struct {
+	VariantType string
+	VariantID   int
+	Quantity    int
+}
+
+// Adjustment changes the stock of a variant. Delta is added to the on-hand
+// quantity; OnHand replaces it instead when set. A nil LowStockThreshold
+// keeps the current threshold.
+type Adjustment struct {
+	Delta             int
+	OnHand            *int
+	LowStockThreshold *int
+	Reason            *string
+	CreatedBy         *int
+}
@@ -12,4 +12,6 @@ type ArticleService interface {
 	GetArticle(ctx context.Context, id int) (article.Article, error)
 	GetMugVariant(ctx context.Context, id int) (article.MugVariant, error)
 	GetMugDetails(ctx context.Context, articleID int) (*article.MugDetails, error)
+	GetShirtVariant(ctx context.Context, id int) (article.ShirtVariant, error)
+	GetShirtDetails(ctx context.Context, articleID int) (*article.ShirtDetails, error)
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,6 +16,8 @@ import (
 
 	"voenix/backend/internal/article"
 	"voenix/backend/internal/auth"
+	"voenix/backend/internal/cart"
+	"voenix/backend/internal/inventory"
 	"voenix/backend/internal/pdf"
 )
 
@@ -48,17 +51,20 @@ type CreateOrderRequest struct {
 }
 
 type OrderItemResponse struct {
-	ID                     int64                   `json:"id"`
-	Article                article.ArticleResponse `json:"article"`
-	Variant                *article.MugVariant     `json:"variant"`
-	Quantity               int                     `json:"quantity"`
-	PricePerItem           int64                   `json:"pricePerItem"`
-	TotalPrice             int64                   `json:"totalPrice"`
-	GeneratedImageID       *int                    `json:"generatedImageId,omitempty"`
-	GeneratedImageFilename *string                 `json:"generatedImageFilename,omitempty"`
-	PromptID               *int                    `json:"promptId,omitempty"`
-	CustomData             map[string]any          `json:"customData"`
-	CreatedAt              time.Time               `json:"createdAt"`
+	ID                     int64                      `json:"id"`
+	Article                article.ArticleResponse    `json:"article"`
+	VariantType            string                     `json:"variantType"`
+	Variant                *article.MugVariant        `json:"variant"`
+	ShirtVariant           *cart.ShirtVariantResponse `json:"shirtVariant,omitempty"`
+	Quantity               int                        `json:"quantity"`
+	PricePerItem           int64                      `json:"pricePerItem"`
+	TotalPrice             int64                      `json:"totalPrice"`
+	PriceTierMinQuantity   int                        `json:"priceTierMinQuantity"`
+	GeneratedImageID       *int                       `json:"generatedImageId,omitempty"`
+	GeneratedImageFilename *string                    `json:"generatedImageFilename,omitempty"`
+	PromptID               *int                       `json:"promptId,omitempty"`
+	CustomData             map[string]any             `json:"customData"`
+	CreatedAt              time.Time                  `json:"createdAt"`
 }
 
 type OrderResponse struct {
@@ -74,6 +80,8 @@ type OrderResponse struct {
 	TaxAmount       int64               `json:"taxAmount"`
 	ShippingAmount  int64               `json:"shippingAmount"`
 	TotalAmount     int64               `json:"totalAmount"`
+	DiscountAmount  int64               `json:"discountAmount"`
+	PromotionCode   *string             `json:"promotionCode,omitempty"`
 	Status          string              `json:"status"`
 	CartID          int                 `json:"cartId"`
 	Notes           *string             `json:"notes,omitempty"`
@@ -106,6 +114,10 @@ func RegisterRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
 	grp.GET("/orders/:orderId", getOrderHandler(svc))
 	grp.GET("/orders/:orderId/pdf", downloadOrderPDFHandler(svc))
 	grp.POST("/orders/:orderId/pdf/send", sendOrderPDFToFTP(svc))
+
+	admin := r.Group("/api/admin/orders")
+	admin.Use(auth.RequireAdmin(db))
+	admin.PUT("/:orderId/status", updateOrderStatusHandler(svc))
 }
 
 func createOrderHandler(svc *Service) gin.HandlerFunc {
@@ -121,6 +133,10 @@ func createOrderHandler(svc *Service) gin.HandlerFunc {
 		}
 		ord, err := svc.CreateOrderFromCart(c.Request.Context(), u.ID, req)
 		if err != nil {
+			if errors.Is(err, inventory.ErrInsufficientStock) || errors.Is(err, cart.ErrPriceChangesPending) {
+				c.JSON(http.StatusConflict, gin.H{"detail": err.Error()})
+				return
+			}
 			c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
 			return
 		}
@@ -133,6 +149,44 @@ func createOrderHandler(svc *Service) gin.HandlerFunc {
 	}
 }
 
+type updateOrderStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+// updateOrderStatusHandler lets admins move an order through its lifecycle.
+func updateOrderStatusHandler(svc *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orderID, parseErr := strconv.ParseInt(c.Param("orderId"), 10, 64)
+		if parseErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid order id"})
+			return
+		}
+		var req updateOrderStatusRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid request"})
+			return
+		}
+		ord, err := svc.UpdateOrderStatus(c.Request.Context(), orderID, req.Status)
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"detail": "Order not found"})
+			case errors.Is(err, ErrInvalidStatusTransition):
+				c.JSON(http.StatusConflict, gin.H{"detail": "Order cannot move to status " + strings.ToUpper(req.Status)})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to update order status"})
+			}
+			return
+		}
+		resp, err := svc.BuildOrderResponse(c.Request.Context(), *ord, BaseURL())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to assemble order"})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
 func listOrdersHandler(svc *Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		u, ok := requireUser(c)
@@ -80,6 +80,27 @@ func (f *fakeRepository) OrderByIDForUser(_ context.Context, userID int, orderID
 	return &clone, nil
 }
 
+func (f *fakeRepository) OrderByID(_ context.Context, orderID int64) (*Order, error) {
+	ord, ok := f.orders[orderID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	clone := ord
+	clone.Items = append([]OrderItem(nil), ord.Items...)
+	return &clone, nil
+}
+
+func (f *fakeRepository) UpdateOrderStatus(ctx context.Context, orderID int64, from, to string) (*Order, error) {
+	ord, ok := f.orders[orderID]
+	if !ok || ord.Status != from {
+		return nil, ErrInvalidStatusTransition
+	}
+	ord.Status = to
+	ord.UpdatedAt = time.Now()
+	f.orders[orderID] = ord
+	return f.OrderByID(ctx, orderID)
+}
+
 func (f *fakeRepository) ListOrdersForUser(_ context.Context, userID int, page, size int) (OrderPage, error) {
 	orders := make([]Order, 0)
 	for _, ord := range f.orders {
@@ -151,18 +172,22 @@ func padInt(i int) string {
 }
 
 type fakeArticleService struct {
-	summaries map[int]article.ArticleResponse
-	articles  map[int]article.Article
-	variants  map[int]article.MugVariant
-	details   map[int]article.MugDetails
+	summaries     map[int]article.ArticleResponse
+	articles      map[int]article.Article
+	variants      map[int]article.MugVariant
+	details       map[int]article.MugDetails
+	shirtVariants map[int]article.ShirtVariant
+	shirtDetails  map[int]article.ShirtDetails
 }
 
 func newFakeArticleService() *fakeArticleService {
 	return &fakeArticleService{
-		summaries: make(map[int]article.ArticleResponse),
-		articles:  make(map[int]article.Article),
-		variants:  make(map[int]article.MugVariant),
-		details:   make(map[int]article.MugDetails),
+		summaries:     make(map[int]article.ArticleResponse),
+		articles:      make(map[int]article.Article),
+		variants:      make(map[int]article.MugVariant),
+		details:       make(map[int]article.MugDetails),
+		shirtVariants: make(map[int]article.ShirtVariant),
+		shirtDetails:  make(map[int]article.ShirtDetails),
 	}
 }
 
@@ -194,3 +219,18 @@ func (s *fakeArticleService) GetMugDetails(_ context.Context, articleID int) (*a
 	}
 	return nil, nil
 }
+
+func (s *fakeArticleService) GetShirtVariant(_ context.Context, id int) (article.ShirtVariant, error) {
+	if v, ok := s.shirtVariants[id]; ok {
+		return v, nil
+	}
+	return article.ShirtVariant{}, errors.New("not found")
+}
+
+func (s *fakeArticleService) GetShirtDetails(_ context.Context, articleID int) (*article.ShirtDetails, error) {
+	if sd, ok := s.shirtDetails[articleID]; ok {
+		clone := sd
+		return &clone, nil
+	}
+	return nil, nil
+}
@@ -20,17 +20,24 @@ func buildOrderPdfData(ctx context.Context, articleSvc ArticleService, repo Repo
 		Items:       make([]pdf.OrderItemPdfData, 0, len(o.Items)),
 	}
 
-	// Preload caches for articles/variants/mug details to avoid N+1 as much as possible
+	// Preload caches for articles/variants/details to avoid N+1 as much as possible
 	artIDs := make(map[int]struct{})
-	varIDs := make(map[int]struct{})
+	mugVarIDs := make(map[int]struct{})
+	shirtVarIDs := make(map[int]struct{})
 	for i := range o.Items {
 		artIDs[o.Items[i].ArticleID] = struct{}{}
-		varIDs[o.Items[i].VariantID] = struct{}{}
+		if itemVariantType(o.Items[i]) == article.ArticleTypeShirt {
+			shirtVarIDs[o.Items[i].VariantID] = struct{}{}
+		} else {
+			mugVarIDs[o.Items[i].VariantID] = struct{}{}
+		}
 	}
 
 	articles := make(map[int]article.Article)
 	mugDetails := make(map[int]article.MugDetails)
+	shirtDetails := make(map[int]article.ShirtDetails)
 	variants := make(map[int]article.MugVariant)
+	shirtVariants := make(map[int]article.ShirtVariant)
 
 	for id := range artIDs {
 		a, err := articleSvc.GetArticle(ctx, id)
@@ -38,6 +45,16 @@ func buildOrderPdfData(ctx context.Context, articleSvc ArticleService, repo Repo
 			return pdf.OrderPdfData{}, err
 		}
 		articles[id] = a
+		if a.ArticleType == article.ArticleTypeShirt {
+			sd, err := articleSvc.GetShirtDetails(ctx, id)
+			if err != nil {
+				return pdf.OrderPdfData{}, err
+			}
+			if sd != nil {
+				shirtDetails[id] = *sd
+			}
+			continue
+		}
 		md, err := articleSvc.GetMugDetails(ctx, id)
 		if err != nil {
 			return pdf.OrderPdfData{}, err
@@ -46,13 +63,20 @@ func buildOrderPdfData(ctx context.Context, articleSvc ArticleService, repo Repo
 			mugDetails[id] = *md
 		}
 	}
-	for id := range varIDs {
+	for id := range mugVarIDs {
 		v, err := articleSvc.GetMugVariant(ctx, id)
 		if err != nil {
 			return pdf.OrderPdfData{}, err
 		}
 		variants[id] = v
 	}
+	for id := range shirtVarIDs {
+		v, err := articleSvc.GetShirtVariant(ctx, id)
+		if err != nil {
+			return pdf.OrderPdfData{}, err
+		}
+		shirtVariants[id] = v
+	}
 
 	generatedIDs := make([]int, 0, len(o.Items))
 	for i := range o.Items {
@@ -68,12 +92,13 @@ func buildOrderPdfData(ctx context.Context, articleSvc ArticleService, repo Repo
 	for i := range o.Items {
 		it := o.Items[i]
 		a := articles[it.ArticleID]
-		v := variants[it.VariantID]
+		isShirt := itemVariantType(it) == article.ArticleTypeShirt
 		md, hasMD := mugDetails[it.ArticleID]
+		sd, hasSD := shirtDetails[it.ArticleID]
 		croppedAreaPixels := parseOrderItemCroppedArea(it.CustomData)
 
 		var mdPtr *pdf.MugDetailsPdfData
-		if hasMD {
+		if hasMD && !isShirt {
 			mdPtr = &pdf.MugDetailsPdfData{
 				PrintTemplateWidthMM:         md.PrintTemplateWidthMm,
 				PrintTemplateHeightMM:        md.PrintTemplateHeightMm,
@@ -83,8 +108,22 @@ func buildOrderPdfData(ctx context.Context, articleSvc ArticleService, repo Repo
 			}
 		}
 
+		var sdPtr *pdf.ShirtDetailsPdfData
+		if hasSD && isShirt {
+			sdPtr = &pdf.ShirtDetailsPdfData{
+				PrintAreaWidthMM:  sd.PrintAreaWidthMm,
+				PrintAreaHeightMM: sd.PrintAreaHeightMm,
+				PrintAreaPosition: sd.PrintAreaPosition,
+			}
+		}
+
 		var variantName *string
-		if v.Name != "" {
+		if isShirt {
+			sv := shirtVariants[it.VariantID]
+			if name := sv.DisplayName(); name != "" {
+				variantName = &name
+			}
+		} else if v := variants[it.VariantID]; v.Name != "" {
 			variantName = &v.Name
 		}
 
@@ -102,6 +141,7 @@ func buildOrderPdfData(ctx context.Context, articleSvc ArticleService, repo Repo
 			Article: pdf.ArticlePdfData{
 				ID:                    it.ArticleID,
 				MugDetails:            mdPtr,
+				ShirtDetails:          sdPtr,
 				SupplierArticleName:   a.SupplierArticleName,
 				SupplierArticleNumber: a.SupplierArticleNumber,
 			},
@@ -3,12 +3,18 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
+	"voenix/backend/internal/article"
 	"voenix/backend/internal/cart"
 	cartpg "voenix/backend/internal/cart/postgres"
+	"voenix/backend/internal/inventory"
+	inventorypg "voenix/backend/internal/inventory/postgres"
 	"voenix/backend/internal/order"
+	"voenix/backend/internal/promotion"
+	promotionpg "voenix/backend/internal/promotion/postgres"
 )
 
 type Repository struct {
@@ -73,7 +79,18 @@ func (r *Repository) CreateOrder(ctx context.Context, ord *order.Order) error {
 			Update("status", string(cart.CartStatusConverted)).Error; err != nil {
 			return err
 		}
-		return nil
+		if err := inventorypg.NewRepository(tx).Reserve(ctx, orderRow.ID, stockLines(ord.Items)); err != nil {
+			return err
+		}
+		if ord.PromotionID == nil {
+			return nil
+		}
+		return promotionpg.NewRepository(tx).Redeem(ctx, &promotion.Redemption{
+			PromotionID: *ord.PromotionID,
+			UserID:      ord.UserID,
+			OrderID:     orderRow.ID,
+			Amount:      ord.DiscountAmount,
+		})
 	})
 	if err != nil {
 		return err
@@ -89,6 +106,50 @@ func (r *Repository) CreateOrder(ctx context.Context, ord *order.Order) error {
 	return nil
 }
 
+func (r *Repository) OrderByID(ctx context.Context, orderID int64) (*order.Order, error) {
+	var row OrderRow
+	err := r.db.WithContext(ctx).
+		Preload("Items").
+		First(&row, "id = ?", orderID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, order.ErrNotFound
+		}
+		return nil, err
+	}
+	domain := row.toDomain()
+	return &domain, nil
+}
+
+func (r *Repository) UpdateOrderStatus(ctx context.Context, orderID int64, from, to string) (*order.Order, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&OrderRow{}).
+			Where("id = ? AND status = ?", orderID, from).
+			Updates(map[string]any{"status": to, "updated_at": time.Now().UTC()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return order.ErrInvalidStatusTransition
+		}
+		stock := inventorypg.NewRepository(tx)
+		switch to {
+		case order.StatusShipped, order.StatusDelivered:
+			return stock.Fulfill(ctx, orderID)
+		case order.StatusCancelled:
+			if err := stock.Release(ctx, orderID); err != nil {
+				return err
+			}
+			return promotionpg.NewRepository(tx).Release(ctx, orderID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.OrderByID(ctx, orderID)
+}
+
 func (r *Repository) OrderByIDForUser(ctx context.Context, userID int, orderID int64) (*order.Order, error) {
 	var row OrderRow
 	err := r.db.WithContext(ctx).
@@ -165,6 +226,20 @@ func (r *Repository) FetchGeneratedImageFilenames(ctx context.Context, ids []int
 	return result, nil
 }
 
+// stockLines converts order items into inventory lines; legacy items without
+// a variant type are mugs.
+func stockLines(items []order.OrderItem) []inventory.Line {
+	lines := make([]inventory.Line, 0, len(items))
+	for _, it := range items {
+		variantType := it.VariantType
+		if variantType == "" {
+			variantType = article.ArticleTypeMug
+		}
+		lines = append(lines, inventory.Line{VariantType: variantType, VariantID: it.VariantID, Quantity: it.Quantity})
+	}
+	return lines
+}
+
 func orderCartItemsOrder(tx *gorm.DB) *gorm.DB {
 	return tx.Order("position asc, created_at asc")
 }
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"gorm.io/driver/sqlite"
@@ -9,7 +10,10 @@ import (
 
 	"voenix/backend/internal/cart"
 	cartpg "voenix/backend/internal/cart/postgres"
+	inventorypg "voenix/backend/internal/inventory/postgres"
 	"voenix/backend/internal/order"
+	"voenix/backend/internal/promotion"
+	promotionpg "voenix/backend/internal/promotion/postgres"
 )
 
 func TestCreateOrderPersistsItemsWithOrderID(t *testing.T) {
@@ -18,17 +22,18 @@ func TestCreateOrderPersistsItemsWithOrderID(t *testing.T) {
 		t.Fatalf("open database: %v", databaseError)
 	}
 
-	if migrateError := testDatabase.AutoMigrate(&OrderRow{}, &OrderItemRow{}, &cartpg.CartRow{}); migrateError != nil {
+	if migrateError := testDatabase.AutoMigrate(&OrderRow{}, &OrderItemRow{}, &cartpg.CartRow{}, &inventorypg.StockRow{}, &inventorypg.MovementRow{}); migrateError != nil {
 		t.Fatalf("auto migrate: %v", migrateError)
 	}
 
-	initialCart := cartpg.CartRow{ID: 1, UserID: 77, Status: string(cart.CartStatusActive)}
+	userID := 77
+	initialCart := cartpg.CartRow{ID: 1, UserID: &userID, Status: string(cart.CartStatusActive)}
 	if insertCartError := testDatabase.Create(&initialCart).Error; insertCartError != nil {
 		t.Fatalf("insert cart: %v", insertCartError)
 	}
 
 	domainOrder := order.Order{
-		UserID:          initialCart.UserID,
+		UserID:          userID,
 		CustomerEmail:   "buyer@example.com",
 		CustomerFirst:   "Buyer",
 		CustomerLast:    "Person",
@@ -87,3 +92,57 @@ func TestCreateOrderPersistsItemsWithOrderID(t *testing.T) {
 		t.Fatalf("expected domain item order id %d, got %d", domainOrder.ID, domainOrder.Items[0].OrderID)
 	}
 }
+
+func TestCreateOrderRedeemsPromotionUntilCancelled(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&OrderRow{}, &OrderItemRow{}, &cartpg.CartRow{}, &inventorypg.StockRow{}, &inventorypg.MovementRow{},
+		&promotionpg.PromotionRow{}, &promotionpg.PromotionTargetRow{}, &promotionpg.PromotionRedemptionRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	maxUses := 1
+	promo := promotionpg.PromotionRow{Code: "ONCE", Kind: string(promotion.KindFixedAmount), Value: 200, MaxUses: &maxUses, Active: true}
+	if err := db.Create(&promo).Error; err != nil {
+		t.Fatalf("insert promotion: %v", err)
+	}
+	userID := 78
+	repo := NewRepository(db)
+	ctx := context.Background()
+	newOrder := func(cartID int) *order.Order {
+		if err := db.Create(&cartpg.CartRow{ID: cartID, UserID: &userID, Status: string(cart.CartStatusActive)}).Error; err != nil {
+			t.Fatalf("insert cart: %v", err)
+		}
+		return &order.Order{
+			UserID: userID, CustomerEmail: "buyer@example.com", CustomerFirst: "Buyer", CustomerLast: "Person",
+			ShippingStreet1: "1 Street", ShippingCity: "Town", ShippingState: "TS", ShippingPostal: "1", ShippingCountry: "USA",
+			Subtotal: 1000, TaxAmount: 64, ShippingAmount: 499, DiscountAmount: 200, TotalAmount: 1363,
+			Status: order.StatusPending, CartID: cartID, PromotionID: &promo.ID, PromotionCode: &promo.Code,
+			Items: []order.OrderItem{{ArticleID: 1, VariantID: 1, Quantity: 1, PricePerItem: 1000, TotalPrice: 1000, CustomData: "{}"}},
+		}
+	}
+
+	first := newOrder(1)
+	if err := repo.CreateOrder(ctx, first); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if first.DiscountAmount != 200 || first.PromotionCode == nil || *first.PromotionCode != "ONCE" {
+		t.Fatalf("expected the discount to be stored, got %+v", first)
+	}
+	if err := repo.CreateOrder(ctx, newOrder(2)); !errors.Is(err, promotion.ErrUsageLimitReached) {
+		t.Fatalf("expected the used up code to fail the order, got %v", err)
+	}
+	var orders int64
+	db.Model(&OrderRow{}).Count(&orders)
+	if orders != 1 {
+		t.Fatalf("the refused order should be rolled back, found %d orders", orders)
+	}
+
+	if _, err := repo.UpdateOrderStatus(ctx, first.ID, order.StatusPending, order.StatusCancelled); err != nil {
+		t.Fatalf("cancel order: %v", err)
+	}
+	if err := repo.CreateOrder(ctx, newOrder(3)); err != nil {
+		t.Fatalf("cancelling should release the code: %v", err)
+	}
+}
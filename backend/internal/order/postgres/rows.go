@@ -30,6 +30,9 @@ type OrderRow struct {
 	TaxAmount       int64          `gorm:"column:tax_amount;not null"`
 	ShippingAmount  int64          `gorm:"column:shipping_amount;not null"`
 	TotalAmount     int64          `gorm:"column:total_amount;not null"`
+	DiscountAmount  int64          `gorm:"column:discount_amount;not null;default:0"`
+	PromotionID     *int           `gorm:"column:promotion_id"`
+	PromotionCode   *string        `gorm:"column:promotion_code;size:64"`
 	Status          string         `gorm:"column:status;size:20;not null"`
 	CartID          int            `gorm:"column:cart_id;not null"`
 	Notes           *string        `gorm:"column:notes;type:text"`
@@ -41,17 +44,19 @@ type OrderRow struct {
 func (OrderRow) TableName() string { return "orders" }
 
 type OrderItemRow struct {
-	ID               int64     `gorm:"primaryKey;column:id"`
-	OrderID          int64     `gorm:"column:order_id;not null;index"`
-	ArticleID        int       `gorm:"column:article_id;not null"`
-	VariantID        int       `gorm:"column:variant_id;not null"`
-	Quantity         int       `gorm:"column:quantity;not null"`
-	PricePerItem     int64     `gorm:"column:price_per_item;not null"`
-	TotalPrice       int64     `gorm:"column:total_price;not null"`
-	GeneratedImageID *int      `gorm:"column:generated_image_id"`
-	PromptID         *int      `gorm:"column:prompt_id"`
-	CustomData       string    `gorm:"column:custom_data;type:text;not null"`
-	CreatedAt        time.Time `gorm:"column:created_at;autoCreateTime"`
+	ID                   int64     `gorm:"primaryKey;column:id"`
+	OrderID              int64     `gorm:"column:order_id;not null;index"`
+	ArticleID            int       `gorm:"column:article_id;not null"`
+	VariantID            int       `gorm:"column:variant_id;not null"`
+	VariantType          string    `gorm:"column:variant_type;size:20;not null;default:MUG"`
+	Quantity             int       `gorm:"column:quantity;not null"`
+	PricePerItem         int64     `gorm:"column:price_per_item;not null"`
+	TotalPrice           int64     `gorm:"column:total_price;not null"`
+	PriceTierMinQuantity int       `gorm:"column:price_tier_min_quantity;not null;default:1"`
+	GeneratedImageID     *int      `gorm:"column:generated_image_id"`
+	PromptID             *int      `gorm:"column:prompt_id"`
+	CustomData           string    `gorm:"column:custom_data;type:text;not null"`
+	CreatedAt            time.Time `gorm:"column:created_at;autoCreateTime"`
 }
 
 func (OrderItemRow) TableName() string { return "order_items" }
@@ -85,6 +90,9 @@ func orderRowFromDomain(o order.Order) OrderRow {
 		TaxAmount:       o.TaxAmount,
 		ShippingAmount:  o.ShippingAmount,
 		TotalAmount:     o.TotalAmount,
+		DiscountAmount:  o.DiscountAmount,
+		PromotionID:     o.PromotionID,
+		PromotionCode:   o.PromotionCode,
 		Status:          o.Status,
 		CartID:          o.CartID,
 		Notes:           o.Notes,
@@ -123,6 +131,9 @@ func (r OrderRow) toDomain() order.Order {
 		TaxAmount:       r.TaxAmount,
 		ShippingAmount:  r.ShippingAmount,
 		TotalAmount:     r.TotalAmount,
+		DiscountAmount:  r.DiscountAmount,
+		PromotionID:     r.PromotionID,
+		PromotionCode:   r.PromotionCode,
 		Status:          r.Status,
 		CartID:          r.CartID,
 		Notes:           r.Notes,
@@ -134,32 +145,36 @@ func (r OrderRow) toDomain() order.Order {
 
 func orderItemRowFromDomain(i order.OrderItem) OrderItemRow {
 	return OrderItemRow{
-		ID:               i.ID,
-		OrderID:          i.OrderID,
-		ArticleID:        i.ArticleID,
-		VariantID:        i.VariantID,
-		Quantity:         i.Quantity,
-		PricePerItem:     i.PricePerItem,
-		TotalPrice:       i.TotalPrice,
-		GeneratedImageID: i.GeneratedImageID,
-		PromptID:         i.PromptID,
-		CustomData:       i.CustomData,
-		CreatedAt:        i.CreatedAt,
+		ID:                   i.ID,
+		OrderID:              i.OrderID,
+		ArticleID:            i.ArticleID,
+		VariantID:            i.VariantID,
+		VariantType:          i.VariantType,
+		Quantity:             i.Quantity,
+		PricePerItem:         i.PricePerItem,
+		TotalPrice:           i.TotalPrice,
+		PriceTierMinQuantity: i.PriceTierMinQuantity,
+		GeneratedImageID:     i.GeneratedImageID,
+		PromptID:             i.PromptID,
+		CustomData:           i.CustomData,
+		CreatedAt:            i.CreatedAt,
 	}
 }
 
 func (r OrderItemRow) toDomain() order.OrderItem {
 	return order.OrderItem{
-		ID:               r.ID,
-		OrderID:          r.OrderID,
-		ArticleID:        r.ArticleID,
-		VariantID:        r.VariantID,
-		Quantity:         r.Quantity,
-		PricePerItem:     r.PricePerItem,
-		TotalPrice:       r.TotalPrice,
-		GeneratedImageID: r.GeneratedImageID,
-		PromptID:         r.PromptID,
-		CustomData:       r.CustomData,
-		CreatedAt:        r.CreatedAt,
+		ID:                   r.ID,
+		OrderID:              r.OrderID,
+		ArticleID:            r.ArticleID,
+		VariantID:            r.VariantID,
+		VariantType:          r.VariantType,
+		Quantity:             r.Quantity,
+		PricePerItem:         r.PricePerItem,
+		TotalPrice:           r.TotalPrice,
+		PriceTierMinQuantity: r.PriceTierMinQuantity,
+		GeneratedImageID:     r.GeneratedImageID,
+		PromptID:             r.PromptID,
+		CustomData:           r.CustomData,
+		CreatedAt:            r.CreatedAt,
 	}
 }
@@ -9,8 +9,17 @@ import (
 type Repository interface {
 	ActiveCart(ctx context.Context, userID int) (*cart.Cart, error)
 	OrderExistsForCart(ctx context.Context, cartID int) (bool, error)
+	// CreateOrder stores the order, converts its cart, reserves stock for its
+	// items and redeems its promotion in one transaction.
 	CreateOrder(ctx context.Context, ord *Order) error
+	OrderByID(ctx context.Context, orderID int64) (*Order, error)
 	OrderByIDForUser(ctx context.Context, userID int, orderID int64) (*Order, error)
 	ListOrdersForUser(ctx context.Context, userID int, page, size int) (OrderPage, error)
+	// UpdateOrderStatus moves an order from one status to another and settles
+	// its stock reservations: shipping fulfills them, cancelling releases
+	// them. Cancelling also releases the order's promotion redemption. It
+	// returns ErrInvalidStatusTransition when the order is no longer
+	// in status from.
+	UpdateOrderStatus(ctx context.Context, orderID int64, from, to string) (*Order, error)
 	FetchGeneratedImageFilenames(ctx context.Context, ids []int) (map[int]string, error)
 }
@@ -6,10 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"voenix/backend/internal/article"
+	"voenix/backend/internal/cart"
 	"voenix/backend/internal/pdf"
+	"voenix/backend/internal/promotion"
 )
 
 const (
@@ -21,13 +24,30 @@ const (
 type Service struct {
 	repo       Repository
 	articleSvc ArticleService
+	promotions cart.PromotionService
+	prices     PriceChecker
+}
+
+// PriceChecker fails with cart.ErrPriceChangesPending while the cart has
+// price changes the customer has not acknowledged.
+type PriceChecker interface {
+	CheckPrices(ctx context.Context, c *cart.Cart) error
 }
 
 func NewService(repo Repository, articleSvc ArticleService) *Service {
 	return &Service{repo: repo, articleSvc: articleSvc}
 }
 
-// CreateOrderFromCart creates an order from the user's active cart.
+// SetPromotions lets orders redeem the promotion code of their cart.
+func (s *Service) SetPromotions(p cart.PromotionService) { s.promotions = p }
+
+// SetPriceChecker makes checkout refuse carts with unacknowledged price
+// changes.
+func (s *Service) SetPriceChecker(p PriceChecker) { s.prices = p }
+
+// CreateOrderFromCart creates an order from the user's active cart. The
+// discount of the cart's promotion code is taken off before tax; a code
+// that no longer applies fails the order so the customer can remove it.
 func (s *Service) CreateOrderFromCart(ctx context.Context, userID int, req CreateOrderRequest) (*Order, error) {
 	c, err := s.repo.ActiveCart(ctx, userID)
 	if err != nil {
@@ -36,6 +56,11 @@ func (s *Service) CreateOrderFromCart(ctx context.Context, userID int, req Creat
 	if c == nil || len(c.Items) == 0 {
 		return nil, fmt.Errorf("no active cart found or cart is empty")
 	}
+	if s.prices != nil {
+		if err := s.prices.CheckPrices(ctx, c); err != nil {
+			return nil, err
+		}
+	}
 	exists, err := s.repo.OrderExistsForCart(ctx, c.ID)
 	if err != nil {
 		return nil, err
@@ -48,12 +73,18 @@ func (s *Service) CreateOrderFromCart(ctx context.Context, userID int, req Creat
 	for _, it := range c.Items {
 		subtotal += int64(it.PriceAtTime * it.Quantity)
 	}
-	tax := int64(float64(subtotal) * taxRate)
 	shipping := int64(0)
 	if len(c.Items) > 0 {
 		shipping = shippingFlatCents
 	}
-	total := subtotal + tax + shipping
+	discount, err := s.quoteDiscount(ctx, c, userID, shipping)
+	if err != nil {
+		return nil, err
+	}
+	itemDiscount := min(int64(discount.Items), subtotal)
+	shippingDiscount := min(int64(discount.Shipping), shipping)
+	tax := int64(float64(subtotal-itemDiscount) * taxRate)
+	total := subtotal + tax + shipping - itemDiscount - shippingDiscount
 
 	ship := req.ShippingAddress
 	bill := req.BillingAddress
@@ -83,6 +114,7 @@ func (s *Service) CreateOrderFromCart(ctx context.Context, userID int, req Creat
 		TaxAmount:       tax,
 		ShippingAmount:  shipping,
 		TotalAmount:     total,
+		DiscountAmount:  itemDiscount + shippingDiscount,
 		Status:          StatusPending,
 		CartID:          c.ID,
 		Notes:           req.Notes,
@@ -92,18 +124,24 @@ func (s *Service) CreateOrderFromCart(ctx context.Context, userID int, req Creat
 	for _, ci := range c.Items {
 		cd := canonicalizeJSON(ci.CustomData)
 		item := OrderItem{
-			ArticleID:        ci.ArticleID,
-			VariantID:        ci.VariantID,
-			Quantity:         ci.Quantity,
-			PricePerItem:     int64(ci.PriceAtTime),
-			TotalPrice:       int64(ci.PriceAtTime * ci.Quantity),
-			GeneratedImageID: ci.GeneratedImageID,
-			PromptID:         ci.PromptID,
-			CustomData:       cd,
+			ArticleID:            ci.ArticleID,
+			VariantID:            ci.VariantID,
+			VariantType:          ci.VariantType,
+			Quantity:             ci.Quantity,
+			PricePerItem:         int64(ci.PriceAtTime),
+			TotalPrice:           int64(ci.PriceAtTime * ci.Quantity),
+			PriceTierMinQuantity: max(ci.PriceTierMinQuantity, 1),
+			GeneratedImageID:     ci.GeneratedImageID,
+			PromptID:             ci.PromptID,
+			CustomData:           cd,
 		}
 		items = append(items, item)
 	}
 	ord.Items = items
+	if discount.Promotion.ID != 0 {
+		ord.PromotionID = &discount.Promotion.ID
+		ord.PromotionCode = &discount.Promotion.Code
+	}
 
 	if err := s.repo.CreateOrder(ctx, ord); err != nil {
 		return nil, err
@@ -111,6 +149,23 @@ func (s *Service) CreateOrderFromCart(ctx context.Context, userID int, req Creat
 	return ord, nil
 }
 
+// quoteDiscount returns the discount of the cart's promotion code on the
+// order's items, which are charged without their prompt price.
+func (s *Service) quoteDiscount(ctx context.Context, c *cart.Cart, userID int, shipping int64) (promotion.Discount, error) {
+	if c.PromotionCode == "" || s.promotions == nil {
+		return promotion.Discount{}, nil
+	}
+	lines := cart.PromotionLines(c.Items)
+	for i := range lines {
+		lines[i].UnitPrice = c.Items[i].PriceAtTime
+	}
+	d, err := s.promotions.Quote(ctx, c.PromotionCode, userID, lines, int(shipping))
+	if err != nil {
+		return promotion.Discount{}, err
+	}
+	return *d, nil
+}
+
 // ListOrders returns paginated orders for a user.
 func (s *Service) ListOrders(ctx context.Context, userID int, page, size int) (OrderPage, error) {
 	return s.repo.ListOrdersForUser(ctx, userID, page, size)
@@ -128,6 +183,28 @@ func (s *Service) GetOrder(ctx context.Context, userID int, orderID int64) (*Ord
 	return o, nil
 }
 
+// orderStatusTransitions lists the statuses an order may move to from each
+// status. Delivered and cancelled orders are final.
+var orderStatusTransitions = map[string][]string{
+	StatusPending:    {StatusProcessing, StatusShipped, StatusCancelled},
+	StatusProcessing: {StatusShipped, StatusCancelled},
+	StatusShipped:    {StatusDelivered},
+}
+
+// UpdateOrderStatus changes the status of any order. Shipping an order
+// fulfills its stock reservations and cancelling releases them.
+func (s *Service) UpdateOrderStatus(ctx context.Context, orderID int64, status string) (*Order, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	o, err := s.repo.OrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(orderStatusTransitions[o.Status], status) {
+		return nil, ErrInvalidStatusTransition
+	}
+	return s.repo.UpdateOrderStatus(ctx, orderID, o.Status, status)
+}
+
 func (s *Service) BuildOrderResponse(ctx context.Context, o Order, baseURL string) (OrderResponse, error) {
 	return s.buildOrderResponse(ctx, o, baseURL)
 }
@@ -156,7 +233,16 @@ func (s *Service) buildOrderResponse(ctx context.Context, o Order, baseURL strin
 		if err != nil {
 			return OrderResponse{}, err
 		}
-		mv, _ := s.loadMugVariant(ctx, it.VariantID)
+		variantType := itemVariantType(it)
+		var mv *article.MugVariant
+		var sv *cart.ShirtVariantResponse
+		if variantType == article.ArticleTypeShirt {
+			if v, err := s.articleSvc.GetShirtVariant(ctx, it.VariantID); err == nil {
+				sv = cart.BuildShirtVariantResponse(&v)
+			}
+		} else {
+			mv, _ = s.loadMugVariant(ctx, it.VariantID)
+		}
 		var genFilename *string
 		if it.GeneratedImageID != nil {
 			if fn, ok := generatedFilenames[*it.GeneratedImageID]; ok && fn != "" {
@@ -166,10 +252,13 @@ func (s *Service) buildOrderResponse(ctx context.Context, o Order, baseURL strin
 		items = append(items, OrderItemResponse{
 			ID:                     it.ID,
 			Article:                art,
+			VariantType:            variantType,
 			Variant:                mv,
+			ShirtVariant:           sv,
 			Quantity:               it.Quantity,
 			PricePerItem:           it.PricePerItem,
 			TotalPrice:             it.TotalPrice,
+			PriceTierMinQuantity:   max(it.PriceTierMinQuantity, 1),
 			GeneratedImageID:       it.GeneratedImageID,
 			GeneratedImageFilename: genFilename,
 			PromptID:               it.PromptID,
@@ -192,6 +281,8 @@ func (s *Service) buildOrderResponse(ctx context.Context, o Order, baseURL strin
 		TaxAmount:       o.TaxAmount,
 		ShippingAmount:  o.ShippingAmount,
 		TotalAmount:     o.TotalAmount,
+		DiscountAmount:  o.DiscountAmount,
+		PromotionCode:   o.PromotionCode,
 		Status:          o.Status,
 		CartID:          o.CartID,
 		Notes:           o.Notes,
@@ -225,6 +316,15 @@ func (s *Service) loadMugVariant(ctx context.Context, id int) (*article.MugVaria
 	}, nil
 }
 
+// itemVariantType returns the variant type of an order item. Items stored
+// before shirts were sellable carry no type and reference mug variants.
+func itemVariantType(it OrderItem) string {
+	if it.VariantType == "" {
+		return article.ArticleTypeMug
+	}
+	return it.VariantType
+}
+
 func toAddressResponsesFromOrder(o Order) (AddressResponse, *AddressResponse) {
 	ship := AddressResponse{
 		StreetAddress1: o.ShippingStreet1,
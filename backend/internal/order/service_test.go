@@ -2,9 +2,12 @@ package order
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"voenix/backend/internal/article"
 	"voenix/backend/internal/cart"
+	"voenix/backend/internal/promotion"
 )
 
 func TestCreateOrderFromCart(t *testing.T) {
@@ -51,3 +54,127 @@ func TestCreateOrderFromCart(t *testing.T) {
 		t.Fatalf("items count = %d", len(ord.Items))
 	}
 }
+
+// fakePromotions quotes its promotion for every code.
+type fakePromotions struct {
+	p promotion.Promotion
+}
+
+func (f fakePromotions) Quote(_ context.Context, _ string, _ int, lines []promotion.Line, shipping int) (*promotion.Discount, error) {
+	d, err := promotion.Apply(&f.p, lines, shipping)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func TestCreateOrderFromCartAppliesPromotion(t *testing.T) {
+	req := CreateOrderRequest{
+		CustomerEmail:     "john@example.com",
+		CustomerFirstName: "John",
+		CustomerLastName:  "Doe",
+		ShippingAddress:   AddressRequest{StreetAddress1: "123 Main", City: "City", State: "ST", PostalCode: "00000", Country: "USA"},
+	}
+	cases := []struct {
+		name                 string
+		p                    promotion.Promotion
+		discount, tax, total int64
+	}{
+		// 10% of 3000 is taken off before tax: 3000 + 216 + 499 - 300.
+		{"percentage", promotion.Promotion{ID: 4, Code: "SAVE10", Kind: promotion.KindPercentage, Value: 10}, 300, 216, 3415},
+		{"free shipping", promotion.Promotion{ID: 5, Code: "SHIPFREE", Kind: promotion.KindFreeShipping}, 499, 240, 3240},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newFakeRepository()
+			svc := NewService(repo, nil)
+			svc.SetPromotions(fakePromotions{p: tc.p})
+			repo.setActiveCart(cart.Cart{
+				ID:            1,
+				UserID:        100,
+				PromotionCode: tc.p.Code,
+				Items:         []cart.CartItem{{ArticleID: 1, VariantID: 1, Quantity: 2, PriceAtTime: 1500, CustomData: "{}"}},
+			})
+			ord, err := svc.CreateOrderFromCart(context.Background(), 100, req)
+			if err != nil {
+				t.Fatalf("create order: %v", err)
+			}
+			if ord.DiscountAmount != tc.discount || ord.TaxAmount != tc.tax || ord.TotalAmount != tc.total {
+				t.Fatalf("expected discount %d, tax %d, total %d, got %d, %d, %d", tc.discount, tc.tax, tc.total, ord.DiscountAmount, ord.TaxAmount, ord.TotalAmount)
+			}
+			if ord.TotalAmount != ord.Subtotal+ord.TaxAmount+ord.ShippingAmount-ord.DiscountAmount {
+				t.Fatalf("total does not add up: %+v", ord)
+			}
+			if ord.PromotionID == nil || *ord.PromotionID != tc.p.ID || ord.PromotionCode == nil || *ord.PromotionCode != tc.p.Code {
+				t.Fatalf("expected the promotion on the order, got %v %v", ord.PromotionID, ord.PromotionCode)
+			}
+		})
+	}
+
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	svc.SetPromotions(fakePromotions{p: promotion.Promotion{Kind: promotion.KindPercentage, Value: 10, ArticleIDs: []int{9}}})
+	repo.setActiveCart(cart.Cart{ID: 2, UserID: 101, PromotionCode: "OTHER", Items: []cart.CartItem{{ArticleID: 1, VariantID: 1, Quantity: 1, PriceAtTime: 1500, CustomData: "{}"}}})
+	if _, err := svc.CreateOrderFromCart(context.Background(), 101, req); !errors.Is(err, promotion.ErrNotApplicable) {
+		t.Fatalf("a code that no longer applies should fail the order, got %v", err)
+	}
+}
+
+// priceCheckFunc adapts a function to PriceChecker.
+type priceCheckFunc func(*cart.Cart) error
+
+func (f priceCheckFunc) CheckPrices(_ context.Context, c *cart.Cart) error { return f(c) }
+
+func TestCreateOrderFromCartRequiresAcknowledgedPrices(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	pending := true
+	svc.SetPriceChecker(priceCheckFunc(func(*cart.Cart) error {
+		if pending {
+			return cart.ErrPriceChangesPending
+		}
+		return nil
+	}))
+	repo.setActiveCart(cart.Cart{ID: 3, UserID: 102, Items: []cart.CartItem{{ArticleID: 1, VariantID: 1, Quantity: 1, PriceAtTime: 1500, CustomData: "{}"}}})
+	req := CreateOrderRequest{
+		CustomerEmail:     "john@example.com",
+		CustomerFirstName: "John",
+		CustomerLastName:  "Doe",
+		ShippingAddress:   AddressRequest{StreetAddress1: "123 Main", City: "City", State: "ST", PostalCode: "00000", Country: "USA"},
+	}
+	if _, err := svc.CreateOrderFromCart(context.Background(), 102, req); !errors.Is(err, cart.ErrPriceChangesPending) {
+		t.Fatalf("expected unacknowledged price changes to block checkout, got %v", err)
+	}
+	pending = false
+	if _, err := svc.CreateOrderFromCart(context.Background(), 102, req); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+}
+
+func TestBuildOrderPDFDataRendersShirtItems(t *testing.T) {
+	repo := newFakeRepository()
+	articleSvc := newFakeArticleService()
+	svc := NewService(repo, articleSvc)
+
+	articleSvc.articles[7] = article.Article{ID: 7, ArticleType: article.ArticleTypeShirt}
+	articleSvc.shirtVariants[11] = article.ShirtVariant{ID: 11, ArticleID: 7, Color: "Black", Size: "M"}
+	articleSvc.shirtDetails[7] = article.ShirtDetails{ArticleID: 7, PrintAreaWidthMm: 300, PrintAreaHeightMm: 400, PrintAreaPosition: article.ShirtPrintAreaFront}
+
+	data, err := svc.BuildOrderPDFData(context.Background(), Order{
+		ID:    1,
+		Items: []OrderItem{{ArticleID: 7, VariantID: 11, VariantType: article.ArticleTypeShirt, Quantity: 1, CustomData: "{}"}},
+	})
+	if err != nil {
+		t.Fatalf("build pdf data: %v", err)
+	}
+	item := data.Items[0]
+	if item.Article.MugDetails != nil {
+		t.Fatalf("expected no mug details for shirt item")
+	}
+	if item.Article.ShirtDetails == nil || item.Article.ShirtDetails.PrintAreaWidthMM != 300 || item.Article.ShirtDetails.PrintAreaPosition != "FRONT" {
+		t.Fatalf("unexpected shirt details: %+v", item.Article.ShirtDetails)
+	}
+	if item.VariantName == nil || *item.VariantName != "Black / M" {
+		t.Fatalf("unexpected variant name: %v", item.VariantName)
+	}
+}
@@ -3,7 +3,11 @@ package order
 import "time"
 
 const (
-	StatusPending = "PENDING"
+	StatusPending    = "PENDING"
+	StatusProcessing = "PROCESSING"
+	StatusShipped    = "SHIPPED"
+	StatusDelivered  = "DELIVERED"
+	StatusCancelled  = "CANCELLED"
 )
 
 // Order captures the domain representation of a customer order.
@@ -31,23 +35,32 @@ type Order struct {
 	TaxAmount       int64
 	ShippingAmount  int64
 	TotalAmount     int64
-	Status          string
-	CartID          int
-	Notes           *string
-	Items           []OrderItem
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	// DiscountAmount is what PromotionCode took off the items and the
+	// shipping; TotalAmount is reduced by it.
+	DiscountAmount int64
+	PromotionID    *int
+	PromotionCode  *string
+	Status         string
+	CartID         int
+	Notes          *string
+	Items          []OrderItem
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 // OrderItem represents a purchased item within an order.
 type OrderItem struct {
-	ID                     int64
-	OrderID                int64
-	ArticleID              int
-	VariantID              int
-	Quantity               int
-	PricePerItem           int64
-	TotalPrice             int64
+	ID           int64
+	OrderID      int64
+	ArticleID    int
+	VariantID    int
+	VariantType  string
+	Quantity     int
+	PricePerItem int64
+	TotalPrice   int64
+	// PriceTierMinQuantity is the quantity tier PricePerItem was taken from;
+	// 1 is the base price.
+	PriceTierMinQuantity   int
 	GeneratedImageID       *int
 	GeneratedImageFilename *string
 	PromptID               *int
@@ -158,6 +158,12 @@ func (service *PDFService) drawPage(document *gopdf.GoPdf, data OrderPdfData, it
 	return nil
 }
 
+// Space around a shirt print area for the vertical texts and the QR code.
+const (
+	shirtPageHorizontalPaddingMM = 40
+	shirtPageVerticalPaddingMM   = 30
+)
+
 func (service *PDFService) pageSizeForFirst(data OrderPdfData) (float64, float64) {
 	if len(data.Items) == 0 {
 		return service.config.Size.WidthMM * MMToPoints, service.config.Size.HeightMM * MMToPoints
@@ -168,6 +174,10 @@ func (service *PDFService) pageSizeForFirst(data OrderPdfData) (float64, float64
 func (service *PDFService) pageSizeForItem(item OrderItemPdfData) (float64, float64) {
 	width := service.config.Size.WidthMM * MMToPoints
 	height := service.config.Size.HeightMM * MMToPoints
+	if shirtDetails := item.Article.ShirtDetails; shirtDetails != nil && shirtDetails.PrintAreaWidthMM > 0 && shirtDetails.PrintAreaHeightMM > 0 {
+		width = (float64(shirtDetails.PrintAreaWidthMM) + shirtPageHorizontalPaddingMM) * MMToPoints
+		height = (float64(shirtDetails.PrintAreaHeightMM) + shirtPageVerticalPaddingMM) * MMToPoints
+	}
 	if mugDetails := item.Article.MugDetails; mugDetails != nil {
 		if mugDetails.DocumentFormatWidthMM != nil {
 			width = float64(*mugDetails.DocumentFormatWidthMM) * MMToPoints
@@ -207,6 +217,9 @@ func (service *PDFService) productInfoLine(item OrderItemPdfData) string {
 	if variant := item.VariantName; variant != nil && *variant != "" {
 		values = append(values, *variant)
 	}
+	if shirtDetails := item.Article.ShirtDetails; shirtDetails != nil && shirtDetails.PrintAreaPosition != "" {
+		values = append(values, shirtDetails.PrintAreaPosition)
+	}
 	return strings.Join(values, " | ")
 }
 
@@ -214,6 +227,14 @@ func (service *PDFService) drawCenteredImage(document *gopdf.GoPdf, data OrderPd
 	margin := service.marginForItem(item)
 	imageWidth := (pageWidth - 2*margin)
 	imageHeight := (pageHeight - 2*margin - (15 * MMToPoints))
+	if shirtDetails := item.Article.ShirtDetails; shirtDetails != nil {
+		if shirtDetails.PrintAreaWidthMM > 0 {
+			imageWidth = float64(shirtDetails.PrintAreaWidthMM) * MMToPoints
+		}
+		if shirtDetails.PrintAreaHeightMM > 0 {
+			imageHeight = float64(shirtDetails.PrintAreaHeightMM) * MMToPoints
+		}
+	}
 	if mugDetails := item.Article.MugDetails; mugDetails != nil {
 		if mugDetails.PrintTemplateWidthMM > 0 {
 			imageWidth = float64(mugDetails.PrintTemplateWidthMM) * MMToPoints
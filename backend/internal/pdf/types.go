@@ -66,6 +66,7 @@ type OrderItemPdfData struct {
 type ArticlePdfData struct {
 	ID                    int
 	MugDetails            *MugDetailsPdfData
+	ShirtDetails          *ShirtDetailsPdfData
 	SupplierArticleName   *string
 	SupplierArticleNumber *string
 }
@@ -78,6 +79,14 @@ type MugDetailsPdfData struct {
 	DocumentFormatMarginBottomMM *int
 }
 
+// ShirtDetailsPdfData describes the printable area of a shirt. The page is
+// sized around the print area with room for the order header and QR code.
+type ShirtDetailsPdfData struct {
+	PrintAreaWidthMM  int
+	PrintAreaHeightMM int
+	PrintAreaPosition string
+}
+
 func FilenameFromOrderNumber(orderNumber string) string {
 	if orderNumber == "" {
 		orderNumber = "ORDER"
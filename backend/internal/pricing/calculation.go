@@ -0,0 +1,170 @@
+package pricing
+
+import (
+	"errors"
+	"math"
+
+	"voenix/backend/internal/article"
+)
+
+var (
+	ErrInvalidAdjustment = errors.New("invalid price adjustment")
+	ErrNegativePrice     = errors.New("adjustment results in a negative price")
+)
+
+// Validate checks target, mode and value of an adjustment.
+func (a Adjustment) Validate() error {
+	switch a.Target {
+	case TargetPurchasePrice, TargetMargin:
+	default:
+		return ErrInvalidAdjustment
+	}
+	switch a.Mode {
+	case ModePercent:
+		if a.Value <= -100 {
+			return ErrInvalidAdjustment
+		}
+	case ModeAbsolute:
+	default:
+		return ErrInvalidAdjustment
+	}
+	if a.Value == 0 || math.IsNaN(a.Value) || math.IsInf(a.Value, 0) {
+		return ErrInvalidAdjustment
+	}
+	return nil
+}
+
+// ApplyAdjustment changes the adjustment target of price and recomputes every
+// dependent field the same way the admin cost calculation form does:
+//   - purchase price tax/gross (or net/tax in GROSS mode) from the VAT rate,
+//   - purchase costs from the cost percent when that row is active,
+//   - purchase totals as price + costs,
+//   - sales totals as purchase total + margin, with the margin anchored by the
+//     sales active row (absolute margin, margin percent or fixed total).
+func ApplyAdjustment(price *article.Price, adj Adjustment) error {
+	if err := adj.Validate(); err != nil {
+		return err
+	}
+	switch adj.Target {
+	case TargetPurchasePrice:
+		oldTotalNet := price.PurchaseTotalNet
+		if price.PurchaseCalculationMode == "GROSS" {
+			gross := adjustAmount(price.PurchasePriceGross, adj)
+			if gross < 0 {
+				return ErrNegativePrice
+			}
+			setPurchasePriceFromGross(price, gross)
+		} else {
+			net := adjustAmount(price.PurchasePriceNet, adj)
+			if net < 0 {
+				return ErrNegativePrice
+			}
+			setPurchasePriceFromNet(price, net)
+		}
+		recalculatePurchaseCost(price)
+		recalculatePurchaseTotals(price)
+		marginNet := price.SalesMarginNet
+		switch price.SalesActiveRow {
+		case "MARGIN_PERCENT":
+			marginNet = roundCents(float64(price.PurchaseTotalNet) * price.SalesMarginPercent / 100)
+		case "TOTAL":
+			marginNet = price.SalesTotalNet - price.PurchaseTotalNet
+		default:
+			if oldTotalNet == 0 && marginNet == 0 {
+				marginNet = roundCents(float64(price.PurchaseTotalNet) * price.SalesMarginPercent / 100)
+			}
+		}
+		return recalculateSales(price, marginNet)
+	case TargetMargin:
+		return recalculateSales(price, adjustAmount(price.SalesMarginNet, adj))
+	}
+	return ErrInvalidAdjustment
+}
+
+// CalculateFromSalesGross fills price from a purchase price, purchase costs and
+// the gross sales price, all net cents except salesGross. VAT percents must be
+// set beforehand. The margin becomes whatever lies between the purchase total
+// and the net sales total, and the sales total is kept fixed on recalculation.
+func CalculateFromSalesGross(price *article.Price, purchaseNet, costNet, salesGross int) error {
+	if purchaseNet < 0 || costNet < 0 || salesGross < 0 {
+		return ErrNegativePrice
+	}
+	price.PurchaseCalculationMode = "NET"
+	price.PurchaseActiveRow = "COST"
+	setPurchasePriceFromNet(price, purchaseNet)
+	price.PurchaseCostNet = costNet
+	price.PurchaseCostTax = roundCents(float64(costNet) * price.PurchaseVatRatePercent / 100)
+	price.PurchaseCostGross = costNet + price.PurchaseCostTax
+	recalculatePurchaseCost(price)
+	recalculatePurchaseTotals(price)
+	salesNet := roundCents(float64(salesGross) / (1 + price.SalesVatRatePercent/100))
+	if err := recalculateSales(price, salesNet-price.PurchaseTotalNet); err != nil {
+		return err
+	}
+	price.SalesCalculationMode = "GROSS"
+	price.SalesActiveRow = "TOTAL"
+	return nil
+}
+
+func adjustAmount(amount int, adj Adjustment) int {
+	if adj.Mode == ModePercent {
+		return roundCents(float64(amount) * (1 + adj.Value/100))
+	}
+	return amount + roundCents(adj.Value)
+}
+
+func setPurchasePriceFromNet(price *article.Price, net int) {
+	price.PurchasePriceNet = net
+	price.PurchasePriceTax = roundCents(float64(net) * price.PurchaseVatRatePercent / 100)
+	price.PurchasePriceGross = net + price.PurchasePriceTax
+}
+
+func setPurchasePriceFromGross(price *article.Price, gross int) {
+	price.PurchasePriceGross = gross
+	price.PurchasePriceNet = roundCents(float64(gross) / (1 + price.PurchaseVatRatePercent/100))
+	price.PurchasePriceTax = gross - price.PurchasePriceNet
+}
+
+// recalculatePurchaseCost derives costs from the cost percent when that row is
+// active; otherwise the absolute costs stay and the percent follows.
+func recalculatePurchaseCost(price *article.Price) {
+	if price.PurchaseActiveRow == "COST_PERCENT" {
+		net := roundCents(float64(price.PurchasePriceNet) * price.PurchaseCostPercent / 100)
+		price.PurchaseCostNet = net
+		price.PurchaseCostTax = roundCents(float64(net) * price.PurchaseVatRatePercent / 100)
+		price.PurchaseCostGross = net + price.PurchaseCostTax
+		return
+	}
+	if price.PurchasePriceNet > 0 {
+		price.PurchaseCostPercent = roundPercent(float64(price.PurchaseCostNet) / float64(price.PurchasePriceNet) * 100)
+	}
+}
+
+func recalculatePurchaseTotals(price *article.Price) {
+	price.PurchaseTotalNet = price.PurchasePriceNet + price.PurchaseCostNet
+	price.PurchaseTotalTax = price.PurchasePriceTax + price.PurchaseCostTax
+	price.PurchaseTotalGross = price.PurchasePriceGross + price.PurchaseCostGross
+}
+
+func recalculateSales(price *article.Price, marginNet int) error {
+	salesNet := price.PurchaseTotalNet + marginNet
+	if salesNet < 0 {
+		return ErrNegativePrice
+	}
+	price.SalesTotalNet = salesNet
+	price.SalesTotalTax = roundCents(float64(salesNet) * price.SalesVatRatePercent / 100)
+	price.SalesTotalGross = salesNet + price.SalesTotalTax
+	price.SalesMarginNet = marginNet
+	price.SalesMarginTax = price.SalesTotalTax - price.PurchaseTotalTax
+	price.SalesMarginGross = price.SalesTotalGross - price.PurchaseTotalGross
+	if price.PurchaseTotalNet > 0 {
+		price.SalesMarginPercent = roundPercent(float64(marginNet) / float64(price.PurchaseTotalNet) * 100)
+	} else {
+		price.SalesMarginPercent = 0
+	}
+	return nil
+}
+
+func roundCents(v float64) int { return int(math.Round(v)) }
+
+func roundPercent(v float64) float64 { return math.Round(v*100) / 100 }
@@ -0,0 +1,95 @@
+package pricing
+
+import (
+	"errors"
+	"testing"
+
+	"voenix/backend/internal/article"
+)
+
+// basePrice mirrors a typical mug calculation: 10.00 net purchase price, 10%
+// costs, 100% margin, 19% VAT on both sides.
+func basePrice() article.Price {
+	return article.Price{
+		ID:                      1,
+		PurchasePriceNet:        1000,
+		PurchasePriceTax:        190,
+		PurchasePriceGross:      1190,
+		PurchaseCostNet:         100,
+		PurchaseCostTax:         19,
+		PurchaseCostGross:       119,
+		PurchaseCostPercent:     10,
+		PurchaseTotalNet:        1100,
+		PurchaseTotalTax:        209,
+		PurchaseTotalGross:      1309,
+		PurchaseVatRatePercent:  19,
+		PurchaseCalculationMode: "NET",
+		PurchaseActiveRow:       "COST_PERCENT",
+		SalesVatRatePercent:     19,
+		SalesMarginNet:          1100,
+		SalesMarginTax:          209,
+		SalesMarginGross:        1309,
+		SalesMarginPercent:      100,
+		SalesTotalNet:           2200,
+		SalesTotalTax:           418,
+		SalesTotalGross:         2618,
+		SalesCalculationMode:    "NET",
+		SalesActiveRow:          "MARGIN_PERCENT",
+	}
+}
+
+func TestApplyAdjustmentPurchasePercentKeepsMarginPercent(t *testing.T) {
+	price := basePrice()
+	if err := ApplyAdjustment(&price, Adjustment{Target: TargetPurchasePrice, Mode: ModePercent, Value: 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price.PurchasePriceNet != 1100 || price.PurchasePriceGross != 1309 {
+		t.Fatalf("unexpected purchase price: net=%d gross=%d", price.PurchasePriceNet, price.PurchasePriceGross)
+	}
+	if price.PurchaseCostNet != 110 || price.PurchaseTotalNet != 1210 {
+		t.Fatalf("expected costs to follow the cost percent, got cost=%d total=%d", price.PurchaseCostNet, price.PurchaseTotalNet)
+	}
+	if price.SalesMarginPercent != 100 || price.SalesTotalNet != 2420 || price.SalesTotalGross != 2880 {
+		t.Fatalf("unexpected sales: percent=%v net=%d gross=%d", price.SalesMarginPercent, price.SalesTotalNet, price.SalesTotalGross)
+	}
+	if price.SalesMarginGross != price.SalesTotalGross-price.PurchaseTotalGross {
+		t.Fatalf("margin gross must equal sales gross minus purchase gross")
+	}
+}
+
+func TestApplyAdjustmentPurchaseKeepsFixedSalesTotal(t *testing.T) {
+	price := basePrice()
+	price.SalesActiveRow = "TOTAL"
+	if err := ApplyAdjustment(&price, Adjustment{Target: TargetPurchasePrice, Mode: ModeAbsolute, Value: 200}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price.SalesTotalNet != 2200 {
+		t.Fatalf("expected sales total to stay fixed, got %d", price.SalesTotalNet)
+	}
+	if price.SalesMarginNet != 2200-price.PurchaseTotalNet {
+		t.Fatalf("expected margin to absorb the change, got %d", price.SalesMarginNet)
+	}
+}
+
+func TestApplyAdjustmentMarginAbsolute(t *testing.T) {
+	price := basePrice()
+	if err := ApplyAdjustment(&price, Adjustment{Target: TargetMargin, Mode: ModeAbsolute, Value: -550}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price.PurchaseTotalNet != 1100 {
+		t.Fatalf("purchase side must not change, got %d", price.PurchaseTotalNet)
+	}
+	if price.SalesMarginNet != 550 || price.SalesMarginPercent != 50 || price.SalesTotalNet != 1650 {
+		t.Fatalf("unexpected margin: net=%d percent=%v total=%d", price.SalesMarginNet, price.SalesMarginPercent, price.SalesTotalNet)
+	}
+}
+
+func TestApplyAdjustmentRejectsInvalidInput(t *testing.T) {
+	price := basePrice()
+	if err := ApplyAdjustment(&price, Adjustment{Target: "VAT", Mode: ModePercent, Value: 5}); !errors.Is(err, ErrInvalidAdjustment) {
+		t.Fatalf("expected ErrInvalidAdjustment, got %v", err)
+	}
+	if err := ApplyAdjustment(&price, Adjustment{Target: TargetMargin, Mode: ModeAbsolute, Value: -5000}); !errors.Is(err, ErrNegativePrice) {
+		t.Fatalf("expected ErrNegativePrice, got %v", err)
+	}
+}
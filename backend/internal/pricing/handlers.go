@@ -0,0 +1,240 @@
+package pricing
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/auth"
+)
+
+type articleFilterRequest struct {
+	IDs           []int  `json:"ids,omitempty"`
+	ArticleType   string `json:"articleType,omitempty"`
+	CategoryID    *int   `json:"categoryId,omitempty"`
+	SubcategoryID *int   `json:"subcategoryId,omitempty"`
+	SupplierID    *int   `json:"supplierId,omitempty"`
+	Active        *bool  `json:"active,omitempty"`
+}
+
+type promptFilterRequest struct {
+	IDs           []int `json:"ids,omitempty"`
+	CategoryID    *int  `json:"categoryId,omitempty"`
+	SubcategoryID *int  `json:"subcategoryId,omitempty"`
+	Active        *bool `json:"active,omitempty"`
+}
+
+type adjustmentRequest struct {
+	Target   string                `json:"target"`
+	Mode     string                `json:"mode"`
+	Value    float64               `json:"value"`
+	Articles *articleFilterRequest `json:"articles"`
+	Prompts  *promptFilterRequest  `json:"prompts"`
+}
+
+func (r adjustmentRequest) adjustment() Adjustment {
+	return Adjustment{
+		Target: AdjustmentTarget(strings.ToUpper(strings.TrimSpace(r.Target))),
+		Mode:   AdjustmentMode(strings.ToUpper(strings.TrimSpace(r.Mode))),
+		Value:  r.Value,
+	}
+}
+
+func (r adjustmentRequest) selection() Selection {
+	var sel Selection
+	if r.Articles != nil {
+		sel.Articles = &ArticleFilter{
+			IDs:           r.Articles.IDs,
+			ArticleType:   strings.ToUpper(strings.TrimSpace(r.Articles.ArticleType)),
+			CategoryID:    r.Articles.CategoryID,
+			SubcategoryID: r.Articles.SubcategoryID,
+			SupplierID:    r.Articles.SupplierID,
+			Active:        r.Articles.Active,
+		}
+	}
+	if r.Prompts != nil {
+		sel.Prompts = &PromptFilter{
+			IDs:           r.Prompts.IDs,
+			CategoryID:    r.Prompts.CategoryID,
+			SubcategoryID: r.Prompts.SubcategoryID,
+			Active:        r.Prompts.Active,
+		}
+	}
+	return sel
+}
+
+// filterJSON serializes the selection for the batch audit record.
+func (r adjustmentRequest) filterJSON() string {
+	b, err := json.Marshal(struct {
+		Articles *articleFilterRequest `json:"articles,omitempty"`
+		Prompts  *promptFilterRequest  `json:"prompts,omitempty"`
+	}{r.Articles, r.Prompts})
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+type EntryResponse struct {
+	ID                    int     `json:"id,omitempty"`
+	PriceID               *int    `json:"priceId"`
+	ArticleID             *int    `json:"articleId"`
+	PromptID              *int    `json:"promptId"`
+	Name                  string  `json:"name,omitempty"`
+	OldPurchasePriceNet   int     `json:"oldPurchasePriceNet"`
+	NewPurchasePriceNet   int     `json:"newPurchasePriceNet"`
+	OldPurchaseTotalNet   int     `json:"oldPurchaseTotalNet"`
+	NewPurchaseTotalNet   int     `json:"newPurchaseTotalNet"`
+	OldSalesMarginNet     int     `json:"oldSalesMarginNet"`
+	NewSalesMarginNet     int     `json:"newSalesMarginNet"`
+	OldSalesMarginPercent float64 `json:"oldSalesMarginPercent"`
+	NewSalesMarginPercent float64 `json:"newSalesMarginPercent"`
+	OldSalesTotalNet      int     `json:"oldSalesTotalNet"`
+	NewSalesTotalNet      int     `json:"newSalesTotalNet"`
+	OldSalesTotalGross    int     `json:"oldSalesTotalGross"`
+	NewSalesTotalGross    int     `json:"newSalesTotalGross"`
+}
+
+type BatchResponse struct {
+	ID        int             `json:"id"`
+	Target    string          `json:"target"`
+	Mode      string          `json:"mode"`
+	Value     float64         `json:"value"`
+	Filter    json.RawMessage `json:"filter"`
+	ItemCount int             `json:"itemCount"`
+	CreatedBy *int            `json:"createdBy"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Entries   []EntryResponse `json:"entries,omitempty"`
+}
+
+func RegisterRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
+	grp := r.Group("/api/admin/pricing/adjustments")
+	grp.Use(auth.RequireAdmin(db))
+
+	grp.GET("", func(c *gin.Context) {
+		rows, err := svc.ListBatches(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch price adjustments"})
+			return
+		}
+		out := make([]BatchResponse, 0, len(rows))
+		for i := range rows {
+			out = append(out, toBatchResponse(&rows[i]))
+		}
+		c.JSON(http.StatusOK, out)
+	})
+
+	grp.GET("/:id", func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		batch, err := svc.GetBatch(c.Request.Context(), id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"detail": "Price adjustment not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch price adjustment"})
+			return
+		}
+		c.JSON(http.StatusOK, toBatchResponse(batch))
+	})
+
+	// POST /api/admin/pricing/adjustments/preview computes the new values
+	// without saving them.
+	grp.POST("/preview", func(c *gin.Context) {
+		var payload adjustmentRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		entries, err := svc.Preview(c.Request.Context(), payload.selection(), payload.adjustment())
+		if err != nil {
+			writeAdjustmentError(c, err, "Failed to preview price adjustment")
+			return
+		}
+		c.JSON(http.StatusOK, toEntryResponses(entries))
+	})
+
+	grp.POST("", func(c *gin.Context) {
+		var payload adjustmentRequest
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		var createdBy *int
+		if u, ok := c.Get("currentUser"); ok {
+			if user, _ := u.(*auth.User); user != nil {
+				id := user.ID
+				createdBy = &id
+			}
+		}
+		batch, err := svc.Apply(c.Request.Context(), payload.selection(), payload.adjustment(), payload.filterJSON(), createdBy)
+		if err != nil {
+			writeAdjustmentError(c, err, "Failed to apply price adjustment")
+			return
+		}
+		c.JSON(http.StatusCreated, toBatchResponse(batch))
+	})
+}
+
+func writeAdjustmentError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ErrInvalidAdjustment):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid adjustment: target must be PURCHASE_PRICE or MARGIN, mode PERCENT or ABSOLUTE and value non-zero"})
+	case errors.Is(err, ErrNegativePrice):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"detail": "Adjustment would result in a negative price"})
+	case errors.Is(err, ErrEmptySelection):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "No prices match the selection"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
+
+func toBatchResponse(b *Batch) BatchResponse {
+	filter := json.RawMessage(b.Filter)
+	if !json.Valid(filter) {
+		filter = json.RawMessage("{}")
+	}
+	return BatchResponse{
+		ID:        b.ID,
+		Target:    string(b.Target),
+		Mode:      string(b.Mode),
+		Value:     b.Value,
+		Filter:    filter,
+		ItemCount: b.ItemCount,
+		CreatedBy: b.CreatedBy,
+		CreatedAt: b.CreatedAt,
+		Entries:   toEntryResponses(b.Entries),
+	}
+}
+
+func toEntryResponses(entries []Entry) []EntryResponse {
+	out := make([]EntryResponse, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, EntryResponse{
+			ID:                    e.ID,
+			PriceID:               e.PriceID,
+			ArticleID:             e.ArticleID,
+			PromptID:              e.PromptID,
+			Name:                  e.Name,
+			OldPurchasePriceNet:   e.OldPurchasePriceNet,
+			NewPurchasePriceNet:   e.NewPurchasePriceNet,
+			OldPurchaseTotalNet:   e.OldPurchaseTotalNet,
+			NewPurchaseTotalNet:   e.NewPurchaseTotalNet,
+			OldSalesMarginNet:     e.OldSalesMarginNet,
+			NewSalesMarginNet:     e.NewSalesMarginNet,
+			OldSalesMarginPercent: e.OldSalesMarginPercent,
+			NewSalesMarginPercent: e.NewSalesMarginPercent,
+			OldSalesTotalNet:      e.OldSalesTotalNet,
+			NewSalesTotalNet:      e.NewSalesTotalNet,
+			OldSalesTotalGross:    e.OldSalesTotalGross,
+			NewSalesTotalGross:    e.NewSalesTotalGross,
+		})
+	}
+	return out
+}
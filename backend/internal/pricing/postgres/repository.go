@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+	articlepg "voenix/backend/internal/article/postgres"
+	"voenix/backend/internal/pricing"
+)
+
+// Repository provides a Postgres-backed implementation of pricing.Repository.
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+var _ pricing.Repository = (*Repository)(nil)
+
+func (r *Repository) with(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx)
+}
+
+func (r *Repository) WithTransaction(ctx context.Context, fn func(pricing.Repository) error) error {
+	return r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Repository{db: tx})
+	})
+}
+
+// ownerRow links a price row to the article or prompt it belongs to.
+type ownerRow struct {
+	PriceID   int
+	ArticleID *int
+	PromptID  *int
+	Name      string
+}
+
+func (r *Repository) ListArticlePrices(ctx context.Context, filter pricing.ArticleFilter) ([]pricing.PricedItem, error) {
+	q := r.with(ctx).Table("prices p").
+		Select("p.id as price_id, a.id as article_id, a.name as name").
+		Joins("JOIN articles a ON a.id = p.article_id")
+	if len(filter.IDs) > 0 {
+		q = q.Where("a.id IN ?", filter.IDs)
+	}
+	if filter.ArticleType != "" {
+		q = q.Where("a.article_type = ?", filter.ArticleType)
+	}
+	if filter.CategoryID != nil {
+		q = q.Where("a.category_id = ?", *filter.CategoryID)
+	}
+	if filter.SubcategoryID != nil {
+		q = q.Where("a.subcategory_id = ?", *filter.SubcategoryID)
+	}
+	if filter.SupplierID != nil {
+		q = q.Where("a.supplier_id = ?", *filter.SupplierID)
+	}
+	if filter.Active != nil {
+		q = q.Where("a.active = ?", *filter.Active)
+	}
+	var owners []ownerRow
+	if err := q.Order("a.id asc").Scan(&owners).Error; err != nil {
+		return nil, err
+	}
+	return r.loadPricedItems(ctx, owners)
+}
+
+func (r *Repository) ListPromptPrices(ctx context.Context, filter pricing.PromptFilter) ([]pricing.PricedItem, error) {
+	q := r.with(ctx).Table("prices p").
+		Select("p.id as price_id, pr.id as prompt_id, pr.title as name").
+		Joins("JOIN prompts pr ON pr.price_id = p.id")
+	if len(filter.IDs) > 0 {
+		q = q.Where("pr.id IN ?", filter.IDs)
+	}
+	if filter.CategoryID != nil {
+		q = q.Where("pr.category_id = ?", *filter.CategoryID)
+	}
+	if filter.SubcategoryID != nil {
+		q = q.Where("pr.subcategory_id = ?", *filter.SubcategoryID)
+	}
+	if filter.Active != nil {
+		q = q.Where("pr.active = ?", *filter.Active)
+	}
+	var owners []ownerRow
+	if err := q.Order("pr.id asc").Scan(&owners).Error; err != nil {
+		return nil, err
+	}
+	return r.loadPricedItems(ctx, owners)
+}
+
+func (r *Repository) loadPricedItems(ctx context.Context, owners []ownerRow) ([]pricing.PricedItem, error) {
+	if len(owners) == 0 {
+		return []pricing.PricedItem{}, nil
+	}
+	ids := make([]int, 0, len(owners))
+	for _, o := range owners {
+		ids = append(ids, o.PriceID)
+	}
+	var prices []article.Price
+	if err := r.with(ctx).Table("prices").Where("id IN ?", ids).Find(&prices).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[int]article.Price, len(prices))
+	for _, p := range prices {
+		byID[p.ID] = p
+	}
+	out := make([]pricing.PricedItem, 0, len(owners))
+	for _, o := range owners {
+		p, ok := byID[o.PriceID]
+		if !ok {
+			continue
+		}
+		out = append(out, pricing.PricedItem{Price: p, ArticleID: o.ArticleID, PromptID: o.PromptID, Name: o.Name})
+	}
+	return out, nil
+}
+
+func (r *Repository) SavePrice(ctx context.Context, price *article.Price) error {
+	return r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("prices").Save(price).Error; err != nil {
+			return err
+		}
+		return articlepg.RecordPriceHistory(tx, price)
+	})
+}
+
+func (r *Repository) CreateBatch(ctx context.Context, batch *pricing.Batch) error {
+	row := batchRowFromDomain(batch)
+	if err := r.with(ctx).Create(&row).Error; err != nil {
+		return err
+	}
+	names := make([]string, len(batch.Entries))
+	for i := range batch.Entries {
+		names[i] = batch.Entries[i].Name
+	}
+	*batch = row.toDomain()
+	for i := range batch.Entries {
+		batch.Entries[i].Name = names[i]
+	}
+	return nil
+}
+
+func (r *Repository) ListBatches(ctx context.Context) ([]pricing.Batch, error) {
+	var rows []batchRow
+	if err := r.with(ctx).Order("id desc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]pricing.Batch, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].toDomain())
+	}
+	return out, nil
+}
+
+func (r *Repository) BatchByID(ctx context.Context, id int) (*pricing.Batch, error) {
+	var row batchRow
+	err := r.with(ctx).
+		Preload("Entries", func(tx *gorm.DB) *gorm.DB { return tx.Order("id asc") }).
+		First(&row, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	domain := row.toDomain()
+	return &domain, nil
+}
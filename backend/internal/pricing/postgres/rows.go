@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"time"
+
+	"voenix/backend/internal/pricing"
+)
+
+type batchRow struct {
+	ID        int        `gorm:"primaryKey"`
+	Target    string     `gorm:"size:30;not null"`
+	Mode      string     `gorm:"size:20;not null"`
+	Value     float64    `gorm:"type:numeric(12,4);not null"`
+	Filter    string     `gorm:"type:jsonb;not null;default:'{}'"`
+	ItemCount int        `gorm:"not null;default:0"`
+	CreatedBy *int       `gorm:"column:created_by"`
+	Entries   []entryRow `gorm:"foreignKey:BatchID;references:ID"`
+	CreatedAt time.Time
+}
+
+func (batchRow) TableName() string { return "price_adjustment_batches" }
+
+type entryRow struct {
+	ID                    int     `gorm:"primaryKey"`
+	BatchID               int     `gorm:"not null;index"`
+	PriceID               *int    `gorm:"index"`
+	ArticleID             *int    `gorm:"column:article_id"`
+	PromptID              *int    `gorm:"column:prompt_id"`
+	OldPurchasePriceNet   int     `gorm:"not null"`
+	NewPurchasePriceNet   int     `gorm:"not null"`
+	OldPurchaseTotalNet   int     `gorm:"not null"`
+	NewPurchaseTotalNet   int     `gorm:"not null"`
+	OldSalesMarginNet     int     `gorm:"not null"`
+	NewSalesMarginNet     int     `gorm:"not null"`
+	OldSalesMarginPercent float64 `gorm:"type:numeric(7,2);not null"`
+	NewSalesMarginPercent float64 `gorm:"type:numeric(7,2);not null"`
+	OldSalesTotalNet      int     `gorm:"not null"`
+	NewSalesTotalNet      int     `gorm:"not null"`
+	OldSalesTotalGross    int     `gorm:"not null"`
+	NewSalesTotalGross    int     `gorm:"not null"`
+	CreatedAt             time.Time
+}
+
+func (entryRow) TableName() string { return "price_adjustment_entries" }
+
+func (r batchRow) toDomain() pricing.Batch {
+	entries := make([]pricing.Entry, 0, len(r.Entries))
+	for i := range r.Entries {
+		entries = append(entries, r.Entries[i].toDomain())
+	}
+	return pricing.Batch{
+		ID:        r.ID,
+		Target:    pricing.AdjustmentTarget(r.Target),
+		Mode:      pricing.AdjustmentMode(r.Mode),
+		Value:     r.Value,
+		Filter:    r.Filter,
+		ItemCount: r.ItemCount,
+		CreatedBy: r.CreatedBy,
+		CreatedAt: r.CreatedAt,
+		Entries:   entries,
+	}
+}
+
+func batchRowFromDomain(b *pricing.Batch) batchRow {
+	entries := make([]entryRow, 0, len(b.Entries))
+	for i := range b.Entries {
+		entries = append(entries, entryRowFromDomain(&b.Entries[i]))
+	}
+	filter := b.Filter
+	if filter == "" {
+		filter = "{}"
+	}
+	return batchRow{
+		ID:        b.ID,
+		Target:    string(b.Target),
+		Mode:      string(b.Mode),
+		Value:     b.Value,
+		Filter:    filter,
+		ItemCount: b.ItemCount,
+		CreatedBy: b.CreatedBy,
+		Entries:   entries,
+		CreatedAt: b.CreatedAt,
+	}
+}
+
+func (r entryRow) toDomain() pricing.Entry {
+	return pricing.Entry{
+		ID:                    r.ID,
+		BatchID:               r.BatchID,
+		PriceID:               r.PriceID,
+		ArticleID:             r.ArticleID,
+		PromptID:              r.PromptID,
+		OldPurchasePriceNet:   r.OldPurchasePriceNet,
+		NewPurchasePriceNet:   r.NewPurchasePriceNet,
+		OldPurchaseTotalNet:   r.OldPurchaseTotalNet,
+		NewPurchaseTotalNet:   r.NewPurchaseTotalNet,
+		OldSalesMarginNet:     r.OldSalesMarginNet,
+		NewSalesMarginNet:     r.NewSalesMarginNet,
+		OldSalesMarginPercent: r.OldSalesMarginPercent,
+		NewSalesMarginPercent: r.NewSalesMarginPercent,
+		OldSalesTotalNet:      r.OldSalesTotalNet,
+		NewSalesTotalNet:      r.NewSalesTotalNet,
+		OldSalesTotalGross:    r.OldSalesTotalGross,
+		NewSalesTotalGross:    r.NewSalesTotalGross,
+		CreatedAt:             r.CreatedAt,
+	}
+}
+
+func entryRowFromDomain(e *pricing.Entry) entryRow {
+	return entryRow{
+		ID:                    e.ID,
+		BatchID:               e.BatchID,
+		PriceID:               e.PriceID,
+		ArticleID:             e.ArticleID,
+		PromptID:              e.PromptID,
+		OldPurchasePriceNet:   e.OldPurchasePriceNet,
+		NewPurchasePriceNet:   e.NewPurchasePriceNet,
+		OldPurchaseTotalNet:   e.OldPurchaseTotalNet,
+		NewPurchaseTotalNet:   e.NewPurchaseTotalNet,
+		OldSalesMarginNet:     e.OldSalesMarginNet,
+		NewSalesMarginNet:     e.NewSalesMarginNet,
+		OldSalesMarginPercent: e.OldSalesMarginPercent,
+		NewSalesMarginPercent: e.NewSalesMarginPercent,
+		OldSalesTotalNet:      e.OldSalesTotalNet,
+		NewSalesTotalNet:      e.NewSalesTotalNet,
+		OldSalesTotalGross:    e.OldSalesTotalGross,
+		NewSalesTotalGross:    e.NewSalesTotalGross,
+		CreatedAt:             e.CreatedAt,
+	}
+}
@@ -0,0 +1,22 @@
+package pricing
+
+import (
+	"context"
+
+	"voenix/backend/internal/article"
+)
+
+// Repository defines persistence for bulk price adjustments.
+type Repository interface {
+	ListArticlePrices(ctx context.Context, filter ArticleFilter) ([]PricedItem, error)
+	ListPromptPrices(ctx context.Context, filter PromptFilter) ([]PricedItem, error)
+	SavePrice(ctx context.Context, price *article.Price) error
+
+	CreateBatch(ctx context.Context, batch *Batch) error
+	ListBatches(ctx context.Context) ([]Batch, error)
+	BatchByID(ctx context.Context, id int) (*Batch, error)
+
+	// WithTransaction executes the given operation within a database transaction.
+	// If the operation returns an error, the transaction is rolled back.
+	WithTransaction(ctx context.Context, fn func(Repository) error) error
+}
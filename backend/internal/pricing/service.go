@@ -0,0 +1,157 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrEmptySelection = errors.New("no prices match the selection")
+
+// CatalogInvalidator drops cached catalog data after prices change.
+type CatalogInvalidator interface {
+	InvalidateCatalog()
+}
+
+type Service struct {
+	repo    Repository
+	catalog CatalogInvalidator
+}
+
+// NewService wires the pricing service. catalog may be nil when no catalog
+// cache needs to be invalidated.
+func NewService(repo Repository, catalog CatalogInvalidator) *Service {
+	return &Service{repo: repo, catalog: catalog}
+}
+
+// Preview computes the outcome of an adjustment without persisting anything.
+func (s *Service) Preview(ctx context.Context, selection Selection, adj Adjustment) ([]Entry, error) {
+	if err := adj.Validate(); err != nil {
+		return nil, err
+	}
+	items, err := collectItems(ctx, s.repo, selection)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(items))
+	for i := range items {
+		entry, err := adjustItem(&items[i], adj)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Apply adjusts all selected prices in one transaction and stores a batch with
+// the old and new values of every price row. filter is the serialized
+// selection kept for the audit trail.
+func (s *Service) Apply(ctx context.Context, selection Selection, adj Adjustment, filter string, createdBy *int) (*Batch, error) {
+	if err := adj.Validate(); err != nil {
+		return nil, err
+	}
+	var batch Batch
+	err := s.repo.WithTransaction(ctx, func(tx Repository) error {
+		items, err := collectItems(ctx, tx, selection)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return ErrEmptySelection
+		}
+		entries := make([]Entry, 0, len(items))
+		for i := range items {
+			entry, err := adjustItem(&items[i], adj)
+			if err != nil {
+				return err
+			}
+			if err := tx.SavePrice(ctx, &items[i].Price); err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+		batch = Batch{
+			Target:    adj.Target,
+			Mode:      adj.Mode,
+			Value:     adj.Value,
+			Filter:    filter,
+			ItemCount: len(entries),
+			CreatedBy: createdBy,
+			CreatedAt: time.Now().UTC(),
+			Entries:   entries,
+		}
+		return tx.CreateBatch(ctx, &batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.catalog != nil {
+		s.catalog.InvalidateCatalog()
+	}
+	return &batch, nil
+}
+
+func (s *Service) ListBatches(ctx context.Context) ([]Batch, error) {
+	return s.repo.ListBatches(ctx)
+}
+
+func (s *Service) GetBatch(ctx context.Context, id int) (*Batch, error) {
+	return s.repo.BatchByID(ctx, id)
+}
+
+// collectItems loads the selected article and prompt prices, skipping price
+// rows that were already selected.
+func collectItems(ctx context.Context, repo Repository, selection Selection) ([]PricedItem, error) {
+	var items []PricedItem
+	if selection.Articles != nil {
+		rows, err := repo.ListArticlePrices(ctx, *selection.Articles)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rows...)
+	}
+	if selection.Prompts != nil {
+		rows, err := repo.ListPromptPrices(ctx, *selection.Prompts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rows...)
+	}
+	seen := make(map[int]struct{}, len(items))
+	out := make([]PricedItem, 0, len(items))
+	for _, item := range items {
+		if _, ok := seen[item.Price.ID]; ok {
+			continue
+		}
+		seen[item.Price.ID] = struct{}{}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+func adjustItem(item *PricedItem, adj Adjustment) (Entry, error) {
+	old := item.Price
+	if err := ApplyAdjustment(&item.Price, adj); err != nil {
+		return Entry{}, err
+	}
+	updated := item.Price
+	return Entry{
+		PriceID:               &old.ID,
+		ArticleID:             item.ArticleID,
+		PromptID:              item.PromptID,
+		Name:                  item.Name,
+		OldPurchasePriceNet:   old.PurchasePriceNet,
+		NewPurchasePriceNet:   updated.PurchasePriceNet,
+		OldPurchaseTotalNet:   old.PurchaseTotalNet,
+		NewPurchaseTotalNet:   updated.PurchaseTotalNet,
+		OldSalesMarginNet:     old.SalesMarginNet,
+		NewSalesMarginNet:     updated.SalesMarginNet,
+		OldSalesMarginPercent: old.SalesMarginPercent,
+		NewSalesMarginPercent: updated.SalesMarginPercent,
+		OldSalesTotalNet:      old.SalesTotalNet,
+		NewSalesTotalNet:      updated.SalesTotalNet,
+		OldSalesTotalGross:    old.SalesTotalGross,
+		NewSalesTotalGross:    updated.SalesTotalGross,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package pricing
+
+import (
+	"time"
+
+	"voenix/backend/internal/article"
+)
+
+// AdjustmentTarget selects which part of a cost calculation a bulk adjustment
+// changes.
+type AdjustmentTarget string
+
+const (
+	// TargetPurchasePrice changes the supplier purchase price. The margin
+	// percent is kept, so sales prices follow the new purchase price.
+	TargetPurchasePrice AdjustmentTarget = "PURCHASE_PRICE"
+	// TargetMargin changes the net sales margin on top of the purchase total.
+	TargetMargin AdjustmentTarget = "MARGIN"
+)
+
+// AdjustmentMode controls how the adjustment value is applied.
+type AdjustmentMode string
+
+const (
+	// ModePercent scales the target amount by value percent (e.g. 5 = +5%).
+	ModePercent AdjustmentMode = "PERCENT"
+	// ModeAbsolute adds value cents to the target amount.
+	ModeAbsolute AdjustmentMode = "ABSOLUTE"
+)
+
+// Adjustment describes a single bulk change.
+type Adjustment struct {
+	Target AdjustmentTarget
+	Mode   AdjustmentMode
+	Value  float64
+}
+
+// ArticleFilter selects article prices. A nil filter selects no articles; an
+// empty one selects all of them.
+type ArticleFilter struct {
+	IDs           []int
+	ArticleType   string
+	CategoryID    *int
+	SubcategoryID *int
+	SupplierID    *int
+	Active        *bool
+}
+
+// PromptFilter selects prompt prices. A nil filter selects no prompts; an
+// empty one selects all prompts with a price.
+type PromptFilter struct {
+	IDs           []int
+	CategoryID    *int
+	SubcategoryID *int
+	Active        *bool
+}
+
+// Selection combines the article and prompt filters of a bulk adjustment.
+type Selection struct {
+	Articles *ArticleFilter
+	Prompts  *PromptFilter
+}
+
+// PricedItem is a price row together with the article or prompt owning it.
+type PricedItem struct {
+	Price     article.Price
+	ArticleID *int
+	PromptID  *int
+	Name      string
+}
+
+// Batch is the audit record of an applied bulk adjustment.
+type Batch struct {
+	ID        int
+	Target    AdjustmentTarget
+	Mode      AdjustmentMode
+	Value     float64
+	Filter    string
+	ItemCount int
+	CreatedBy *int
+	CreatedAt time.Time
+	Entries   []Entry
+}
+
+// Entry records the old and new values of one adjusted price row. PriceID is
+// nil once that price row has been deleted; the entry stays in the audit trail.
+type Entry struct {
+	ID                    int
+	BatchID               int
+	PriceID               *int
+	ArticleID             *int
+	PromptID              *int
+	Name                  string
+	OldPurchasePriceNet   int
+	NewPurchasePriceNet   int
+	OldPurchaseTotalNet   int
+	NewPurchaseTotalNet   int
+	OldSalesMarginNet     int
+	NewSalesMarginNet     int
+	OldSalesMarginPercent float64
+	NewSalesMarginPercent float64
+	OldSalesTotalNet      int
+	NewSalesTotalNet      int
+	OldSalesTotalGross    int
+	NewSalesTotalGross    int
+	CreatedAt             time.Time
+}
@@ -0,0 +1,83 @@
+package promotion
+
+import (
+	"slices"
+	"time"
+)
+
+// Apply computes the discount p gives on lines and the shipping costs. It
+// fails with ErrNotApplicable when no line is eligible. The discount on the
+// items never exceeds their price.
+func Apply(p *Promotion, lines []Line, shipping int) (Discount, error) {
+	var eligible []Line
+	subtotal := 0
+	for _, l := range lines {
+		if l.Quantity > 0 && p.appliesTo(l) {
+			eligible = append(eligible, l)
+			subtotal += l.UnitPrice * l.Quantity
+		}
+	}
+	if len(eligible) == 0 {
+		return Discount{}, ErrNotApplicable
+	}
+	d := Discount{Promotion: *p}
+	switch p.Kind {
+	case KindPercentage:
+		d.Items = subtotal * p.Value / 100
+	case KindFixedAmount:
+		d.Items = min(p.Value, subtotal)
+	case KindFreeShipping:
+		d.Shipping = max(shipping, 0)
+	case KindBuyXGetOne:
+		d.Items = cheapestUnits(eligible, p.BuyQuantity)
+	}
+	return d, nil
+}
+
+// cheapestUnits sums the price of the cheapest unit of every buy+1 units.
+func cheapestUnits(lines []Line, buy int) int {
+	units := 0
+	for _, l := range lines {
+		units += l.Quantity
+	}
+	free := units / (buy + 1)
+	slices.SortStableFunc(lines, func(a, b Line) int { return a.UnitPrice - b.UnitPrice })
+	total := 0
+	for _, l := range lines {
+		n := min(free, l.Quantity)
+		total += n * l.UnitPrice
+		free -= n
+		if free == 0 {
+			break
+		}
+	}
+	return total
+}
+
+func (p *Promotion) appliesTo(l Line) bool {
+	if len(p.ArticleIDs) > 0 && !slices.Contains(p.ArticleIDs, l.ArticleID) {
+		return false
+	}
+	if len(p.CategoryIDs) > 0 && !slices.Contains(p.CategoryIDs, l.CategoryID) {
+		return false
+	}
+	if len(p.PromptIDs) > 0 && (l.PromptID == nil || !slices.Contains(p.PromptIDs, *l.PromptID)) {
+		return false
+	}
+	return true
+}
+
+// usable fails when p cannot be used at now, or by a user who already used
+// it userUses times.
+func (p *Promotion) usable(now time.Time, userUses int) error {
+	if !p.Active || (p.StartsAt != nil && now.Before(*p.StartsAt)) || (p.EndsAt != nil && !now.Before(*p.EndsAt)) {
+		return ErrNotActive
+	}
+	if p.MaxUses != nil && p.TimesUsed >= *p.MaxUses {
+		return ErrUsageLimitReached
+	}
+	if p.MaxUsesPerUser != nil && userUses >= *p.MaxUsesPerUser {
+		return ErrUsageLimitReached
+	}
+	return nil
+}
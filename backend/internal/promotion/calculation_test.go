@@ -0,0 +1,70 @@
+package promotion
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestApplyDiscountsEligibleLines(t *testing.T) {
+	prompt := 7
+	lines := []Line{
+		{ArticleID: 1, CategoryID: 10, Quantity: 2, UnitPrice: 1500},
+		{ArticleID: 2, CategoryID: 20, PromptID: &prompt, Quantity: 3, UnitPrice: 1000},
+	}
+	cases := []struct {
+		name     string
+		p        Promotion
+		items    int
+		shipping int
+	}{
+		{"percentage of all items", Promotion{Kind: KindPercentage, Value: 10}, 600, 0},
+		{"percentage of a category", Promotion{Kind: KindPercentage, Value: 10, CategoryIDs: []int{20}}, 300, 0},
+		{"fixed amount capped at eligible items", Promotion{Kind: KindFixedAmount, Value: 5000, ArticleIDs: []int{1}}, 3000, 0},
+		{"free shipping", Promotion{Kind: KindFreeShipping}, 0, 499},
+		{"buy two get the cheapest free", Promotion{Kind: KindBuyXGetOne, BuyQuantity: 2}, 1000, 0},
+		{"buy one get one of a prompt", Promotion{Kind: KindBuyXGetOne, BuyQuantity: 1, PromptIDs: []int{7}}, 1000, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := Apply(&tc.p, lines, 499)
+			if err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+			if d.Items != tc.items || d.Shipping != tc.shipping {
+				t.Fatalf("expected %d off items and %d off shipping, got %+v", tc.items, tc.shipping, d)
+			}
+		})
+	}
+
+	// Restrictions of different types must all match.
+	p := Promotion{Kind: KindPercentage, Value: 10, ArticleIDs: []int{1}, PromptIDs: []int{7}}
+	if _, err := Apply(&p, lines, 499); !errors.Is(err, ErrNotApplicable) {
+		t.Fatalf("expected ErrNotApplicable, got %v", err)
+	}
+}
+
+func TestUsableChecksWindowAndLimits(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	cases := []struct {
+		name     string
+		p        Promotion
+		userUses int
+		want     error
+	}{
+		{"open", Promotion{Active: true}, 0, nil},
+		{"inactive", Promotion{Active: false}, 0, ErrNotActive},
+		{"not started", Promotion{Active: true, StartsAt: &future}, 0, ErrNotActive},
+		{"ended", Promotion{Active: true, EndsAt: &past}, 0, ErrNotActive},
+		{"used up", Promotion{Active: true, MaxUses: intPtr(3), TimesUsed: 3}, 0, ErrUsageLimitReached},
+		{"used up by user", Promotion{Active: true, MaxUsesPerUser: intPtr(1)}, 1, ErrUsageLimitReached},
+	}
+	for _, tc := range cases {
+		if err := tc.p.usable(now, tc.userUses); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
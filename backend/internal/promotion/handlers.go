@@ -0,0 +1,194 @@
+package promotion
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type promotionRequest struct {
+	Code           string     `json:"code" binding:"required"`
+	Description    *string    `json:"description"`
+	Kind           Kind       `json:"kind" binding:"required"`
+	Value          int        `json:"value"`
+	BuyQuantity    int        `json:"buyQuantity"`
+	StartsAt       *time.Time `json:"startsAt"`
+	EndsAt         *time.Time `json:"endsAt"`
+	MaxUses        *int       `json:"maxUses"`
+	MaxUsesPerUser *int       `json:"maxUsesPerUser"`
+	Active         *bool      `json:"active"`
+	ArticleIDs     []int      `json:"articleIds"`
+	CategoryIDs    []int      `json:"categoryIds"`
+	PromptIDs      []int      `json:"promptIds"`
+}
+
+type PromotionResponse struct {
+	ID             int        `json:"id"`
+	Code           string     `json:"code"`
+	Description    *string    `json:"description"`
+	Kind           Kind       `json:"kind"`
+	Value          int        `json:"value"`
+	BuyQuantity    int        `json:"buyQuantity"`
+	StartsAt       *time.Time `json:"startsAt"`
+	EndsAt         *time.Time `json:"endsAt"`
+	MaxUses        *int       `json:"maxUses"`
+	MaxUsesPerUser *int       `json:"maxUsesPerUser"`
+	TimesUsed      int        `json:"timesUsed"`
+	Active         bool       `json:"active"`
+	ArticleIDs     []int      `json:"articleIds"`
+	CategoryIDs    []int      `json:"categoryIds"`
+	PromptIDs      []int      `json:"promptIds"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// RegisterRoutes mounts the promotion admin routes under
+// /api/admin/promotions.
+func RegisterRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service) {
+	grp := r.Group("/api/admin/promotions")
+	grp.Use(adminMiddleware)
+
+	grp.GET("", func(c *gin.Context) {
+		promotions, err := svc.List(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch promotions"})
+			return
+		}
+		out := make([]PromotionResponse, 0, len(promotions))
+		for i := range promotions {
+			out = append(out, toPromotionResponse(&promotions[i]))
+		}
+		c.JSON(http.StatusOK, out)
+	})
+
+	grp.GET("/:id", func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+		p, err := svc.Get(c.Request.Context(), id)
+		if err != nil {
+			writeError(c, err, "Failed to fetch promotion")
+			return
+		}
+		c.JSON(http.StatusOK, toPromotionResponse(p))
+	})
+
+	grp.POST("", func(c *gin.Context) {
+		var req promotionRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		p, err := svc.Create(c.Request.Context(), req.toInput())
+		if err != nil {
+			writeError(c, err, "Failed to create promotion")
+			return
+		}
+		c.JSON(http.StatusCreated, toPromotionResponse(p))
+	})
+
+	grp.PUT("/:id", func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+		var req promotionRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		p, err := svc.Update(c.Request.Context(), id, req.toInput())
+		if err != nil {
+			writeError(c, err, "Failed to update promotion")
+			return
+		}
+		c.JSON(http.StatusOK, toPromotionResponse(p))
+	})
+
+	grp.DELETE("/:id", func(c *gin.Context) {
+		id, ok := parseID(c)
+		if !ok {
+			return
+		}
+		if err := svc.Delete(c.Request.Context(), id); err != nil {
+			writeError(c, err, "Failed to delete promotion")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+}
+
+// toInput maps the request; promotions are active unless active is false.
+func (r promotionRequest) toInput() PromotionInput {
+	return PromotionInput{
+		Code:           r.Code,
+		Description:    r.Description,
+		Kind:           r.Kind,
+		Value:          r.Value,
+		BuyQuantity:    r.BuyQuantity,
+		StartsAt:       r.StartsAt,
+		EndsAt:         r.EndsAt,
+		MaxUses:        r.MaxUses,
+		MaxUsesPerUser: r.MaxUsesPerUser,
+		Active:         r.Active == nil || *r.Active,
+		ArticleIDs:     r.ArticleIDs,
+		CategoryIDs:    r.CategoryIDs,
+		PromptIDs:      r.PromptIDs,
+	}
+}
+
+func toPromotionResponse(p *Promotion) PromotionResponse {
+	return PromotionResponse{
+		ID:             p.ID,
+		Code:           p.Code,
+		Description:    p.Description,
+		Kind:           p.Kind,
+		Value:          p.Value,
+		BuyQuantity:    p.BuyQuantity,
+		StartsAt:       p.StartsAt,
+		EndsAt:         p.EndsAt,
+		MaxUses:        p.MaxUses,
+		MaxUsesPerUser: p.MaxUsesPerUser,
+		TimesUsed:      p.TimesUsed,
+		Active:         p.Active,
+		ArticleIDs:     nonNil(p.ArticleIDs),
+		CategoryIDs:    nonNil(p.CategoryIDs),
+		PromptIDs:      nonNil(p.PromptIDs),
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+}
+
+func nonNil(ids []int) []int {
+	if ids == nil {
+		return []int{}
+	}
+	return ids
+}
+
+func parseID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid id"})
+		return 0, false
+	}
+	return id, true
+}
+
+func writeError(c *gin.Context, err error, fallback string) {
+	var validation ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": "Promotion not found"})
+	case errors.Is(err, ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"detail": ErrConflict.Error()})
+	case errors.As(err, &validation):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/promotion"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+var _ promotion.Repository = (*Repository)(nil)
+
+func (r *Repository) List(ctx context.Context) ([]promotion.Promotion, error) {
+	var rows []PromotionRow
+	if err := r.db.WithContext(ctx).Preload("Targets", orderTargets).Order("created_at desc, id desc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]promotion.Promotion, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].toDomain())
+	}
+	return out, nil
+}
+
+func (r *Repository) ByID(ctx context.Context, id int) (*promotion.Promotion, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *Repository) ByCode(ctx context.Context, code string) (*promotion.Promotion, error) {
+	return r.first(ctx, "code = ?", code)
+}
+
+func (r *Repository) first(ctx context.Context, query string, arg any) (*promotion.Promotion, error) {
+	var row PromotionRow
+	if err := r.db.WithContext(ctx).Preload("Targets", orderTargets).Where(query, arg).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, promotion.ErrNotFound
+		}
+		return nil, err
+	}
+	p := row.toDomain()
+	return &p, nil
+}
+
+func (r *Repository) Create(ctx context.Context, p *promotion.Promotion) error {
+	row := fromDomain(*p)
+	targets := row.Targets
+	row.Targets = nil
+	return r.save(ctx, p, func(tx *gorm.DB) error {
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+		return saveTargets(tx, row.ID, targets)
+	})
+}
+
+func (r *Repository) Update(ctx context.Context, p *promotion.Promotion) error {
+	row := fromDomain(*p)
+	targets := row.Targets
+	row.Targets = nil
+	return r.save(ctx, p, func(tx *gorm.DB) error {
+		if err := tx.Omit("times_used", "created_at").Save(&row).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("promotion_id = ?", row.ID).Delete(&PromotionTargetRow{}).Error; err != nil {
+			return err
+		}
+		return saveTargets(tx, row.ID, targets)
+	})
+}
+
+// save runs write in a transaction and reloads p by its code.
+func (r *Repository) save(ctx context.Context, p *promotion.Promotion, write func(tx *gorm.DB) error) error {
+	if err := r.db.WithContext(ctx).Transaction(write); err != nil {
+		if isUniqueViolation(err) {
+			return promotion.ErrConflict
+		}
+		return err
+	}
+	saved, err := r.ByCode(ctx, p.Code)
+	if err != nil {
+		return err
+	}
+	*p = *saved
+	return nil
+}
+
+func saveTargets(tx *gorm.DB, promotionID int, targets []PromotionTargetRow) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	for i := range targets {
+		targets[i].PromotionID = promotionID
+	}
+	return tx.Create(&targets).Error
+}
+
+func (r *Repository) Delete(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("promotion_id = ?", id).Delete(&PromotionTargetRow{}).Error; err != nil {
+			return err
+		}
+		res := tx.Delete(&PromotionRow{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return promotion.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (r *Repository) CountRedemptions(ctx context.Context, promotionID, userID int) (int, error) {
+	var n int64
+	err := r.db.WithContext(ctx).Model(&PromotionRedemptionRow{}).
+		Where("promotion_id = ? AND user_id = ?", promotionID, userID).
+		Count(&n).Error
+	return int(n), err
+}
+
+func (r *Repository) ArticleCategories(ctx context.Context, articleIDs []int) (map[int]int, error) {
+	out := make(map[int]int, len(articleIDs))
+	if len(articleIDs) == 0 {
+		return out, nil
+	}
+	var rows []struct {
+		ID         int
+		CategoryID int
+	}
+	if err := r.db.WithContext(ctx).Table("articles").
+		Select("id, category_id").
+		Where("id IN ?", articleIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.ID] = row.CategoryID
+	}
+	return out, nil
+}
+
+func (r *Repository) Redeem(ctx context.Context, red *promotion.Redemption) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Counting the use only while uses are left keeps concurrent orders
+		// from exceeding MaxUses. The update also locks the promotion row, so
+		// the per-user count below sees the redemptions of concurrent orders.
+		res := tx.Model(&PromotionRow{}).
+			Where("id = ? AND (max_uses IS NULL OR times_used < max_uses)", red.PromotionID).
+			UpdateColumn("times_used", gorm.Expr("times_used + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+		var p PromotionRow
+		if err := tx.Select("id", "max_uses_per_user").First(&p, red.PromotionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return promotion.ErrNotFound
+			}
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return promotion.ErrUsageLimitReached
+		}
+		if p.MaxUsesPerUser != nil {
+			var uses int64
+			if err := tx.Model(&PromotionRedemptionRow{}).
+				Where("promotion_id = ? AND user_id = ?", red.PromotionID, red.UserID).
+				Count(&uses).Error; err != nil {
+				return err
+			}
+			if uses >= int64(*p.MaxUsesPerUser) {
+				return promotion.ErrUsageLimitReached
+			}
+		}
+		row := PromotionRedemptionRow{PromotionID: red.PromotionID, UserID: red.UserID, OrderID: red.OrderID, Amount: red.Amount}
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+		red.ID, red.CreatedAt = row.ID, row.CreatedAt
+		return nil
+	})
+}
+
+func (r *Repository) Release(ctx context.Context, orderID int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var row PromotionRedemptionRow
+		err := tx.Where("order_id = ?", orderID).First(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&row).Error; err != nil {
+			return err
+		}
+		return tx.Model(&PromotionRow{}).
+			Where("id = ? AND times_used > 0", row.PromotionID).
+			UpdateColumn("times_used", gorm.Expr("times_used - 1")).Error
+	})
+}
+
+func orderTargets(tx *gorm.DB) *gorm.DB {
+	return tx.Order("target_type asc, target_id asc")
+}
+
+func isUniqueViolation(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unique constraint") ||
+		strings.Contains(msg, "duplicate key value") ||
+		strings.Contains(msg, "unique failed")
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/promotion"
+)
+
+func TestRedeemEnforcesUsageLimits(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.AutoMigrate(&PromotionRow{}, &PromotionTargetRow{}, &PromotionRedemptionRow{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	repo := NewRepository(db)
+	svc := promotion.NewService(repo)
+	ctx := context.Background()
+
+	total, perUser := 3, 1
+	p, err := svc.Create(ctx, promotion.PromotionInput{
+		Code: " summer10 ", Kind: promotion.KindPercentage, Value: 10, Active: true,
+		MaxUses: &total, MaxUsesPerUser: &perUser, CategoryIDs: []int{4, 2, 4},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if p.Code != "SUMMER10" || len(p.CategoryIDs) != 2 || p.CategoryIDs[0] != 2 {
+		t.Fatalf("unexpected promotion: %+v", p)
+	}
+	if _, err := svc.Create(ctx, promotion.PromotionInput{Code: "Summer10", Kind: promotion.KindFreeShipping}); !errors.Is(err, promotion.ErrConflict) {
+		t.Fatalf("expected a conflict for a duplicate code, got %v", err)
+	}
+
+	redeem := func(userID int, orderID int64) error {
+		return repo.Redeem(ctx, &promotion.Redemption{PromotionID: p.ID, UserID: userID, OrderID: orderID, Amount: 100})
+	}
+	if err := redeem(1, 1); err != nil {
+		t.Fatalf("first redemption: %v", err)
+	}
+	if err := redeem(1, 2); !errors.Is(err, promotion.ErrUsageLimitReached) {
+		t.Fatalf("a second use by the same user should be refused, got %v", err)
+	}
+	if err := redeem(2, 3); err != nil {
+		t.Fatalf("redemption of user 2: %v", err)
+	}
+	if err := redeem(3, 4); err != nil {
+		t.Fatalf("redemption of user 3: %v", err)
+	}
+	if err := redeem(4, 5); !errors.Is(err, promotion.ErrUsageLimitReached) {
+		t.Fatalf("redemptions beyond maxUses should be refused, got %v", err)
+	}
+
+	if err := repo.Release(ctx, 1); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if err := redeem(4, 5); err != nil {
+		t.Fatalf("a released use should be available again: %v", err)
+	}
+	got, err := svc.Update(ctx, p.ID, promotion.PromotionInput{Code: "SUMMER10", Kind: promotion.KindPercentage, Value: 15, MaxUses: &total, ArticleIDs: []int{9}})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got.TimesUsed != 3 || got.Value != 15 || got.Active || len(got.CategoryIDs) != 0 || len(got.ArticleIDs) != 1 {
+		t.Fatalf("update should replace the attributes but keep the uses: %+v", got)
+	}
+	if uses, _ := repo.CountRedemptions(ctx, p.ID, 1); uses != 0 {
+		t.Fatalf("the released redemption should not count for user 1, got %d", uses)
+	}
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"time"
+
+	"voenix/backend/internal/promotion"
+)
+
+const (
+	targetArticle  = "ARTICLE"
+	targetCategory = "CATEGORY"
+	targetPrompt   = "PROMPT"
+)
+
+type PromotionRow struct {
+	ID             int                  `gorm:"primaryKey;column:id"`
+	Code           string               `gorm:"column:code;size:64;uniqueIndex;not null"`
+	Description    *string              `gorm:"column:description;type:text"`
+	Kind           string               `gorm:"column:kind;size:20;not null"`
+	Value          int                  `gorm:"column:value;not null;default:0"`
+	BuyQuantity    int                  `gorm:"column:buy_quantity;not null;default:0"`
+	StartsAt       *time.Time           `gorm:"column:starts_at"`
+	EndsAt         *time.Time           `gorm:"column:ends_at"`
+	MaxUses        *int                 `gorm:"column:max_uses"`
+	MaxUsesPerUser *int                 `gorm:"column:max_uses_per_user"`
+	TimesUsed      int                  `gorm:"column:times_used;not null;default:0"`
+	Active         bool                 `gorm:"column:active;not null;default:true"`
+	Targets        []PromotionTargetRow `gorm:"foreignKey:PromotionID;references:ID"`
+	CreatedAt      time.Time            `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time            `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (PromotionRow) TableName() string { return "promotions" }
+
+type PromotionTargetRow struct {
+	PromotionID int    `gorm:"primaryKey;column:promotion_id"`
+	TargetType  string `gorm:"primaryKey;column:target_type;size:20"`
+	TargetID    int    `gorm:"primaryKey;column:target_id;autoIncrement:false"`
+}
+
+func (PromotionTargetRow) TableName() string { return "promotion_targets" }
+
+type PromotionRedemptionRow struct {
+	ID          int       `gorm:"primaryKey;column:id"`
+	PromotionID int       `gorm:"column:promotion_id;not null;index"`
+	UserID      int       `gorm:"column:user_id;not null"`
+	OrderID     int64     `gorm:"column:order_id;not null;uniqueIndex"`
+	Amount      int64     `gorm:"column:amount;not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (PromotionRedemptionRow) TableName() string { return "promotion_redemptions" }
+
+func fromDomain(p promotion.Promotion) PromotionRow {
+	row := PromotionRow{
+		ID:             p.ID,
+		Code:           p.Code,
+		Description:    p.Description,
+		Kind:           string(p.Kind),
+		Value:          p.Value,
+		BuyQuantity:    p.BuyQuantity,
+		StartsAt:       p.StartsAt,
+		EndsAt:         p.EndsAt,
+		MaxUses:        p.MaxUses,
+		MaxUsesPerUser: p.MaxUsesPerUser,
+		TimesUsed:      p.TimesUsed,
+		Active:         p.Active,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+	for _, t := range []struct {
+		kind string
+		ids  []int
+	}{{targetArticle, p.ArticleIDs}, {targetCategory, p.CategoryIDs}, {targetPrompt, p.PromptIDs}} {
+		for _, id := range t.ids {
+			row.Targets = append(row.Targets, PromotionTargetRow{PromotionID: p.ID, TargetType: t.kind, TargetID: id})
+		}
+	}
+	return row
+}
+
+func (r PromotionRow) toDomain() promotion.Promotion {
+	p := promotion.Promotion{
+		ID:             r.ID,
+		Code:           r.Code,
+		Description:    r.Description,
+		Kind:           promotion.Kind(r.Kind),
+		Value:          r.Value,
+		BuyQuantity:    r.BuyQuantity,
+		StartsAt:       r.StartsAt,
+		EndsAt:         r.EndsAt,
+		MaxUses:        r.MaxUses,
+		MaxUsesPerUser: r.MaxUsesPerUser,
+		TimesUsed:      r.TimesUsed,
+		Active:         r.Active,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+	for _, t := range r.Targets {
+		switch t.TargetType {
+		case targetArticle:
+			p.ArticleIDs = append(p.ArticleIDs, t.TargetID)
+		case targetCategory:
+			p.CategoryIDs = append(p.CategoryIDs, t.TargetID)
+		case targetPrompt:
+			p.PromptIDs = append(p.PromptIDs, t.TargetID)
+		}
+	}
+	return p
+}
@@ -0,0 +1,25 @@
+package promotion
+
+import "context"
+
+// Repository persists promotions and their redemptions.
+type Repository interface {
+	List(ctx context.Context) ([]Promotion, error)
+	// ByID and ByCode fail with ErrNotFound.
+	ByID(ctx context.Context, id int) (*Promotion, error)
+	ByCode(ctx context.Context, code string) (*Promotion, error)
+	// Create and Update store the promotion with its restrictions. They fail
+	// with ErrConflict when another promotion has the code.
+	Create(ctx context.Context, p *Promotion) error
+	Update(ctx context.Context, p *Promotion) error
+	Delete(ctx context.Context, id int) error
+	// CountRedemptions returns how often userID used the promotion.
+	CountRedemptions(ctx context.Context, promotionID, userID int) (int, error)
+	// ArticleCategories maps the articles to their category.
+	ArticleCategories(ctx context.Context, articleIDs []int) (map[int]int, error)
+	// Redeem records the redemption and counts it against the limits of its
+	// promotion. It fails with ErrUsageLimitReached when they are exhausted.
+	Redeem(ctx context.Context, r *Redemption) error
+	// Release undoes the redemption of an order, if there is one.
+	Release(ctx context.Context, orderID int64) error
+}
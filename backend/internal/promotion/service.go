@@ -0,0 +1,182 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("promotion not found")
+	ErrConflict = errors.New("a promotion with this code already exists")
+	// ErrNotActive, ErrUsageLimitReached and ErrNotApplicable reject a code
+	// customers entered.
+	ErrNotActive         = errors.New("promotion code is not valid at this time")
+	ErrUsageLimitReached = errors.New("promotion code has reached its usage limit")
+	ErrNotApplicable     = errors.New("promotion code does not apply to any item")
+)
+
+// Rejected reports whether err rejects a promotion code rather than being a
+// failure to check it.
+func Rejected(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrNotActive) ||
+		errors.Is(err, ErrUsageLimitReached) || errors.Is(err, ErrNotApplicable)
+}
+
+type ValidationError struct {
+	message string
+}
+
+func (e ValidationError) Error() string { return e.message }
+
+func newValidationError(msg string) error { return ValidationError{message: msg} }
+
+var codePattern = regexp.MustCompile(`^[A-Z0-9_-]{1,64}$`)
+
+// NormalizeCode returns code the way it is stored; codes are not case
+// sensitive.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) List(ctx context.Context) ([]Promotion, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *Service) Get(ctx context.Context, id int) (*Promotion, error) {
+	return s.repo.ByID(ctx, id)
+}
+
+func (s *Service) Create(ctx context.Context, in PromotionInput) (*Promotion, error) {
+	p := &Promotion{}
+	if err := applyInput(p, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Service) Update(ctx context.Context, id int, in PromotionInput) (*Promotion, error) {
+	p, err := s.repo.ByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyInput(p, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// Quote checks that userID may use the promotion with code now and computes
+// its discount on lines and the shipping costs. Without a user the limit per
+// user is not checked; it is checked again when the order is placed.
+func (s *Service) Quote(ctx context.Context, code string, userID int, lines []Line, shipping int) (*Discount, error) {
+	p, err := s.repo.ByCode(ctx, NormalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	uses := 0
+	if userID != 0 && p.MaxUsesPerUser != nil {
+		if uses, err = s.repo.CountRedemptions(ctx, p.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.usable(time.Now(), uses); err != nil {
+		return nil, err
+	}
+	if len(p.CategoryIDs) > 0 {
+		ids := make([]int, 0, len(lines))
+		for _, l := range lines {
+			ids = append(ids, l.ArticleID)
+		}
+		categories, err := s.repo.ArticleCategories(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		lines = slices.Clone(lines)
+		for i := range lines {
+			lines[i].CategoryID = categories[lines[i].ArticleID]
+		}
+	}
+	d, err := Apply(p, lines, shipping)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// applyInput validates in and copies it onto p.
+func applyInput(p *Promotion, in PromotionInput) error {
+	code := NormalizeCode(in.Code)
+	if !codePattern.MatchString(code) {
+		return newValidationError("code must be 1-64 letters, digits, '-' or '_'")
+	}
+	value, buy := in.Value, in.BuyQuantity
+	switch in.Kind {
+	case KindPercentage:
+		if value < 1 || value > 100 {
+			return newValidationError("percentage must be between 1 and 100")
+		}
+		buy = 0
+	case KindFixedAmount:
+		if value < 1 {
+			return newValidationError("amount must be positive")
+		}
+		buy = 0
+	case KindFreeShipping:
+		value, buy = 0, 0
+	case KindBuyXGetOne:
+		if buy < 1 {
+			return newValidationError("buyQuantity must be at least 1")
+		}
+		value = 0
+	default:
+		return newValidationError("unknown promotion kind")
+	}
+	if in.StartsAt != nil && in.EndsAt != nil && !in.StartsAt.Before(*in.EndsAt) {
+		return newValidationError("startsAt must be before endsAt")
+	}
+	if (in.MaxUses != nil && *in.MaxUses < 1) || (in.MaxUsesPerUser != nil && *in.MaxUsesPerUser < 1) {
+		return newValidationError("usage limits must be positive")
+	}
+	targets := [][]int{in.ArticleIDs, in.CategoryIDs, in.PromptIDs}
+	for i, ids := range targets {
+		ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+		if len(ids) > 0 && ids[0] < 1 {
+			return newValidationError("invalid restriction id")
+		}
+		targets[i] = ids
+	}
+	p.Code = code
+	p.Description = in.Description
+	p.Kind = in.Kind
+	p.Value = value
+	p.BuyQuantity = buy
+	p.StartsAt = in.StartsAt
+	p.EndsAt = in.EndsAt
+	p.MaxUses = in.MaxUses
+	p.MaxUsesPerUser = in.MaxUsesPerUser
+	p.Active = in.Active
+	p.ArticleIDs, p.CategoryIDs, p.PromptIDs = targets[0], targets[1], targets[2]
+	return nil
+}
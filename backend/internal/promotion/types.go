@@ -0,0 +1,96 @@
+package promotion
+
+import "time"
+
+// Kind selects how a promotion discounts a cart.
+type Kind string
+
+const (
+	// KindPercentage takes Value percent off the eligible items.
+	KindPercentage Kind = "PERCENTAGE"
+	// KindFixedAmount takes Value cents off the eligible items.
+	KindFixedAmount Kind = "FIXED_AMOUNT"
+	// KindFreeShipping waives the shipping costs.
+	KindFreeShipping Kind = "FREE_SHIPPING"
+	// KindBuyXGetOne makes the cheapest unit of every BuyQuantity+1 eligible
+	// units free.
+	KindBuyXGetOne Kind = "BUY_X_GET_ONE"
+)
+
+// Promotion is a discount customers get by entering its code.
+type Promotion struct {
+	ID          int
+	Code        string
+	Description *string
+	Kind        Kind
+	// Value is the percentage for KindPercentage and the amount in cents for
+	// KindFixedAmount.
+	Value       int
+	BuyQuantity int
+	// StartsAt and EndsAt bound when the code can be used; EndsAt is
+	// exclusive.
+	StartsAt *time.Time
+	EndsAt   *time.Time
+	// MaxUses limits the orders using the code; MaxUsesPerUser the orders of
+	// one user. TimesUsed counts the orders so far.
+	MaxUses        *int
+	MaxUsesPerUser *int
+	TimesUsed      int
+	Active         bool
+	// ArticleIDs, CategoryIDs and PromptIDs restrict the promotion to items
+	// matching one ID of every non-empty list.
+	ArticleIDs  []int
+	CategoryIDs []int
+	PromptIDs   []int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// PromotionInput carries the attributes admins set on a promotion.
+type PromotionInput struct {
+	Code           string
+	Description    *string
+	Kind           Kind
+	Value          int
+	BuyQuantity    int
+	StartsAt       *time.Time
+	EndsAt         *time.Time
+	MaxUses        *int
+	MaxUsesPerUser *int
+	Active         bool
+	ArticleIDs     []int
+	CategoryIDs    []int
+	PromptIDs      []int
+}
+
+// Line is an item a promotion may discount, e.g. a cart item.
+type Line struct {
+	ArticleID int
+	// CategoryID is filled in by Service.Quote.
+	CategoryID int
+	PromptID   *int
+	Quantity   int
+	// UnitPrice is the price of one unit in cents.
+	UnitPrice int
+}
+
+// Discount is what a promotion takes off, in cents: Items off the items and
+// Shipping off the shipping costs.
+type Discount struct {
+	Promotion Promotion
+	Items     int
+	Shipping  int
+}
+
+// Total is the whole discount.
+func (d Discount) Total() int { return d.Items + d.Shipping }
+
+// Redemption records that a user's order used a promotion.
+type Redemption struct {
+	ID          int
+	PromotionID int
+	UserID      int
+	OrderID     int64
+	Amount      int64
+	CreatedAt   time.Time
+}
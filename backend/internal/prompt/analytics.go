@@ -0,0 +1,131 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Public prompt listing sort orders.
+const (
+	PublicPromptSortDefault = ""
+	PublicPromptSortPopular = "popular"
+)
+
+// popularityWindow is the trailing period used to rank prompts for the
+// "popular" public sort.
+const popularityWindow = 90 * 24 * time.Hour
+
+// PromptAnalytics reports funnel metrics for every prompt within [from, to).
+// Prompts without any activity are included with zero values so the admin UI
+// can spot prompts that never sell. Rows are ordered by revenue, then orders.
+func (s *Service) PromptAnalytics(ctx context.Context, from, to *time.Time) (*PromptAnalyticsReportRead, error) {
+	prompts, err := s.repo.ListPrompts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.repo.PromptUsage(ctx, nil, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byPrompt := make(map[int]PromptUsage, len(usage))
+	for _, u := range usage {
+		byPrompt[u.PromptID] = u
+	}
+
+	report := PromptAnalyticsReportRead{From: from, To: to, Prompts: make([]PromptAnalyticsRead, 0, len(prompts))}
+	for i := range prompts {
+		row := toPromptAnalyticsRead(&prompts[i], byPrompt[prompts[i].ID])
+		report.Prompts = append(report.Prompts, row)
+		report.Totals.Generations += row.Generations
+		report.Totals.CartAdds += row.CartAdds
+		report.Totals.Orders += row.Orders
+		report.Totals.Revenue += row.Revenue
+	}
+	report.Totals.ConversionRate = conversionRate(report.Totals.Orders, report.Totals.Generations)
+	sort.SliceStable(report.Prompts, func(i, j int) bool {
+		a, b := report.Prompts[i], report.Prompts[j]
+		if a.Revenue != b.Revenue {
+			return a.Revenue > b.Revenue
+		}
+		if a.Orders != b.Orders {
+			return a.Orders > b.Orders
+		}
+		return a.PromptID < b.PromptID
+	})
+	return &report, nil
+}
+
+// PromptAnalyticsByID reports funnel metrics for a single prompt. It returns
+// (nil, nil) when the prompt does not exist.
+func (s *Service) PromptAnalyticsByID(ctx context.Context, id int, from, to *time.Time) (*PromptAnalyticsRead, error) {
+	p, err := s.repo.PromptByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	usage, err := s.repo.PromptUsage(ctx, []int{id}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var u PromptUsage
+	for _, row := range usage {
+		if row.PromptID == id {
+			u = row
+		}
+	}
+	v := toPromptAnalyticsRead(p, u)
+	return &v, nil
+}
+
+// sortByPopularity orders prompts by orders, cart additions and generations
+// within the popularity window. Ties keep the repository order.
+func (s *Service) sortByPopularity(ctx context.Context, prompts []Prompt) error {
+	since := time.Now().UTC().Add(-popularityWindow)
+	usage, err := s.repo.PromptUsage(ctx, nil, &since, nil)
+	if err != nil {
+		return err
+	}
+	byPrompt := make(map[int]PromptUsage, len(usage))
+	for _, u := range usage {
+		byPrompt[u.PromptID] = u
+	}
+	sort.SliceStable(prompts, func(i, j int) bool {
+		a, b := byPrompt[prompts[i].ID], byPrompt[prompts[j].ID]
+		if a.Orders != b.Orders {
+			return a.Orders > b.Orders
+		}
+		if a.CartAdds != b.CartAdds {
+			return a.CartAdds > b.CartAdds
+		}
+		return a.Generations > b.Generations
+	})
+	return nil
+}
+
+func toPromptAnalyticsRead(p *Prompt, u PromptUsage) PromptAnalyticsRead {
+	return PromptAnalyticsRead{
+		PromptID:       p.ID,
+		Title:          p.Title,
+		Active:         p.Active,
+		Generations:    u.Generations,
+		CartAdds:       u.CartAdds,
+		Orders:         u.Orders,
+		Revenue:        u.Revenue,
+		ConversionRate: conversionRate(u.Orders, u.Generations),
+	}
+}
+
+// conversionRate returns orders per generation rounded to four decimals.
+func conversionRate(orders, generations int) float64 {
+	if generations <= 0 {
+		return 0
+	}
+	return math.Round(float64(orders)/float64(generations)*10000) / 10000
+}
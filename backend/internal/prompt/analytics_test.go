@@ -0,0 +1,109 @@
+package prompt
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type analyticsRepository struct {
+	*mockRepository
+	prompts []Prompt
+	usage   []PromptUsage
+}
+
+func (r *analyticsRepository) ListPrompts(context.Context) ([]Prompt, error) {
+	return append([]Prompt(nil), r.prompts...), nil
+}
+
+func (r *analyticsRepository) ListPublicPrompts(context.Context) ([]Prompt, error) {
+	return append([]Prompt(nil), r.prompts...), nil
+}
+
+func (r *analyticsRepository) PromptUsage(_ context.Context, promptIDs []int, _, _ *time.Time) ([]PromptUsage, error) {
+	if len(promptIDs) == 0 {
+		return r.usage, nil
+	}
+	out := []PromptUsage{}
+	for _, u := range r.usage {
+		for _, id := range promptIDs {
+			if u.PromptID == id {
+				out = append(out, u)
+			}
+		}
+	}
+	return out, nil
+}
+
+func newAnalyticsService() *Service {
+	repo := &analyticsRepository{
+		mockRepository: newMockRepository(),
+		prompts: []Prompt{
+			{ID: 3, Title: "Unused", Active: true},
+			{ID: 2, Title: "Browsed", Active: true},
+			{ID: 1, Title: "Bestseller", Active: true},
+		},
+		usage: []PromptUsage{
+			{PromptID: 1, Generations: 40, CartAdds: 10, Orders: 5, Revenue: 12500},
+			{PromptID: 2, Generations: 80, CartAdds: 12, Orders: 0, Revenue: 0},
+		},
+	}
+	return NewService(repo, nil)
+}
+
+func TestPromptAnalyticsReportsFunnelPerPrompt(t *testing.T) {
+	svc := newAnalyticsService()
+
+	report, err := svc.PromptAnalytics(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Prompts) != 3 {
+		t.Fatalf("expected all prompts including unused ones, got %d", len(report.Prompts))
+	}
+	first := report.Prompts[0]
+	if first.PromptID != 1 || first.Revenue != 12500 || first.ConversionRate != 0.125 {
+		t.Fatalf("expected bestseller first with 12.5%% conversion, got %+v", first)
+	}
+	if report.Prompts[2].PromptID != 3 || report.Prompts[2].Generations != 0 {
+		t.Fatalf("expected unused prompt last with zero usage, got %+v", report.Prompts[2])
+	}
+	if report.Totals.Generations != 120 || report.Totals.Orders != 5 || report.Totals.Revenue != 12500 {
+		t.Fatalf("unexpected totals: %+v", report.Totals)
+	}
+}
+
+func TestListPublicPromptsPopularSort(t *testing.T) {
+	svc := newAnalyticsService()
+
+	rows, err := svc.ListPublicPrompts(context.Background(), PublicPromptSortPopular)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := []int{rows[0].ID, rows[1].ID, rows[2].ID}
+	want := []int{1, 2, 3}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, got)
+		}
+	}
+}
+
+func TestParseAnalyticsRangeTreatsDateOnlyToAsInclusive(t *testing.T) {
+	from, to, err := parseAnalyticsRange("2025-03-01", "2025-03-31")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !from.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected from: %v", from)
+	}
+	if !to.Equal(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected exclusive upper bound on the next day, got %v", to)
+	}
+	if _, _, err := parseAnalyticsRange("2025-03-31", "2025-03-01"); err == nil {
+		t.Fatalf("expected error for inverted range")
+	}
+	if _, _, err := parseAnalyticsRange("yesterday", ""); err == nil {
+		t.Fatalf("expected error for invalid date")
+	}
+}
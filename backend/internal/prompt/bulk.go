@@ -0,0 +1,234 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// WithTransaction runs operation with a Service bound to a single database
+// transaction. Any error rolls back every write made through that Service.
+func (s *Service) WithTransaction(ctx context.Context, operation func(*Service) error) error {
+	return s.repo.WithTransaction(ctx, func(tx Repository) error {
+		return operation(&Service{repo: tx, allowedLLMs: s.allowedLLMs})
+	})
+}
+
+// ClonePrompt deep-copies a prompt: its slot variant mappings, a fresh price
+// row with the same cost calculation and a copy of the example image. The
+// clone starts inactive unless the payload says otherwise so it can be
+// adjusted before it shows up in the public listing.
+func (s *Service) ClonePrompt(ctx context.Context, id int, payload promptClone) (*PromptRead, error) {
+	var copiedImage *string
+	var cloneID int
+	err := s.WithTransaction(ctx, func(tx *Service) error {
+		source, err := tx.repo.PromptByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		row := Prompt{
+			Title:         strings.TrimSpace(source.Title) + " (Copy)",
+			PromptText:    source.PromptText,
+			CategoryID:    source.CategoryID,
+			SubcategoryID: source.SubcategoryID,
+			LLM:           source.LLM,
+			Active:        false,
+		}
+		if payload.Title != nil && strings.TrimSpace(*payload.Title) != "" {
+			row.Title = strings.TrimSpace(*payload.Title)
+		}
+		if payload.Active != nil {
+			row.Active = *payload.Active
+		}
+		if payload.CategoryID != nil || payload.SubcategoryID != nil {
+			categoryID, subcategoryID, err := tx.resolveCategoryMove(ctx, payload.CategoryID, payload.SubcategoryID, source.CategoryID)
+			if err != nil {
+				return err
+			}
+			row.CategoryID = categoryID
+			row.SubcategoryID = subcategoryID
+		}
+		position, err := tx.repo.FirstPromptPosition(ctx, row.CategoryID, row.SubcategoryID)
+		if err != nil {
+			return err
+		}
+		row.Position = position
+		if source.Price != nil {
+			priceID, err := tx.createOrUpdatePrice(ctx, nil, priceToCostCalculation(source.Price))
+			if err != nil {
+				return err
+			}
+			row.PriceID = &priceID
+		}
+		if source.ExampleImageFilename != nil && strings.TrimSpace(*source.ExampleImageFilename) != "" {
+			filename, err := copyPublicImage(*source.ExampleImageFilename)
+			if err != nil {
+				return err
+			}
+			copiedImage = &filename
+			row.ExampleImageFilename = copiedImage
+		}
+		if err := tx.repo.CreatePrompt(ctx, &row); err != nil {
+			return err
+		}
+		slotIDs := make([]int, 0, len(source.PromptSlotVariantMappings))
+		for i := range source.PromptSlotVariantMappings {
+			slotIDs = append(slotIDs, source.PromptSlotVariantMappings[i].SlotID)
+		}
+		if len(slotIDs) > 0 {
+			if err := tx.repo.ReplacePromptSlotVariantMappings(ctx, row.ID, slotIDs); err != nil {
+				return err
+			}
+		}
+		cloneID = row.ID
+		return nil
+	})
+	if err != nil {
+		if copiedImage != nil {
+			safeDeletePublicImage(*copiedImage, "prompt")
+		}
+		return nil, err
+	}
+	s.reindexPrompts(ctx, cloneID)
+	return s.GetPrompt(ctx, cloneID)
+}
+
+// BulkSetActive activates or deactivates all given prompts.
+func (s *Service) BulkSetActive(ctx context.Context, ids []int, active bool) ([]PromptRead, error) {
+	return s.bulkUpdate(ctx, ids, func(_ *Service, p *Prompt) error {
+		p.Active = active
+		return nil
+	})
+}
+
+// BulkMove assigns all given prompts to a category and optional subcategory.
+// Without a subcategory the prompts' current subcategory is cleared because it
+// belongs to the previous category. Moved prompts are placed after the last
+// prompt of their new group in the order of ids.
+func (s *Service) BulkMove(ctx context.Context, ids []int, categoryID, subcategoryID *int) ([]PromptRead, error) {
+	if categoryID == nil && subcategoryID == nil {
+		return nil, errMissingCategory
+	}
+	cat, sub, err := s.resolveCategoryMove(ctx, categoryID, subcategoryID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.bulkUpdate(ctx, ids, func(tx *Service, p *Prompt) error {
+		if !sameGroup(p, cat, sub) {
+			position, err := tx.repo.NextPromptPosition(ctx, cat, sub)
+			if err != nil {
+				return err
+			}
+			p.Position = position
+		}
+		p.CategoryID = cat
+		p.Category = nil
+		p.SubcategoryID = sub
+		p.Subcategory = nil
+		return nil
+	})
+}
+
+// BulkSetLLM changes the LLM of all given prompts.
+func (s *Service) BulkSetLLM(ctx context.Context, ids []int, llm string) ([]PromptRead, error) {
+	llm = strings.TrimSpace(llm)
+	if llm == "" || !s.isValidLLM(llm) {
+		return nil, errInvalidLLM
+	}
+	return s.bulkUpdate(ctx, ids, func(_ *Service, p *Prompt) error {
+		value := llm
+		p.LLM = &value
+		return nil
+	})
+}
+
+// bulkUpdate loads every prompt, applies mutate with the transaction's
+// Service and saves it in one transaction. A missing ID aborts the whole
+// batch with gorm.ErrRecordNotFound.
+func (s *Service) bulkUpdate(ctx context.Context, ids []int, mutate func(*Service, *Prompt) error) ([]PromptRead, error) {
+	ids = uniquePositiveIDs(ids)
+	if len(ids) == 0 {
+		return nil, errEmptySelection
+	}
+	err := s.WithTransaction(ctx, func(tx *Service) error {
+		for _, id := range ids {
+			existing, err := tx.repo.PromptByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := mutate(tx, existing); err != nil {
+				return err
+			}
+			if err := tx.repo.SavePrompt(ctx, existing); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.reindexPrompts(ctx, ids...)
+	rows, err := s.repo.PromptsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]PromptRead, 0, len(rows))
+	for i := range rows {
+		out = append(out, toPromptRead(&rows[i]))
+	}
+	return out, nil
+}
+
+// resolveCategoryMove validates a category/subcategory pair. When only a
+// subcategory is given its category is used, falling back to currentCategory.
+func (s *Service) resolveCategoryMove(ctx context.Context, categoryID, subcategoryID, currentCategory *int) (*int, *int, error) {
+	if subcategoryID != nil {
+		sc, err := s.repo.SubCategoryByID(ctx, *subcategoryID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if categoryID != nil && sc.PromptCategoryID != *categoryID {
+			return nil, nil, errSubcategoryMismatch
+		}
+		cat := sc.PromptCategoryID
+		sub := sc.ID
+		return &cat, &sub, nil
+	}
+	if categoryID == nil {
+		return currentCategory, nil, nil
+	}
+	exists, err := s.repo.CategoryExists(ctx, *categoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exists {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+	cat := *categoryID
+	return &cat, nil, nil
+}
+
+var (
+	errEmptySelection      = errors.New("no prompt ids given")
+	errMissingCategory     = errors.New("categoryId or subcategoryId is required")
+	errSubcategoryMismatch = errors.New("subcategory does not belong to the specified category")
+)
+
+func uniquePositiveIDs(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	out := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
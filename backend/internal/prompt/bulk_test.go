@@ -0,0 +1,229 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+	img "voenix/backend/internal/image"
+)
+
+// bulkRepository keeps prompts, prices and slot mappings in memory and
+// restores them when a transaction fails.
+type bulkRepository struct {
+	*mockRepository
+	prompts  map[int]Prompt
+	prices   map[int]article.Price
+	mappings map[int][]int
+}
+
+func newBulkRepository(prompts ...Prompt) *bulkRepository {
+	r := &bulkRepository{
+		mockRepository: newMockRepository(),
+		prompts:        map[int]Prompt{},
+		prices:         map[int]article.Price{},
+		mappings:       map[int][]int{},
+	}
+	for _, p := range prompts {
+		r.prompts[p.ID] = p
+	}
+	return r
+}
+
+func (r *bulkRepository) PromptByID(_ context.Context, id int) (*Prompt, error) {
+	p, ok := r.prompts[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if p.PriceID != nil {
+		price := r.prices[*p.PriceID]
+		p.Price = &price
+	}
+	p.PromptSlotVariantMappings = nil
+	for _, slotID := range r.mappings[id] {
+		p.PromptSlotVariantMappings = append(p.PromptSlotVariantMappings, PromptSlotVariantMapping{PromptID: id, SlotID: slotID})
+	}
+	return &p, nil
+}
+
+func (r *bulkRepository) PromptsByIDs(ctx context.Context, ids []int) ([]Prompt, error) {
+	out := []Prompt{}
+	for _, id := range ids {
+		if p, err := r.PromptByID(ctx, id); err == nil {
+			out = append(out, *p)
+		}
+	}
+	return out, nil
+}
+
+func (r *bulkRepository) CreatePrompt(_ context.Context, p *Prompt) error {
+	p.ID = len(r.prompts) + 1
+	r.prompts[p.ID] = *p
+	return nil
+}
+
+func (r *bulkRepository) SavePrompt(_ context.Context, p *Prompt) error {
+	r.prompts[p.ID] = *p
+	return nil
+}
+
+func (r *bulkRepository) ReplacePromptSlotVariantMappings(_ context.Context, promptID int, slotIDs []int) error {
+	r.mappings[promptID] = slices.Clone(slotIDs)
+	return nil
+}
+
+func (r *bulkRepository) NextPromptPosition(_ context.Context, categoryID, subcategoryID *int) (int, error) {
+	next := 0
+	for _, p := range r.prompts {
+		if sameGroup(&p, categoryID, subcategoryID) && p.Position >= next {
+			next = p.Position + 1
+		}
+	}
+	return next, nil
+}
+
+func (r *bulkRepository) FirstPromptPosition(_ context.Context, categoryID, subcategoryID *int) (int, error) {
+	first, found := 0, false
+	for _, p := range r.prompts {
+		if sameGroup(&p, categoryID, subcategoryID) && (!found || p.Position <= first) {
+			first, found = p.Position-1, true
+		}
+	}
+	return first, nil
+}
+
+func (r *bulkRepository) CategoryExists(_ context.Context, id int) (bool, error) {
+	return id > 0, nil
+}
+
+func (r *bulkRepository) CreatePrice(_ context.Context, price *article.Price) error {
+	price.ID = len(r.prices) + 1
+	r.prices[price.ID] = *price
+	return nil
+}
+
+func (r *bulkRepository) WithTransaction(_ context.Context, fn func(Repository) error) error {
+	prompts, prices, mappings := maps.Clone(r.prompts), maps.Clone(r.prices), maps.Clone(r.mappings)
+	if err := fn(r); err != nil {
+		r.prompts, r.prices, r.mappings = prompts, prices, mappings
+		return err
+	}
+	return nil
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestClonePromptCopiesMappingsPriceAndExampleImage(t *testing.T) {
+	t.Setenv("STORAGE_ROOT", t.TempDir())
+	loc, err := img.NewStorageLocations()
+	if err != nil {
+		t.Fatalf("storage locations: %v", err)
+	}
+	if err := os.MkdirAll(loc.PromptExample(), 0o755); err != nil {
+		t.Fatalf("create example dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(loc.PromptExample(), "cats.png"), []byte("cats"), 0o644); err != nil {
+		t.Fatalf("write example image: %v", err)
+	}
+	repo := newBulkRepository(
+		Prompt{ID: 1, Title: "Cats", CategoryID: intPtr(1), PriceID: intPtr(1), Active: true, ExampleImageFilename: strPtr("cats.png"), LLM: strPtr("flux")},
+		Prompt{ID: 2, Title: "Dogs", CategoryID: intPtr(1), Position: 1, Active: true},
+	)
+	repo.prices[1] = article.Price{ID: 1, SalesTotalNet: 420, SalesTotalGross: 500}
+	repo.mappings[1] = []int{4, 5}
+	svc := NewService(repo, nil)
+
+	created, err := svc.ClonePrompt(context.Background(), 1, promptClone{})
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	clone := repo.prompts[created.ID]
+	if clone.Active || clone.Title != "Cats (Copy)" || clone.Position != -1 || clone.LLM == nil || *clone.LLM != "flux" {
+		t.Fatalf("unexpected clone: %+v", clone)
+	}
+	if clone.PriceID == nil || *clone.PriceID == 1 || repo.prices[*clone.PriceID].SalesTotalGross != 500 {
+		t.Fatalf("expected a new price row with the same amounts, got %v", clone.PriceID)
+	}
+	if !slices.Equal(repo.mappings[clone.ID], []int{4, 5}) {
+		t.Fatalf("expected the slot mappings to be copied, got %v", repo.mappings[clone.ID])
+	}
+	if clone.ExampleImageFilename == nil || *clone.ExampleImageFilename == "cats.png" {
+		t.Fatalf("expected a copy of the example image, got %v", clone.ExampleImageFilename)
+	}
+	data, err := os.ReadFile(filepath.Join(loc.PromptExample(), *clone.ExampleImageFilename))
+	if err != nil || string(data) != "cats" {
+		t.Fatalf("read copied image: %q, %v", data, err)
+	}
+}
+
+func TestBulkOperationsRollBackWhenAPromptIsMissing(t *testing.T) {
+	repo := newBulkRepository(
+		Prompt{ID: 1, Title: "Cats", CategoryID: intPtr(1), Active: true},
+		Prompt{ID: 2, Title: "Dogs", CategoryID: intPtr(1), Position: 1, Active: true},
+	)
+	svc := NewService(repo, []string{"flux", "gpt-image-1"})
+	ctx := context.Background()
+
+	if _, err := svc.BulkSetActive(ctx, []int{1, 2, 99}, false); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if _, err := svc.BulkMove(ctx, []int{1, 99}, intPtr(2), nil); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if _, err := svc.BulkSetLLM(ctx, []int{2, 99}, "flux"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	for _, id := range []int{1, 2} {
+		p := repo.prompts[id]
+		if !p.Active || *p.CategoryID != 1 || p.Position != id-1 || p.LLM != nil {
+			t.Fatalf("prompt %d changed despite the failed batch: %+v", id, p)
+		}
+	}
+}
+
+func TestBulkMovePlacesPromptsAtTheEndOfTheTargetCategory(t *testing.T) {
+	repo := newBulkRepository(
+		Prompt{ID: 1, Title: "Cats", CategoryID: intPtr(1)},
+		Prompt{ID: 2, Title: "Dogs", CategoryID: intPtr(1), Position: 1},
+		Prompt{ID: 3, Title: "Birds", CategoryID: intPtr(2)},
+	)
+	svc := NewService(repo, nil)
+
+	if _, err := svc.BulkMove(context.Background(), []int{2, 1, 3}, intPtr(2), nil); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	got := []int{repo.prompts[3].Position, repo.prompts[2].Position, repo.prompts[1].Position}
+	if !slices.Equal(got, []int{0, 1, 2}) {
+		t.Fatalf("expected the moved prompts after the existing one in the given order, got %v", got)
+	}
+}
+
+func TestPromptBulkHandlersRejectEmptySelection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	mountPromptBulkRoutes(r.Group("/api/admin/prompts"), NewService(newBulkRepository(), []string{"flux"}))
+
+	for path, body := range map[string]string{
+		"activate":   `{"ids":[]}`,
+		"deactivate": `{"ids":[]}`,
+		"move":       `{"ids":[],"categoryId":1}`,
+		"llm":        `{"ids":[],"llm":"flux"}`,
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/admin/prompts/bulk/"+path, strings.NewReader(body)))
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "No prompt ids given") {
+			t.Fatalf("%s: expected 400 for an empty selection, got %d %s", path, w.Code, w.Body.String())
+		}
+	}
+}
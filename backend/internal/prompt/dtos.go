@@ -27,6 +27,7 @@ type PromptSlotVariantRead struct {
 type PromptCategoryRead struct {
 	ID                 int        `json:"id"`
 	Name               string     `json:"name"`
+	Slug               string     `json:"slug"`
 	PromptsCount       int        `json:"promptsCount"`
 	SubcategoriesCount int        `json:"subcategoriesCount"`
 	CreatedAt          *time.Time `json:"createdAt"`
@@ -55,16 +56,37 @@ type PromptRead struct {
 	PriceID         *int                    `json:"priceId"`
 	CostCalculation *costCalculationRequest `json:"costCalculation"`
 	Active          bool                    `json:"active"`
+	Position        int                     `json:"position"`
+	Featured        bool                    `json:"featured"`
+	FeaturedFrom    *time.Time              `json:"featuredFrom"`
+	FeaturedUntil   *time.Time              `json:"featuredUntil"`
 	Slots           []PromptSlotVariantRead `json:"slots"`
+	SlotIssues      []PromptSlotIssueRead   `json:"slotIssues"`
 	ExampleImageURL *string                 `json:"exampleImageUrl"`
 	CreatedAt       *time.Time              `json:"createdAt"`
 	UpdatedAt       *time.Time              `json:"updatedAt"`
 }
 
+// PromptSlotIssueRead flags a slot type mapped to a prompt that has no variant
+// compatible with the prompt's LLM.
+type PromptSlotIssueRead struct {
+	SlotTypeID    int      `json:"slotTypeId"`
+	SlotTypeName  string   `json:"slotTypeName"`
+	AvailableLLMs []string `json:"availableLlms"`
+}
+
+type PromptValidationRead struct {
+	PromptID int                   `json:"promptId"`
+	Title    string                `json:"title"`
+	LLM      *string               `json:"llm"`
+	Issues   []PromptSlotIssueRead `json:"issues"`
+}
+
 // Public DTOs
 type PublicPromptCategoryRead struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
+	Slug string `json:"slug"`
 }
 
 type PublicPromptSubCategoryRead struct {
@@ -94,9 +116,46 @@ type PublicPromptRead struct {
 	Subcategory     *PublicPromptSubCategoryRead `json:"subcategory"`
 	Slots           []PublicPromptSlotRead       `json:"slots"`
 	Price           *int                         `json:"price,omitempty"`
+	Featured        bool                         `json:"featured"`
+}
+
+// PublicPromptCategoryPage is a prompt category with its active prompts.
+type PublicPromptCategoryPage struct {
+	ID      int                `json:"id"`
+	Name    string             `json:"name"`
+	Slug    string             `json:"slug"`
+	Prompts []PublicPromptRead `json:"prompts"`
 }
 
 type PromptSummaryRead struct {
 	ID    int    `json:"id"`
 	Title string `json:"title"`
 }
+
+// Analytics DTOs
+
+type PromptAnalyticsRead struct {
+	PromptID       int     `json:"promptId"`
+	Title          string  `json:"title"`
+	Active         bool    `json:"active"`
+	Generations    int     `json:"generations"`
+	CartAdds       int     `json:"cartAdds"`
+	Orders         int     `json:"orders"`
+	Revenue        int64   `json:"revenue"`
+	ConversionRate float64 `json:"conversionRate"`
+}
+
+type PromptAnalyticsTotalsRead struct {
+	Generations    int     `json:"generations"`
+	CartAdds       int     `json:"cartAdds"`
+	Orders         int     `json:"orders"`
+	Revenue        int64   `json:"revenue"`
+	ConversionRate float64 `json:"conversionRate"`
+}
+
+type PromptAnalyticsReportRead struct {
+	From    *time.Time                `json:"from"`
+	To      *time.Time                `json:"to"`
+	Prompts []PromptAnalyticsRead     `json:"prompts"`
+	Totals  PromptAnalyticsTotalsRead `json:"totals"`
+}
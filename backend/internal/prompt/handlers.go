@@ -13,6 +13,10 @@ func RegisterRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
 	registerAdminCategoryRoutes(r, db, svc)
 	registerAdminSubCategoryRoutes(r, db, svc)
 	registerAdminPromptRoutes(r, db, svc)
+	registerAdminPromptAnalyticsRoutes(r, db, svc)
+	registerAdminPromptBulkRoutes(r, db, svc)
+	registerAdminPromptOrderingRoutes(r, db, svc)
+	registerAdminPromptPriceHistoryRoutes(r, db, svc)
 
 	// Public
 	registerPublicPromptRoutes(r, svc)
@@ -0,0 +1,94 @@
+package prompt
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/auth"
+)
+
+func registerAdminPromptAnalyticsRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
+	grp := r.Group("/api/admin/prompts/analytics")
+	grp.Use(auth.RequireAdmin(db))
+
+	// GET /api/admin/prompts/analytics?from=2025-01-01&to=2025-01-31
+	grp.GET("", func(c *gin.Context) {
+		from, to, err := parseAnalyticsRange(c.Query("from"), c.Query("to"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid date range"})
+			return
+		}
+		report, err := svc.PromptAnalytics(c.Request.Context(), from, to)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch prompt analytics"})
+			return
+		}
+		c.JSON(http.StatusOK, report)
+	})
+
+	grp.GET("/:id", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil || id <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid prompt id"})
+			return
+		}
+		from, to, err := parseAnalyticsRange(c.Query("from"), c.Query("to"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid date range"})
+			return
+		}
+		row, err := svc.PromptAnalyticsByID(c.Request.Context(), id, from, to)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch prompt analytics"})
+			return
+		}
+		if row == nil {
+			c.JSON(http.StatusNotFound, gin.H{"detail": "Prompt not found"})
+			return
+		}
+		c.JSON(http.StatusOK, row)
+	})
+}
+
+// parseAnalyticsRange accepts dates (2006-01-02) or RFC3339 timestamps. A
+// date-only "to" value is treated as inclusive, i.e. the whole day counts.
+func parseAnalyticsRange(fromRaw, toRaw string) (*time.Time, *time.Time, error) {
+	from, _, err := parseAnalyticsTime(fromRaw)
+	if err != nil {
+		return nil, nil, errors.New("invalid from date")
+	}
+	to, dateOnly, err := parseAnalyticsTime(toRaw)
+	if err != nil {
+		return nil, nil, errors.New("invalid to date")
+	}
+	if to != nil && dateOnly {
+		next := to.AddDate(0, 0, 1)
+		to = &next
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, errors.New("from must be before to")
+	}
+	return from, to, nil
+}
+
+func parseAnalyticsTime(raw string) (*time.Time, bool, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, false, nil
+	}
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		return &t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, false, err
+	}
+	utc := t.UTC()
+	return &utc, false, nil
+}
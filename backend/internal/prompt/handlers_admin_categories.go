@@ -13,11 +13,13 @@ import (
 )
 
 type categoryCreate struct {
-	Name string `json:"name"`
+	Name string  `json:"name"`
+	Slug *string `json:"slug"`
 }
 
 type categoryUpdate struct {
 	Name *string `json:"name"`
+	Slug *string `json:"slug"`
 }
 
 func registerAdminCategoryRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
@@ -39,8 +41,11 @@ func registerAdminCategoryRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
 			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
 			return
 		}
-		created, err := svc.CreateCategory(c.Request.Context(), payload.Name)
+		created, err := svc.CreateCategory(c.Request.Context(), payload.Name, payload.Slug)
 		if err != nil {
+			if writeSlugError(c, err) {
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to create category"})
 			return
 		}
@@ -54,12 +59,15 @@ func registerAdminCategoryRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
 			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
 			return
 		}
-		updated, err := svc.UpdateCategory(c.Request.Context(), id, payload.Name)
+		updated, err := svc.UpdateCategory(c.Request.Context(), id, payload.Name, payload.Slug)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				c.JSON(http.StatusNotFound, gin.H{"detail": "PromptCategory not found"})
 				return
 			}
+			if writeSlugError(c, err) {
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to update category"})
 			return
 		}
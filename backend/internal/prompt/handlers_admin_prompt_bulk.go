@@ -0,0 +1,139 @@
+package prompt
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/auth"
+)
+
+type promptClone struct {
+	Title         *string `json:"title"`
+	CategoryID    *int    `json:"categoryId"`
+	SubcategoryID *int    `json:"subcategoryId"`
+	Active        *bool   `json:"active"`
+}
+
+type promptBulkSelection struct {
+	IDs []int `json:"ids"`
+}
+
+type promptBulkMove struct {
+	IDs           []int `json:"ids"`
+	CategoryID    *int  `json:"categoryId"`
+	SubcategoryID *int  `json:"subcategoryId"`
+}
+
+type promptBulkLLM struct {
+	IDs []int  `json:"ids"`
+	LLM string `json:"llm"`
+}
+
+func registerAdminPromptBulkRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
+	grp := r.Group("/api/admin/prompts")
+	grp.Use(auth.RequireAdmin(db))
+	mountPromptBulkRoutes(grp, svc)
+}
+
+// mountPromptBulkRoutes adds the clone and bulk endpoints to grp, which
+// carries the admin check.
+func mountPromptBulkRoutes(grp *gin.RouterGroup, svc *Service) {
+	grp.POST("/:id/clone", func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		var payload promptClone
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&payload); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+				return
+			}
+		}
+		created, err := svc.ClonePrompt(c.Request.Context(), id, payload)
+		if err != nil {
+			writePromptBulkError(c, err, "Failed to clone prompt")
+			return
+		}
+		c.JSON(http.StatusCreated, created)
+	})
+
+	bulk := grp.Group("/bulk")
+
+	bulk.POST("/activate", func(c *gin.Context) {
+		var payload promptBulkSelection
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		rows, err := svc.BulkSetActive(c.Request.Context(), payload.IDs, true)
+		if err != nil {
+			writePromptBulkError(c, err, "Failed to activate prompts")
+			return
+		}
+		c.JSON(http.StatusOK, rows)
+	})
+
+	bulk.POST("/deactivate", func(c *gin.Context) {
+		var payload promptBulkSelection
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		rows, err := svc.BulkSetActive(c.Request.Context(), payload.IDs, false)
+		if err != nil {
+			writePromptBulkError(c, err, "Failed to deactivate prompts")
+			return
+		}
+		c.JSON(http.StatusOK, rows)
+	})
+
+	bulk.POST("/move", func(c *gin.Context) {
+		var payload promptBulkMove
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		rows, err := svc.BulkMove(c.Request.Context(), payload.IDs, payload.CategoryID, payload.SubcategoryID)
+		if err != nil {
+			writePromptBulkError(c, err, "Failed to move prompts")
+			return
+		}
+		c.JSON(http.StatusOK, rows)
+	})
+
+	bulk.POST("/llm", func(c *gin.Context) {
+		var payload promptBulkLLM
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		rows, err := svc.BulkSetLLM(c.Request.Context(), payload.IDs, payload.LLM)
+		if err != nil {
+			writePromptBulkError(c, err, "Failed to change prompt LLM")
+			return
+		}
+		c.JSON(http.StatusOK, rows)
+	})
+}
+
+func writePromptBulkError(c *gin.Context, err error, fallback string) {
+	var conflict conflictError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": "Prompt/Category/Subcategory not found"})
+	case errors.Is(err, errEmptySelection):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "No prompt ids given"})
+	case errors.Is(err, errMissingCategory):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "categoryId or subcategoryId is required"})
+	case errors.Is(err, errSubcategoryMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Subcategory does not belong to the specified category"})
+	case errors.Is(err, errInvalidLLM):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid llm selection"})
+	case errors.As(err, &conflict):
+		c.JSON(http.StatusConflict, gin.H{"detail": conflict.Detail})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
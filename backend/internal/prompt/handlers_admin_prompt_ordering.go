@@ -0,0 +1,76 @@
+package prompt
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/auth"
+)
+
+type promptReorder struct {
+	CategoryID    *int  `json:"categoryId"`
+	SubcategoryID *int  `json:"subcategoryId"`
+	IDs           []int `json:"ids"`
+}
+
+type promptFeatured struct {
+	Featured      bool       `json:"featured"`
+	FeaturedFrom  *time.Time `json:"featuredFrom"`
+	FeaturedUntil *time.Time `json:"featuredUntil"`
+}
+
+func registerAdminPromptOrderingRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
+	grp := r.Group("/api/admin/prompts")
+	grp.Use(auth.RequireAdmin(db))
+
+	// POST /api/admin/prompts/reorder stores the drag-and-drop order of the
+	// prompts in one category/subcategory group.
+	grp.POST("/reorder", func(c *gin.Context) {
+		var payload promptReorder
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		rows, err := svc.ReorderPrompts(c.Request.Context(), payload.CategoryID, payload.SubcategoryID, payload.IDs)
+		if err != nil {
+			writePromptOrderingError(c, err, "Failed to reorder prompts")
+			return
+		}
+		c.JSON(http.StatusOK, rows)
+	})
+
+	grp.PUT("/:id/featured", func(c *gin.Context) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		var payload promptFeatured
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid payload"})
+			return
+		}
+		row, err := svc.SetPromptFeatured(c.Request.Context(), id, payload)
+		if err != nil {
+			writePromptOrderingError(c, err, "Failed to update featured placement")
+			return
+		}
+		c.JSON(http.StatusOK, row)
+	})
+}
+
+func writePromptOrderingError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": "Prompt not found"})
+	case errors.Is(err, errEmptySelection):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "No prompt ids given"})
+	case errors.Is(err, errReorderGroup):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "All prompts must belong to the specified category/subcategory"})
+	case errors.Is(err, errFeaturedWindow):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "featuredFrom must be before featuredUntil"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
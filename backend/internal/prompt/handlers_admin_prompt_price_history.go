@@ -0,0 +1,39 @@
+package prompt
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/auth"
+)
+
+func registerAdminPromptPriceHistoryRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
+	grp := r.Group("/api/admin/prompts")
+	grp.Use(auth.RequireAdmin(db))
+
+	// GET /api/admin/prompts/:id/price-history
+	grp.GET("/:id/price-history", func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil || id <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid prompt id"})
+			return
+		}
+		timeline, err := svc.PromptPriceTimeline(c.Request.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"detail": "Prompt not found"})
+			case errors.Is(err, errPromptWithoutPrice):
+				c.JSON(http.StatusNotFound, gin.H{"detail": "Prompt has no price"})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch price history"})
+			}
+			return
+		}
+		c.JSON(http.StatusOK, timeline)
+	})
+}
@@ -55,6 +55,17 @@ func registerAdminPromptRoutes(r *gin.Engine, db *gorm.DB, svc *Service) {
 		c.JSON(http.StatusOK, rows)
 	})
 
+	// GET /api/admin/prompts/validation lists prompts whose slots have no
+	// variant for the prompt's LLM.
+	grp.GET("/validation", func(c *gin.Context) {
+		rows, err := svc.ValidatePrompts(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to validate prompts"})
+			return
+		}
+		c.JSON(http.StatusOK, rows)
+	})
+
 	grp.GET("/:id", func(c *gin.Context) {
 		id, _ := strconv.Atoi(c.Param("id"))
 		row, err := svc.GetPrompt(c.Request.Context(), id)
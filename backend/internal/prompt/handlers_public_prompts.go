@@ -1,16 +1,25 @@
 package prompt
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func registerPublicPromptRoutes(r *gin.Engine, svc *Service) {
 	grp := r.Group("/api/prompts")
 
 	grp.GET("", func(c *gin.Context) {
-		rows, err := svc.ListPublicPrompts(c.Request.Context())
+		sortBy := strings.ToLower(strings.TrimSpace(c.Query("sort")))
+		if sortBy != PublicPromptSortDefault && sortBy != PublicPromptSortPopular {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid sort"})
+			return
+		}
+		rows, err := svc.ListPublicPrompts(c.Request.Context(), sortBy)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch prompts"})
 			return
@@ -18,6 +27,25 @@ func registerPublicPromptRoutes(r *gin.Engine, svc *Service) {
 		c.JSON(http.StatusOK, rows)
 	})
 
+	// Former category slugs answer with a permanent redirect to the current one.
+	grp.GET("/categories/:slug", func(c *gin.Context) {
+		slug := c.Param("slug")
+		cat, prompts, err := svc.PublicCategory(c.Request.Context(), slug)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"detail": "PromptCategory not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to fetch category"})
+			return
+		}
+		if cat.Slug != slug {
+			c.Redirect(http.StatusMovedPermanently, "/api/prompts/categories/"+url.PathEscape(cat.Slug))
+			return
+		}
+		c.JSON(http.StatusOK, PublicPromptCategoryPage{ID: cat.ID, Name: cat.Name, Slug: cat.Slug, Prompts: prompts})
+	})
+
 	grp.GET("/batch", func(c *gin.Context) {
 		// Support both ids=1,2 and repeated ids parameters
 		ids := parseIDs(c.QueryArray("ids"), c.Query("ids"))
@@ -1,16 +1,35 @@
 package prompt
 
 import (
+	"errors"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/gin-gonic/gin"
+
 	"voenix/backend/internal/article"
 	img "voenix/backend/internal/image"
+	"voenix/backend/internal/utility"
 )
 
+// writeSlugError answers slug validation errors and reports whether err was
+// one.
+func writeSlugError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, utility.ErrInvalidSlug):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+	case errors.Is(err, utility.ErrSlugTaken):
+		c.JSON(http.StatusConflict, gin.H{"detail": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
 func toSlotTypeRead(t *PromptSlotType) PromptSlotTypeRead {
 	return PromptSlotTypeRead{
 		ID:        t.ID,
@@ -89,7 +108,12 @@ func toPromptRead(p *Prompt) PromptRead {
 		PriceID:         p.PriceID,
 		CostCalculation: price,
 		Active:          p.Active,
+		Position:        p.Position,
+		Featured:        p.Featured,
+		FeaturedFrom:    p.FeaturedFrom,
+		FeaturedUntil:   p.FeaturedUntil,
 		Slots:           slots,
+		SlotIssues:      slotCompatibilityIssues(p),
 		ExampleImageURL: strPtrOrNil(publicPromptExampleURL(p.ExampleImageFilename)),
 		CreatedAt:       timePtr(p.CreatedAt),
 		UpdatedAt:       timePtr(p.UpdatedAt),
@@ -99,7 +123,7 @@ func toPromptRead(p *Prompt) PromptRead {
 func toPublicPromptRead(p *Prompt) PublicPromptRead {
 	var cat *PublicPromptCategoryRead
 	if p.Category != nil {
-		cat = &PublicPromptCategoryRead{ID: p.Category.ID, Name: p.Category.Name}
+		cat = &PublicPromptCategoryRead{ID: p.Category.ID, Name: p.Category.Name, Slug: p.Category.Slug}
 	}
 	var subcat *PublicPromptSubCategoryRead
 	if p.Subcategory != nil {
@@ -136,6 +160,7 @@ func toPublicPromptRead(p *Prompt) PublicPromptRead {
 		Subcategory:     subcat,
 		Slots:           slots,
 		Price:           pricePtr,
+		Featured:        isFeaturedAt(p, time.Now().UTC()),
 	}
 }
 
@@ -253,6 +278,26 @@ func safeDeletePublicImage(filename, kind string) {
 	_ = os.Remove(path)
 }
 
+// copyPublicImage duplicates a prompt example image under a new random name
+// in the same directory and returns the new filename.
+func copyPublicImage(filename string) (string, error) {
+	loc, err := img.NewStorageLocations()
+	if err != nil {
+		return "", err
+	}
+	dir := loc.PromptExample()
+	name := filepath.Base(filename)
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	stored, err := img.StoreImageBytes(data, dir, "", strings.TrimPrefix(filepath.Ext(name), "."), false)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(stored), nil
+}
+
 func timePtr(t time.Time) *time.Time { return &t }
 
 func strPtrOrNil(s string) *string {
@@ -0,0 +1,125 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	errFeaturedWindow = errors.New("featuredFrom must be before featuredUntil")
+	errReorderGroup   = errors.New("prompt does not belong to the specified category/subcategory")
+)
+
+// isFeaturedAt reports whether p is featured at now. Open start or end dates
+// mean the featured placement has no lower or upper bound.
+func isFeaturedAt(p *Prompt, now time.Time) bool {
+	if !p.Featured {
+		return false
+	}
+	if p.FeaturedFrom != nil && now.Before(*p.FeaturedFrom) {
+		return false
+	}
+	if p.FeaturedUntil != nil && !now.Before(*p.FeaturedUntil) {
+		return false
+	}
+	return true
+}
+
+// sortFeaturedFirst moves currently featured prompts to the front while
+// keeping the repository order (position) within both groups.
+func sortFeaturedFirst(prompts []Prompt, now time.Time) {
+	sort.SliceStable(prompts, func(i, j int) bool {
+		return isFeaturedAt(&prompts[i], now) && !isFeaturedAt(&prompts[j], now)
+	})
+}
+
+func sameGroup(p *Prompt, categoryID, subcategoryID *int) bool {
+	return intPtrEqual(p.CategoryID, categoryID) && intPtrEqual(p.SubcategoryID, subcategoryID)
+}
+
+func intPtrEqual(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// ReorderPrompts stores the order of ids as the positions of the prompts in
+// the given category/subcategory group. Prompts of the group missing from ids
+// keep their relative order after the listed ones.
+func (s *Service) ReorderPrompts(ctx context.Context, categoryID, subcategoryID *int, ids []int) ([]PromptRead, error) {
+	ids = uniquePositiveIDs(ids)
+	if len(ids) == 0 {
+		return nil, errEmptySelection
+	}
+	var ordered []int
+	err := s.WithTransaction(ctx, func(tx *Service) error {
+		rows, err := tx.repo.ListPrompts(ctx)
+		if err != nil {
+			return err
+		}
+		group := make(map[int]*Prompt)
+		for i := range rows {
+			if sameGroup(&rows[i], categoryID, subcategoryID) {
+				group[rows[i].ID] = &rows[i]
+			}
+		}
+		listed := make(map[int]struct{}, len(ids))
+		for _, id := range ids {
+			if _, ok := group[id]; !ok {
+				return errReorderGroup
+			}
+			listed[id] = struct{}{}
+		}
+		rest := make([]*Prompt, 0, len(group)-len(ids))
+		for _, p := range group {
+			if _, ok := listed[p.ID]; !ok {
+				rest = append(rest, p)
+			}
+		}
+		sort.Slice(rest, func(i, j int) bool {
+			if rest[i].Position != rest[j].Position {
+				return rest[i].Position < rest[j].Position
+			}
+			return rest[i].ID > rest[j].ID
+		})
+		ordered = append(ordered, ids...)
+		for _, p := range rest {
+			ordered = append(ordered, p.ID)
+		}
+		return tx.repo.UpdatePromptPositions(ctx, ordered)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.PromptsByIDs(ctx, ordered)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Position < rows[j].Position })
+	out := make([]PromptRead, 0, len(rows))
+	for i := range rows {
+		out = append(out, toPromptRead(&rows[i]))
+	}
+	return out, nil
+}
+
+// SetPromptFeatured replaces the featured flag and window of a prompt.
+func (s *Service) SetPromptFeatured(ctx context.Context, id int, payload promptFeatured) (*PromptRead, error) {
+	if payload.FeaturedFrom != nil && payload.FeaturedUntil != nil && !payload.FeaturedFrom.Before(*payload.FeaturedUntil) {
+		return nil, errFeaturedWindow
+	}
+	existing, err := s.repo.PromptByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	existing.Featured = payload.Featured
+	existing.FeaturedFrom = payload.FeaturedFrom
+	existing.FeaturedUntil = payload.FeaturedUntil
+	if err := s.repo.SavePrompt(ctx, existing); err != nil {
+		return nil, err
+	}
+	return s.GetPrompt(ctx, id)
+}
@@ -0,0 +1,51 @@
+package prompt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsFeaturedAtRespectsWindow(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-24 * time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	cases := []struct {
+		name   string
+		prompt Prompt
+		want   bool
+	}{
+		{name: "not featured", prompt: Prompt{Featured: false}, want: false},
+		{name: "open window", prompt: Prompt{Featured: true}, want: true},
+		{name: "started", prompt: Prompt{Featured: true, FeaturedFrom: &past}, want: true},
+		{name: "not started", prompt: Prompt{Featured: true, FeaturedFrom: &future}, want: false},
+		{name: "ended", prompt: Prompt{Featured: true, FeaturedUntil: &past}, want: false},
+		{name: "ends at now", prompt: Prompt{Featured: true, FeaturedUntil: &now}, want: false},
+		{name: "within window", prompt: Prompt{Featured: true, FeaturedFrom: &past, FeaturedUntil: &future}, want: true},
+	}
+	for _, tc := range cases {
+		if got := isFeaturedAt(&tc.prompt, now); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestSortFeaturedFirstKeepsPositionOrder(t *testing.T) {
+	now := time.Now().UTC()
+	expired := now.Add(-time.Hour)
+	prompts := []Prompt{
+		{ID: 1, Position: 0},
+		{ID: 2, Position: 1, Featured: true},
+		{ID: 3, Position: 2, Featured: true, FeaturedUntil: &expired},
+		{ID: 4, Position: 3},
+		{ID: 5, Position: 4, Featured: true},
+	}
+	sortFeaturedFirst(prompts, now)
+
+	want := []int{2, 5, 1, 3, 4}
+	for i, id := range want {
+		if prompts[i].ID != id {
+			t.Fatalf("expected order %v, got prompt %d at index %d", want, prompts[i].ID, i)
+		}
+	}
+}
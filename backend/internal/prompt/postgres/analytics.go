@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/order"
+	"voenix/backend/internal/prompt"
+)
+
+// usageCountRow receives the grouped counts of the analytics queries.
+type usageCountRow struct {
+	PromptID int
+	Total    int64
+	Revenue  int64
+}
+
+// PromptUsage aggregates the prompt funnel from generated_images, cart_items
+// and order_items. Each source is grouped separately to avoid join fan-out and
+// the results are merged per prompt. Cancelled orders do not count.
+func (r *Repository) PromptUsage(ctx context.Context, promptIDs []int, from, to *time.Time) ([]prompt.PromptUsage, error) {
+	usage := map[int]*prompt.PromptUsage{}
+	entry := func(id int) *prompt.PromptUsage {
+		u, ok := usage[id]
+		if !ok {
+			u = &prompt.PromptUsage{PromptID: id}
+			usage[id] = u
+		}
+		return u
+	}
+
+	var generations []usageCountRow
+	q := r.with(ctx).Table("generated_images").Select("prompt_id, count(*) as total")
+	q = applyUsageFilters(q, "prompt_id", "created_at", promptIDs, from, to)
+	if err := q.Group("prompt_id").Scan(&generations).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range generations {
+		entry(row.PromptID).Generations = int(row.Total)
+	}
+
+	var cartAdds []usageCountRow
+	q = r.with(ctx).Table("cart_items").Select("prompt_id, count(*) as total").Where("prompt_id IS NOT NULL")
+	q = applyUsageFilters(q, "prompt_id", "created_at", promptIDs, from, to)
+	if err := q.Group("prompt_id").Scan(&cartAdds).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range cartAdds {
+		entry(row.PromptID).CartAdds = int(row.Total)
+	}
+
+	var orders []usageCountRow
+	q = r.with(ctx).Table("order_items oi").
+		Select("oi.prompt_id as prompt_id, count(distinct oi.order_id) as total, coalesce(sum(oi.total_price), 0) as revenue").
+		Joins("JOIN orders o ON o.id = oi.order_id").
+		Where("oi.prompt_id IS NOT NULL AND o.status <> ?", order.StatusCancelled)
+	q = applyUsageFilters(q, "oi.prompt_id", "o.created_at", promptIDs, from, to)
+	if err := q.Group("oi.prompt_id").Scan(&orders).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range orders {
+		u := entry(row.PromptID)
+		u.Orders = int(row.Total)
+		u.Revenue = row.Revenue
+	}
+
+	out := make([]prompt.PromptUsage, 0, len(usage))
+	for _, u := range usage {
+		out = append(out, *u)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PromptID < out[j].PromptID })
+	return out, nil
+}
+
+func applyUsageFilters(q *gorm.DB, promptColumn, timeColumn string, promptIDs []int, from, to *time.Time) *gorm.DB {
+	if len(promptIDs) > 0 {
+		q = q.Where(promptColumn+" IN ?", promptIDs)
+	}
+	if from != nil {
+		q = q.Where(timeColumn+" >= ?", *from)
+	}
+	if to != nil {
+		q = q.Where(timeColumn+" < ?", *to)
+	}
+	return q
+}
@@ -7,6 +7,7 @@ import (
 	"gorm.io/gorm"
 
 	"voenix/backend/internal/article"
+	articlepg "voenix/backend/internal/article/postgres"
 	"voenix/backend/internal/prompt"
 	"voenix/backend/internal/vat"
 )
@@ -37,6 +38,12 @@ func (r *Repository) with(ctx context.Context) *gorm.DB {
 	return r.db.WithContext(ctx)
 }
 
+func (r *Repository) WithTransaction(ctx context.Context, fn func(prompt.Repository) error) error {
+	return r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Repository{db: tx})
+	})
+}
+
 func (r *Repository) promptQuery(ctx context.Context) *gorm.DB {
 	return r.with(ctx).
 		Preload("Category").
@@ -222,7 +229,13 @@ func (r *Repository) ListCategories(ctx context.Context) ([]prompt.PromptCategor
 
 func (r *Repository) CreateCategory(ctx context.Context, category *prompt.PromptCategory) error {
 	row := promptCategoryRowFromDomain(category)
-	if err := r.with(ctx).Create(&row).Error; err != nil {
+	err := r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+		return tx.Where("slug = ?", row.Slug).Delete(&PromptCategorySlugRedirectRow{}).Error
+	})
+	if err != nil {
 		return err
 	}
 	*category = row.toDomain()
@@ -240,7 +253,23 @@ func (r *Repository) CategoryByID(ctx context.Context, id int) (*prompt.PromptCa
 
 func (r *Repository) SaveCategory(ctx context.Context, category *prompt.PromptCategory) error {
 	row := promptCategoryRowFromDomain(category)
-	if err := r.with(ctx).Save(&row).Error; err != nil {
+	err := r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		var previous string
+		if err := tx.Model(&PromptCategoryRow{}).Where("id = ?", row.ID).Select("slug").Scan(&previous).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(&row).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("slug IN ?", []string{row.Slug, previous}).Delete(&PromptCategorySlugRedirectRow{}).Error; err != nil {
+			return err
+		}
+		if previous == "" || previous == row.Slug {
+			return nil
+		}
+		return tx.Create(&PromptCategorySlugRedirectRow{Slug: previous, CategoryID: row.ID}).Error
+	})
+	if err != nil {
 		return err
 	}
 	*category = row.toDomain()
@@ -274,6 +303,30 @@ func (r *Repository) CountSubCategoriesByCategory(ctx context.Context, categoryI
 	return int(cnt), nil
 }
 
+func (r *Repository) CategoryIDBySlug(ctx context.Context, slug string) (int, error) {
+	var row PromptCategoryRow
+	err := r.with(ctx).Select("id").First(&row, "slug = ?", slug).Error
+	if err == nil {
+		return row.ID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	var redirect PromptCategorySlugRedirectRow
+	if err := r.with(ctx).First(&redirect, "slug = ?", slug).Error; err != nil {
+		return 0, wrapNotFound(err)
+	}
+	return redirect.CategoryID, nil
+}
+
+func (r *Repository) CategorySlugTaken(ctx context.Context, slug string, exceptID int) (bool, error) {
+	var cnt int64
+	if err := r.with(ctx).Model(&PromptCategoryRow{}).Where("slug = ? AND id <> ?", slug, exceptID).Count(&cnt).Error; err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
 // Subcategories
 
 func (r *Repository) ListSubCategories(ctx context.Context) ([]prompt.PromptSubCategory, error) {
@@ -370,7 +423,7 @@ func (r *Repository) ListPrompts(ctx context.Context) ([]prompt.Prompt, error) {
 
 func (r *Repository) ListPublicPrompts(ctx context.Context) ([]prompt.Prompt, error) {
 	var rows []PromptRow
-	if err := r.promptQuery(ctx).Where("active = ?", true).Order("id desc").Find(&rows).Error; err != nil {
+	if err := r.promptQuery(ctx).Where("active = ?", true).Order("category_id, subcategory_id, position, id").Find(&rows).Error; err != nil {
 		return nil, err
 	}
 	out := make([]prompt.Prompt, 0, len(rows))
@@ -450,8 +503,51 @@ func (r *Repository) ReplacePromptSlotVariantMappings(ctx context.Context, promp
 
 // Prices and VAT
 
+func (r *Repository) NextPromptPosition(ctx context.Context, categoryID, subcategoryID *int) (int, error) {
+	return r.groupPosition(ctx, categoryID, subcategoryID, "COALESCE(MAX(position) + 1, 0)")
+}
+
+func (r *Repository) FirstPromptPosition(ctx context.Context, categoryID, subcategoryID *int) (int, error) {
+	return r.groupPosition(ctx, categoryID, subcategoryID, "COALESCE(MIN(position) - 1, 0)")
+}
+
+// groupPosition evaluates the aggregate expr over the positions of the
+// prompts of a category/subcategory group.
+func (r *Repository) groupPosition(ctx context.Context, categoryID, subcategoryID *int, expr string) (int, error) {
+	q := r.with(ctx).Model(&PromptRow{})
+	if categoryID != nil {
+		q = q.Where("category_id = ?", *categoryID)
+	} else {
+		q = q.Where("category_id IS NULL")
+	}
+	if subcategoryID != nil {
+		q = q.Where("subcategory_id = ?", *subcategoryID)
+	} else {
+		q = q.Where("subcategory_id IS NULL")
+	}
+	var position int
+	if err := q.Select(expr).Scan(&position).Error; err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+func (r *Repository) UpdatePromptPositions(ctx context.Context, ids []int) error {
+	for i, id := range ids {
+		if err := r.with(ctx).Model(&PromptRow{}).Where("id = ?", id).Update("position", i).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *Repository) CreatePrice(ctx context.Context, price *article.Price) error {
-	return r.with(ctx).Table("prices").Create(price).Error
+	return r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("prices").Create(price).Error; err != nil {
+			return err
+		}
+		return articlepg.RecordPriceHistory(tx, price)
+	})
 }
 
 func (r *Repository) PriceByID(ctx context.Context, id int) (*article.Price, error) {
@@ -463,7 +559,16 @@ func (r *Repository) PriceByID(ctx context.Context, id int) (*article.Price, err
 }
 
 func (r *Repository) SavePrice(ctx context.Context, price *article.Price) error {
-	return r.with(ctx).Table("prices").Save(price).Error
+	return r.with(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("prices").Save(price).Error; err != nil {
+			return err
+		}
+		return articlepg.RecordPriceHistory(tx, price)
+	})
+}
+
+func (r *Repository) PriceHistory(ctx context.Context, priceID int) ([]article.PriceHistoryEntry, error) {
+	return articlepg.NewRepository(r.db).ListPriceHistory(ctx, priceID)
 }
 
 func (r *Repository) VatExists(ctx context.Context, id int) (bool, error) {
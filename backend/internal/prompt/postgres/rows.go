@@ -13,6 +13,7 @@ import (
 type PromptCategoryRow struct {
 	ID        int    `gorm:"primaryKey"`
 	Name      string `gorm:"size:255;uniqueIndex;not null"`
+	Slug      string `gorm:"size:255;uniqueIndex;not null"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -21,10 +22,23 @@ func (PromptCategoryRow) TableName() string {
 	return "prompt_categories"
 }
 
+// PromptCategorySlugRedirectRow remembers a slug a category had before it
+// was changed so old links keep resolving.
+type PromptCategorySlugRedirectRow struct {
+	Slug       string `gorm:"primaryKey;size:255"`
+	CategoryID int    `gorm:"column:category_id;not null"`
+	CreatedAt  time.Time
+}
+
+func (PromptCategorySlugRedirectRow) TableName() string {
+	return "prompt_category_slug_redirects"
+}
+
 func (r PromptCategoryRow) toDomain() prompt.PromptCategory {
 	return prompt.PromptCategory{
 		ID:        r.ID,
 		Name:      r.Name,
+		Slug:      r.Slug,
 		CreatedAt: r.CreatedAt,
 		UpdatedAt: r.UpdatedAt,
 	}
@@ -34,6 +48,7 @@ func promptCategoryRowFromDomain(v *prompt.PromptCategory) PromptCategoryRow {
 	return PromptCategoryRow{
 		ID:        v.ID,
 		Name:      v.Name,
+		Slug:      v.Slug,
 		CreatedAt: v.CreatedAt,
 		UpdatedAt: v.UpdatedAt,
 	}
@@ -230,6 +245,10 @@ type PromptRow struct {
 	Active                    bool                          `gorm:"not null;default:true"`
 	ExampleImageFilename      *string                       `gorm:"size:500"`
 	LLM                       *string                       `gorm:"size:255"`
+	Position                  int                           `gorm:"not null;default:0"`
+	Featured                  bool                          `gorm:"not null;default:false"`
+	FeaturedFrom              *time.Time                    `gorm:"column:featured_from"`
+	FeaturedUntil             *time.Time                    `gorm:"column:featured_until"`
 	PromptSlotVariantMappings []PromptSlotVariantMappingRow `gorm:"foreignKey:PromptID;references:ID"`
 	CreatedAt                 time.Time
 	UpdatedAt                 time.Time
@@ -269,6 +288,10 @@ func (r PromptRow) toDomain(includeMappings bool) prompt.Prompt {
 		Active:                    r.Active,
 		ExampleImageFilename:      r.ExampleImageFilename,
 		LLM:                       r.LLM,
+		Position:                  r.Position,
+		Featured:                  r.Featured,
+		FeaturedFrom:              r.FeaturedFrom,
+		FeaturedUntil:             r.FeaturedUntil,
 		PromptSlotVariantMappings: mappings,
 		CreatedAt:                 r.CreatedAt,
 		UpdatedAt:                 r.UpdatedAt,
@@ -299,6 +322,10 @@ func promptRowFromDomain(v *prompt.Prompt) PromptRow {
 		Active:                    v.Active,
 		ExampleImageFilename:      v.ExampleImageFilename,
 		LLM:                       v.LLM,
+		Position:                  v.Position,
+		Featured:                  v.Featured,
+		FeaturedFrom:              v.FeaturedFrom,
+		FeaturedUntil:             v.FeaturedUntil,
 		PromptSlotVariantMappings: promptSlotVariantMappingRowsFromDomain(v.PromptSlotVariantMappings),
 		CreatedAt:                 v.CreatedAt,
 		UpdatedAt:                 v.UpdatedAt,
@@ -0,0 +1,72 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"voenix/backend/internal/article"
+)
+
+var errPromptWithoutPrice = errors.New("prompt has no price")
+
+// PromptPriceHistoryEntryRead is one version of a prompt price. Status is
+// SCHEDULED, ACTIVE or PAST relative to the time of the request.
+type PromptPriceHistoryEntryRead struct {
+	ID              int                     `json:"id"`
+	EffectiveFrom   time.Time               `json:"effectiveFrom"`
+	AppliedAt       *time.Time              `json:"appliedAt"`
+	Status          string                  `json:"status"`
+	CostCalculation *costCalculationRequest `json:"costCalculation"`
+	CreatedAt       time.Time               `json:"createdAt"`
+}
+
+// PromptPriceTimelineRead lists the current price of a prompt and every
+// version of it, including scheduled changes. Changes are scheduled through
+// the article module's /api/admin/prices/:priceId/history endpoints.
+type PromptPriceTimelineRead struct {
+	PromptID int                           `json:"promptId"`
+	PriceID  int                           `json:"priceId"`
+	Current  *costCalculationRequest       `json:"current"`
+	Entries  []PromptPriceHistoryEntryRead `json:"entries"`
+}
+
+// PromptPriceTimeline returns the price timeline of a prompt. It fails with
+// gorm.ErrRecordNotFound for unknown prompts and errPromptWithoutPrice when
+// the prompt has no price.
+func (s *Service) PromptPriceTimeline(ctx context.Context, promptID int) (*PromptPriceTimelineRead, error) {
+	p, err := s.repo.PromptByID(ctx, promptID)
+	if err != nil {
+		return nil, err
+	}
+	if p.PriceID == nil {
+		return nil, errPromptWithoutPrice
+	}
+	price, err := s.repo.PriceByID(ctx, *p.PriceID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repo.PriceHistory(ctx, price.ID)
+	if err != nil {
+		return nil, err
+	}
+	timeline := article.PriceTimeline{Current: price, Entries: entries}
+	now := time.Now()
+	out := &PromptPriceTimelineRead{
+		PromptID: p.ID,
+		PriceID:  price.ID,
+		Current:  priceToCostCalculation(price),
+		Entries:  make([]PromptPriceHistoryEntryRead, 0, len(entries)),
+	}
+	for i := range entries {
+		out.Entries = append(out.Entries, PromptPriceHistoryEntryRead{
+			ID:              entries[i].ID,
+			EffectiveFrom:   entries[i].EffectiveFrom,
+			AppliedAt:       entries[i].AppliedAt,
+			Status:          timeline.Status(i, now),
+			CostCalculation: priceToCostCalculation(&entries[i].Calculation),
+			CreatedAt:       entries[i].CreatedAt,
+		})
+	}
+	return out, nil
+}
@@ -2,6 +2,7 @@ package prompt
 
 import (
 	"context"
+	"time"
 
 	"voenix/backend/internal/article"
 )
@@ -37,6 +38,11 @@ type Repository interface {
 	DeleteCategory(ctx context.Context, id int) error
 	CountPromptsByCategory(ctx context.Context, categoryID int) (int, error)
 	CountSubCategoriesByCategory(ctx context.Context, categoryID int) (int, error)
+	// CategoryIDBySlug matches current category slugs first, then former
+	// ones. Saving a category takes its slug out of the redirect history and
+	// records the previous slug when it changed.
+	CategoryIDBySlug(ctx context.Context, slug string) (int, error)
+	CategorySlugTaken(ctx context.Context, slug string, exceptID int) (bool, error)
 
 	// Subcategories
 	ListSubCategories(ctx context.Context) ([]PromptSubCategory, error)
@@ -57,10 +63,30 @@ type Repository interface {
 	SavePrompt(ctx context.Context, prompt *Prompt) error
 	DeletePrompt(ctx context.Context, id int) error
 	ReplacePromptSlotVariantMappings(ctx context.Context, promptID int, slotIDs []int) error
+	// NextPromptPosition returns the position after the last prompt of the
+	// given category/subcategory group; nil IDs match prompts without one.
+	NextPromptPosition(ctx context.Context, categoryID, subcategoryID *int) (int, error)
+	// FirstPromptPosition returns the position before the first prompt of the
+	// group, so new prompts are listed first like before positions existed.
+	FirstPromptPosition(ctx context.Context, categoryID, subcategoryID *int) (int, error)
+	// UpdatePromptPositions stores the index of every ID as its position.
+	UpdatePromptPositions(ctx context.Context, ids []int) error
 
 	// Price and VAT helpers
 	CreatePrice(ctx context.Context, price *article.Price) error
 	PriceByID(ctx context.Context, id int) (*article.Price, error)
 	SavePrice(ctx context.Context, price *article.Price) error
+	// PriceHistory lists every version of a price ordered by effective date.
+	PriceHistory(ctx context.Context, priceID int) ([]article.PriceHistoryEntry, error)
 	VatExists(ctx context.Context, id int) (bool, error)
+
+	// Analytics
+	// PromptUsage aggregates generations, cart additions, orders and revenue
+	// per prompt. A nil/empty promptIDs slice covers all prompts; from is
+	// inclusive and to is exclusive, either may be nil for an open range.
+	PromptUsage(ctx context.Context, promptIDs []int, from, to *time.Time) ([]PromptUsage, error)
+
+	// WithTransaction executes the given operation within a database transaction.
+	// If the operation returns an error, the transaction is rolled back.
+	WithTransaction(ctx context.Context, fn func(Repository) error) error
 }
@@ -0,0 +1,121 @@
+package prompt
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"voenix/backend/internal/search"
+)
+
+// SetSearchIndex makes admin writes update the search documents of the
+// prompts they touch. It is set after construction because the search
+// service reads its documents from this service.
+func (s *Service) SetSearchIndex(idx search.Index) { s.index = idx }
+
+func (s *Service) SearchKind() search.Kind { return search.KindPrompt }
+
+// SearchDocuments returns the search documents of all active prompts.
+func (s *Service) SearchDocuments(ctx context.Context) ([]search.Document, error) {
+	prompts, err := s.repo.ListPublicPrompts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]search.Document, 0, len(prompts))
+	for i := range prompts {
+		out = append(out, promptSearchDocument(&prompts[i]))
+	}
+	return out, nil
+}
+
+// reindexPrompts updates the search documents of prompts after a write and
+// removes those of deleted or inactive prompts. Failures are logged only: the
+// write has happened and a rebuild repairs the index.
+func (s *Service) reindexPrompts(ctx context.Context, ids ...int) {
+	if s.index == nil || len(ids) == 0 {
+		return
+	}
+	rows, err := s.repo.PromptsByIDs(ctx, ids)
+	if err != nil {
+		slog.Warn("loading prompts for search index failed", "prompts", ids, "error", err)
+		return
+	}
+	found := make(map[int]bool, len(rows))
+	var docs []search.Document
+	var hidden []int
+	for i := range rows {
+		found[rows[i].ID] = true
+		if rows[i].Active {
+			docs = append(docs, promptSearchDocument(&rows[i]))
+		} else {
+			hidden = append(hidden, rows[i].ID)
+		}
+	}
+	for _, id := range ids {
+		if !found[id] {
+			hidden = append(hidden, id)
+		}
+	}
+	if err := s.index.Remove(ctx, search.KindPrompt, hidden...); err != nil {
+		slog.Warn("removing prompts from search index failed", "prompts", hidden, "error", err)
+	}
+	if err := s.index.Upsert(ctx, docs...); err != nil {
+		slog.Warn("updating prompts in search index failed", "error", err)
+	}
+}
+
+// reindexCategory updates the documents of the active prompts of a category,
+// or of a subcategory when subcategoryID is set, after it was renamed.
+func (s *Service) reindexCategory(ctx context.Context, categoryID int, subcategoryID *int) {
+	if s.index == nil {
+		return
+	}
+	prompts, err := s.repo.ListPublicPrompts(ctx)
+	if err != nil {
+		slog.Warn("loading prompts for search index failed", "category", categoryID, "error", err)
+		return
+	}
+	var docs []search.Document
+	for i := range prompts {
+		p := &prompts[i]
+		if p.CategoryID == nil || *p.CategoryID != categoryID {
+			continue
+		}
+		if subcategoryID != nil && (p.SubcategoryID == nil || *p.SubcategoryID != *subcategoryID) {
+			continue
+		}
+		docs = append(docs, promptSearchDocument(p))
+	}
+	if err := s.index.Upsert(ctx, docs...); err != nil {
+		slog.Warn("updating prompts in search index failed", "error", err)
+	}
+}
+
+// promptSearchDocument indexes the title of a prompt over its category and
+// subcategory names and the subcategory description. The prompt text stays
+// out of the index: it is not shown in the storefront.
+func promptSearchDocument(p *Prompt) search.Document {
+	var body []string
+	if p.Category != nil {
+		body = append(body, p.Category.Name)
+	}
+	if sub := p.Subcategory; sub != nil {
+		body = append(body, sub.Name)
+		if sub.Description != nil {
+			body = append(body, *sub.Description)
+		}
+	}
+	var summary string
+	if p.Subcategory != nil && p.Subcategory.Description != nil {
+		summary = *p.Subcategory.Description
+	}
+	return search.Document{
+		Kind:    search.KindPrompt,
+		RefID:   p.ID,
+		Title:   p.Title,
+		Body:    strings.Join(body, "\n"),
+		Summary: summary,
+		Path:    "/prompts/" + strconv.Itoa(p.ID),
+	}
+}
@@ -4,15 +4,18 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
 	"voenix/backend/internal/article"
+	"voenix/backend/internal/search"
 )
 
 type Service struct {
 	repo        Repository
 	allowedLLMs map[string]struct{}
+	index       search.Index
 }
 
 func NewService(repo Repository, allowedLLMs []string) *Service {
@@ -38,6 +41,12 @@ func (s *Service) isValidLLM(llm string) bool {
 	return ok
 }
 
+// isValidSlotVariantLLM additionally accepts the generic marker so a slot
+// variant can serve as the fallback for every LLM.
+func (s *Service) isValidSlotVariantLLM(llm string) bool {
+	return llm == SlotVariantLLMGeneric || s.isValidLLM(llm)
+}
+
 func (s *Service) ListSlotTypes(ctx context.Context) ([]PromptSlotTypeRead, error) {
 	rows, err := s.repo.ListSlotTypes(ctx)
 	if err != nil {
@@ -165,7 +174,7 @@ func (s *Service) CreateSlotVariant(ctx context.Context, payload slotVariantCrea
 		return nil, gorm.ErrRecordNotFound
 	}
 	llm := strings.TrimSpace(payload.LLM)
-	if llm == "" || !s.isValidLLM(llm) {
+	if llm == "" || !s.isValidSlotVariantLLM(llm) {
 		return nil, errInvalidLLM
 	}
 	exists, err = s.repo.SlotVariantNameExists(ctx, payload.Name, nil)
@@ -211,7 +220,7 @@ func (s *Service) UpdateSlotVariant(ctx context.Context, id int, payload slotVar
 	}
 	if payload.LLM != nil {
 		llm := strings.TrimSpace(*payload.LLM)
-		if llm == "" || !s.isValidLLM(llm) {
+		if llm == "" || !s.isValidSlotVariantLLM(llm) {
 			return nil, errInvalidLLM
 		}
 		existing.LLM = llm
@@ -269,6 +278,7 @@ func (s *Service) ListCategories(ctx context.Context) ([]PromptCategoryRead, err
 		out = append(out, PromptCategoryRead{
 			ID:                 cat.ID,
 			Name:               cat.Name,
+			Slug:               cat.Slug,
 			PromptsCount:       promptsCount,
 			SubcategoriesCount: subcatCount,
 			CreatedAt:          timePtr(cat.CreatedAt),
@@ -278,14 +288,19 @@ func (s *Service) ListCategories(ctx context.Context) ([]PromptCategoryRead, err
 	return out, nil
 }
 
-func (s *Service) CreateCategory(ctx context.Context, name string) (*PromptCategoryRead, error) {
+// CreateCategory derives the slug from the name unless slug is given.
+func (s *Service) CreateCategory(ctx context.Context, name string, slug *string) (*PromptCategoryRead, error) {
 	row := PromptCategory{Name: name}
+	if err := s.assignCategorySlug(ctx, &row, slug); err != nil {
+		return nil, err
+	}
 	if err := s.repo.CreateCategory(ctx, &row); err != nil {
 		return nil, err
 	}
 	v := PromptCategoryRead{
 		ID:                 row.ID,
 		Name:               row.Name,
+		Slug:               row.Slug,
 		PromptsCount:       0,
 		SubcategoriesCount: 0,
 		CreatedAt:          timePtr(row.CreatedAt),
@@ -294,7 +309,9 @@ func (s *Service) CreateCategory(ctx context.Context, name string) (*PromptCateg
 	return &v, nil
 }
 
-func (s *Service) UpdateCategory(ctx context.Context, id int, name *string) (*PromptCategoryRead, error) {
+// UpdateCategory keeps the slug unless a different one is given; the old
+// slug then redirects to the category.
+func (s *Service) UpdateCategory(ctx context.Context, id int, name *string, slug *string) (*PromptCategoryRead, error) {
 	existing, err := s.repo.CategoryByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -302,9 +319,15 @@ func (s *Service) UpdateCategory(ctx context.Context, id int, name *string) (*Pr
 	if name != nil {
 		existing.Name = *name
 	}
+	if slug != nil && *slug != existing.Slug {
+		if err := s.assignCategorySlug(ctx, existing, slug); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.repo.SaveCategory(ctx, existing); err != nil {
 		return nil, err
 	}
+	s.reindexCategory(ctx, existing.ID, nil)
 	promptsCount, err := s.repo.CountPromptsByCategory(ctx, existing.ID)
 	if err != nil {
 		return nil, err
@@ -316,6 +339,7 @@ func (s *Service) UpdateCategory(ctx context.Context, id int, name *string) (*Pr
 	v := PromptCategoryRead{
 		ID:                 existing.ID,
 		Name:               existing.Name,
+		Slug:               existing.Slug,
 		PromptsCount:       promptsCount,
 		SubcategoriesCount: subcatCount,
 		CreatedAt:          timePtr(existing.CreatedAt),
@@ -412,6 +436,7 @@ func (s *Service) UpdateSubCategory(ctx context.Context, id int, payload subcatU
 	if err := s.repo.SaveSubCategory(ctx, existing); err != nil {
 		return nil, err
 	}
+	s.reindexCategory(ctx, existing.PromptCategoryID, &existing.ID)
 	count, err := s.repo.CountPromptsBySubCategory(ctx, existing.ID)
 	if err != nil {
 		return nil, err
@@ -436,6 +461,29 @@ func (s *Service) ListPrompts(ctx context.Context) ([]PromptRead, error) {
 	return out, nil
 }
 
+// ValidatePrompts returns the prompts whose slot variant mappings have no
+// variant compatible with the prompt's LLM.
+func (s *Service) ValidatePrompts(ctx context.Context) ([]PromptValidationRead, error) {
+	rows, err := s.repo.ListPrompts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := []PromptValidationRead{}
+	for i := range rows {
+		issues := slotCompatibilityIssues(&rows[i])
+		if len(issues) == 0 {
+			continue
+		}
+		out = append(out, PromptValidationRead{
+			PromptID: rows[i].ID,
+			Title:    rows[i].Title,
+			LLM:      rows[i].LLM,
+			Issues:   issues,
+		})
+	}
+	return out, nil
+}
+
 func (s *Service) GetPrompt(ctx context.Context, id int) (*PromptRead, error) {
 	row, err := s.repo.PromptByID(ctx, id)
 	if err != nil {
@@ -491,6 +539,11 @@ func (s *Service) CreatePrompt(ctx context.Context, payload promptCreate) (*Prom
 	}
 	llmValue := llm
 	row.LLM = &llmValue
+	position, err := s.repo.FirstPromptPosition(ctx, row.CategoryID, row.SubcategoryID)
+	if err != nil {
+		return nil, err
+	}
+	row.Position = position
 	if payload.CostCalculation != nil {
 		priceID, err := s.createOrUpdatePrice(ctx, nil, payload.CostCalculation)
 		if err != nil {
@@ -515,6 +568,7 @@ func (s *Service) CreatePrompt(ctx context.Context, payload promptCreate) (*Prom
 			return nil, err
 		}
 	}
+	s.reindexPrompts(ctx, row.ID)
 	created, err := s.repo.PromptByID(ctx, row.ID)
 	if err != nil {
 		return nil, err
@@ -554,6 +608,7 @@ func (s *Service) UpdatePrompt(ctx context.Context, id int, payload promptUpdate
 		llmValue := llm
 		existing.LLM = &llmValue
 	}
+	previousCategoryID, previousSubcategoryID := existing.CategoryID, existing.SubcategoryID
 	if payload.Title != nil {
 		existing.Title = *payload.Title
 	}
@@ -571,6 +626,13 @@ func (s *Service) UpdatePrompt(ctx context.Context, id int, payload promptUpdate
 	if payload.Active != nil {
 		existing.Active = *payload.Active
 	}
+	if !sameGroup(existing, previousCategoryID, previousSubcategoryID) {
+		position, err := s.repo.NextPromptPosition(ctx, existing.CategoryID, existing.SubcategoryID)
+		if err != nil {
+			return nil, err
+		}
+		existing.Position = position
+	}
 	if payload.CostCalculation != nil {
 		var target *int
 		if payload.PriceID != nil && *payload.PriceID > 0 {
@@ -618,6 +680,7 @@ func (s *Service) UpdatePrompt(ctx context.Context, id int, payload promptUpdate
 			return nil, err
 		}
 	}
+	s.reindexPrompts(ctx, existing.ID)
 	updated, err := s.repo.PromptByID(ctx, existing.ID)
 	if err != nil {
 		return nil, err
@@ -637,14 +700,25 @@ func (s *Service) DeletePrompt(ctx context.Context, id int) error {
 	if err := s.repo.ReplacePromptSlotVariantMappings(ctx, existing.ID, nil); err != nil {
 		return err
 	}
-	return s.repo.DeletePrompt(ctx, existing.ID)
+	if err := s.repo.DeletePrompt(ctx, existing.ID); err != nil {
+		return err
+	}
+	s.reindexPrompts(ctx, existing.ID)
+	return nil
 }
 
-func (s *Service) ListPublicPrompts(ctx context.Context) ([]PublicPromptRead, error) {
+func (s *Service) ListPublicPrompts(ctx context.Context, sortBy string) ([]PublicPromptRead, error) {
 	rows, err := s.repo.ListPublicPrompts(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if sortBy == PublicPromptSortPopular {
+		if err := s.sortByPopularity(ctx, rows); err != nil {
+			return nil, err
+		}
+	} else {
+		sortFeaturedFirst(rows, time.Now().UTC())
+	}
 	out := make([]PublicPromptRead, 0, len(rows))
 	for i := range rows {
 		out = append(out, toPublicPromptRead(&rows[i]))
@@ -196,6 +196,14 @@ func (m *mockRepository) CountSubCategoriesByCategory(context.Context, int) (int
 	panic("not implemented")
 }
 
+func (m *mockRepository) CategoryIDBySlug(context.Context, string) (int, error) {
+	panic("not implemented")
+}
+
+func (m *mockRepository) CategorySlugTaken(context.Context, string, int) (bool, error) {
+	panic("not implemented")
+}
+
 func (m *mockRepository) ListSubCategories(context.Context) ([]PromptSubCategory, error) {
 	panic("not implemented")
 }
@@ -260,6 +268,18 @@ func (m *mockRepository) ReplacePromptSlotVariantMappings(context.Context, int,
 	panic("not implemented")
 }
 
+func (m *mockRepository) NextPromptPosition(context.Context, *int, *int) (int, error) {
+	panic("not implemented")
+}
+
+func (m *mockRepository) FirstPromptPosition(context.Context, *int, *int) (int, error) {
+	panic("not implemented")
+}
+
+func (m *mockRepository) UpdatePromptPositions(context.Context, []int) error {
+	panic("not implemented")
+}
+
 func (m *mockRepository) CreatePrice(context.Context, *article.Price) error {
 	panic("not implemented")
 }
@@ -272,10 +292,22 @@ func (m *mockRepository) SavePrice(context.Context, *article.Price) error {
 	panic("not implemented")
 }
 
+func (m *mockRepository) PriceHistory(context.Context, int) ([]article.PriceHistoryEntry, error) {
+	panic("not implemented")
+}
+
 func (m *mockRepository) VatExists(context.Context, int) (bool, error) {
 	panic("not implemented")
 }
 
+func (m *mockRepository) PromptUsage(context.Context, []int, *time.Time, *time.Time) ([]PromptUsage, error) {
+	panic("not implemented")
+}
+
+func (m *mockRepository) WithTransaction(_ context.Context, fn func(Repository) error) error {
+	return fn(m)
+}
+
 func setupPromptServiceTest(t *testing.T) (*Service, *mockRepository) {
 	t.Helper()
 	repo := newMockRepository()
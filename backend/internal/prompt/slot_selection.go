@@ -0,0 +1,89 @@
+package prompt
+
+import (
+	"sort"
+	"strings"
+)
+
+// SlotVariantLLMGeneric marks a slot variant whose text works with every LLM.
+// Variants without an LLM (rows created before the column existed) are treated
+// the same way.
+const SlotVariantLLMGeneric = "generic"
+
+// IsGenericSlotVariantLLM reports whether a slot variant LLM value is generic.
+func IsGenericSlotVariantLLM(llm string) bool {
+	trimmed := strings.TrimSpace(llm)
+	return trimmed == "" || strings.EqualFold(trimmed, SlotVariantLLMGeneric)
+}
+
+// SelectSlotVariantsForLLM picks the slot variants that apply to the given LLM.
+// Variants are grouped by slot type: when a slot type has variants written for
+// the LLM those are used, otherwise its generic variants are used. Slot types
+// with neither contribute nothing. An empty llm selects generic variants only.
+// The input order is preserved.
+func SelectSlotVariantsForLLM(variants []PromptSlotVariantRead, llm string) []PromptSlotVariantRead {
+	llm = strings.TrimSpace(llm)
+	hasSpecific := map[int]bool{}
+	for _, v := range variants {
+		if llm != "" && v.LLM == llm {
+			hasSpecific[v.PromptSlotTypeID] = true
+		}
+	}
+	out := make([]PromptSlotVariantRead, 0, len(variants))
+	for _, v := range variants {
+		if hasSpecific[v.PromptSlotTypeID] {
+			if v.LLM == llm {
+				out = append(out, v)
+			}
+			continue
+		}
+		if IsGenericSlotVariantLLM(v.LLM) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// slotCompatibilityIssues lists the slot types mapped to a prompt that have no
+// variant usable with the prompt's LLM. Prompts without an LLM are not checked.
+func slotCompatibilityIssues(p *Prompt) []PromptSlotIssueRead {
+	if p.LLM == nil || strings.TrimSpace(*p.LLM) == "" {
+		return []PromptSlotIssueRead{}
+	}
+	llm := strings.TrimSpace(*p.LLM)
+	type slotState struct {
+		issue      PromptSlotIssueRead
+		compatible bool
+	}
+	states := map[int]*slotState{}
+	order := []int{}
+	for i := range p.PromptSlotVariantMappings {
+		v := p.PromptSlotVariantMappings[i].PromptSlotVariant
+		if v == nil {
+			continue
+		}
+		st, ok := states[v.PromptSlotTypeID]
+		if !ok {
+			st = &slotState{issue: PromptSlotIssueRead{SlotTypeID: v.PromptSlotTypeID, AvailableLLMs: []string{}}}
+			if v.PromptSlotType != nil {
+				st.issue.SlotTypeName = v.PromptSlotType.Name
+			}
+			states[v.PromptSlotTypeID] = st
+			order = append(order, v.PromptSlotTypeID)
+		}
+		if v.LLM == llm || IsGenericSlotVariantLLM(v.LLM) {
+			st.compatible = true
+		}
+		st.issue.AvailableLLMs = append(st.issue.AvailableLLMs, v.LLM)
+	}
+	issues := []PromptSlotIssueRead{}
+	for _, id := range order {
+		st := states[id]
+		if st.compatible {
+			continue
+		}
+		sort.Strings(st.issue.AvailableLLMs)
+		issues = append(issues, st.issue)
+	}
+	return issues
+}
@@ -0,0 +1,46 @@
+package prompt
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSlotCompatibilityIssuesFlagsSlotTypesWithoutCompatibleVariant(t *testing.T) {
+	llm := "gpt-image-1"
+	style := &PromptSlotType{ID: 1, Name: "Style"}
+	background := &PromptSlotType{ID: 2, Name: "Background"}
+	p := Prompt{
+		ID:  7,
+		LLM: &llm,
+		PromptSlotVariantMappings: []PromptSlotVariantMapping{
+			{SlotID: 1, PromptSlotVariant: &PromptSlotVariant{ID: 1, PromptSlotTypeID: 1, PromptSlotType: style, LLM: "gemini-2.5-flash-image-preview"}},
+			{SlotID: 2, PromptSlotVariant: &PromptSlotVariant{ID: 2, PromptSlotTypeID: 2, PromptSlotType: background, LLM: "gemini-2.5-flash-image-preview"}},
+			{SlotID: 3, PromptSlotVariant: &PromptSlotVariant{ID: 3, PromptSlotTypeID: 2, PromptSlotType: background, LLM: SlotVariantLLMGeneric}},
+		},
+	}
+
+	issues := slotCompatibilityIssues(&p)
+	if len(issues) != 1 {
+		t.Fatalf("expected exactly one issue, got %+v", issues)
+	}
+	if issues[0].SlotTypeID != 1 || issues[0].SlotTypeName != "Style" {
+		t.Fatalf("expected the style slot to be flagged, got %+v", issues[0])
+	}
+}
+
+func TestCreateSlotVariantAcceptsGenericLLM(t *testing.T) {
+	svc, repo := setupPromptServiceTest(t)
+	slotType := repo.addSlotType("Primary", 1)
+	payload := slotVariantCreate{
+		PromptSlotTypeID: slotType.ID,
+		Name:             "Generic variant",
+		LLM:              SlotVariantLLMGeneric,
+	}
+	created, err := svc.CreateSlotVariant(context.Background(), payload)
+	if err != nil {
+		t.Fatalf("expected generic llm to be accepted, got %v", err)
+	}
+	if created.LLM != SlotVariantLLMGeneric {
+		t.Fatalf("expected llm %q, got %q", SlotVariantLLMGeneric, created.LLM)
+	}
+}
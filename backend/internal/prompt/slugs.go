@@ -0,0 +1,64 @@
+package prompt
+
+import (
+	"context"
+	"strconv"
+
+	"voenix/backend/internal/sitemap"
+	"voenix/backend/internal/utility"
+)
+
+func (s *Service) assignCategorySlug(ctx context.Context, cat *PromptCategory, requested *string) error {
+	slug, err := utility.ResolveSlug(requested, cat.Name, "category", nil, func(slug string) (bool, error) {
+		return s.repo.CategorySlugTaken(ctx, slug, cat.ID)
+	})
+	if err != nil {
+		return err
+	}
+	cat.Slug = slug
+	return nil
+}
+
+// PublicCategory returns the category known under slug, now or before a
+// rename, with its active prompts in storefront order.
+func (s *Service) PublicCategory(ctx context.Context, slug string) (*PromptCategory, []PublicPromptRead, error) {
+	id, err := s.repo.CategoryIDBySlug(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+	cat, err := s.repo.CategoryByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	prompts, err := s.ListPublicPrompts(ctx, PublicPromptSortDefault)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make([]PublicPromptRead, 0)
+	for i := range prompts {
+		if prompts[i].Category != nil && prompts[i].Category.ID == id {
+			out = append(out, prompts[i])
+		}
+	}
+	return cat, out, nil
+}
+
+// SitemapEntries lists the storefront pages of active prompts and of the
+// categories that have any.
+func (s *Service) SitemapEntries(ctx context.Context) ([]sitemap.Entry, error) {
+	prompts, err := s.repo.ListPublicPrompts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]sitemap.Entry, 0, len(prompts))
+	seen := make(map[int]bool)
+	for i := range prompts {
+		p := &prompts[i]
+		out = append(out, sitemap.Entry{Path: "/prompts/" + strconv.Itoa(p.ID), LastModified: p.UpdatedAt})
+		if cat := p.Category; cat != nil && !seen[cat.ID] {
+			seen[cat.ID] = true
+			out = append(out, sitemap.Entry{Path: "/prompts/categories/" + cat.Slug, LastModified: cat.UpdatedAt})
+		}
+	}
+	return out, nil
+}
@@ -13,6 +13,7 @@ import (
 type PromptCategory struct {
 	ID        int
 	Name      string
+	Slug      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -69,7 +70,21 @@ type Prompt struct {
 	Active                    bool
 	ExampleImageFilename      *string
 	LLM                       *string
+	Position                  int
+	Featured                  bool
+	FeaturedFrom              *time.Time
+	FeaturedUntil             *time.Time
 	PromptSlotVariantMappings []PromptSlotVariantMapping
 	CreatedAt                 time.Time
 	UpdatedAt                 time.Time
 }
+
+// PromptUsage aggregates how a prompt moved through the purchase funnel within
+// a reporting window. Revenue is expressed in cents.
+type PromptUsage struct {
+	PromptID    int
+	Generations int
+	CartAdds    int
+	Orders      int
+	Revenue     int64
+}
@@ -0,0 +1,81 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type resultResponse struct {
+	Type    string  `json:"type"`
+	ID      int     `json:"id"`
+	Title   string  `json:"title"`
+	Summary string  `json:"summary"`
+	Path    string  `json:"path"`
+	Rank    float64 `json:"rank"`
+}
+
+type searchResponse struct {
+	Query   string           `json:"query"`
+	Results []resultResponse `json:"results"`
+}
+
+// RegisterRoutes mounts the public search at /api/search and the admin
+// rebuild of the index.
+func RegisterRoutes(r *gin.Engine, adminMiddleware gin.HandlerFunc, svc *Service) {
+	// Search articles and prompts: ?q= is the query, ?type= an optional
+	// comma-separated list of kinds and ?limit= caps the results.
+	r.GET("/api/search", func(c *gin.Context) {
+		q := Query{Text: c.Query("q")}
+		if raw := strings.TrimSpace(c.Query("type")); raw != "" {
+			for _, k := range strings.Split(raw, ",") {
+				q.Kinds = append(q.Kinds, Kind(strings.TrimSpace(k)))
+			}
+		}
+		if raw := c.Query("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid limit"})
+				return
+			}
+			q.Limit = n
+		}
+		results, err := svc.Search(c.Request.Context(), q)
+		if err != nil {
+			if errors.Is(err, ErrUnknownKind) {
+				c.JSON(http.StatusBadRequest, gin.H{"detail": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to search"})
+			return
+		}
+		out := searchResponse{Query: q.Text, Results: make([]resultResponse, 0, len(results))}
+		for _, res := range results {
+			out.Results = append(out.Results, resultResponse{
+				Type:    string(res.Kind),
+				ID:      res.RefID,
+				Title:   res.Title,
+				Summary: res.Summary,
+				Path:    res.Path,
+				Rank:    res.Rank,
+			})
+		}
+		c.JSON(http.StatusOK, out)
+	})
+
+	admin := r.Group("/api/admin/search")
+	admin.Use(adminMiddleware)
+
+	// Rebuild the whole index, e.g. after imports that bypass the services.
+	admin.POST("/rebuild", func(c *gin.Context) {
+		n, err := svc.Rebuild(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to rebuild search index"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"documents": n})
+	})
+}
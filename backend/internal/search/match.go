@@ -0,0 +1,121 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxTerms bounds how many words of a query are matched.
+const maxTerms = 8
+
+// Terms splits text into distinct lower-case words of letters and digits.
+func Terms(text string) []string {
+	all := words(text)
+	out := make([]string, 0, len(all))
+	seen := make(map[string]bool, len(all))
+	for _, w := range all {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		out = append(out, w)
+		if len(out) == maxTerms {
+			break
+		}
+	}
+	return out
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// typoBudget is the number of typos tolerated in a term: none below five
+// letters, one below nine and two from there.
+func typoBudget(term []rune) int {
+	switch {
+	case len(term) < 5:
+		return 0
+	case len(term) < 9:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Match scores doc against terms. Every term must match a word of the title
+// or body, exactly, as a prefix or within its typo budget; title matches
+// count double. ok is false when a term matches nothing.
+func Match(doc *Document, terms []string) (rank float64, ok bool) {
+	title, body := words(doc.Title), words(doc.Body)
+	for _, term := range terms {
+		t := []rune(term)
+		best := 2 * wordScore(t, title)
+		best = max(best, wordScore(t, body))
+		if best == 0 {
+			return 0, false
+		}
+		rank += best
+	}
+	return rank, true
+}
+
+// wordScore is the best score of term against words: 1 for an exact match,
+// 0.8 for a prefix, 0.7 for a part of a compound word like "becher" in
+// "kaffeebecher", 0.6 minus 0.1 per further typo within the budget and 0
+// otherwise.
+func wordScore(term []rune, words []string) float64 {
+	budget := typoBudget(term)
+	best := 0.0
+	for _, w := range words {
+		switch {
+		case w == string(term):
+			return 1
+		case strings.HasPrefix(w, string(term)):
+			best = max(best, 0.8)
+		case len(term) >= 3 && strings.Contains(w, string(term)):
+			best = max(best, 0.7)
+		case budget > 0:
+			if d := editDistance(term, []rune(w), budget); d <= budget {
+				best = max(best, 0.6-0.1*float64(d-1))
+			}
+		}
+	}
+	return best
+}
+
+// editDistance is the optimal string alignment distance between a and b, or
+// limit+1 once it exceeds limit.
+func editDistance(a, b []rune, limit int) int {
+	if diff := len(a) - len(b); diff > limit || -diff > limit {
+		return limit + 1
+	}
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,43 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	got := Terms("  Große Tasse, große-TASSE! 0,5l ")
+	want := []string{"große", "tasse", "0", "5l"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Terms = %v, want %v", got, want)
+	}
+}
+
+func TestMatchToleratesTyposInLongerWords(t *testing.T) {
+	doc := &Document{Title: "Watercolor Portrait", Body: "soft painted faces"}
+	cases := []struct {
+		query string
+		ok    bool
+	}{
+		{"watercolor", true},
+		{"water", true},
+		{"watrecolor", true},   // transposition
+		{"watercolr", true},    // deletion
+		{"whatercolour", true}, // two typos in a long word
+		{"paintet", true},
+		{"color", true}, // part of a compound word
+		{"sfot", false}, // short words need to be exact
+		{"portrait dog", false},
+	}
+	for _, c := range cases {
+		if _, ok := Match(doc, Terms(c.query)); ok != c.ok {
+			t.Errorf("Match(%q) = %v, want %v", c.query, ok, c.ok)
+		}
+	}
+	exact, _ := Match(doc, Terms("portrait"))
+	typo, _ := Match(doc, Terms("portriat"))
+	body, _ := Match(doc, Terms("painted"))
+	if !(exact > typo && typo > body) {
+		t.Fatalf("ranks exact=%v typo=%v body=%v", exact, typo, body)
+	}
+}
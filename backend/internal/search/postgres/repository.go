@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/search"
+)
+
+// sqliteCandidateLimit bounds how many FTS5 matches are scored per search.
+const sqliteCandidateLimit = 200
+
+type documentRow struct {
+	Kind    string `gorm:"primaryKey;size:20"`
+	RefID   int    `gorm:"column:ref_id;primaryKey;autoIncrement:false"`
+	Title   string `gorm:"size:500;not null"`
+	Body    string `gorm:"not null;default:''"`
+	Summary string `gorm:"not null;default:''"`
+	Path    string `gorm:"size:500;not null"`
+}
+
+func (documentRow) TableName() string { return "search_documents" }
+
+// Repository stores search documents in Postgres, where the migration adds a
+// weighted tsvector and a trigram index, or in SQLite. SQLite has no
+// migrations, so the table is created on first use: an FTS5 table with the
+// trigram tokenizer when the driver was built with the sqlite_fts5 tag, a
+// plain table scanned on every search otherwise. SQLite results are ranked
+// by search.Match.
+type Repository struct {
+	db *gorm.DB
+
+	sqliteOnce sync.Once
+	sqliteErr  error
+	fts5       bool
+}
+
+func NewRepository(db *gorm.DB) *Repository { return &Repository{db: db} }
+
+func (r *Repository) isSQLite() bool { return r.db.Dialector.Name() == "sqlite" }
+
+// prepare creates the SQLite table once; it does nothing on Postgres.
+func (r *Repository) prepare(ctx context.Context) error {
+	if !r.isSQLite() {
+		return nil
+	}
+	r.sqliteOnce.Do(func() {
+		db := r.db.WithContext(ctx)
+		var fts5 int
+		if err := db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&fts5).Error; err != nil {
+			r.sqliteErr = err
+			return
+		}
+		r.fts5 = fts5 == 1
+		if r.fts5 {
+			r.sqliteErr = db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS search_documents USING fts5(
+				kind UNINDEXED, ref_id UNINDEXED, title, body, summary UNINDEXED, path UNINDEXED,
+				tokenize = 'trigram')`).Error
+			return
+		}
+		r.sqliteErr = db.AutoMigrate(&documentRow{})
+	})
+	return r.sqliteErr
+}
+
+func (r *Repository) Upsert(ctx context.Context, docs []search.Document) error {
+	if err := r.prepare(ctx); err != nil || len(docs) == 0 {
+		return err
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, d := range docs {
+			if err := tx.Where("kind = ? AND ref_id = ?", string(d.Kind), d.RefID).Delete(&documentRow{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(toRows(docs)).Error
+	})
+}
+
+func (r *Repository) Remove(ctx context.Context, kind search.Kind, refIDs []int) error {
+	if err := r.prepare(ctx); err != nil || len(refIDs) == 0 {
+		return err
+	}
+	return r.db.WithContext(ctx).Where("kind = ? AND ref_id IN ?", string(kind), refIDs).Delete(&documentRow{}).Error
+}
+
+func (r *Repository) Replace(ctx context.Context, kind search.Kind, docs []search.Document) error {
+	if err := r.prepare(ctx); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("kind = ?", string(kind)).Delete(&documentRow{}).Error; err != nil {
+			return err
+		}
+		if len(docs) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(toRows(docs), 500).Error
+	})
+}
+
+type resultRow struct {
+	documentRow
+	Rank float64
+}
+
+func (r *Repository) Search(ctx context.Context, q search.Query, terms []string) ([]search.Result, error) {
+	if err := r.prepare(ctx); err != nil {
+		return nil, err
+	}
+	if r.isSQLite() {
+		return r.searchSQLite(ctx, q, terms)
+	}
+	// Every term is matched as a prefix; the trigram word similarity of the
+	// whole query catches typos the tsquery misses.
+	prefixes := make([]string, 0, len(terms))
+	for _, t := range terms {
+		prefixes = append(prefixes, t+":*")
+	}
+	tsquery := strings.Join(prefixes, " & ")
+	text := strings.Join(terms, " ")
+	tx := r.db.WithContext(ctx).
+		Table("search_documents").
+		Select("kind, ref_id, title, body, summary, path, "+
+			"ts_rank(document, to_tsquery('simple', ?)) + word_similarity(?, search_text) AS rank", tsquery, text).
+		Where("(document @@ to_tsquery('simple', ?) OR ? <% search_text)", tsquery, text)
+	tx = whereKinds(tx, q.Kinds)
+	var rows []resultRow
+	if err := tx.Order("rank desc, title asc").Limit(q.Limit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]search.Result, 0, len(rows))
+	for i := range rows {
+		out = append(out, search.Result{Document: toDocument(&rows[i].documentRow), Rank: rows[i].Rank})
+	}
+	return out, nil
+}
+
+// searchSQLite loads candidates sharing a trigram with the terms through
+// FTS5, or all documents without it or for terms too short for trigrams,
+// and ranks them with search.Match.
+func (r *Repository) searchSQLite(ctx context.Context, q search.Query, terms []string) ([]search.Result, error) {
+	tx := whereKinds(r.db.WithContext(ctx).Model(&documentRow{}), q.Kinds)
+	if trigrams := queryTrigrams(terms); r.fts5 && len(trigrams) > 0 {
+		tx = tx.Where("search_documents MATCH ?", strings.Join(trigrams, " OR ")).
+			Order("bm25(search_documents)").
+			Limit(sqliteCandidateLimit)
+	}
+	var rows []documentRow
+	if err := tx.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]search.Result, 0, len(rows))
+	for i := range rows {
+		doc := toDocument(&rows[i])
+		if rank, ok := search.Match(&doc, terms); ok {
+			out = append(out, search.Result{Document: doc, Rank: rank})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Rank != out[j].Rank {
+			return out[i].Rank > out[j].Rank
+		}
+		return out[i].Title < out[j].Title
+	})
+	if len(out) > q.Limit {
+		out = out[:q.Limit]
+	}
+	return out, nil
+}
+
+// queryTrigrams returns the quoted trigrams of all terms, or nil when a term
+// is too short to have any: such a term could not be found through them.
+func queryTrigrams(terms []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, t := range terms {
+		runes := []rune(t)
+		if len(runes) < 3 {
+			return nil
+		}
+		for i := 0; i+3 <= len(runes); i++ {
+			tri := string(runes[i : i+3])
+			if !seen[tri] {
+				seen[tri] = true
+				out = append(out, `"`+tri+`"`)
+			}
+		}
+	}
+	return out
+}
+
+func whereKinds(tx *gorm.DB, kinds []search.Kind) *gorm.DB {
+	if len(kinds) == 0 {
+		return tx
+	}
+	values := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		values = append(values, string(k))
+	}
+	return tx.Where("kind IN ?", values)
+}
+
+func toRows(docs []search.Document) []documentRow {
+	rows := make([]documentRow, 0, len(docs))
+	for _, d := range docs {
+		rows = append(rows, documentRow{
+			Kind:    string(d.Kind),
+			RefID:   d.RefID,
+			Title:   d.Title,
+			Body:    d.Body,
+			Summary: d.Summary,
+			Path:    d.Path,
+		})
+	}
+	return rows
+}
+
+func toDocument(row *documentRow) search.Document {
+	return search.Document{
+		Kind:    search.Kind(row.Kind),
+		RefID:   row.RefID,
+		Title:   row.Title,
+		Body:    row.Body,
+		Summary: row.Summary,
+		Path:    row.Path,
+	}
+}
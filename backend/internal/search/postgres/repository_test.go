@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/search"
+)
+
+type staticSource struct {
+	kind search.Kind
+	docs []search.Document
+}
+
+func (s staticSource) SearchKind() search.Kind { return s.kind }
+
+func (s staticSource) SearchDocuments(context.Context) ([]search.Document, error) { return s.docs, nil }
+
+func TestSearchDocumentsInSQLite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	ctx := context.Background()
+	articles := staticSource{kind: search.KindArticle, docs: []search.Document{
+		{Kind: search.KindArticle, RefID: 1, Title: "Kaffeetasse Classic", Body: "Keramik Tassen SUP-4711", Path: "/articles/kaffeetasse-classic"},
+		{Kind: search.KindArticle, RefID: 2, Title: "Teebecher", Body: "Becher aus Keramik für Kaffee und Tee", Path: "/articles/teebecher"},
+	}}
+	prompts := staticSource{kind: search.KindPrompt, docs: []search.Document{
+		{Kind: search.KindPrompt, RefID: 7, Title: "Watercolor portrait", Body: "Portraits Soft painted faces", Path: "/prompts/7"},
+	}}
+	svc := search.NewService(NewRepository(db), articles, prompts)
+	if n, err := svc.Rebuild(ctx); err != nil || n != 3 {
+		t.Fatalf("rebuild: %d, %v", n, err)
+	}
+
+	titles := func(q search.Query) []string {
+		t.Helper()
+		results, err := svc.Search(ctx, q)
+		if err != nil {
+			t.Fatalf("search %q: %v", q.Text, err)
+		}
+		out := make([]string, 0, len(results))
+		for _, r := range results {
+			out = append(out, r.Title)
+		}
+		return out
+	}
+	if got := titles(search.Query{Text: "kaffee"}); len(got) != 2 || got[0] != "Kaffeetasse Classic" {
+		t.Fatalf("title prefix should outrank body match: %v", got)
+	}
+	if got := titles(search.Query{Text: "watercolr"}); len(got) != 1 || got[0] != "Watercolor portrait" {
+		t.Fatalf("typo: %v", got)
+	}
+	if got := titles(search.Query{Text: "sup-4711"}); len(got) != 1 || got[0] != "Kaffeetasse Classic" {
+		t.Fatalf("supplier number: %v", got)
+	}
+	if got := titles(search.Query{Text: "keramik tee", Kinds: []search.Kind{search.KindArticle}}); len(got) != 1 || got[0] != "Teebecher" {
+		t.Fatalf("all terms must match: %v", got)
+	}
+	if got := titles(search.Query{Text: "keramik", Kinds: []search.Kind{search.KindPrompt}}); len(got) != 0 {
+		t.Fatalf("kind filter: %v", got)
+	}
+
+	if err := svc.Upsert(ctx, search.Document{Kind: search.KindArticle, RefID: 2, Title: "Thermobecher", Path: "/articles/thermobecher"}); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if got := titles(search.Query{Text: "becher"}); len(got) != 1 || got[0] != "Thermobecher" {
+		t.Fatalf("upserted document: %v", got)
+	}
+	if err := svc.Remove(ctx, search.KindPrompt, 7); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if got := titles(search.Query{Text: "portrait"}); len(got) != 0 {
+		t.Fatalf("removed document: %v", got)
+	}
+}
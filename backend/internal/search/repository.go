@@ -0,0 +1,15 @@
+package search
+
+import "context"
+
+// Repository stores the search documents. Upsert replaces documents with the
+// same kind and reference ID.
+type Repository interface {
+	Upsert(ctx context.Context, docs []Document) error
+	Remove(ctx context.Context, kind Kind, refIDs []int) error
+	// Replace swaps all documents of a kind for docs.
+	Replace(ctx context.Context, kind Kind, docs []Document) error
+	// Search returns up to q.Limit documents matching all terms, best first.
+	// terms are normalized with Terms.
+	Search(ctx context.Context, q Query, terms []string) ([]Result, error)
+}
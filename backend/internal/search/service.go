@@ -0,0 +1,77 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 50
+)
+
+var ErrUnknownKind = errors.New("unknown search kind")
+
+// Service answers storefront searches and keeps the documents current. It
+// implements Index so admin writes can update single documents.
+type Service struct {
+	repo    Repository
+	sources []Source
+}
+
+// NewService builds a search over the documents of sources.
+func NewService(repo Repository, sources ...Source) *Service {
+	return &Service{repo: repo, sources: sources}
+}
+
+// Search returns the documents matching every word of q.Text, allowing typos
+// in longer words and treating words as prefixes.
+func (s *Service) Search(ctx context.Context, q Query) ([]Result, error) {
+	for _, k := range q.Kinds {
+		if !slices.ContainsFunc(s.sources, func(src Source) bool { return src.SearchKind() == k }) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownKind, k)
+		}
+	}
+	terms := Terms(q.Text)
+	if len(terms) == 0 {
+		return []Result{}, nil
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultLimit
+	}
+	q.Limit = min(q.Limit, maxLimit)
+	return s.repo.Search(ctx, q, terms)
+}
+
+// Rebuild replaces the documents of every source and returns how many were
+// stored. It repairs the index after writes that bypassed the services.
+func (s *Service) Rebuild(ctx context.Context) (int, error) {
+	total := 0
+	for _, src := range s.sources {
+		docs, err := src.SearchDocuments(ctx)
+		if err != nil {
+			return total, err
+		}
+		if err := s.repo.Replace(ctx, src.SearchKind(), docs); err != nil {
+			return total, err
+		}
+		total += len(docs)
+	}
+	return total, nil
+}
+
+func (s *Service) Upsert(ctx context.Context, docs ...Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	return s.repo.Upsert(ctx, docs)
+}
+
+func (s *Service) Remove(ctx context.Context, kind Kind, refIDs ...int) error {
+	if len(refIDs) == 0 {
+		return nil
+	}
+	return s.repo.Remove(ctx, kind, refIDs)
+}
@@ -0,0 +1,50 @@
+package search
+
+import "context"
+
+// Kind tells what a document describes.
+type Kind string
+
+const (
+	KindArticle Kind = "article"
+	KindPrompt  Kind = "prompt"
+)
+
+// Document is the searchable text of one storefront item. Title is weighted
+// above Body; Summary and Path are shown with results. Path is relative to
+// the storefront base URL, like sitemap paths.
+type Document struct {
+	Kind    Kind
+	RefID   int
+	Title   string
+	Body    string
+	Summary string
+	Path    string
+}
+
+// Query describes a search. Kinds restricts the results to documents of
+// those kinds; empty searches all of them.
+type Query struct {
+	Text  string
+	Kinds []Kind
+	Limit int
+}
+
+// Result is a matching document with its relevance; higher ranks first.
+type Result struct {
+	Document
+	Rank float64
+}
+
+// Source provides the documents of one kind, e.g. articles or prompts, for
+// rebuilding the index. Only items the storefront shows are included.
+type Source interface {
+	SearchKind() Kind
+	SearchDocuments(ctx context.Context) ([]Document, error)
+}
+
+// Index receives incremental updates from admin writes.
+type Index interface {
+	Upsert(ctx context.Context, docs ...Document) error
+	Remove(ctx context.Context, kind Kind, refIDs ...int) error
+}
@@ -0,0 +1,20 @@
+package sitemap
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes mounts the public sitemap at /api/sitemap.xml.
+func RegisterRoutes(r *gin.Engine, svc *Service) {
+	r.GET("/api/sitemap.xml", func(c *gin.Context) {
+		body, err := svc.XML(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to generate sitemap"})
+			return
+		}
+		c.Header("Cache-Control", "public, max-age=900")
+		c.Data(http.StatusOK, "application/xml; charset=utf-8", body)
+	})
+}
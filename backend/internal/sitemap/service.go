@@ -0,0 +1,97 @@
+package sitemap
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheTTL bounds how long a generated sitemap is served. Crawlers fetch it
+// rarely, so there is no invalidation on writes.
+const cacheTTL = 15 * time.Minute
+
+// Service renders the sitemap from its sources and caches the result.
+type Service struct {
+	sources []Source
+	baseURL string
+
+	mu          sync.Mutex
+	cached      []byte
+	generatedAt time.Time
+}
+
+// NewService builds a sitemap over sources. Page URLs are prefixed with the
+// storefront base URL from PUBLIC_APP_BASE_URL or APP_BASE_URL.
+func NewService(sources ...Source) *Service {
+	return &Service{sources: sources, baseURL: storefrontBaseURL()}
+}
+
+func storefrontBaseURL() string {
+	for _, key := range []string{"PUBLIC_APP_BASE_URL", "APP_BASE_URL"} {
+		if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+			return strings.TrimRight(v, "/")
+		}
+	}
+	return "http://localhost:8081"
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []url    `xml:"url"`
+}
+
+type url struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// XML returns the sitemap document, regenerating it when the cached copy is
+// older than cacheTTL.
+func (s *Service) XML(ctx context.Context) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cached != nil && time.Since(s.generatedAt) < cacheTTL {
+		return s.cached, nil
+	}
+	var entries []Entry
+	for _, src := range s.sources {
+		es, err := src.SitemapEntries(ctx)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, es...)
+	}
+	out, err := render(s.baseURL, entries)
+	if err != nil {
+		return nil, err
+	}
+	s.cached, s.generatedAt = out, time.Now()
+	return out, nil
+}
+
+// render writes entries as a sitemaps.org urlset, ordered by path with
+// duplicates dropped.
+func render(baseURL string, entries []Entry) ([]byte, error) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	set := urlSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9", URLs: make([]url, 0, len(entries))}
+	for i, e := range entries {
+		if i > 0 && e.Path == entries[i-1].Path {
+			continue
+		}
+		u := url{Loc: baseURL + e.Path}
+		if !e.LastModified.IsZero() {
+			u.LastMod = e.LastModified.UTC().Format("2006-01-02")
+		}
+		set.URLs = append(set.URLs, u)
+	}
+	body, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,55 @@
+package sitemap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type staticSource []Entry
+
+func (s staticSource) SitemapEntries(context.Context) ([]Entry, error) { return s, nil }
+
+type failingSource struct{}
+
+func (failingSource) SitemapEntries(context.Context) ([]Entry, error) {
+	return nil, errors.New("boom")
+}
+
+func TestXMLListsEntriesOfAllSources(t *testing.T) {
+	modified := time.Date(2026, 3, 4, 22, 30, 0, 0, time.FixedZone("CET", 3600))
+	svc := &Service{
+		baseURL: "https://shop.example",
+		sources: []Source{
+			staticSource{{Path: "/articles/mug", LastModified: modified}},
+			staticSource{{Path: "/prompts/7"}, {Path: "/articles/mug"}},
+		},
+	}
+	out, err := svc.XML(context.Background())
+	if err != nil {
+		t.Fatalf("XML: %v", err)
+	}
+	doc := string(out)
+	for _, want := range []string{
+		`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`,
+		"<loc>https://shop.example/articles/mug</loc>",
+		"<lastmod>2026-03-04</lastmod>",
+		"<loc>https://shop.example/prompts/7</loc>",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("sitemap missing %q:\n%s", want, doc)
+		}
+	}
+	if n := strings.Count(doc, "<url>"); n != 2 {
+		t.Errorf("got %d urls, want 2 (duplicates dropped):\n%s", n, doc)
+	}
+}
+
+func TestXMLFailsWhenASourceFails(t *testing.T) {
+	svc := &Service{sources: []Source{failingSource{}}}
+	if _, err := svc.XML(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,19 @@
+package sitemap
+
+import (
+	"context"
+	"time"
+)
+
+// Entry is one storefront page listed in the sitemap. Path is relative to the
+// storefront base URL and starts with a slash.
+type Entry struct {
+	Path         string
+	LastModified time.Time
+}
+
+// Source contributes the public pages of one domain, e.g. articles or
+// prompts, to the sitemap.
+type Source interface {
+	SitemapEntries(ctx context.Context) ([]Entry, error)
+}
@@ -0,0 +1,105 @@
+package utility
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxSlugLength bounds slugs so that a numeric suffix still fits the
+// varchar(255) slug columns.
+const MaxSlugLength = 200
+
+var (
+	ErrInvalidSlug = errors.New("invalid slug: use lowercase letters, digits and single hyphens")
+	ErrSlugTaken   = errors.New("slug already in use")
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// slugTransliterations spells out the letters most common in product names
+// that have no ASCII form; other non-ASCII characters separate words.
+var slugTransliterations = map[rune]string{
+	'ä': "ae", 'ö': "oe", 'ü': "ue", 'ß': "ss",
+	'à': "a", 'á': "a", 'â': "a", 'ç': "c", 'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o",
+	'ù': "u", 'ú': "u", 'û': "u",
+}
+
+// Slugify derives a URL slug from a display name, e.g. "Tasse Größe L" becomes
+// "tasse-groesse-l". The result is empty when s has no letters or digits.
+func Slugify(s string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(s) {
+		var part string
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			part = string(r)
+		default:
+			part = slugTransliterations[r]
+		}
+		if part == "" {
+			pendingHyphen = b.Len() > 0
+			continue
+		}
+		if pendingHyphen {
+			b.WriteByte('-')
+			pendingHyphen = false
+		}
+		b.WriteString(part)
+	}
+	out := b.String()
+	if len(out) > MaxSlugLength {
+		out = strings.TrimRight(out[:MaxSlugLength], "-")
+	}
+	return out
+}
+
+// ValidSlug reports whether s is a well-formed slug.
+func ValidSlug(s string) bool {
+	return len(s) <= MaxSlugLength && slugPattern.MatchString(s)
+}
+
+// ResolveSlug picks the slug for an entity. A requested slug is normalised,
+// validated and must be free; otherwise the slug is derived from name (or
+// fallback when name has no usable characters) and suffixed with -2, -3, …
+// until taken reports it free. reserved lists slugs that collide with routes.
+func ResolveSlug(requested *string, name, fallback string, reserved []string, taken func(string) (bool, error)) (string, error) {
+	if requested != nil {
+		slug := strings.ToLower(strings.TrimSpace(*requested))
+		if !ValidSlug(slug) || slices.Contains(reserved, slug) {
+			return "", ErrInvalidSlug
+		}
+		used, err := taken(slug)
+		if err != nil {
+			return "", err
+		}
+		if used {
+			return "", ErrSlugTaken
+		}
+		return slug, nil
+	}
+	base := Slugify(name)
+	if base == "" {
+		base = fallback
+	}
+	for n := 1; ; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = base + "-" + strconv.Itoa(n)
+		}
+		if slices.Contains(reserved, candidate) {
+			continue
+		}
+		used, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return candidate, nil
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package utility
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"Tasse Größe L":      "tasse-groesse-l",
+		"  Café -- Crème!  ": "cafe-creme",
+		"T-Shirt (Bio) 100%": "t-shirt-bio-100",
+		"!!!":                "",
+		"Emoji 🎉 Mug":        "emoji-mug",
+	}
+	for in, want := range cases {
+		if got := Slugify(in); got != want {
+			t.Errorf("Slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestResolveSlug(t *testing.T) {
+	used := map[string]bool{"classic-mug": true, "classic-mug-2": true}
+	taken := func(s string) (bool, error) { return used[s], nil }
+
+	got, err := ResolveSlug(nil, "Classic Mug", "article", nil, taken)
+	if err != nil || got != "classic-mug-3" {
+		t.Fatalf("derived slug = %q, %v; want classic-mug-3", got, err)
+	}
+	got, err = ResolveSlug(nil, "???", "article", nil, taken)
+	if err != nil || got != "article" {
+		t.Fatalf("fallback slug = %q, %v; want article", got, err)
+	}
+	got, err = ResolveSlug(nil, "Categories", "article", []string{"categories"}, taken)
+	if err != nil || got != "categories-2" {
+		t.Fatalf("reserved slug = %q, %v; want categories-2", got, err)
+	}
+
+	requested := " My-Mug "
+	got, err = ResolveSlug(&requested, "ignored", "article", nil, taken)
+	if err != nil || got != "my-mug" {
+		t.Fatalf("requested slug = %q, %v; want my-mug", got, err)
+	}
+	for _, bad := range []string{"", "a--b", "-a", "a_b", "categories"} {
+		bad := bad
+		if _, err := ResolveSlug(&bad, "x", "article", []string{"categories"}, taken); !errors.Is(err, ErrInvalidSlug) {
+			t.Errorf("ResolveSlug(%q) err = %v, want ErrInvalidSlug", bad, err)
+		}
+	}
+	dup := "classic-mug"
+	if _, err := ResolveSlug(&dup, "x", "article", nil, taken); !errors.Is(err, ErrSlugTaken) {
+		t.Fatalf("taken slug err = %v, want ErrSlugTaken", err)
+	}
+}
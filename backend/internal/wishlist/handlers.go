@@ -0,0 +1,329 @@
+package wishlist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"voenix/backend/internal/article"
+	"voenix/backend/internal/auth"
+	"voenix/backend/internal/cart"
+)
+
+const publicPath = "/api/public/saved-items/"
+
+type saveRequest struct {
+	ArticleID        int            `json:"articleId" binding:"required"`
+	VariantID        int            `json:"variantId" binding:"required"`
+	GeneratedImageID *int           `json:"generatedImageId"`
+	PromptID         *int           `json:"promptId"`
+	CustomData       map[string]any `json:"customData"`
+}
+
+type moveToCartRequest struct {
+	Quantity int `json:"quantity"`
+}
+
+type SavedItemResponse struct {
+	ID                     int                     `json:"id"`
+	Article                article.ArticleResponse `json:"article"`
+	VariantID              int                     `json:"variantId"`
+	VariantType            string                  `json:"variantType"`
+	GeneratedImageID       *int                    `json:"generatedImageId"`
+	GeneratedImageFilename *string                 `json:"generatedImageFilename"`
+	ImageURL               *string                 `json:"imageUrl"`
+	PromptID               *int                    `json:"promptId"`
+	PromptTitle            *string                 `json:"promptTitle,omitempty"`
+	CustomData             map[string]any          `json:"customData"`
+	CreatedAt              time.Time               `json:"createdAt"`
+}
+
+type shareResponse struct {
+	Token     string    `json:"token"`
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type sharedListResponse struct {
+	Items []SavedItemResponse `json:"items"`
+}
+
+// RegisterRoutes mounts the saved items of the signed-in user under
+// /api/user/saved-items and the read-only view of shared lists under
+// /api/public/saved-items.
+func RegisterRoutes(r *gin.Engine, middleware gin.HandlerFunc, svc *Service) {
+	grp := r.Group("/api/user/saved-items")
+	grp.Use(middleware)
+
+	grp.GET("", func(c *gin.Context) {
+		u, ok := requireUser(c)
+		if !ok {
+			return
+		}
+		items, err := svc.List(c.Request.Context(), u.ID)
+		if err != nil {
+			writeError(c, err, "Failed to fetch saved items")
+			return
+		}
+		writeItems(c, svc, items)
+	})
+
+	grp.POST("", func(c *gin.Context) {
+		u, ok := requireUser(c)
+		if !ok {
+			return
+		}
+		var req saveRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid request"})
+			return
+		}
+		item, err := svc.Save(c.Request.Context(), u.ID, SaveInput(req))
+		if err != nil {
+			writeError(c, err, "Failed to save item")
+			return
+		}
+		writeItem(c, svc, http.StatusCreated, item)
+	})
+
+	grp.DELETE("/:id", func(c *gin.Context) {
+		u, ok := requireUser(c)
+		if !ok {
+			return
+		}
+		id, ok := parseID(c, "id")
+		if !ok {
+			return
+		}
+		if err := svc.Delete(c.Request.Context(), u.ID, id); err != nil {
+			writeError(c, err, "Failed to delete saved item")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	// POST /api/user/saved-items/:id/move-to-cart responds with the cart.
+	grp.POST("/:id/move-to-cart", func(c *gin.Context) {
+		u, ok := requireUser(c)
+		if !ok {
+			return
+		}
+		id, ok := parseID(c, "id")
+		if !ok {
+			return
+		}
+		var req moveToCartRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil || req.Quantity < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid request"})
+				return
+			}
+		}
+		detail, err := svc.MoveToCart(c.Request.Context(), u.ID, id, req.Quantity)
+		if err != nil {
+			writeError(c, err, "Failed to move saved item to cart")
+			return
+		}
+		dto, err := svc.carts.ToCartResponse(c.Request.Context(), detail)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to assemble cart"})
+			return
+		}
+		c.JSON(http.StatusOK, dto)
+	})
+
+	grp.POST("/from-cart/:itemId", func(c *gin.Context) {
+		u, ok := requireUser(c)
+		if !ok {
+			return
+		}
+		itemID, ok := parseID(c, "itemId")
+		if !ok {
+			return
+		}
+		item, err := svc.MoveFromCart(c.Request.Context(), u.ID, itemID)
+		if err != nil {
+			writeError(c, err, "Failed to move cart item")
+			return
+		}
+		writeItem(c, svc, http.StatusCreated, item)
+	})
+
+	grp.POST("/share", func(c *gin.Context) {
+		u, ok := requireUser(c)
+		if !ok {
+			return
+		}
+		share, err := svc.ShareLink(c.Request.Context(), u.ID)
+		if err != nil {
+			writeError(c, err, "Failed to share saved items")
+			return
+		}
+		c.JSON(http.StatusOK, shareResponse{Token: share.Token, Path: publicPath + share.Token, CreatedAt: share.CreatedAt})
+	})
+
+	grp.DELETE("/share", func(c *gin.Context) {
+		u, ok := requireUser(c)
+		if !ok {
+			return
+		}
+		if err := svc.StopSharing(c.Request.Context(), u.ID); err != nil {
+			writeError(c, err, "Failed to stop sharing")
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	pub := r.Group("/api/public/saved-items")
+
+	pub.GET("/:token", func(c *gin.Context) {
+		token := c.Param("token")
+		_, items, err := svc.Shared(c.Request.Context(), token)
+		if err != nil {
+			writeError(c, err, "Failed to fetch shared items")
+			return
+		}
+		responses, err := svc.toResponses(c.Request.Context(), items, sharedImageURL(token))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to assemble saved items"})
+			return
+		}
+		c.JSON(http.StatusOK, sharedListResponse{Items: responses})
+	})
+
+	pub.GET("/:token/images/:filename", func(c *gin.Context) {
+		filename := c.Param("filename")
+		imageBytes, contentType, err := svc.SharedImage(c.Request.Context(), c.Param("token"), filename)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"detail": "Not found"})
+			return
+		}
+		c.Header("Content-Disposition", "inline; filename=\""+filename+"\"")
+		c.Data(http.StatusOK, contentType, imageBytes)
+	})
+}
+
+// toResponses converts items for the API; imageURL gives the URL of a
+// generated image file.
+func (s *Service) toResponses(ctx context.Context, items []SavedItem, imageURL func(string) string) ([]SavedItemResponse, error) {
+	filenames, err := s.repo.GeneratedImageFilenames(ctx, generatedImageIDs(items))
+	if err != nil {
+		return nil, err
+	}
+	promptIDs := make([]int, 0, len(items))
+	for _, it := range items {
+		if it.PromptID != nil {
+			promptIDs = append(promptIDs, *it.PromptID)
+		}
+	}
+	titles, err := s.repo.PromptTitles(ctx, promptIDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SavedItemResponse, 0, len(items))
+	for _, it := range items {
+		art, err := s.articles.GetArticleSummary(ctx, it.ArticleID)
+		if err != nil {
+			return nil, err
+		}
+		customData := map[string]any{}
+		_ = json.Unmarshal([]byte(it.CustomData), &customData)
+		resp := SavedItemResponse{
+			ID:               it.ID,
+			Article:          art,
+			VariantID:        it.VariantID,
+			VariantType:      it.VariantType,
+			GeneratedImageID: it.GeneratedImageID,
+			PromptID:         it.PromptID,
+			CustomData:       customData,
+			CreatedAt:        it.CreatedAt,
+		}
+		if it.GeneratedImageID != nil {
+			if name, ok := filenames[*it.GeneratedImageID]; ok && name != "" {
+				u := imageURL(name)
+				resp.GeneratedImageFilename = &name
+				resp.ImageURL = &u
+			}
+		}
+		if it.PromptID != nil {
+			if title, ok := titles[*it.PromptID]; ok && title != "" {
+				resp.PromptTitle = &title
+			}
+		}
+		out = append(out, resp)
+	}
+	return out, nil
+}
+
+func userImageURL(filename string) string {
+	return "/api/user/images/" + url.PathEscape(filename)
+}
+
+func sharedImageURL(token string) func(string) string {
+	return func(filename string) string {
+		return publicPath + url.PathEscape(token) + "/images/" + url.PathEscape(filename)
+	}
+}
+
+func writeItems(c *gin.Context, svc *Service, items []SavedItem) {
+	responses, err := svc.toResponses(c.Request.Context(), items, userImageURL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to assemble saved items"})
+		return
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+func writeItem(c *gin.Context, svc *Service, status int, item *SavedItem) {
+	responses, err := svc.toResponses(c.Request.Context(), []SavedItem{*item}, userImageURL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": "Failed to assemble saved item"})
+		return
+	}
+	c.JSON(status, responses[0])
+}
+
+func requireUser(c *gin.Context) (*auth.User, bool) {
+	uVal, _ := c.Get("currentUser")
+	u, _ := uVal.(*auth.User)
+	if u == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"detail": "Not authenticated"})
+		return nil, false
+	}
+	return u, true
+}
+
+func parseID(c *gin.Context, param string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(param))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"detail": "Invalid id"})
+		return 0, false
+	}
+	return id, true
+}
+
+func writeError(c *gin.Context, err error, fallback string) {
+	var validation cart.ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": ErrNotFound.Error()})
+	case errors.Is(err, ErrImageNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": ErrImageNotFound.Error()})
+	case errors.Is(err, ErrShareNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": ErrShareNotFound.Error()})
+	case errors.Is(err, cart.ErrCartItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"detail": cart.ErrCartItemNotFound.Error()})
+	case errors.Is(err, cart.ErrCartConflict):
+		c.JSON(http.StatusConflict, gin.H{"detail": cart.ErrCartConflict.Error()})
+	case errors.As(err, &validation):
+		c.JSON(http.StatusBadRequest, gin.H{"detail": validation.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"detail": fallback})
+	}
+}
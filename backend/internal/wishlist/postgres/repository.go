@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/wishlist"
+)
+
+type Repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *Repository {
+	return &Repository{db: db}
+}
+
+var _ wishlist.Repository = (*Repository)(nil)
+
+func (r *Repository) List(ctx context.Context, userID int) ([]wishlist.SavedItem, error) {
+	var rows []SavedItemRow
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at desc, id desc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]wishlist.SavedItem, 0, len(rows))
+	for i := range rows {
+		out = append(out, rows[i].toDomain())
+	}
+	return out, nil
+}
+
+func (r *Repository) ByID(ctx context.Context, userID, id int) (*wishlist.SavedItem, error) {
+	var row SavedItemRow
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, wishlist.ErrNotFound
+		}
+		return nil, err
+	}
+	item := row.toDomain()
+	return &item, nil
+}
+
+func (r *Repository) Create(ctx context.Context, item *wishlist.SavedItem) error {
+	row := fromDomain(*item)
+	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return err
+	}
+	*item = row.toDomain()
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, userID, id int) (bool, error) {
+	res := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&SavedItemRow{})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *Repository) GeneratedImage(ctx context.Context, id int) (*wishlist.GeneratedImage, error) {
+	var rows []wishlist.GeneratedImage
+	if err := r.db.WithContext(ctx).
+		Table("generated_images").
+		Select("id, filename, prompt_id, user_id").
+		Where("id = ?", id).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *Repository) GeneratedImageFilenames(ctx context.Context, ids []int) (map[int]string, error) {
+	return r.names(ctx, "generated_images", "filename", ids)
+}
+
+func (r *Repository) PromptTitles(ctx context.Context, ids []int) (map[int]string, error) {
+	return r.names(ctx, "prompts", "title", ids)
+}
+
+// names maps the IDs of rows of table to their column.
+func (r *Repository) names(ctx context.Context, table, column string, ids []int) (map[int]string, error) {
+	result := make(map[int]string, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	type row struct {
+		ID   int
+		Name string
+	}
+	var rows []row
+	if err := r.db.WithContext(ctx).
+		Table(table).
+		Select("id, "+column+" AS name").
+		Where("id IN ?", ids).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		result[r.ID] = r.Name
+	}
+	return result, nil
+}
+
+func (r *Repository) Share(ctx context.Context, userID int) (*wishlist.Share, error) {
+	var row ShareRow
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return row.toDomain(), nil
+}
+
+func (r *Repository) ShareByToken(ctx context.Context, token string) (*wishlist.Share, error) {
+	var row ShareRow
+	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, wishlist.ErrShareNotFound
+		}
+		return nil, err
+	}
+	return row.toDomain(), nil
+}
+
+func (r *Repository) CreateShare(ctx context.Context, share *wishlist.Share) error {
+	row := ShareRow{UserID: share.UserID, Token: share.Token}
+	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return err
+	}
+	*share = *row.toDomain()
+	return nil
+}
+
+func (r *Repository) DeleteShare(ctx context.Context, userID int) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&ShareRow{}).Error
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"time"
+
+	"voenix/backend/internal/wishlist"
+)
+
+type SavedItemRow struct {
+	ID               int    `gorm:"primaryKey;column:id"`
+	UserID           int    `gorm:"column:user_id;not null;index"`
+	ArticleID        int    `gorm:"column:article_id;not null"`
+	VariantID        int    `gorm:"column:variant_id;not null"`
+	VariantType      string `gorm:"column:variant_type;size:20;not null;default:MUG"`
+	GeneratedImageID *int   `gorm:"column:generated_image_id"`
+	PromptID         *int   `gorm:"column:prompt_id"`
+	CustomData       string `gorm:"column:custom_data;type:text;not null"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (SavedItemRow) TableName() string { return "saved_items" }
+
+type ShareRow struct {
+	UserID    int    `gorm:"primaryKey;column:user_id;autoIncrement:false"`
+	Token     string `gorm:"column:token;size:64;uniqueIndex;not null"`
+	CreatedAt time.Time
+}
+
+func (ShareRow) TableName() string { return "saved_item_shares" }
+
+func (r *SavedItemRow) toDomain() wishlist.SavedItem {
+	return wishlist.SavedItem{
+		ID:               r.ID,
+		UserID:           r.UserID,
+		ArticleID:        r.ArticleID,
+		VariantID:        r.VariantID,
+		VariantType:      r.VariantType,
+		GeneratedImageID: r.GeneratedImageID,
+		PromptID:         r.PromptID,
+		CustomData:       r.CustomData,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
+
+func fromDomain(it wishlist.SavedItem) SavedItemRow {
+	return SavedItemRow{
+		ID:               it.ID,
+		UserID:           it.UserID,
+		ArticleID:        it.ArticleID,
+		VariantID:        it.VariantID,
+		VariantType:      it.VariantType,
+		GeneratedImageID: it.GeneratedImageID,
+		PromptID:         it.PromptID,
+		CustomData:       it.CustomData,
+		CreatedAt:        it.CreatedAt,
+		UpdatedAt:        it.UpdatedAt,
+	}
+}
+
+func (r *ShareRow) toDomain() *wishlist.Share {
+	return &wishlist.Share{UserID: r.UserID, Token: r.Token, CreatedAt: r.CreatedAt}
+}
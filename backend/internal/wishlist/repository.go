@@ -0,0 +1,21 @@
+package wishlist
+
+import "context"
+
+type Repository interface {
+	// List returns the saved items of the user, newest first.
+	List(ctx context.Context, userID int) ([]SavedItem, error)
+	// ByID returns ErrNotFound unless the item belongs to the user.
+	ByID(ctx context.Context, userID, id int) (*SavedItem, error)
+	Create(ctx context.Context, item *SavedItem) error
+	Delete(ctx context.Context, userID, id int) (bool, error)
+	// GeneratedImage returns nil when there is no image with the ID.
+	GeneratedImage(ctx context.Context, id int) (*GeneratedImage, error)
+	GeneratedImageFilenames(ctx context.Context, ids []int) (map[int]string, error)
+	PromptTitles(ctx context.Context, ids []int) (map[int]string, error)
+	// Share returns nil when the user does not share their saved items.
+	Share(ctx context.Context, userID int) (*Share, error)
+	ShareByToken(ctx context.Context, token string) (*Share, error)
+	CreateShare(ctx context.Context, share *Share) error
+	DeleteShare(ctx context.Context, userID int) error
+}
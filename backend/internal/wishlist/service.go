@@ -0,0 +1,293 @@
+package wishlist
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"voenix/backend/internal/article"
+	"voenix/backend/internal/cart"
+)
+
+var (
+	ErrNotFound      = errors.New("saved item not found")
+	ErrImageNotFound = errors.New("generated image not found")
+	ErrShareNotFound = errors.New("shared list not found")
+)
+
+// CartService is the part of the cart service saved items move through.
+type CartService interface {
+	ValidateItem(ctx context.Context, articleID, variantID int, promptID *int) (string, error)
+	GetCart(ctx context.Context, owner cart.Owner) (*cart.CartDetail, error)
+	AddItem(ctx context.Context, owner cart.Owner, input cart.AddItemInput) (*cart.CartDetail, error)
+	DeleteItem(ctx context.Context, owner cart.Owner, itemID int, ifMatch *cart.CartVersion) (*cart.CartDetail, error)
+	ToCartResponse(ctx context.Context, detail *cart.CartDetail) (*cart.CartResponse, error)
+}
+
+// ArticleService looks up the articles shown with saved items.
+type ArticleService interface {
+	GetArticleSummary(ctx context.Context, id int) (article.ArticleResponse, error)
+}
+
+// ImageStore reads the image files of a user.
+type ImageStore interface {
+	GetUserImage(ctx context.Context, userID int, filename string) ([]byte, string, error)
+}
+
+type Service struct {
+	repo     Repository
+	carts    CartService
+	articles ArticleService
+	images   ImageStore
+}
+
+func NewService(repo Repository, carts CartService, articles ArticleService, images ImageStore) *Service {
+	return &Service{repo: repo, carts: carts, articles: articles, images: images}
+}
+
+func (s *Service) List(ctx context.Context, userID int) ([]SavedItem, error) {
+	return s.repo.List(ctx, userID)
+}
+
+// Save adds an item to the user's saved items. The generated image must be
+// one of the user's; its prompt is used when no prompt is given. Saving an
+// item that is already saved returns the saved one.
+func (s *Service) Save(ctx context.Context, userID int, in SaveInput) (*SavedItem, error) {
+	customData := "{}"
+	if len(in.CustomData) > 0 {
+		if b, err := json.Marshal(in.CustomData); err == nil {
+			customData = string(b)
+		}
+	}
+	return s.save(ctx, SavedItem{
+		UserID:           userID,
+		ArticleID:        in.ArticleID,
+		VariantID:        in.VariantID,
+		GeneratedImageID: in.GeneratedImageID,
+		PromptID:         in.PromptID,
+		CustomData:       customData,
+	})
+}
+
+func (s *Service) save(ctx context.Context, item SavedItem) (*SavedItem, error) {
+	if item.GeneratedImageID != nil {
+		img, err := s.repo.GeneratedImage(ctx, *item.GeneratedImageID)
+		if err != nil {
+			return nil, err
+		}
+		if img == nil || img.UserID == nil || *img.UserID != item.UserID {
+			return nil, ErrImageNotFound
+		}
+		if item.PromptID == nil {
+			item.PromptID = &img.PromptID
+		}
+	}
+	variantType, err := s.carts.ValidateItem(ctx, item.ArticleID, item.VariantID, item.PromptID)
+	if err != nil {
+		return nil, err
+	}
+	item.VariantType = variantType
+	item.CustomData = canonicalizeJSON(item.CustomData)
+	saved, err := s.repo.List(ctx, item.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range saved {
+		if sameDesign(saved[i], item) {
+			return &saved[i], nil
+		}
+	}
+	if err := s.repo.Create(ctx, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (s *Service) Delete(ctx context.Context, userID, id int) error {
+	deleted, err := s.repo.Delete(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// MoveToCart removes the saved item and adds quantity units of it to the
+// user's cart. The item is removed first so a repeated request fails with
+// ErrNotFound instead of adding the design twice; it is restored when the
+// cart refuses the item.
+func (s *Service) MoveToCart(ctx context.Context, userID, id, quantity int) (*cart.CartDetail, error) {
+	item, err := s.repo.ByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := s.repo.Delete(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return nil, ErrNotFound
+	}
+	var customData map[string]any
+	if err := json.Unmarshal([]byte(item.CustomData), &customData); err != nil {
+		customData = map[string]any{}
+	}
+	detail, err := s.carts.AddItem(ctx, cart.UserOwner(userID), cart.AddItemInput{
+		ArticleID:        item.ArticleID,
+		VariantID:        item.VariantID,
+		Quantity:         quantity,
+		CustomData:       customData,
+		GeneratedImageID: item.GeneratedImageID,
+		PromptID:         item.PromptID,
+	})
+	if err != nil {
+		if restoreErr := s.repo.Create(ctx, item); restoreErr != nil {
+			slog.Error("restoring saved item after failed move to cart failed", "user", userID, "item", id, "error", restoreErr)
+			return nil, errors.Join(err, fmt.Errorf("restore saved item %d: %w", id, restoreErr))
+		}
+		return nil, err
+	}
+	return detail, nil
+}
+
+// MoveFromCart saves the cart item and removes it from the user's cart.
+func (s *Service) MoveFromCart(ctx context.Context, userID, cartItemID int) (*SavedItem, error) {
+	detail, err := s.carts.GetCart(ctx, cart.UserOwner(userID))
+	if err != nil {
+		return nil, err
+	}
+	var source *cart.CartItem
+	for i := range detail.Cart.Items {
+		if detail.Cart.Items[i].ID == cartItemID {
+			source = &detail.Cart.Items[i]
+			break
+		}
+	}
+	if source == nil {
+		return nil, cart.ErrCartItemNotFound
+	}
+	existing, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.save(ctx, SavedItem{
+		UserID:           userID,
+		ArticleID:        source.ArticleID,
+		VariantID:        source.VariantID,
+		GeneratedImageID: source.GeneratedImageID,
+		PromptID:         source.PromptID,
+		CustomData:       source.CustomData,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.carts.DeleteItem(ctx, cart.UserOwner(userID), cartItemID, nil); err != nil {
+		// Leave the saved items as they were when the item stays in the cart.
+		if !containsItem(existing, saved.ID) {
+			_, _ = s.repo.Delete(ctx, userID, saved.ID)
+		}
+		return nil, err
+	}
+	return saved, nil
+}
+
+// ShareLink returns the user's share link, creating it on first use.
+func (s *Service) ShareLink(ctx context.Context, userID int) (*Share, error) {
+	share, err := s.repo.Share(ctx, userID)
+	if err != nil || share != nil {
+		return share, err
+	}
+	share = &Share{UserID: userID, Token: rand.Text()}
+	if err := s.repo.CreateShare(ctx, share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// StopSharing revokes the user's share link; a new link gets a new token.
+func (s *Service) StopSharing(ctx context.Context, userID int) error {
+	return s.repo.DeleteShare(ctx, userID)
+}
+
+// Shared returns the saved items shared through token.
+func (s *Service) Shared(ctx context.Context, token string) (*Share, []SavedItem, error) {
+	share, err := s.repo.ShareByToken(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := s.repo.List(ctx, share.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return share, items, nil
+}
+
+// SharedImage returns a generated image of the saved items shared through
+// token. Other images of the user cannot be read this way.
+func (s *Service) SharedImage(ctx context.Context, token, filename string) ([]byte, string, error) {
+	share, items, err := s.Shared(ctx, token)
+	if err != nil {
+		return nil, "", err
+	}
+	filenames, err := s.repo.GeneratedImageFilenames(ctx, generatedImageIDs(items))
+	if err != nil {
+		return nil, "", err
+	}
+	for _, name := range filenames {
+		if name == filename {
+			return s.images.GetUserImage(ctx, share.UserID, filename)
+		}
+	}
+	return nil, "", ErrImageNotFound
+}
+
+func sameDesign(a, b SavedItem) bool {
+	return a.ArticleID == b.ArticleID && a.VariantID == b.VariantID && a.VariantType == b.VariantType &&
+		equalIDs(a.GeneratedImageID, b.GeneratedImageID) && equalIDs(a.PromptID, b.PromptID) &&
+		canonicalizeJSON(a.CustomData) == b.CustomData
+}
+
+func equalIDs(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func containsItem(items []SavedItem, id int) bool {
+	for _, it := range items {
+		if it.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func generatedImageIDs(items []SavedItem) []int {
+	ids := make([]int, 0, len(items))
+	for _, it := range items {
+		if it.GeneratedImageID != nil {
+			ids = append(ids, *it.GeneratedImageID)
+		}
+	}
+	return ids
+}
+
+// canonicalizeJSON rewrites a JSON object with sorted keys so equal custom
+// data compares equal.
+func canonicalizeJSON(s string) string {
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return s
+	}
+	return string(b)
+}
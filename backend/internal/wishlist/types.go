@@ -0,0 +1,43 @@
+package wishlist
+
+import "time"
+
+// SavedItem is a design a user kept for later: an article variant with the
+// generated image, prompt and custom data it would be ordered with.
+type SavedItem struct {
+	ID               int
+	UserID           int
+	ArticleID        int
+	VariantID        int
+	VariantType      string
+	GeneratedImageID *int
+	PromptID         *int
+	CustomData       string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// SaveInput is what a user saves.
+type SaveInput struct {
+	ArticleID        int
+	VariantID        int
+	GeneratedImageID *int
+	PromptID         *int
+	CustomData       map[string]any
+}
+
+// GeneratedImage is the part of a generated image saved items need.
+type GeneratedImage struct {
+	ID       int
+	Filename string
+	PromptID int
+	UserID   *int
+}
+
+// Share is the link through which a user's saved items can be viewed by
+// anyone who has it.
+type Share struct {
+	UserID    int
+	Token     string
+	CreatedAt time.Time
+}
@@ -0,0 +1,193 @@
+package wishlist_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"voenix/backend/internal/article"
+	"voenix/backend/internal/cart"
+	"voenix/backend/internal/wishlist"
+	"voenix/backend/internal/wishlist/postgres"
+)
+
+type generatedImageRow struct {
+	ID       int `gorm:"primaryKey"`
+	Filename string
+	PromptID int
+	UserID   *int
+}
+
+func (generatedImageRow) TableName() string { return "generated_images" }
+
+type promptRow struct {
+	ID    int `gorm:"primaryKey"`
+	Title string
+}
+
+func (promptRow) TableName() string { return "prompts" }
+
+// fakeCarts keeps a single cart in memory. While refuse is set AddItem fails.
+type fakeCarts struct {
+	items  []cart.CartItem
+	nextID int
+	refuse error
+}
+
+func (f *fakeCarts) ValidateItem(context.Context, int, int, *int) (string, error) {
+	return article.ArticleTypeMug, nil
+}
+
+func (f *fakeCarts) GetCart(context.Context, cart.Owner) (*cart.CartDetail, error) {
+	return &cart.CartDetail{Cart: &cart.Cart{Items: append([]cart.CartItem(nil), f.items...)}}, nil
+}
+
+func (f *fakeCarts) AddItem(_ context.Context, _ cart.Owner, in cart.AddItemInput) (*cart.CartDetail, error) {
+	if f.refuse != nil {
+		return nil, f.refuse
+	}
+	f.nextID++
+	b, _ := json.Marshal(in.CustomData)
+	f.items = append(f.items, cart.CartItem{ID: f.nextID, ArticleID: in.ArticleID, VariantID: in.VariantID, Quantity: max(in.Quantity, 1),
+		GeneratedImageID: in.GeneratedImageID, PromptID: in.PromptID, CustomData: string(b)})
+	return f.GetCart(context.Background(), cart.Owner{})
+}
+
+func (f *fakeCarts) DeleteItem(_ context.Context, _ cart.Owner, itemID int, _ *cart.CartVersion) (*cart.CartDetail, error) {
+	for i := range f.items {
+		if f.items[i].ID == itemID {
+			f.items = append(f.items[:i], f.items[i+1:]...)
+			return f.GetCart(context.Background(), cart.Owner{})
+		}
+	}
+	return nil, cart.ErrCartItemNotFound
+}
+
+func (f *fakeCarts) ToCartResponse(context.Context, *cart.CartDetail) (*cart.CartResponse, error) {
+	return &cart.CartResponse{}, nil
+}
+
+type fakeArticles struct{}
+
+func (fakeArticles) GetArticleSummary(_ context.Context, id int) (article.ArticleResponse, error) {
+	return article.ArticleResponse{ID: id, Name: "Mug"}, nil
+}
+
+type fakeImages struct{}
+
+func (fakeImages) GetUserImage(_ context.Context, _ int, filename string) ([]byte, string, error) {
+	return []byte(filename), "image/png", nil
+}
+
+func TestSavedItemsMoveBetweenCartAndSharedList(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&postgres.SavedItemRow{}, &postgres.ShareRow{}, &generatedImageRow{}, &promptRow{}); err != nil {
+		t.Fatalf("auto migrate: %v", err)
+	}
+	if err := db.Create(&promptRow{ID: 3, Title: "Cats"}).Error; err != nil {
+		t.Fatalf("seed prompt: %v", err)
+	}
+	owner, other := 5, 6
+	for _, img := range []generatedImageRow{{ID: 1, Filename: "mine.png", PromptID: 3, UserID: &owner}, {ID: 2, Filename: "theirs.png", PromptID: 3, UserID: &other}} {
+		if err := db.Create(&img).Error; err != nil {
+			t.Fatalf("seed image: %v", err)
+		}
+	}
+	carts := &fakeCarts{}
+	svc := wishlist.NewService(postgres.NewRepository(db), carts, fakeArticles{}, fakeImages{})
+	ctx := context.Background()
+
+	theirs := 2
+	if _, err := svc.Save(ctx, owner, wishlist.SaveInput{ArticleID: 1, VariantID: 1, GeneratedImageID: &theirs}); !errors.Is(err, wishlist.ErrImageNotFound) {
+		t.Fatalf("expected another user's image to be refused, got %v", err)
+	}
+	mine := 1
+	in := wishlist.SaveInput{ArticleID: 1, VariantID: 1, GeneratedImageID: &mine, CustomData: map[string]any{"b": 1, "a": 2}}
+	saved, err := svc.Save(ctx, owner, in)
+	if err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if saved.PromptID == nil || *saved.PromptID != 3 || saved.VariantType != article.ArticleTypeMug {
+		t.Fatalf("expected the image's prompt and the variant type, got %+v", saved)
+	}
+	if again, err := svc.Save(ctx, owner, in); err != nil || again.ID != saved.ID {
+		t.Fatalf("saving the same design twice should return the saved item, got %+v (%v)", again, err)
+	}
+
+	carts.refuse = errors.New("cart unavailable")
+	if _, err := svc.MoveToCart(ctx, owner, saved.ID, 2); err == nil {
+		t.Fatalf("expected the refused move to fail")
+	}
+	if items, _ := svc.List(ctx, owner); len(items) != 1 || items[0].ID != saved.ID {
+		t.Fatalf("expected a refused move to keep the saved item, got %+v", items)
+	}
+	carts.refuse = nil
+	if _, err := svc.MoveToCart(ctx, owner, saved.ID, 2); err != nil {
+		t.Fatalf("move to cart: %v", err)
+	}
+	if _, err := svc.MoveToCart(ctx, owner, saved.ID, 2); !errors.Is(err, wishlist.ErrNotFound) {
+		t.Fatalf("expected a repeated move to fail, got %v", err)
+	}
+	if len(carts.items) != 1 || carts.items[0].Quantity != 2 || carts.items[0].GeneratedImageID == nil {
+		t.Fatalf("expected the design in the cart, got %+v", carts.items)
+	}
+	if items, _ := svc.List(ctx, owner); len(items) != 0 {
+		t.Fatalf("expected the moved item to leave the saved items, got %+v", items)
+	}
+	back, err := svc.MoveFromCart(ctx, owner, carts.items[0].ID)
+	if err != nil {
+		t.Fatalf("move from cart: %v", err)
+	}
+	if len(carts.items) != 0 || back.GeneratedImageID == nil || *back.GeneratedImageID != mine || back.CustomData != `{"a":2,"b":1}` {
+		t.Fatalf("expected the cart item to be saved and removed, got %+v, cart %+v", back, carts.items)
+	}
+	if _, err := svc.MoveFromCart(ctx, owner, 99); !errors.Is(err, cart.ErrCartItemNotFound) {
+		t.Fatalf("expected a missing cart item to be reported, got %v", err)
+	}
+
+	share, err := svc.ShareLink(ctx, owner)
+	if err != nil {
+		t.Fatalf("share: %v", err)
+	}
+	if again, _ := svc.ShareLink(ctx, owner); again.Token != share.Token {
+		t.Fatalf("expected the share link to be kept")
+	}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	wishlist.RegisterRoutes(r, func(c *gin.Context) { c.Next() }, svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/public/saved-items/"+share.Token, nil))
+	var shared struct {
+		Items []wishlist.SavedItemResponse `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &shared); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("read shared list: %d %s", w.Code, w.Body.String())
+	}
+	if len(shared.Items) != 1 || shared.Items[0].ImageURL == nil || *shared.Items[0].ImageURL != "/api/public/saved-items/"+share.Token+"/images/mine.png" ||
+		shared.Items[0].PromptTitle == nil || *shared.Items[0].PromptTitle != "Cats" {
+		t.Fatalf("unexpected shared list: %+v", shared.Items)
+	}
+	if _, _, err := svc.SharedImage(ctx, share.Token, "mine.png"); err != nil {
+		t.Fatalf("read shared image: %v", err)
+	}
+	if _, _, err := svc.SharedImage(ctx, share.Token, "theirs.png"); !errors.Is(err, wishlist.ErrImageNotFound) {
+		t.Fatalf("expected images outside the list to be hidden, got %v", err)
+	}
+
+	if err := svc.StopSharing(ctx, owner); err != nil {
+		t.Fatalf("stop sharing: %v", err)
+	}
+	if _, _, err := svc.Shared(ctx, share.Token); !errors.Is(err, wishlist.ErrShareNotFound) {
+		t.Fatalf("expected the revoked link to stop working, got %v", err)
+	}
+}